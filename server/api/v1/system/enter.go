@@ -16,6 +16,7 @@ type ApiGroup struct {
 	OperationRecordApi
 	DictionaryDetailApi
 	AuthorityBtnApi
+	AuthorityFieldApi
 	SysExportTemplateApi
 	AutoCodePluginApi
 	AutoCodePackageApi
@@ -26,7 +27,12 @@ type ApiGroup struct {
 	SysErrorApi
 	LoginLogApi
 	ApiTokenApi
+	AccessKeyApi
 	SkillsApi
+	SessionApi
+	TenantApi
+	ChangeHistoryApi
+	AuditLogApi
 }
 
 var (
@@ -40,6 +46,7 @@ var (
 	authorityService        = service.ServiceGroupApp.SystemServiceGroup.AuthorityService
 	dictionaryService       = service.ServiceGroupApp.SystemServiceGroup.DictionaryService
 	authorityBtnService     = service.ServiceGroupApp.SystemServiceGroup.AuthorityBtnService
+	authorityFieldService   = service.ServiceGroupApp.SystemServiceGroup.AuthorityFieldService
 	systemConfigService     = service.ServiceGroupApp.SystemServiceGroup.SystemConfigService
 	sysParamsService        = service.ServiceGroupApp.SystemServiceGroup.SysParamsService
 	operationRecordService  = service.ServiceGroupApp.SystemServiceGroup.OperationRecordService
@@ -53,5 +60,14 @@ var (
 	sysErrorService         = service.ServiceGroupApp.SystemServiceGroup.SysErrorService
 	loginLogService         = service.ServiceGroupApp.SystemServiceGroup.LoginLogService
 	apiTokenService         = service.ServiceGroupApp.SystemServiceGroup.ApiTokenService
+	accessKeyService        = service.ServiceGroupApp.SystemServiceGroup.AccessKeyService
 	skillsService           = service.ServiceGroupApp.SystemServiceGroup.SkillsService
+	refreshTokenService     = service.ServiceGroupApp.SystemServiceGroup.RefreshTokenService
+	sessionService          = service.ServiceGroupApp.SystemServiceGroup.SessionService
+	twoFactorService        = service.ServiceGroupApp.SystemServiceGroup.TwoFactorService
+	userIdentityService     = service.ServiceGroupApp.SystemServiceGroup.UserIdentityService
+	loginLockService        = service.ServiceGroupApp.SystemServiceGroup.LoginLockService
+	tenantService           = service.ServiceGroupApp.SystemServiceGroup.TenantService
+	changeHistoryService    = service.ServiceGroupApp.SystemServiceGroup.ChangeHistoryService
+	auditLogService         = service.ServiceGroupApp.SystemServiceGroup.AuditLogService
 )
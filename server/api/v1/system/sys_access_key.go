@@ -0,0 +1,93 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	sysReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	sysRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AccessKeyApi struct{}
+
+// CreateAccessKey
+// @Tags      AccessKey
+// @Summary   签发AK/SK SecretKey只返回一次
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      sysReq.CreateAccessKey                                              true  "用户ID,角色ID,有效天数,备注"
+// @Success   200   {object}  response.Response{data=sysRes.CreateAccessKeyResponse,msg=string}  "返回AccessKey与SecretKey"
+// @Router    /accessKey/createAccessKey [post]
+func (s *AccessKeyApi) CreateAccessKey(c *gin.Context) {
+	var req sysReq.CreateAccessKey
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	ak, sk, err := accessKeyService.CreateAccessKey(c.Request.Context(), req)
+	if err != nil {
+		global.GVA_LOG.Error("签发失败!", zap.Error(err))
+		response.FailWithMessage("签发失败: "+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(sysRes.CreateAccessKeyResponse{AccessKey: ak, SecretKey: sk}, "签发成功", c)
+}
+
+// GetAccessKeyList
+// @Tags      AccessKey
+// @Summary   分页获取AK/SK列表
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      sysReq.SysAccessKeySearch                               true  "页码, 每页大小, 用户ID, 状态"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取AK/SK列表"
+// @Router    /accessKey/getAccessKeyList [post]
+func (s *AccessKeyApi) GetAccessKeyList(c *gin.Context) {
+	var pageInfo sysReq.SysAccessKeySearch
+	err := c.ShouldBindJSON(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := accessKeyService.GetAccessKeyList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// DeleteAccessKey
+// @Tags      AccessKey
+// @Summary   作废AK/SK
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "AK/SK记录ID"
+// @Success   200   {object}  response.Response{msg=string}  "作废AK/SK"
+// @Router    /accessKey/deleteAccessKey [post]
+func (s *AccessKeyApi) DeleteAccessKey(c *gin.Context) {
+	var reqId request.GetById
+	err := c.ShouldBindJSON(&reqId)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = accessKeyService.DeleteAccessKey(c.Request.Context(), uint(reqId.ID))
+	if err != nil {
+		global.GVA_LOG.Error("作废失败!", zap.Error(err))
+		response.FailWithMessage("作废失败", c)
+		return
+	}
+	response.OkWithMessage("作废成功", c)
+}
@@ -13,25 +13,14 @@ type ApiTokenApi struct{}
 
 // CreateApiToken 签发Token
 func (s *ApiTokenApi) CreateApiToken(c *gin.Context) {
-	var req struct {
-		UserID      uint   `json:"userId"`
-		AuthorityID uint   `json:"authorityId"`
-		Days        int    `json:"days"` // -1为永久, 其他为天数
-		Remark      string `json:"remark"`
-	}
+	var req sysReq.CreateApiToken
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
 
-	token := system.SysApiToken{
-		UserID:      req.UserID,
-		AuthorityID: req.AuthorityID,
-		Remark:      req.Remark,
-	}
-
-	jwtStr, err := apiTokenService.CreateApiToken(token, req.Days)
+	jwtStr, err := apiTokenService.CreateApiToken(c.Request.Context(), req)
 	if err != nil {
 		global.GVA_LOG.Error("签发失败!", zap.Error(err))
 		response.FailWithMessage("签发失败: "+err.Error(), c)
@@ -49,7 +38,7 @@ func (s *ApiTokenApi) GetApiTokenList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := apiTokenService.GetApiTokenList(pageInfo)
+	list, total, err := apiTokenService.GetApiTokenList(c.Request.Context(), pageInfo)
 	if err != nil {
 		global.GVA_LOG.Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
@@ -71,7 +60,7 @@ func (s *ApiTokenApi) DeleteApiToken(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = apiTokenService.DeleteApiToken(req.ID)
+	err = apiTokenService.DeleteApiToken(c.Request.Context(), req.ID)
 	if err != nil {
 		global.GVA_LOG.Error("作废失败!", zap.Error(err))
 		response.FailWithMessage("作废失败", c)
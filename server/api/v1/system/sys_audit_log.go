@@ -0,0 +1,37 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuditLogApi struct{}
+
+// VerifyAuditLog
+// @Tags      AuditLog
+// @Summary   分批校验操作记录与登录日志的审计哈希链 完整校验与归档文件校验使用命令行 audit verify
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  query     systemReq.SysAuditVerify                                        true  "起始位置, 本批记录数"
+// @Success   200   {object}  response.Response{data=systemRes.SysAuditVerifyResult,msg=string}  "校验一批审计哈希链,返回是否通过,校验到的位置,是否到链尾与发现的问题"
+// @Router    /auditLog/verifyAuditLog [get]
+func (s *AuditLogApi) VerifyAuditLog(c *gin.Context) {
+	var req systemReq.SysAuditVerify
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var res systemRes.SysAuditVerifyResult
+	res, err = auditLogService.VerifyAuditLogPage(req)
+	if err != nil {
+		global.GVA_LOG.Error("校验失败!", zap.Error(err))
+		response.FailWithMessage("校验失败", c)
+		return
+	}
+	response.OkWithDetailed(res, "校验完成", c)
+}
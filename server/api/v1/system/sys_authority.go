@@ -40,7 +40,7 @@ func (a *AuthorityApi) CreateAuthority(c *gin.Context) {
 		authority.ParentId = utils.Pointer(utils.GetUserAuthorityId(c))
 	}
 
-	if authBack, err = authorityService.CreateAuthority(authority); err != nil {
+	if authBack, err = authorityService.CreateAuthority(c.Request.Context(), authority); err != nil {
 		global.GVA_LOG.Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败"+err.Error(), c)
 		return
@@ -81,12 +81,17 @@ func (a *AuthorityApi) CopyAuthority(c *gin.Context) {
 		return
 	}
 	adminAuthorityID := utils.GetUserAuthorityId(c)
-	authBack, err := authorityService.CopyAuthority(adminAuthorityID, copyInfo)
+	authBack, err := authorityService.CopyAuthority(c.Request.Context(), adminAuthorityID, copyInfo)
 	if err != nil {
 		global.GVA_LOG.Error("拷贝失败!", zap.Error(err))
 		response.FailWithMessage("拷贝失败"+err.Error(), c)
 		return
 	}
+	if err = casbinService.FreshCasbin(); err != nil {
+		global.GVA_LOG.Error("拷贝成功，权限刷新失败。", zap.Error(err))
+		response.FailWithMessage("拷贝成功，权限刷新失败。"+err.Error(), c)
+		return
+	}
 	response.OkWithDetailed(systemRes.SysAuthorityResponse{Authority: authBack}, "拷贝成功", c)
 }
 
@@ -111,7 +116,7 @@ func (a *AuthorityApi) DeleteAuthority(c *gin.Context) {
 		return
 	}
 	// 删除角色之前需要判断是否有用户正在使用此角色
-	if err = authorityService.DeleteAuthority(&authority); err != nil {
+	if err = authorityService.DeleteAuthority(c.Request.Context(), &authority); err != nil {
 		global.GVA_LOG.Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败"+err.Error(), c)
 		return
@@ -141,12 +146,18 @@ func (a *AuthorityApi) UpdateAuthority(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	authority, err := authorityService.UpdateAuthority(auth)
+	authority, err := authorityService.UpdateAuthority(c.Request.Context(), auth)
 	if err != nil {
 		global.GVA_LOG.Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败"+err.Error(), c)
 		return
 	}
+	// 父角色或继承开关变更后需要重新加载继承关系
+	if err = casbinService.FreshCasbin(); err != nil {
+		global.GVA_LOG.Error("更新成功，权限刷新失败。", zap.Error(err))
+		response.FailWithMessage("更新成功，权限刷新失败。"+err.Error(), c)
+		return
+	}
 	response.OkWithDetailed(systemRes.SysAuthorityResponse{Authority: authority}, "更新成功", c)
 }
 
@@ -161,7 +172,7 @@ func (a *AuthorityApi) UpdateAuthority(c *gin.Context) {
 // @Router    /authority/getAuthorityList [post]
 func (a *AuthorityApi) GetAuthorityList(c *gin.Context) {
 	authorityID := utils.GetUserAuthorityId(c)
-	list, err := authorityService.GetAuthorityInfoList(authorityID)
+	list, err := authorityService.GetAuthorityInfoList(c.Request.Context(), authorityID)
 	if err != nil {
 		global.GVA_LOG.Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败"+err.Error(), c)
@@ -192,7 +203,7 @@ func (a *AuthorityApi) SetDataAuthority(c *gin.Context) {
 		return
 	}
 	adminAuthorityID := utils.GetUserAuthorityId(c)
-	err = authorityService.SetDataAuthority(adminAuthorityID, auth)
+	err = authorityService.SetDataAuthority(c.Request.Context(), adminAuthorityID, auth)
 	if err != nil {
 		global.GVA_LOG.Error("设置失败!", zap.Error(err))
 		response.FailWithMessage("设置失败"+err.Error(), c)
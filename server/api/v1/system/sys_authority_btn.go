@@ -4,6 +4,7 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -51,7 +52,7 @@ func (a *AuthorityBtnApi) SetAuthorityBtn(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = authorityBtnService.SetAuthorityBtn(req)
+	err = authorityBtnService.SetAuthorityBtn(utils.GetUserAuthorityId(c), req)
 	if err != nil {
 		global.GVA_LOG.Error("分配失败!", zap.Error(err))
 		response.FailWithMessage("分配失败", c)
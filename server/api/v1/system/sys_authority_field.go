@@ -0,0 +1,78 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AuthorityFieldApi struct{}
+
+// GetFieldModels
+// @Tags      AuthorityField
+// @Summary   获取可配置字段权限的模型
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]response.SysFieldModel,msg=string}  "返回模型列表"
+// @Router    /authorityField/getFieldModels [get]
+func (a *AuthorityFieldApi) GetFieldModels(c *gin.Context) {
+	list, err := authorityFieldService.GetFieldModels()
+	if err != nil {
+		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		response.FailWithMessage("查询失败", c)
+		return
+	}
+	response.OkWithDetailed(list, "查询成功", c)
+}
+
+// GetAuthorityField
+// @Tags      AuthorityField
+// @Summary   获取模型字段与角色的字段权限
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAuthorityFieldReq                                      true  "角色id, 模型"
+// @Success   200   {object}  response.Response{data=response.SysAuthorityFieldRes,msg=string}  "返回字段与规则"
+// @Router    /authorityField/getAuthorityField [post]
+func (a *AuthorityFieldApi) GetAuthorityField(c *gin.Context) {
+	var req request.SysAuthorityFieldReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	res, err := authorityFieldService.GetAuthorityField(req)
+	if err != nil {
+		global.GVA_LOG.Error("查询失败!", zap.Error(err))
+		response.FailWithMessage("查询失败", c)
+		return
+	}
+	response.OkWithDetailed(res, "查询成功", c)
+}
+
+// SetAuthorityField
+// @Tags      AuthorityField
+// @Summary   设置角色的字段权限
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAuthorityFieldReq   true  "角色id, 模型, 字段规则"
+// @Success   200   {object}  response.Response{msg=string}  "设置字段权限"
+// @Router    /authorityField/setAuthorityField [post]
+func (a *AuthorityFieldApi) SetAuthorityField(c *gin.Context) {
+	var req request.SysAuthorityFieldReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = authorityFieldService.SetAuthorityField(req)
+	if err != nil {
+		global.GVA_LOG.Error("设置失败!", zap.Error(err))
+		response.FailWithMessage("设置失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("设置成功", c)
+}
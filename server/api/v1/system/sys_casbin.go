@@ -1,6 +1,9 @@
 package system
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
@@ -67,3 +70,80 @@ func (cas *CasbinApi) GetPolicyPathByAuthorityId(c *gin.Context) {
 	paths := casbinService.GetPolicyPathByAuthorityId(casbin.AuthorityId)
 	response.OkWithDetailed(systemRes.PolicyPathResponse{Paths: paths}, "获取成功", c)
 }
+
+// ExplainPermission
+// @Tags      Casbin
+// @Summary   权限诊断 返回用户或角色访问指定接口的鉴权结果 命中的策略以及菜单和按钮权限
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.PermissionExplain                                               true  "用户ID或角色ID, 方法, 路径"
+// @Success   200   {object}  response.Response{data=systemRes.PermissionExplainResponse,msg=string}  "权限诊断结果"
+// @Router    /casbin/explainPermission [post]
+func (cas *CasbinApi) ExplainPermission(c *gin.Context) {
+	var req request.PermissionExplain
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	res, err := casbinService.ExplainPermission(utils.GetUserAuthorityId(c), req)
+	if err != nil {
+		global.GVA_LOG.Error("权限诊断失败!", zap.Error(err))
+		response.FailWithMessage("权限诊断失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "获取成功", c)
+}
+
+// GetPermissionMatrix
+// @Tags      Casbin
+// @Summary   获取用户或角色对全部api的实际权限
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.PermissionMatrix                                               true  "用户ID或角色ID"
+// @Success   200   {object}  response.Response{data=systemRes.PermissionMatrixResponse,msg=string}  "权限矩阵"
+// @Router    /casbin/getPermissionMatrix [post]
+func (cas *CasbinApi) GetPermissionMatrix(c *gin.Context) {
+	var req request.PermissionMatrix
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	res, err := casbinService.GetPermissionMatrix(utils.GetUserAuthorityId(c), req)
+	if err != nil {
+		global.GVA_LOG.Error("获取权限矩阵失败!", zap.Error(err))
+		response.FailWithMessage("获取权限矩阵失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "获取成功", c)
+}
+
+// ExportPermissionMatrix
+// @Tags      Casbin
+// @Summary   导出权限矩阵Excel
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/octet-stream
+// @Param     data  body  request.PermissionMatrix  true  "用户ID或角色ID"
+// @Success   200
+// @Router    /casbin/exportPermissionMatrix [post]
+func (cas *CasbinApi) ExportPermissionMatrix(c *gin.Context) {
+	var req request.PermissionMatrix
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	file, err := casbinService.ExportPermissionMatrix(utils.GetUserAuthorityId(c), req)
+	if err != nil {
+		global.GVA_LOG.Error("导出权限矩阵失败!", zap.Error(err))
+		response.FailWithMessage("导出权限矩阵失败:"+err.Error(), c)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", "permission_matrix_"+utils.RandomString(6)+".xlsx"))
+	c.Header("success", "true")
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", file.Bytes())
+}
@@ -0,0 +1,41 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ChangeHistoryApi struct{}
+
+// GetChangeHistoryList
+// @Tags      ChangeHistory
+// @Summary   分页获取变更历史 传入表名与记录主键时为该记录的时间线
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  query     systemReq.SysChangeHistorySearch                       true  "表名, 记录主键, 请求ID, 操作人, 页码, 每页大小"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取变更历史,返回包括列表,总数,页码,每页数量"
+// @Router    /changeHistory/getChangeHistoryList [get]
+func (s *ChangeHistoryApi) GetChangeHistoryList(c *gin.Context) {
+	var pageInfo systemReq.SysChangeHistorySearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := changeHistoryService.GetChangeHistoryList(c.Request.Context(), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
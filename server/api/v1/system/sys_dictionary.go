@@ -27,7 +27,7 @@ func (s *DictionaryApi) CreateSysDictionary(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = dictionaryService.CreateSysDictionary(dictionary)
+	err = dictionaryService.CreateSysDictionary(c.Request.Context(), dictionary)
 	if err != nil {
 		global.GVA_LOG.Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败", c)
@@ -52,7 +52,7 @@ func (s *DictionaryApi) DeleteSysDictionary(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = dictionaryService.DeleteSysDictionary(dictionary)
+	err = dictionaryService.DeleteSysDictionary(c.Request.Context(), dictionary)
 	if err != nil {
 		global.GVA_LOG.Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
@@ -77,7 +77,7 @@ func (s *DictionaryApi) UpdateSysDictionary(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = dictionaryService.UpdateSysDictionary(&dictionary)
+	err = dictionaryService.UpdateSysDictionary(c.Request.Context(), &dictionary)
 	if err != nil {
 		global.GVA_LOG.Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败", c)
@@ -102,7 +102,7 @@ func (s *DictionaryApi) FindSysDictionary(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	sysDictionary, err := dictionaryService.GetSysDictionary(dictionary.Type, dictionary.ID, dictionary.Status)
+	sysDictionary, err := dictionaryService.GetSysDictionary(c.Request.Context(), dictionary.Type, dictionary.ID, dictionary.Status)
 	if err != nil {
 		global.GVA_LOG.Error("字典未创建或未开启!", zap.Error(err))
 		response.FailWithMessage("字典未创建或未开启", c)
@@ -156,7 +156,7 @@ func (s *DictionaryApi) ExportSysDictionary(c *gin.Context) {
 		response.FailWithMessage("字典ID不能为空", c)
 		return
 	}
-	exportData, err := dictionaryService.ExportSysDictionary(dictionary.ID)
+	exportData, err := dictionaryService.ExportSysDictionary(c.Request.Context(), dictionary.ID)
 	if err != nil {
 		global.GVA_LOG.Error("导出失败!", zap.Error(err))
 		response.FailWithMessage("导出失败", c)
@@ -181,7 +181,7 @@ func (s *DictionaryApi) ImportSysDictionary(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = dictionaryService.ImportSysDictionary(req.Json)
+	err = dictionaryService.ImportSysDictionary(c.Request.Context(), req.Json)
 	if err != nil {
 		global.GVA_LOG.Error("导入失败!", zap.Error(err))
 		response.FailWithMessage("导入失败: "+err.Error(), c)
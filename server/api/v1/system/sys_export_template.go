@@ -261,9 +261,11 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportExcel(c *gin.Context) {
 	token := utils.RandomString(32) // 随机32位
 
 	// 记录本次请求参数
+	// 下载链接不携带登录信息 记录导出人角色用于字段权限
 	exportParams := map[string]interface{}{
 		"templateID":  templateID,
 		"queryParams": queryParams,
+		"authorityId": utils.GetUserAuthorityId(c),
 	}
 
 	// 参数保留记录完成鉴权
@@ -314,6 +316,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportExcelByToken(c *gin.Cont
 	// 获取导出参数
 	templateID := exportParams["templateID"].(string)
 	queryParams := exportParams["queryParams"].(url.Values)
+	authorityId, _ := exportParams["authorityId"].(uint)
 
 	// 清理一次性token
 	tokenMutex.Lock()
@@ -322,7 +325,7 @@ func (sysExportTemplateApi *SysExportTemplateApi) ExportExcelByToken(c *gin.Cont
 	tokenMutex.Unlock()
 
 	// 导出
-	if file, name, err := sysExportTemplateService.ExportExcel(templateID, queryParams); err != nil {
+	if file, name, err := sysExportTemplateService.ExportExcel(templateID, queryParams, authorityId); err != nil {
 		global.GVA_LOG.Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
 	} else {
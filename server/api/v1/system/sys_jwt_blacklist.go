@@ -1,6 +1,9 @@
 package system
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
@@ -28,6 +31,29 @@ func (j *JwtApi) JsonInBlacklist(c *gin.Context) {
 		response.FailWithMessage("jwt作废失败", c)
 		return
 	}
+	// 退出登录时同时移除会话并注销本会话的refresh token
+	if claims := utils.GetUserInfo(c); claims != nil {
+		if err = sessionService.EndSession(claims.BaseClaims.ID, claims.SessionID); err != nil {
+			global.GVA_LOG.Error("会话注销失败!", zap.Error(err))
+		}
+	}
 	utils.ClearToken(c)
 	response.OkWithMessage("jwt作废成功", c)
 }
+
+// Jwks
+// @Tags      Jwt
+// @Summary   获取jwt验签公钥(JWKS)
+// @Produce   application/json
+// @Success   200  {object}  utils.JWKS  "当前有效的验签公钥"
+// @Router    /.well-known/jwks.json [get]
+func (j *JwtApi) Jwks(c *gin.Context) {
+	set := utils.GetJWTKeySet()
+	if set == nil {
+		// HS256 对称签名不对外公开密钥
+		c.JSON(http.StatusOK, utils.JWKS{Keys: []utils.JWK{}})
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, set.JWKS(time.Now()))
+}
@@ -2,7 +2,6 @@ package system
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
-	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
@@ -12,38 +11,6 @@ import (
 
 type LoginLogApi struct{}
 
-func (s *LoginLogApi) DeleteLoginLog(c *gin.Context) {
-	var loginLog system.SysLoginLog
-	err := c.ShouldBindJSON(&loginLog)
-	if err != nil {
-		response.FailWithMessage(err.Error(), c)
-		return
-	}
-	err = loginLogService.DeleteLoginLog(loginLog)
-	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
-		response.FailWithMessage("删除失败", c)
-		return
-	}
-	response.OkWithMessage("删除成功", c)
-}
-
-func (s *LoginLogApi) DeleteLoginLogByIds(c *gin.Context) {
-	var SDS request.IdsReq
-	err := c.ShouldBindJSON(&SDS)
-	if err != nil {
-		response.FailWithMessage(err.Error(), c)
-		return
-	}
-	err = loginLogService.DeleteLoginLogByIds(SDS)
-	if err != nil {
-		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
-		response.FailWithMessage("批量删除失败", c)
-		return
-	}
-	response.OkWithMessage("批量删除成功", c)
-}
-
 func (s *LoginLogApi) FindLoginLog(c *gin.Context) {
 	var loginLog system.SysLoginLog
 	err := c.ShouldBindQuery(&loginLog)
@@ -2,10 +2,10 @@ package system
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
-	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -13,56 +13,6 @@ import (
 
 type OperationRecordApi struct{}
 
-// DeleteSysOperationRecord
-// @Tags      SysOperationRecord
-// @Summary   删除SysOperationRecord
-// @Security  ApiKeyAuth
-// @accept    application/json
-// @Produce   application/json
-// @Param     data  body      system.SysOperationRecord      true  "SysOperationRecord模型"
-// @Success   200   {object}  response.Response{msg=string}  "删除SysOperationRecord"
-// @Router    /sysOperationRecord/deleteSysOperationRecord [delete]
-func (s *OperationRecordApi) DeleteSysOperationRecord(c *gin.Context) {
-	var sysOperationRecord system.SysOperationRecord
-	err := c.ShouldBindJSON(&sysOperationRecord)
-	if err != nil {
-		response.FailWithMessage(err.Error(), c)
-		return
-	}
-	err = operationRecordService.DeleteSysOperationRecord(sysOperationRecord)
-	if err != nil {
-		global.GVA_LOG.Error("删除失败!", zap.Error(err))
-		response.FailWithMessage("删除失败", c)
-		return
-	}
-	response.OkWithMessage("删除成功", c)
-}
-
-// DeleteSysOperationRecordByIds
-// @Tags      SysOperationRecord
-// @Summary   批量删除SysOperationRecord
-// @Security  ApiKeyAuth
-// @accept    application/json
-// @Produce   application/json
-// @Param     data  body      request.IdsReq                 true  "批量删除SysOperationRecord"
-// @Success   200   {object}  response.Response{msg=string}  "批量删除SysOperationRecord"
-// @Router    /sysOperationRecord/deleteSysOperationRecordByIds [delete]
-func (s *OperationRecordApi) DeleteSysOperationRecordByIds(c *gin.Context) {
-	var IDS request.IdsReq
-	err := c.ShouldBindJSON(&IDS)
-	if err != nil {
-		response.FailWithMessage(err.Error(), c)
-		return
-	}
-	err = operationRecordService.DeleteSysOperationRecordByIds(IDS)
-	if err != nil {
-		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
-		response.FailWithMessage("批量删除失败", c)
-		return
-	}
-	response.OkWithMessage("批量删除成功", c)
-}
-
 // FindSysOperationRecord
 // @Tags      SysOperationRecord
 // @Summary   用id查询SysOperationRecord
@@ -122,3 +72,16 @@ func (s *OperationRecordApi) GetSysOperationRecordList(c *gin.Context) {
 		PageSize: pageInfo.PageSize,
 	}, "获取成功", c)
 }
+
+// GetOperationRecordStats
+// @Tags      SysOperationRecord
+// @Summary   获取操作记录后台写入的统计
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=systemRes.SysOperationRecordStats,msg=string}  "获取操作记录后台写入的统计,返回包括队列长度,已写入,丢弃与失败的记录数"
+// @Router    /sysOperationRecord/getOperationRecordStats [get]
+func (s *OperationRecordApi) GetOperationRecordStats(c *gin.Context) {
+	var stats systemRes.SysOperationRecordStats
+	stats = operationRecordService.GetOperationRecordStats()
+	response.OkWithDetailed(stats, "获取成功", c)
+}
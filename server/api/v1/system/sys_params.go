@@ -27,7 +27,7 @@ func (sysParamsApi *SysParamsApi) CreateSysParams(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = sysParamsService.CreateSysParams(&sysParams)
+	err = sysParamsService.CreateSysParams(c.Request.Context(), &sysParams)
 	if err != nil {
 		global.GVA_LOG.Error("创建失败!", zap.Error(err))
 		response.FailWithMessage("创建失败:"+err.Error(), c)
@@ -47,7 +47,7 @@ func (sysParamsApi *SysParamsApi) CreateSysParams(c *gin.Context) {
 // @Router /sysParams/deleteSysParams [delete]
 func (sysParamsApi *SysParamsApi) DeleteSysParams(c *gin.Context) {
 	ID := c.Query("ID")
-	err := sysParamsService.DeleteSysParams(ID)
+	err := sysParamsService.DeleteSysParams(c.Request.Context(), ID)
 	if err != nil {
 		global.GVA_LOG.Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败:"+err.Error(), c)
@@ -66,7 +66,7 @@ func (sysParamsApi *SysParamsApi) DeleteSysParams(c *gin.Context) {
 // @Router /sysParams/deleteSysParamsByIds [delete]
 func (sysParamsApi *SysParamsApi) DeleteSysParamsByIds(c *gin.Context) {
 	IDs := c.QueryArray("IDs[]")
-	err := sysParamsService.DeleteSysParamsByIds(IDs)
+	err := sysParamsService.DeleteSysParamsByIds(c.Request.Context(), IDs)
 	if err != nil {
 		global.GVA_LOG.Error("批量删除失败!", zap.Error(err))
 		response.FailWithMessage("批量删除失败:"+err.Error(), c)
@@ -91,7 +91,7 @@ func (sysParamsApi *SysParamsApi) UpdateSysParams(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = sysParamsService.UpdateSysParams(sysParams)
+	err = sysParamsService.UpdateSysParams(c.Request.Context(), sysParams)
 	if err != nil {
 		global.GVA_LOG.Error("更新失败!", zap.Error(err))
 		response.FailWithMessage("更新失败:"+err.Error(), c)
@@ -111,7 +111,7 @@ func (sysParamsApi *SysParamsApi) UpdateSysParams(c *gin.Context) {
 // @Router /sysParams/findSysParams [get]
 func (sysParamsApi *SysParamsApi) FindSysParams(c *gin.Context) {
 	ID := c.Query("ID")
-	resysParams, err := sysParamsService.GetSysParams(ID)
+	resysParams, err := sysParamsService.GetSysParams(c.Request.Context(), ID)
 	if err != nil {
 		global.GVA_LOG.Error("查询失败!", zap.Error(err))
 		response.FailWithMessage("查询失败:"+err.Error(), c)
@@ -136,7 +136,7 @@ func (sysParamsApi *SysParamsApi) GetSysParamsList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := sysParamsService.GetSysParamsInfoList(pageInfo)
+	list, total, err := sysParamsService.GetSysParamsInfoList(c.Request.Context(), pageInfo)
 	if err != nil {
 		global.GVA_LOG.Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败:"+err.Error(), c)
@@ -161,7 +161,7 @@ func (sysParamsApi *SysParamsApi) GetSysParamsList(c *gin.Context) {
 // @Router /sysParams/getSysParam [get]
 func (sysParamsApi *SysParamsApi) GetSysParam(c *gin.Context) {
 	k := c.Query("key")
-	params, err := sysParamsService.GetSysParam(k)
+	params, err := sysParamsService.GetSysParam(c.Request.Context(), k)
 	if err != nil {
 		global.GVA_LOG.Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败:"+err.Error(), c)
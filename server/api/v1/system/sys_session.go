@@ -0,0 +1,126 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SessionApi struct{}
+
+// GetSelfSessions
+// @Tags      Session
+// @Summary   获取当前用户的在线会话
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]systemRes.SysSessionResponse,msg=string}  "获取当前用户的在线会话"
+// @Router    /session/getSelfSessions [get]
+func (s *SessionApi) GetSelfSessions(c *gin.Context) {
+	claims := utils.GetUserInfo(c)
+	list, err := sessionService.GetUserSessions(claims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(toSessionResponse(list, claims.SessionID), "获取成功", c)
+}
+
+// RevokeSelfSession
+// @Tags      Session
+// @Summary   注销当前用户的某个会话
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.SessionReq           true  "会话ID"
+// @Success   200   {object}  response.Response{msg=string}  "注销当前用户的某个会话"
+// @Router    /session/revokeSelfSession [post]
+func (s *SessionApi) RevokeSelfSession(c *gin.Context) {
+	var req systemReq.SessionReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.ID == "" {
+		response.FailWithMessage("会话ID不能为空", c)
+		return
+	}
+	err = sessionService.RevokeSession(utils.GetUserID(c), req.ID)
+	if err != nil {
+		global.GVA_LOG.Error("注销失败!", zap.Error(err))
+		response.FailWithMessage("注销失败", c)
+		return
+	}
+	response.OkWithMessage("注销成功", c)
+}
+
+// GetUserSessions
+// @Tags      Session
+// @Summary   管理员获取指定用户的在线会话
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.SessionReq                                              true  "用户ID"
+// @Success   200   {object}  response.Response{data=[]systemRes.SysSessionResponse,msg=string}  "获取指定用户的在线会话"
+// @Router    /session/getUserSessions [post]
+func (s *SessionApi) GetUserSessions(c *gin.Context) {
+	var req systemReq.SessionReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, err := sessionService.GetTenantUserSessions(c.Request.Context(), req.UserID)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(toSessionResponse(list, utils.GetUserInfo(c).SessionID), "获取成功", c)
+}
+
+// ForceLogout
+// @Tags      Session
+// @Summary   管理员强制下线 会话ID为空时下线该用户的全部会话
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.SessionReq           true  "用户ID, 会话ID"
+// @Success   200   {object}  response.Response{msg=string}  "强制下线"
+// @Router    /session/forceLogout [post]
+func (s *SessionApi) ForceLogout(c *gin.Context) {
+	var req systemReq.SessionReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.UserID == 0 {
+		response.FailWithMessage("用户ID不能为空", c)
+		return
+	}
+	err = sessionService.ForceLogout(c.Request.Context(), req.UserID, req.ID)
+	if err != nil {
+		global.GVA_LOG.Error("强制下线失败!", zap.Error(err))
+		response.FailWithMessage("强制下线失败", c)
+		return
+	}
+	response.OkWithMessage("强制下线成功", c)
+}
+
+func toSessionResponse(list []system.SysSession, currentID string) []systemRes.SysSessionResponse {
+	res := make([]systemRes.SysSessionResponse, 0, len(list))
+	for i := range list {
+		res = append(res, systemRes.SysSessionResponse{
+			SysSession: list[i],
+			Current:    currentID != "" && list[i].ID == currentID,
+		})
+	}
+	return res
+}
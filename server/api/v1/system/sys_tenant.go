@@ -0,0 +1,114 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TenantApi struct{}
+
+// CreateTenant
+// @Tags      SysTenant
+// @Summary   创建租户
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      system.SysTenant               true  "租户名称, 租户编码"
+// @Success   200   {object}  response.Response{msg=string}  "创建租户"
+// @Router    /tenant/createTenant [post]
+func (t *TenantApi) CreateTenant(c *gin.Context) {
+	var tenant system.SysTenant
+	err := c.ShouldBindJSON(&tenant)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = tenantService.CreateTenant(tenant); err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateTenant
+// @Tags      SysTenant
+// @Summary   更新租户
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      system.SysTenant               true  "租户ID, 租户名称, 租户编码, 是否启用"
+// @Success   200   {object}  response.Response{msg=string}  "更新租户"
+// @Router    /tenant/updateTenant [put]
+func (t *TenantApi) UpdateTenant(c *gin.Context) {
+	var tenant system.SysTenant
+	err := c.ShouldBindJSON(&tenant)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = tenantService.UpdateTenant(tenant); err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteTenant
+// @Tags      SysTenant
+// @Summary   删除租户
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "租户ID"
+// @Success   200   {object}  response.Response{msg=string}  "删除租户"
+// @Router    /tenant/deleteTenant [delete]
+func (t *TenantApi) DeleteTenant(c *gin.Context) {
+	var reqId request.GetById
+	err := c.ShouldBindJSON(&reqId)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = tenantService.DeleteTenant(uint(reqId.ID)); err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetTenantList
+// @Tags      SysTenant
+// @Summary   分页获取租户列表
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.PageInfo                                        true  "页码, 每页大小, 关键字"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取租户列表"
+// @Router    /tenant/getTenantList [post]
+func (t *TenantApi) GetTenantList(c *gin.Context) {
+	var pageInfo request.PageInfo
+	err := c.ShouldBindJSON(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := tenantService.GetTenantList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
@@ -0,0 +1,192 @@
+package system
+
+import (
+	"errors"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// EnrollTwoFactor
+// @Tags     Base
+// @Summary  登录时绑定两步验证(角色强制且用户未绑定)
+// @Produce   application/json
+// @Param    data  body      systemReq.TwoFactorChallengeReq                                      true  "challenge token"
+// @Success  200   {object}  response.Response{data=systemRes.TwoFactorSetupResponse,msg=string}  "返回密钥,otpauth地址与恢复码"
+// @Router   /base/enrollTwoFactor [post]
+func (b *BaseApi) EnrollTwoFactor(c *gin.Context) {
+	var req systemReq.TwoFactorChallengeReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var setup systemRes.TwoFactorSetupResponse
+	setup, err = twoFactorService.EnrollChallenge(req.ChallengeToken)
+	if err != nil {
+		global.GVA_LOG.Error("获取两步验证密钥失败!", zap.Error(err))
+		response.FailWithMessage(twoFactorErrMessage(err, "获取失败"), c)
+		return
+	}
+	response.OkWithDetailed(setup, "获取成功", c)
+}
+
+// VerifyTwoFactor
+// @Tags     Base
+// @Summary  登录二次验证
+// @Produce   application/json
+// @Param    data  body      systemReq.TwoFactorVerifyReq                                true  "challenge token, 验证码或恢复码"
+// @Success  200   {object}  response.Response{data=systemRes.LoginResponse,msg=string}  "返回包括用户信息,token,过期时间"
+// @Router   /base/verifyTwoFactor [post]
+func (b *BaseApi) VerifyTwoFactor(c *gin.Context) {
+	var req systemReq.TwoFactorVerifyReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.ChallengeToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		response.FailWithMessage("验证码不能为空", c)
+		return
+	}
+	user, err := twoFactorService.VerifyChallenge(req.ChallengeToken, req.Code, req.RecoveryCode)
+	if err != nil {
+		global.GVA_LOG.Error("两步验证失败!", zap.Error(err))
+		loginLog := system.SysLoginLog{
+			Ip:           c.ClientIP(),
+			Agent:        c.Request.UserAgent(),
+			Status:       false,
+			ErrorMessage: "两步验证失败",
+		}
+		if user != nil {
+			loginLog.Username = user.Username
+			loginLog.UserID = user.ID
+		}
+		loginLogService.CreateLoginLog(loginLog)
+		response.FailWithMessage(twoFactorErrMessage(err, "验证失败"), c)
+		return
+	}
+	if user.Enable != 1 {
+		response.FailWithMessage("用户被禁止登录", c)
+		return
+	}
+	b.TokenNext(c, *user)
+}
+
+// SetupTwoFactor
+// @Tags      SysUser
+// @Summary   获取两步验证绑定信息
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=systemRes.TwoFactorSetupResponse,msg=string}  "返回密钥,otpauth地址与恢复码"
+// @Router    /user/setupTwoFactor [post]
+func (b *BaseApi) SetupTwoFactor(c *gin.Context) {
+	setup, err := twoFactorService.SetupTwoFactor(utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取两步验证密钥失败!", zap.Error(err))
+		response.FailWithMessage(twoFactorErrMessage(err, "获取失败"), c)
+		return
+	}
+	response.OkWithDetailed(setup, "获取成功", c)
+}
+
+// EnableTwoFactor
+// @Tags      SysUser
+// @Summary   确认绑定两步验证
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.TwoFactorCodeReq     true  "验证码"
+// @Success   200   {object}  response.Response{msg=string}  "启用两步验证"
+// @Router    /user/enableTwoFactor [post]
+func (b *BaseApi) EnableTwoFactor(c *gin.Context) {
+	var req systemReq.TwoFactorCodeReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = twoFactorService.EnableTwoFactor(utils.GetUserID(c), req.Code)
+	if err != nil {
+		global.GVA_LOG.Error("启用两步验证失败!", zap.Error(err))
+		response.FailWithMessage(twoFactorErrMessage(err, "启用失败"), c)
+		return
+	}
+	response.OkWithMessage("启用成功", c)
+}
+
+// DisableTwoFactor
+// @Tags      SysUser
+// @Summary   关闭两步验证
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.TwoFactorCodeReq     true  "验证码或恢复码"
+// @Success   200   {object}  response.Response{msg=string}  "关闭两步验证"
+// @Router    /user/disableTwoFactor [post]
+func (b *BaseApi) DisableTwoFactor(c *gin.Context) {
+	var req systemReq.TwoFactorCodeReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = twoFactorService.DisableTwoFactor(utils.GetUserID(c), req.Code, req.RecoveryCode)
+	if err != nil {
+		global.GVA_LOG.Error("关闭两步验证失败!", zap.Error(err))
+		response.FailWithMessage(twoFactorErrMessage(err, "关闭失败"), c)
+		return
+	}
+	response.OkWithMessage("关闭成功", c)
+}
+
+// ResetTwoFactor
+// @Tags      SysUser
+// @Summary   管理员重置用户两步验证
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "用户ID"
+// @Success   200   {object}  response.Response{msg=string}  "重置两步验证"
+// @Router    /user/resetTwoFactor [post]
+func (b *BaseApi) ResetTwoFactor(c *gin.Context) {
+	var reqId request.GetById
+	err := c.ShouldBindJSON(&reqId)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = utils.Verify(reqId, utils.IdVerify)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = twoFactorService.ResetTwoFactor(c.Request.Context(), uint(reqId.ID))
+	if err != nil {
+		global.GVA_LOG.Error("重置两步验证失败!", zap.Error(err))
+		response.FailWithMessage("重置失败", c)
+		return
+	}
+	response.OkWithMessage("重置成功", c)
+}
+
+// twoFactorErrMessage 业务错误直接提示 其余错误使用通用提示
+func twoFactorErrMessage(err error, fallback string) string {
+	switch {
+	case errors.Is(err, systemService.ErrTwoFactorChallengeInvalid),
+		errors.Is(err, systemService.ErrTwoFactorCodeInvalid),
+		errors.Is(err, systemService.ErrTwoFactorNotEnrolled),
+		errors.Is(err, systemService.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, systemService.ErrTwoFactorRequired):
+		return err.Error()
+	}
+	return fallback
+}
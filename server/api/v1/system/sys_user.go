@@ -1,6 +1,9 @@
 package system
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"strconv"
 	"time"
 
@@ -11,9 +14,9 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
@@ -62,20 +65,55 @@ func (b *BaseApi) Login(c *gin.Context) {
 		return
 	}
 
-	u := &system.SysUser{Username: l.Username, Password: l.Password}
-	user, err := userService.Login(u)
+	// 按用户名锁定 按ip计数的验证码无法防御分散ip的撞库
+	if until, locked, err := loginLockService.LockedUntil(l.Username); err != nil {
+		global.GVA_LOG.Error("查询登录锁定状态失败!", zap.Error(err))
+	} else if locked {
+		msg := loginLockedMessage(until)
+		response.FailWithMessage(msg, c)
+		loginLogService.CreateLoginLog(system.SysLoginLog{
+			Username:     l.Username,
+			Ip:           c.ClientIP(),
+			Agent:        c.Request.UserAgent(),
+			Status:       false,
+			ErrorMessage: msg,
+			Event:        system.LoginEventLocked,
+		})
+		return
+	}
+
+	var user *system.SysUser
+	if l.Provider == "" {
+		user, err = userService.Login(&system.SysUser{Username: l.Username, Password: l.Password})
+	} else {
+		// LDAP等外部身份提供方 认证通过后按配置关联或创建本地用户
+		user, err = userIdentityService.PasswordLogin(c.Request.Context(), l.Provider, l.Username, l.Password)
+	}
+	if err == nil {
+		// 不属于当前租户的用户同样提示用户名或密码错误 避免探测其他租户的用户名
+		err = tenantService.CheckLoginTenant(c.Request.Context(), user.TenantID)
+	}
 	if err != nil {
 		global.GVA_LOG.Error("登陆失败! 用户名不存在或者密码错误!", zap.Error(err))
+		msg := identityErrMessage(err, "用户名不存在或者密码错误")
+		event := system.LoginEventFailed
+		if until, locked, e := loginLockService.RecordFailure(l.Username); e != nil {
+			global.GVA_LOG.Error("记录登录失败次数失败!", zap.Error(e))
+		} else if locked {
+			msg = loginLockedMessage(until)
+			event = system.LoginEventLocked
+		}
 		// 验证码次数+1
 		global.BlackCache.Increment(key, 1)
-		response.FailWithMessage("用户名不存在或者密码错误", c)
+		response.FailWithMessage(msg, c)
 		// 记录登录失败日志
 		loginLogService.CreateLoginLog(system.SysLoginLog{
 			Username:     l.Username,
 			Ip:           c.ClientIP(),
 			Agent:        c.Request.UserAgent(),
 			Status:       false,
-			ErrorMessage: "用户名不存在或者密码错误",
+			ErrorMessage: msg,
+			Event:        event,
 		})
 		return
 	}
@@ -95,12 +133,45 @@ func (b *BaseApi) Login(c *gin.Context) {
 		})
 		return
 	}
+	if err = loginLockService.RecordSuccess(l.Username); err != nil {
+		global.GVA_LOG.Error("清除登录失败次数失败!", zap.Error(err))
+	}
+	b.loginNext(c, user)
+}
+
+// loginLockedMessage 账号锁定提示 不足一分钟按一分钟计
+func loginLockedMessage(until time.Time) string {
+	minutes := int(math.Ceil(time.Until(until).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	return fmt.Sprintf("登录失败次数过多，账号已锁定，请%d分钟后重试", minutes)
+}
+
+// loginNext 身份校验通过后 需要两步验证时只返回challenge token 验证通过后才签发token
+func (b *BaseApi) loginNext(c *gin.Context, user *system.SysUser) {
+	if twoFactorService.RequiresTwoFactor(user) {
+		challenge, err := twoFactorService.BeginChallenge(user)
+		if err != nil {
+			global.GVA_LOG.Error("开启两步验证失败!", zap.Error(err))
+			response.FailWithMessage("登录失败", c)
+			return
+		}
+		response.OkWithDetailed(challenge, "请输入两步验证码", c)
+		return
+	}
 	b.TokenNext(c, *user)
 }
 
-// TokenNext 登录以后签发jwt
+// TokenNext 登录以后签发access token与refresh token
 func (b *BaseApi) TokenNext(c *gin.Context, user system.SysUser) {
-	token, claims, err := utils.LoginToken(&user)
+	refreshToken, refresh, err := refreshTokenService.IssueRefreshToken(user.ID, "", c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		global.GVA_LOG.Error("获取refresh token失败!", zap.Error(err))
+		response.FailWithMessage("获取token失败", c)
+		return
+	}
+	token, claims, err := utils.LoginToken(&user, refresh.FamilyID)
 	if err != nil {
 		global.GVA_LOG.Error("获取token失败!", zap.Error(err))
 		response.FailWithMessage("获取token失败", c)
@@ -115,49 +186,71 @@ func (b *BaseApi) TokenNext(c *gin.Context, user system.SysUser) {
 		UserID:   user.ID,
 		ErrorMessage: "登录成功",
 	})
-	if !global.GVA_CONFIG.System.UseMultipoint {
-		utils.SetToken(c, token, int(claims.RegisteredClaims.ExpiresAt.Unix()-time.Now().Unix()))
-		response.OkWithDetailed(systemRes.LoginResponse{
-			User:      user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix() * 1000,
-		}, "登录成功", c)
+	// 登记会话 超出角色允许的在线数时会注销最久未活跃的会话
+	if err = sessionService.CreateSession(user, refresh.FamilyID, token, c.ClientIP(), c.Request.UserAgent(), refresh.ExpiresAt); err != nil {
+		global.GVA_LOG.Error("设置登录状态失败!", zap.Error(err))
+		response.FailWithMessage("设置登录状态失败", c)
 		return
 	}
+	utils.SetToken(c, token, int(claims.RegisteredClaims.ExpiresAt.Unix()-time.Now().Unix()))
+	response.OkWithDetailed(systemRes.LoginResponse{
+		User:             user,
+		Token:            token,
+		ExpiresAt:        claims.RegisteredClaims.ExpiresAt.Unix() * 1000,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refresh.ExpiresAt.Unix() * 1000,
+	}, "登录成功", c)
+}
 
-	if jwtStr, err := jwtService.GetRedisJWT(user.Username); err == redis.Nil {
-		if err := utils.SetRedisJWT(token, user.Username); err != nil {
-			global.GVA_LOG.Error("设置登录状态失败!", zap.Error(err))
-			response.FailWithMessage("设置登录状态失败", c)
-			return
-		}
-		utils.SetToken(c, token, int(claims.RegisteredClaims.ExpiresAt.Unix()-time.Now().Unix()))
-		response.OkWithDetailed(systemRes.LoginResponse{
-			User:      user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix() * 1000,
-		}, "登录成功", c)
-	} else if err != nil {
-		global.GVA_LOG.Error("设置登录状态失败!", zap.Error(err))
-		response.FailWithMessage("设置登录状态失败", c)
-	} else {
-		var blackJWT system.JwtBlacklist
-		blackJWT.Jwt = jwtStr
-		if err := jwtService.JsonInBlacklist(blackJWT); err != nil {
-			response.FailWithMessage("jwt作废失败", c)
-			return
-		}
-		if err := utils.SetRedisJWT(token, user.GetUsername()); err != nil {
-			response.FailWithMessage("设置登录状态失败", c)
-			return
-		}
-		utils.SetToken(c, token, int(claims.RegisteredClaims.ExpiresAt.Unix()-time.Now().Unix()))
-		response.OkWithDetailed(systemRes.LoginResponse{
-			User:      user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix() * 1000,
-		}, "登录成功", c)
+// RefreshToken
+// @Tags     Base
+// @Summary  使用refresh token换取新的令牌对
+// @Produce   application/json
+// @Param    data  body      systemReq.RefreshTokenReq                                   true  "refresh token"
+// @Success  200   {object}  response.Response{data=systemRes.LoginResponse,msg=string}  "返回新的access token与refresh token"
+// @Router   /base/refresh [post]
+func (b *BaseApi) RefreshToken(c *gin.Context) {
+	var req systemReq.RefreshTokenReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.RefreshToken == "" {
+		response.NoAuth("refresh token不能为空", c)
+		return
+	}
+	refreshToken, refresh, err := refreshTokenService.RotateRefreshToken(req.RefreshToken, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		global.GVA_LOG.Warn("刷新token失败!", zap.Error(err), zap.String("ip", c.ClientIP()))
+		utils.ClearToken(c)
+		response.NoAuth("登录已过期，请重新登录", c)
+		return
+	}
+	user, err := userService.FindUserById(int(refresh.UserID))
+	if err != nil || user.Enable != 1 {
+		_ = sessionService.RevokeFamilySession(refresh.UserID, refresh.FamilyID)
+		utils.ClearToken(c)
+		response.NoAuth("用户不存在或已被禁止登录", c)
+		return
+	}
+	token, claims, err := utils.LoginToken(user, refresh.FamilyID)
+	if err != nil {
+		global.GVA_LOG.Error("获取token失败!", zap.Error(err))
+		response.FailWithMessage("获取token失败", c)
+		return
 	}
+	if err = sessionService.RenewSession(claims, token, c.ClientIP(), c.Request.UserAgent(), refresh.ExpiresAt); err != nil {
+		global.GVA_LOG.Error("更新会话失败!", zap.Error(err))
+	}
+	utils.SetToken(c, token, int(claims.RegisteredClaims.ExpiresAt.Unix()-time.Now().Unix()))
+	response.OkWithDetailed(systemRes.LoginResponse{
+		User:             *user,
+		Token:            token,
+		ExpiresAt:        claims.RegisteredClaims.ExpiresAt.Unix() * 1000,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refresh.ExpiresAt.Unix() * 1000,
+	}, "刷新成功", c)
 }
 
 // Register
@@ -186,10 +279,15 @@ func (b *BaseApi) Register(c *gin.Context) {
 		})
 	}
 	user := &system.SysUser{Username: r.Username, NickName: r.NickName, Password: r.Password, HeaderImg: r.HeaderImg, AuthorityId: r.AuthorityId, Authorities: authorities, Enable: r.Enable, Phone: r.Phone, Email: r.Email}
-	userReturn, err := userService.Register(*user)
+	user.MustChangePassword = global.GVA_CONFIG.PasswordPolicy.ChangeOnFirstLogin
+	userReturn, err := userService.Register(c.Request.Context(), *user)
 	if err != nil {
 		global.GVA_LOG.Error("注册失败!", zap.Error(err))
-		response.FailWithDetailed(systemRes.SysUserResponse{User: userReturn}, "注册失败", c)
+		msg := "注册失败"
+		if errors.Is(err, utils.ErrPasswordPolicy) {
+			msg = err.Error()
+		}
+		response.FailWithDetailed(systemRes.SysUserResponse{User: userReturn}, msg, c)
 		return
 	}
 	response.OkWithDetailed(systemRes.SysUserResponse{User: userReturn}, "注册成功", c)
@@ -220,7 +318,14 @@ func (b *BaseApi) ChangePassword(c *gin.Context) {
 	err = userService.ChangePassword(u, req.NewPassword)
 	if err != nil {
 		global.GVA_LOG.Error("修改失败!", zap.Error(err))
-		response.FailWithMessage("修改失败，原密码与当前账户不符", c)
+		msg := "修改失败"
+		switch {
+		case errors.Is(err, systemService.ErrOldPasswordWrong):
+			msg = "修改失败，原密码与当前账户不符"
+		case errors.Is(err, utils.ErrPasswordPolicy):
+			msg = err.Error()
+		}
+		response.FailWithMessage(msg, c)
 		return
 	}
 	response.OkWithMessage("修改成功", c)
@@ -247,7 +352,7 @@ func (b *BaseApi) GetUserList(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	list, total, err := userService.GetUserInfoList(pageInfo)
+	list, total, err := userService.GetUserInfoList(c.Request.Context(), pageInfo)
 	if err != nil {
 		global.GVA_LOG.Error("获取失败!", zap.Error(err))
 		response.FailWithMessage("获取失败", c)
@@ -319,7 +424,7 @@ func (b *BaseApi) SetUserAuthorities(c *gin.Context) {
 		return
 	}
 	authorityID := utils.GetUserAuthorityId(c)
-	err = userService.SetUserAuthorities(authorityID, sua.ID, sua.AuthorityIds)
+	err = userService.SetUserAuthorities(c.Request.Context(), authorityID, utils.GetUserID(c), sua.ID, sua.AuthorityIds)
 	if err != nil {
 		global.GVA_LOG.Error("修改失败!", zap.Error(err))
 		response.FailWithMessage("修改失败", c)
@@ -354,7 +459,7 @@ func (b *BaseApi) DeleteUser(c *gin.Context) {
 		response.FailWithMessage("删除失败, 无法删除自己。", c)
 		return
 	}
-	err = userService.DeleteUser(reqId.ID)
+	err = userService.DeleteUser(c.Request.Context(), reqId.ID)
 	if err != nil {
 		global.GVA_LOG.Error("删除失败!", zap.Error(err))
 		response.FailWithMessage("删除失败", c)
@@ -386,14 +491,14 @@ func (b *BaseApi) SetUserInfo(c *gin.Context) {
 	}
 	if len(user.AuthorityIds) != 0 {
 		authorityID := utils.GetUserAuthorityId(c)
-		err = userService.SetUserAuthorities(authorityID, user.ID, user.AuthorityIds)
+		err = userService.SetUserAuthorities(c.Request.Context(), authorityID, utils.GetUserID(c), user.ID, user.AuthorityIds)
 		if err != nil {
 			global.GVA_LOG.Error("设置失败!", zap.Error(err))
 			response.FailWithMessage("设置失败", c)
 			return
 		}
 	}
-	err = userService.SetUserInfo(system.SysUser{
+	err = userService.SetUserInfo(c.Request.Context(), system.SysUser{
 		GVA_MODEL: global.GVA_MODEL{
 			ID: user.ID,
 		},
@@ -488,7 +593,12 @@ func (b *BaseApi) GetUserInfo(c *gin.Context) {
 		response.FailWithMessage("获取失败", c)
 		return
 	}
-	response.OkWithDetailed(gin.H{"userInfo": ReqUser}, "获取成功", c)
+	data := gin.H{"userInfo": ReqUser}
+	// 模拟登录时返回实际操作人 前端据此显示模拟登录标识
+	if claims := utils.GetUserInfo(c); claims != nil && claims.ImpersonatorID != 0 {
+		data["impersonator"] = systemRes.Impersonator{ID: claims.ImpersonatorID, Username: claims.ImpersonatorName}
+	}
+	response.OkWithDetailed(data, "获取成功", c)
 }
 
 // ResetPassword
@@ -506,7 +616,7 @@ func (b *BaseApi) ResetPassword(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = userService.ResetPassword(rps.ID, rps.Password)
+	err = userService.ResetPassword(c.Request.Context(), rps.ID, rps.Password)
 	if err != nil {
 		global.GVA_LOG.Error("重置失败!", zap.Error(err))
 		response.FailWithMessage("重置失败"+err.Error(), c)
@@ -514,3 +624,35 @@ func (b *BaseApi) ResetPassword(c *gin.Context) {
 	}
 	response.OkWithMessage("重置成功", c)
 }
+
+// UnlockUser
+// @Tags      SysUser
+// @Summary   解除用户登录锁定
+// @Security  ApiKeyAuth
+// @Produce  application/json
+// @Param     data  body      systemReq.UnlockUser           true  "用户名"
+// @Success   200   {object}  response.Response{msg=string}  "解除用户登录锁定"
+// @Router    /user/unlockUser [post]
+func (b *BaseApi) UnlockUser(c *gin.Context) {
+	var req systemReq.UnlockUser
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = loginLockService.UnlockUser(c.Request.Context(), req.Username)
+	if err != nil {
+		global.GVA_LOG.Error("解锁失败!", zap.Error(err))
+		response.FailWithMessage("解锁失败", c)
+		return
+	}
+	loginLogService.CreateLoginLog(system.SysLoginLog{
+		Username:     req.Username,
+		Ip:           c.ClientIP(),
+		Agent:        c.Request.UserAgent(),
+		Status:       false,
+		ErrorMessage: "管理员 " + utils.GetUserName(c) + " 解除锁定",
+		Event:        system.LoginEventUnlocked,
+	})
+	response.OkWithMessage("解锁成功", c)
+}
@@ -0,0 +1,167 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateAuthorityGrant
+// @Tags      SysUser
+// @Summary   发起临时角色授权 到达开始时间后生效 到期自动回收
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.CreateAuthorityGrant                                    true  "用户ID, 角色ID, 开始时间, 结束时间, 原因"
+// @Success   200   {object}  response.Response{data=system.SysUserAuthorityGrant,msg=string}  "发起临时角色授权"
+// @Router    /user/createAuthorityGrant [post]
+func (b *BaseApi) CreateAuthorityGrant(c *gin.Context) {
+	var req systemReq.CreateAuthorityGrant
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	grant, err := userService.CreateAuthorityGrant(c.Request.Context(), utils.GetUserAuthorityId(c), utils.GetUserID(c), req)
+	if err != nil {
+		global.GVA_LOG.Error("授权失败!", zap.Error(err))
+		response.FailWithMessage("授权失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(grant, "授权成功", c)
+}
+
+// ApproveAuthorityGrant
+// @Tags      SysUser
+// @Summary   审批通过临时角色授权 审批人不能是发起人
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.AuthorityGrantAction  true  "临时授权ID, 审批意见"
+// @Success   200   {object}  response.Response{msg=string}   "审批通过临时角色授权"
+// @Router    /user/approveAuthorityGrant [post]
+func (b *BaseApi) ApproveAuthorityGrant(c *gin.Context) {
+	var req systemReq.AuthorityGrantAction
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = userService.ApproveAuthorityGrant(utils.GetUserAuthorityId(c), utils.GetUserID(c), req); err != nil {
+		global.GVA_LOG.Error("审批失败!", zap.Error(err))
+		response.FailWithMessage("审批失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("审批成功", c)
+}
+
+// RejectAuthorityGrant
+// @Tags      SysUser
+// @Summary   拒绝临时角色授权
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.AuthorityGrantAction  true  "临时授权ID, 审批意见"
+// @Success   200   {object}  response.Response{msg=string}   "拒绝临时角色授权"
+// @Router    /user/rejectAuthorityGrant [post]
+func (b *BaseApi) RejectAuthorityGrant(c *gin.Context) {
+	var req systemReq.AuthorityGrantAction
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = userService.RejectAuthorityGrant(utils.GetUserAuthorityId(c), utils.GetUserID(c), req); err != nil {
+		global.GVA_LOG.Error("拒绝失败!", zap.Error(err))
+		response.FailWithMessage("拒绝失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("已拒绝", c)
+}
+
+// RevokeAuthorityGrant
+// @Tags      SysUser
+// @Summary   撤销临时角色授权 已生效的立即回收
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.AuthorityGrantAction  true  "临时授权ID, 撤销原因"
+// @Success   200   {object}  response.Response{msg=string}   "撤销临时角色授权"
+// @Router    /user/revokeAuthorityGrant [post]
+func (b *BaseApi) RevokeAuthorityGrant(c *gin.Context) {
+	var req systemReq.AuthorityGrantAction
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = userService.RevokeAuthorityGrant(utils.GetUserAuthorityId(c), utils.GetUserID(c), req); err != nil {
+		global.GVA_LOG.Error("撤销失败!", zap.Error(err))
+		response.FailWithMessage("撤销失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("撤销成功", c)
+}
+
+// GetAuthorityGrantList
+// @Tags      SysUser
+// @Summary   分页获取临时角色授权
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.AuthorityGrantSearch                          true  "页码, 每页大小, 用户ID, 状态"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取临时角色授权"
+// @Router    /user/getAuthorityGrantList [post]
+func (b *BaseApi) GetAuthorityGrantList(c *gin.Context) {
+	var pageInfo systemReq.AuthorityGrantSearch
+	err := c.ShouldBindJSON(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := userService.GetAuthorityGrantList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetAuthorityAuditList
+// @Tags      SysUser
+// @Summary   分页获取用户角色授权审计记录
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.AuthorityAuditSearch                          true  "页码, 每页大小, 用户ID, 角色ID, 动作"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取用户角色授权审计记录"
+// @Router    /user/getAuthorityAuditList [post]
+func (b *BaseApi) GetAuthorityAuditList(c *gin.Context) {
+	var pageInfo systemReq.AuthorityAuditSearch
+	err := c.ShouldBindJSON(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := userService.GetAuthorityAuditList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
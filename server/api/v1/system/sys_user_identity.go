@@ -0,0 +1,184 @@
+package system
+
+import (
+	"errors"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/identity"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GetIdentityProviders
+// @Tags     Base
+// @Summary  获取已启用的外部身份提供方
+// @Produce   application/json
+// @Success  200  {object}  response.Response{data=[]identity.ProviderInfo,msg=string}  "返回提供方名称,类型,展示名称"
+// @Router   /base/identityProviders [get]
+func (b *BaseApi) GetIdentityProviders(c *gin.Context) {
+	response.OkWithDetailed(identity.List(), "获取成功", c)
+}
+
+// SSOAuthorize
+// @Tags     Base
+// @Summary  发起单点登录 返回身份提供方授权地址
+// @Produce   application/json
+// @Param    data  body      systemReq.SSOAuthorizeReq                                   true  "身份提供方名称"
+// @Success  200   {object}  response.Response{data=systemRes.SSOAuthorizeResponse,msg=string}  "返回授权地址"
+// @Router   /base/ssoAuthorize [post]
+func (b *BaseApi) SSOAuthorize(c *gin.Context) {
+	var req systemReq.SSOAuthorizeReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	url, err := userIdentityService.BeginSSO(c.Request.Context(), req.Provider, 0)
+	if err != nil {
+		global.GVA_LOG.Error("发起单点登录失败!", zap.Error(err))
+		response.FailWithMessage(identityErrMessage(err, "发起单点登录失败"), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.SSOAuthorizeResponse{Url: url}, "获取成功", c)
+}
+
+// SSOCallback
+// @Tags     Base
+// @Summary  单点登录回调 登录时返回token 绑定时返回绑定结果
+// @Produce   application/json
+// @Param    data  body      systemReq.SSOCallbackReq                                    true  "code, state"
+// @Success  200   {object}  response.Response{data=systemRes.LoginResponse,msg=string}  "返回包括用户信息,token,过期时间"
+// @Router   /base/ssoCallback [post]
+func (b *BaseApi) SSOCallback(c *gin.Context) {
+	var req systemReq.SSOCallbackReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	user, linked, err := userIdentityService.FinishSSO(c.Request.Context(), req.Code, req.State)
+	if err != nil {
+		global.GVA_LOG.Error("单点登录失败!", zap.Error(err))
+		msg := identityErrMessage(err, "单点登录失败")
+		loginLogService.CreateLoginLog(system.SysLoginLog{
+			Ip:           c.ClientIP(),
+			Agent:        c.Request.UserAgent(),
+			Status:       false,
+			ErrorMessage: msg,
+		})
+		response.FailWithMessage(msg, c)
+		return
+	}
+	if linked {
+		response.OkWithDetailed(systemRes.SSOCallbackResponse{Linked: true}, "绑定成功", c)
+		return
+	}
+	if user.Enable != 1 {
+		response.FailWithMessage("用户被禁止登录", c)
+		return
+	}
+	b.loginNext(c, user)
+}
+
+// LinkIdentity
+// @Tags      SysUser
+// @Summary   绑定外部身份 LDAP直接校验账号密码 OIDC返回授权地址
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.LinkIdentityReq                                         true  "身份提供方,账号,密码"
+// @Success   200   {object}  response.Response{data=systemRes.SSOAuthorizeResponse,msg=string}  "绑定外部身份"
+// @Router    /user/linkIdentity [post]
+func (b *BaseApi) LinkIdentity(c *gin.Context) {
+	var req systemReq.LinkIdentityReq
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	p, err := identity.GetProvider(req.Provider)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	userID := utils.GetUserID(c)
+	if _, ok := p.(identity.PasswordProvider); ok {
+		err = userIdentityService.LinkPasswordIdentity(c.Request.Context(), userID, req.Provider, req.Username, req.Password)
+		if err != nil {
+			global.GVA_LOG.Error("绑定失败!", zap.Error(err))
+			response.FailWithMessage(identityErrMessage(err, "绑定失败"), c)
+			return
+		}
+		response.OkWithMessage("绑定成功", c)
+		return
+	}
+	url, err := userIdentityService.BeginSSO(c.Request.Context(), req.Provider, userID)
+	if err != nil {
+		global.GVA_LOG.Error("发起绑定失败!", zap.Error(err))
+		response.FailWithMessage(identityErrMessage(err, "发起绑定失败"), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.SSOAuthorizeResponse{Url: url}, "获取成功", c)
+}
+
+// GetIdentities
+// @Tags      SysUser
+// @Summary   获取当前用户绑定的外部身份
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]system.SysUserIdentity,msg=string}  "获取当前用户绑定的外部身份"
+// @Router    /user/getIdentities [get]
+func (b *BaseApi) GetIdentities(c *gin.Context) {
+	list, err := userIdentityService.GetUserIdentities(utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// UnlinkIdentity
+// @Tags      SysUser
+// @Summary   解除外部身份绑定
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "绑定记录ID"
+// @Success   200   {object}  response.Response{msg=string}  "解除外部身份绑定"
+// @Router    /user/unlinkIdentity [post]
+func (b *BaseApi) UnlinkIdentity(c *gin.Context) {
+	var reqId request.GetById
+	err := c.ShouldBindJSON(&reqId)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = userIdentityService.UnlinkIdentity(utils.GetUserID(c), uint(reqId.ID))
+	if err != nil {
+		global.GVA_LOG.Error("解绑失败!", zap.Error(err))
+		response.FailWithMessage("解绑失败", c)
+		return
+	}
+	response.OkWithMessage("解绑成功", c)
+}
+
+// identityErrMessage 业务错误直接提示 连接失败等内部错误使用通用提示
+func identityErrMessage(err error, fallback string) string {
+	switch {
+	case errors.Is(err, identity.ErrInvalidCredentials),
+		errors.Is(err, identity.ErrProviderNotFound),
+		errors.Is(err, systemService.ErrIdentityNotLinked),
+		errors.Is(err, systemService.ErrIdentityUsernameTaken),
+		errors.Is(err, systemService.ErrIdentityLinkedToOther):
+		return err.Error()
+	}
+	return fallback
+}
@@ -0,0 +1,96 @@
+package system
+
+import (
+	"errors"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Impersonate
+// @Tags      SysUser
+// @Summary   模拟登录 以目标用户身份访问系统 期间的操作记录会标记实际操作人
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                                             true  "目标用户ID"
+// @Success   200   {object}  response.Response{data=systemRes.LoginResponse,msg=string}  "返回目标用户信息,模拟登录token,过期时间"
+// @Router    /user/impersonate [post]
+func (b *BaseApi) Impersonate(c *gin.Context) {
+	var reqId request.GetById
+	err := c.ShouldBindJSON(&reqId)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	operator := utils.GetUserInfo(c)
+	if operator == nil {
+		response.FailWithMessage("获取当前用户失败", c)
+		return
+	}
+	user, token, claims, err := userService.Impersonate(c.Request.Context(), operator, uint(reqId.ID))
+	if err != nil {
+		global.GVA_LOG.Error("模拟登录失败!", zap.Error(err))
+		msg := "模拟登录失败"
+		if errors.Is(err, systemService.ErrImpersonateForbidden) || errors.Is(err, systemService.ErrImpersonateNested) ||
+			errors.Is(err, systemService.ErrImpersonateSelf) || errors.Is(err, systemService.ErrImpersonateTarget) {
+			msg = err.Error()
+		}
+		response.FailWithMessage(msg, c)
+		return
+	}
+	loginLogService.CreateLoginLog(system.SysLoginLog{
+		Username:         user.Username,
+		Ip:               c.ClientIP(),
+		Agent:            c.Request.UserAgent(),
+		Status:           true,
+		UserID:           user.ID,
+		Event:            system.LoginEventImpersonate,
+		ImpersonatorID:   operator.BaseClaims.ID,
+		ImpersonatorName: operator.Username,
+	})
+	response.OkWithDetailed(systemRes.LoginResponse{
+		User:      user,
+		Token:     token,
+		ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix() * 1000,
+	}, "模拟登录成功", c)
+}
+
+// StopImpersonate
+// @Tags      SysUser
+// @Summary   退出模拟登录 作废当前模拟登录token
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{msg=string}  "退出模拟登录"
+// @Router    /user/stopImpersonate [post]
+func (b *BaseApi) StopImpersonate(c *gin.Context) {
+	claims := utils.GetUserInfo(c)
+	if claims == nil || claims.ImpersonatorID == 0 {
+		response.FailWithMessage("当前未处于模拟登录", c)
+		return
+	}
+	err := jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: utils.GetToken(c)})
+	if err != nil {
+		global.GVA_LOG.Error("退出模拟登录失败!", zap.Error(err))
+		response.FailWithMessage("退出模拟登录失败", c)
+		return
+	}
+	loginLogService.CreateLoginLog(system.SysLoginLog{
+		Username:         claims.Username,
+		Ip:               c.ClientIP(),
+		Agent:            c.Request.UserAgent(),
+		Status:           true,
+		UserID:           claims.BaseClaims.ID,
+		Event:            system.LoginEventStopImpersonate,
+		ImpersonatorID:   claims.ImpersonatorID,
+		ImpersonatorName: claims.ImpersonatorName,
+	})
+	response.OkWithMessage("已退出模拟登录", c)
+}
@@ -0,0 +1,247 @@
+package system
+
+import (
+	"errors"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ForgotPassword
+// @Tags      Base
+// @Summary   找回密码 向邮箱发送重置密码链接
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.ForgotPassword       true  "邮箱, 验证码"
+// @Success   200   {object}  response.Response{msg=string}  "发送重置密码邮件"
+// @Router    /base/forgotPassword [post]
+func (b *BaseApi) ForgotPassword(c *gin.Context) {
+	var req systemReq.ForgotPassword
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.Captcha == "" || req.CaptchaId == "" || !store.Verify(req.CaptchaId, req.Captcha, true) {
+		response.FailWithMessage("验证码错误", c)
+		return
+	}
+	if err = userService.ForgotPassword(req.Email); err != nil {
+		global.GVA_LOG.Error("发送重置密码邮件失败!", zap.Error(err))
+	}
+	// 无论邮箱是否存在都返回相同结果
+	response.OkWithMessage("如果该邮箱已绑定账号 重置密码链接将发送至该邮箱", c)
+}
+
+// ResetPasswordByToken
+// @Tags      Base
+// @Summary   通过邮件中的链接重置密码
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.ResetPasswordByToken  true  "链接令牌, 新密码"
+// @Success   200   {object}  response.Response{msg=string}   "重置密码"
+// @Router    /base/resetPasswordByToken [post]
+func (b *BaseApi) ResetPasswordByToken(c *gin.Context) {
+	var req systemReq.ResetPasswordByToken
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = userService.ResetPasswordByToken(req.Token, req.Password)
+	if err != nil {
+		global.GVA_LOG.Error("重置密码失败!", zap.Error(err))
+		msg := "重置密码失败"
+		if errors.Is(err, utils.ErrPasswordPolicy) || errors.Is(err, systemService.ErrResetTokenInvalid) {
+			msg = err.Error()
+		}
+		response.FailWithMessage(msg, c)
+		return
+	}
+	response.OkWithMessage("密码已重置 请使用新密码登录", c)
+}
+
+// GetRegistrationOptions
+// @Tags      Base
+// @Summary   获取注册页信息 是否开放注册以及邀请链接信息
+// @Produce   application/json
+// @Param     invitation  query     string                                                     false  "邀请令牌"
+// @Success   200         {object}  response.Response{data=systemRes.RegistrationOptions,msg=string}  "注册页信息"
+// @Router    /base/registrationOptions [get]
+func (b *BaseApi) GetRegistrationOptions(c *gin.Context) {
+	options, err := userService.GetRegistrationOptions(c.Query("invitation"))
+	if err != nil {
+		if errors.Is(err, systemService.ErrInvitationInvalid) {
+			response.FailWithMessage(err.Error(), c)
+			return
+		}
+		global.GVA_LOG.Error("获取注册信息失败!", zap.Error(err))
+		response.FailWithMessage("获取注册信息失败", c)
+		return
+	}
+	response.OkWithDetailed(options, "获取成功", c)
+}
+
+// SelfRegister
+// @Tags      Base
+// @Summary   自助注册或邀请注册 提交后需完成邮箱验证
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.SelfRegister         true  "用户名, 昵称, 密码, 邮箱, 邀请令牌, 验证码"
+// @Success   200   {object}  response.Response{msg=string}  "发送验证邮件"
+// @Router    /base/selfRegister [post]
+func (b *BaseApi) SelfRegister(c *gin.Context) {
+	var req systemReq.SelfRegister
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if req.Captcha == "" || req.CaptchaId == "" || !store.Verify(req.CaptchaId, req.Captcha, true) {
+		response.FailWithMessage("验证码错误", c)
+		return
+	}
+	err = userService.SelfRegister(req)
+	if err != nil {
+		global.GVA_LOG.Error("注册失败!", zap.Error(err))
+		msg := "注册失败"
+		if errors.Is(err, utils.ErrPasswordPolicy) || isSelfServiceError(err) {
+			msg = err.Error()
+		}
+		response.FailWithMessage(msg, c)
+		return
+	}
+	response.OkWithMessage("验证邮件已发送 请前往邮箱完成验证", c)
+}
+
+// VerifyRegistration
+// @Tags      Base
+// @Summary   验证注册邮箱 验证通过后创建用户
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.VerifyRegistration   true  "验证令牌"
+// @Success   200   {object}  response.Response{msg=string}  "完成注册"
+// @Router    /base/verifyRegistration [post]
+func (b *BaseApi) VerifyRegistration(c *gin.Context) {
+	var req systemReq.VerifyRegistration
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	_, err = userService.VerifyRegistration(req.Token)
+	if err != nil {
+		global.GVA_LOG.Error("验证注册失败!", zap.Error(err))
+		msg := "验证失败"
+		if isSelfServiceError(err) {
+			msg = err.Error()
+		}
+		response.FailWithMessage(msg, c)
+		return
+	}
+	response.OkWithMessage("注册成功 请登录", c)
+}
+
+// CreateInvitation
+// @Tags      SysUser
+// @Summary   生成注册邀请链接 链接只返回一次
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.CreateInvitation                                            true  "角色ID, 邮箱域名, 有效天数, 备注"
+// @Success   200   {object}  response.Response{data=systemRes.CreateInvitationResponse,msg=string}  "返回邀请链接"
+// @Router    /user/createInvitation [post]
+func (b *BaseApi) CreateInvitation(c *gin.Context) {
+	var req systemReq.CreateInvitation
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	link, err := userService.CreateInvitation(utils.GetUserAuthorityId(c), utils.GetUserID(c), req)
+	if err != nil {
+		global.GVA_LOG.Error("生成邀请失败!", zap.Error(err))
+		response.FailWithMessage("生成邀请失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.CreateInvitationResponse{Url: link}, "生成成功", c)
+}
+
+// GetInvitationList
+// @Tags      SysUser
+// @Summary   分页获取注册邀请
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.SysUserInvitationSearch                      true  "页码, 每页大小"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "分页获取注册邀请"
+// @Router    /user/getInvitationList [post]
+func (b *BaseApi) GetInvitationList(c *gin.Context) {
+	var pageInfo systemReq.SysUserInvitationSearch
+	err := c.ShouldBindJSON(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := userService.GetInvitationList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// RevokeInvitation
+// @Tags      SysUser
+// @Summary   作废注册邀请
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "邀请ID"
+// @Success   200   {object}  response.Response{msg=string}  "作废注册邀请"
+// @Router    /user/revokeInvitation [post]
+func (b *BaseApi) RevokeInvitation(c *gin.Context) {
+	var reqId request.GetById
+	err := c.ShouldBindJSON(&reqId)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if err = userService.RevokeInvitation(uint(reqId.ID)); err != nil {
+		global.GVA_LOG.Error("作废失败!", zap.Error(err))
+		response.FailWithMessage("作废失败", c)
+		return
+	}
+	response.OkWithMessage("作废成功", c)
+}
+
+// isSelfServiceError 可以直接提示给用户的注册相关错误
+func isSelfServiceError(err error) bool {
+	for _, e := range []error{
+		systemService.ErrResetTokenInvalid,
+		systemService.ErrSelfRegistrationDisabled,
+		systemService.ErrInvitationInvalid,
+		systemService.ErrInvitationEmailDomain,
+		systemService.ErrEmailInvalid,
+		systemService.ErrEmailTaken,
+		systemService.ErrUsernameTaken,
+	} {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,6 @@
+package config
+
+type AccessKey struct {
+	ClockSkew   int   `mapstructure:"clock-skew" json:"clock-skew" yaml:"clock-skew"`          // 签名请求允许的时间偏差 同时也是nonce的防重放窗口 单位:s(秒)
+	MaxBodySize int64 `mapstructure:"max-body-size" json:"max-body-size" yaml:"max-body-size"` // 签名请求体的最大长度 计算签名需要读入整个请求体 单位:MB 默认10
+}
@@ -0,0 +1,8 @@
+package config
+
+type Audit struct {
+	SigningKey         string `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`                         // 检查点签名密钥 必须单独配置且不能与jwt的签名密钥相同 为空时不生成检查点
+	CheckpointInterval string `mapstructure:"checkpoint-interval" json:"checkpoint-interval" yaml:"checkpoint-interval"` // 生成检查点的间隔 如1h
+	RetentionDays      int    `mapstructure:"retention-days" json:"retention-days" yaml:"retention-days"`                // 审计日志在库中保留的天数 超出后归档 0为不归档
+	ArchiveDir         string `mapstructure:"archive-dir" json:"archive-dir" yaml:"archive-dir"`                         // 归档文件目录
+}
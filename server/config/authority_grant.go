@@ -0,0 +1,8 @@
+package config
+
+type AuthorityGrant struct {
+	RequireApproval    bool   `mapstructure:"require-approval" json:"require-approval" yaml:"require-approval"`             // 临时授权需要另一名管理员审批后才会生效
+	MaxDuration        string `mapstructure:"max-duration" json:"max-duration" yaml:"max-duration"`                         // 单次临时授权的最长时长 为空表示不限制
+	Interval           string `mapstructure:"interval" json:"interval" yaml:"interval"`                                     // 定时生效与回收临时授权的间隔 为空时1m
+	DefaultAuthorityId uint   `mapstructure:"default-authority-id" json:"default-authority-id" yaml:"default-authority-id"` // 回收临时角色后用户没有其他角色时切换到的角色 为0时回收失败
+}
@@ -9,6 +9,28 @@ type Server struct {
 	Email     Email   `mapstructure:"email" json:"email" yaml:"email"`
 	System    System  `mapstructure:"system" json:"system" yaml:"system"`
 	Captcha   Captcha `mapstructure:"captcha" json:"captcha" yaml:"captcha"`
+	// 登录失败锁定
+	LoginLock LoginLock `mapstructure:"login-lock" json:"login-lock" yaml:"login-lock"`
+	// AK/SK 签名鉴权
+	AccessKey AccessKey `mapstructure:"access-key" json:"access-key" yaml:"access-key"`
+	// 模拟登录
+	Impersonation Impersonation `mapstructure:"impersonation" json:"impersonation" yaml:"impersonation"`
+	// 临时授权
+	AuthorityGrant AuthorityGrant `mapstructure:"authority-grant" json:"authority-grant" yaml:"authority-grant"`
+	// 多租户
+	Tenant Tenant `mapstructure:"tenant" json:"tenant" yaml:"tenant"`
+	// 找回密码 邀请注册与自助注册
+	SelfService SelfService `mapstructure:"self-service" json:"self-service" yaml:"self-service"`
+	// 密码策略
+	PasswordPolicy PasswordPolicy `mapstructure:"password-policy" json:"password-policy" yaml:"password-policy"`
+	// 外部身份提供方
+	Identity Identity `mapstructure:"identity" json:"identity" yaml:"identity"`
+	// 操作记录异步写入
+	OperationRecord OperationRecord `mapstructure:"operation-record" json:"operation-record" yaml:"operation-record"`
+	// 敏感数据脱敏
+	Redaction Redaction `mapstructure:"redaction" json:"redaction" yaml:"redaction"`
+	// 审计日志哈希链 检查点与归档
+	Audit Audit `mapstructure:"audit" json:"audit" yaml:"audit"`
 	// auto
 	AutoCode Autocode `mapstructure:"autocode" json:"autocode" yaml:"autocode"`
 	// gorm
@@ -0,0 +1,51 @@
+package config
+
+type Identity struct {
+	Providers []IdentityProvider `mapstructure:"providers" json:"providers" yaml:"providers"` // 外部身份提供方
+}
+
+type IdentityProvider struct {
+	Name               string                 `mapstructure:"name" json:"name" yaml:"name"`                                                 // 唯一标识 登录时指定
+	Type               string                 `mapstructure:"type" json:"type" yaml:"type"`                                                 // 类型: oidc | ldap
+	DisplayName        string                 `mapstructure:"display-name" json:"display-name" yaml:"display-name"`                         // 登录页展示名称
+	Enable             bool                   `mapstructure:"enable" json:"enable" yaml:"enable"`                                           // 是否启用
+	AutoCreate         bool                   `mapstructure:"auto-create" json:"auto-create" yaml:"auto-create"`                            // 首次登录时自动创建本地用户
+	AutoLink           bool                   `mapstructure:"auto-link" json:"auto-link" yaml:"auto-link"`                                  // 首次登录时按用户名自动关联已有本地用户
+	SyncAuthorities    bool                   `mapstructure:"sync-authorities" json:"sync-authorities" yaml:"sync-authorities"`             // 每次登录按组映射同步用户角色
+	DefaultAuthorityId uint                   `mapstructure:"default-authority-id" json:"default-authority-id" yaml:"default-authority-id"` // 没有匹配的组映射时自动创建用户使用的角色
+	GroupMappings      []IdentityGroupMapping `mapstructure:"group-mappings" json:"group-mappings" yaml:"group-mappings"`                   // 组与角色的映射
+	OIDC               OIDCProvider           `mapstructure:"oidc" json:"oidc" yaml:"oidc"`
+	LDAP               LDAPProvider           `mapstructure:"ldap" json:"ldap" yaml:"ldap"`
+}
+
+type IdentityGroupMapping struct {
+	Group       string `mapstructure:"group" json:"group" yaml:"group"`                      // IdP组名或LDAP组cn
+	AuthorityId uint   `mapstructure:"authority-id" json:"authority-id" yaml:"authority-id"` // 映射到的角色ID
+}
+
+type OIDCProvider struct {
+	Issuer        string   `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                         // 签发者地址 用于发现配置
+	ClientID      string   `mapstructure:"client-id" json:"client-id" yaml:"client-id"`                // 客户端ID
+	ClientSecret  string   `mapstructure:"client-secret" json:"client-secret" yaml:"client-secret"`    // 客户端密钥 公共客户端可为空
+	RedirectURL   string   `mapstructure:"redirect-url" json:"redirect-url" yaml:"redirect-url"`       // 回调地址 指向前端登录页
+	Scopes        []string `mapstructure:"scopes" json:"scopes" yaml:"scopes"`                         // 默认 openid profile email
+	UsernameClaim string   `mapstructure:"username-claim" json:"username-claim" yaml:"username-claim"` // 用户名字段 默认 preferred_username
+	GroupsClaim   string   `mapstructure:"groups-claim" json:"groups-claim" yaml:"groups-claim"`       // 组字段 默认 groups
+}
+
+type LDAPProvider struct {
+	URL                string `mapstructure:"url" json:"url" yaml:"url"`                                                    // ldap://host:389 或 ldaps://host:636
+	StartTLS           bool   `mapstructure:"start-tls" json:"start-tls" yaml:"start-tls"`                                  // 是否使用StartTLS
+	InsecureSkipVerify bool   `mapstructure:"insecure-skip-verify" json:"insecure-skip-verify" yaml:"insecure-skip-verify"` // 跳过证书校验 仅测试环境使用
+	BindDN             string `mapstructure:"bind-dn" json:"bind-dn" yaml:"bind-dn"`                                        // 查询用户使用的服务账号 为空时匿名查询
+	BindPassword       string `mapstructure:"bind-password" json:"bind-password" yaml:"bind-password"`                      // 服务账号密码
+	BaseDN             string `mapstructure:"base-dn" json:"base-dn" yaml:"base-dn"`                                        // 用户查询根
+	UserFilter         string `mapstructure:"user-filter" json:"user-filter" yaml:"user-filter"`                            // 用户查询条件 %s为用户名 默认 (uid=%s)
+	UsernameAttr       string `mapstructure:"username-attr" json:"username-attr" yaml:"username-attr"`                      // 用户名属性 默认 uid
+	EmailAttr          string `mapstructure:"email-attr" json:"email-attr" yaml:"email-attr"`                               // 邮箱属性 默认 mail
+	NameAttr           string `mapstructure:"name-attr" json:"name-attr" yaml:"name-attr"`                                  // 昵称属性 默认 cn
+	PhoneAttr          string `mapstructure:"phone-attr" json:"phone-attr" yaml:"phone-attr"`                               // 手机号属性 默认 telephoneNumber
+	GroupBaseDN        string `mapstructure:"group-base-dn" json:"group-base-dn" yaml:"group-base-dn"`                      // 组查询根 为空时不查询组
+	GroupFilter        string `mapstructure:"group-filter" json:"group-filter" yaml:"group-filter"`                         // 组查询条件 %s为用户DN 默认 (member=%s)
+	GroupAttr          string `mapstructure:"group-attr" json:"group-attr" yaml:"group-attr"`                               // 组名属性 默认 cn
+}
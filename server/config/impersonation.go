@@ -0,0 +1,16 @@
+package config
+
+type Impersonation struct {
+	AuthorityIds []uint `mapstructure:"authority-ids" json:"authority-ids" yaml:"authority-ids"` // 允许模拟登录的角色 为空表示关闭模拟登录 这些角色的用户也不能被模拟
+	ExpiresTime  string `mapstructure:"expires-time" json:"expires-time" yaml:"expires-time"`    // 模拟登录token有效期 到期后需重新发起 为空时1h
+}
+
+// Allowed 角色是否允许模拟登录其他用户
+func (i Impersonation) Allowed(authorityId uint) bool {
+	for _, id := range i.AuthorityIds {
+		if id == authorityId {
+			return true
+		}
+	}
+	return false
+}
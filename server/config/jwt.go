@@ -1,8 +1,18 @@
 package config
 
 type JWT struct {
-	SigningKey  string `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`    // jwt签名
-	ExpiresTime string `mapstructure:"expires-time" json:"expires-time" yaml:"expires-time"` // 过期时间
-	BufferTime  string `mapstructure:"buffer-time" json:"buffer-time" yaml:"buffer-time"`    // 缓冲时间
-	Issuer      string `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                   // 签发者
+	SigningKey         string   `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`                            // jwt签名
+	ExpiresTime        string   `mapstructure:"expires-time" json:"expires-time" yaml:"expires-time"`                         // access token过期时间
+	RefreshExpiresTime string   `mapstructure:"refresh-expires-time" json:"refresh-expires-time" yaml:"refresh-expires-time"` // refresh token过期时间
+	Issuer             string   `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                                           // 签发者
+	SigningMethod      string   `mapstructure:"signing-method" json:"signing-method" yaml:"signing-method"`                   // 签名算法:HS256(默认)|RS256|ES256|EdDSA
+	RotationGrace      string   `mapstructure:"rotation-grace" json:"rotation-grace" yaml:"rotation-grace"`                   // 密钥轮换后旧密钥仍可验签的宽限期 为空时等于过期时间
+	Keys               []JWTKey `mapstructure:"keys" json:"keys" yaml:"keys"`                                                 // 非对称签名密钥列表 按kid区分
+	BlacklistPoll      string   `mapstructure:"blacklist-poll" json:"blacklist-poll" yaml:"blacklist-poll"`                   // 未开启redis时从数据库同步其他实例拉黑的token的间隔 为空时10s
+}
+
+type JWTKey struct {
+	Kid        string `mapstructure:"kid" json:"kid" yaml:"kid"`                         // 密钥ID 写入token头部
+	PrivateKey string `mapstructure:"private-key" json:"private-key" yaml:"private-key"` // PEM格式私钥文件路径或PEM内容(PKCS8/PKCS1/SEC1)
+	ActiveFrom string `mapstructure:"active-from" json:"active-from" yaml:"active-from"` // 开始用于签名的时间(RFC3339) 为空表示立即生效
 }
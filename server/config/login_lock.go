@@ -0,0 +1,9 @@
+package config
+
+type LoginLock struct {
+	MaxAttempts  int `mapstructure:"max-attempts" json:"max-attempts" yaml:"max-attempts"`    // 连续失败多少次后锁定账号 0表示不锁定
+	Window       int `mapstructure:"window" json:"window" yaml:"window"`                      // 失败次数统计窗口 单位:s(秒)
+	LockDuration int `mapstructure:"lock-duration" json:"lock-duration" yaml:"lock-duration"` // 首次锁定时长 之后每次锁定翻倍 单位:s(秒)
+	MaxDuration  int `mapstructure:"max-duration" json:"max-duration" yaml:"max-duration"`    // 最长锁定时长 单位:s(秒)
+	ResetAfter   int `mapstructure:"reset-after" json:"reset-after" yaml:"reset-after"`       // 超过该时间未再被锁定则锁定时长重新从首次锁定时长计算 单位:s(秒)
+}
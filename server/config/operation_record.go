@@ -0,0 +1,8 @@
+package config
+
+type OperationRecord struct {
+	QueueSize     int    `mapstructure:"queue-size" json:"queue-size" yaml:"queue-size"`             // 内存队列长度
+	BatchSize     int    `mapstructure:"batch-size" json:"batch-size" yaml:"batch-size"`             // 每批写入的记录数
+	FlushInterval int    `mapstructure:"flush-interval" json:"flush-interval" yaml:"flush-interval"` // 不足一批时的最长写入间隔 单位:ms(毫秒)
+	DropPolicy    string `mapstructure:"drop-policy" json:"drop-policy" yaml:"drop-policy"`          // 队列已满时的丢弃策略 newest:丢弃新记录 oldest:丢弃队列中最早的记录
+}
@@ -0,0 +1,13 @@
+package config
+
+type PasswordPolicy struct {
+	MinLength          int      `mapstructure:"min-length" json:"min-length" yaml:"min-length"`                                  // 最小长度 0表示不限制
+	RequireUpper       bool     `mapstructure:"require-upper" json:"require-upper" yaml:"require-upper"`                         // 必须包含大写字母
+	RequireLower       bool     `mapstructure:"require-lower" json:"require-lower" yaml:"require-lower"`                         // 必须包含小写字母
+	RequireDigit       bool     `mapstructure:"require-digit" json:"require-digit" yaml:"require-digit"`                         // 必须包含数字
+	RequireSymbol      bool     `mapstructure:"require-symbol" json:"require-symbol" yaml:"require-symbol"`                      // 必须包含特殊字符
+	BannedPasswords    []string `mapstructure:"banned-passwords" json:"banned-passwords" yaml:"banned-passwords"`                // 禁止使用的弱密码 不区分大小写
+	HistoryCount       int      `mapstructure:"history-count" json:"history-count" yaml:"history-count"`                         // 不允许与最近N次使用过的密码相同 0表示不限制
+	MaxAge             int      `mapstructure:"max-age" json:"max-age" yaml:"max-age"`                                           // 密码有效期 单位:天 0表示永不过期
+	ChangeOnFirstLogin bool     `mapstructure:"change-on-first-login" json:"change-on-first-login" yaml:"change-on-first-login"` // 管理员创建的用户首次登录必须修改密码
+}
@@ -0,0 +1,9 @@
+package config
+
+// Redaction 操作记录与报错邮件中的敏感数据脱敏 在内置的密码 token secret等规则基础上追加
+type Redaction struct {
+	Mask     string   `mapstructure:"mask" json:"mask" yaml:"mask"`             // 替换内容 默认******
+	Fields   []string `mapstructure:"fields" json:"fields" yaml:"fields"`       // json字段 不含"."时匹配任意层级的同名字段 含"."时为从根开始的路径
+	Headers  []string `mapstructure:"headers" json:"headers" yaml:"headers"`    // 请求头名称
+	Patterns []string `mapstructure:"patterns" json:"patterns" yaml:"patterns"` // 正则 有分组时只替换第一个分组
+}
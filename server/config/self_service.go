@@ -0,0 +1,7 @@
+package config
+
+type SelfService struct {
+	SiteURL       string `mapstructure:"site-url" json:"site-url" yaml:"site-url"`                   // 前端访问地址 用于拼接邮件中的链接
+	ResetExpires  string `mapstructure:"reset-expires" json:"reset-expires" yaml:"reset-expires"`    // 找回密码链接有效期 为空时30m
+	VerifyExpires string `mapstructure:"verify-expires" json:"verify-expires" yaml:"verify-expires"` // 注册邮箱验证链接有效期 为空时24h
+}
@@ -7,9 +7,12 @@ type System struct {
 	Addr          int    `mapstructure:"addr" json:"addr" yaml:"addr"` // 端口值
 	LimitCountIP  int    `mapstructure:"iplimit-count" json:"iplimit-count" yaml:"iplimit-count"`
 	LimitTimeIP   int    `mapstructure:"iplimit-time" json:"iplimit-time" yaml:"iplimit-time"`
-	UseMultipoint bool   `mapstructure:"use-multipoint" json:"use-multipoint" yaml:"use-multipoint"`    // 多点登录拦截
+	UseMultipoint bool   `mapstructure:"use-multipoint" json:"use-multipoint" yaml:"use-multipoint"`    // 多点登录拦截 兼容旧配置 未配置max-sessions时等价于max-sessions为1
+	MaxSessions   int    `mapstructure:"max-sessions" json:"max-sessions" yaml:"max-sessions"`          // 每个用户默认最大同时在线会话数 0为不限制 角色可单独配置
 	UseRedis      bool   `mapstructure:"use-redis" json:"use-redis" yaml:"use-redis"`                   // 使用redis
 	UseMongo      bool   `mapstructure:"use-mongo" json:"use-mongo" yaml:"use-mongo"`                   // 使用mongo
 	UseStrictAuth bool   `mapstructure:"use-strict-auth" json:"use-strict-auth" yaml:"use-strict-auth"` // 使用树形角色分配模式
+	CasbinPoll    string `mapstructure:"casbin-poll" json:"casbin-poll" yaml:"casbin-poll"`             // 未开启redis时从数据库同步其他实例casbin策略变更的间隔 为空时5s
+	DataScopeSQL  bool   `mapstructure:"data-scope-sql" json:"data-scope-sql" yaml:"data-scope-sql"`    // 允许角色使用自定义SQL数据权限 条件会直接拼接进查询 默认关闭
 	DisableAutoMigrate   bool   `mapstructure:"disable-auto-migrate" json:"disable-auto-migrate" yaml:"disable-auto-migrate"`          // 自动迁移数据库表结构，生产环境建议设为false，手动迁移
 }
@@ -0,0 +1,7 @@
+package config
+
+type Tenant struct {
+	Enable bool   `mapstructure:"enable" json:"enable" yaml:"enable"` // 开启多租户 关闭时所有数据都属于平台租户
+	Header string `mapstructure:"header" json:"header" yaml:"header"` // 携带租户编码的请求头 用于未登录时指定租户与平台管理员切换租户 为空时x-tenant
+	Domain string `mapstructure:"domain" json:"domain" yaml:"domain"` // 按子域名解析租户时的主域名 如example.com时acme.example.com解析为编码acme的租户 为空不按子域名解析
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+)
+
+const commandUsage = `用法:
+  server [-c config.yaml] audit verify [-archives]  校验审计哈希链 -archives同时校验归档文件
+  server [-c config.yaml] audit checkpoint          立即生成检查点
+  server [-c config.yaml] audit archive             立即归档超出保留天数的记录`
+
+// RunCommand 执行命令行子命令 返回进程退出码 校验不通过时返回1
+func RunCommand(args []string) int {
+	if len(args) < 2 || args[0] != "audit" {
+		fmt.Fprintln(os.Stderr, commandUsage)
+		return 2
+	}
+	if global.GVA_DB == nil {
+		fmt.Fprintln(os.Stderr, "数据库未配置")
+		return 2
+	}
+	service := system.AuditLogServiceApp
+	switch args[1] {
+	case "verify":
+		fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+		archives := fs.Bool("archives", false, "同时校验归档文件")
+		if err := fs.Parse(args[2:]); err != nil {
+			return 2
+		}
+		res, err := service.VerifyAuditLog(*archives)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "校验失败:", err)
+			return 2
+		}
+		out, _ := json.MarshalIndent(res, "", "  ")
+		fmt.Println(string(out))
+		if !res.Valid {
+			return 1
+		}
+	case "checkpoint":
+		if err := service.CreateAuditCheckpoint(); err != nil {
+			fmt.Fprintln(os.Stderr, "生成检查点失败:", err)
+			return 2
+		}
+	case "archive":
+		count, err := service.ArchiveAuditLog()
+		fmt.Printf("已归档%d条记录\n", count)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "归档失败:", err)
+			return 2
+		}
+	default:
+		fmt.Fprintln(os.Stderr, commandUsage)
+		return 2
+	}
+	return 0
+}
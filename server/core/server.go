@@ -1,9 +1,11 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/initialize"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"go.uber.org/zap"
 	"time"
@@ -24,9 +26,18 @@ func RunServer() {
 			zap.L().Error(fmt.Sprintf("%+v", err))
 		}
 	}
-	// 从db加载jwt数据
+	// 从db加载jwt数据 并持续同步其他实例拉黑的token与用户状态变更
 	if global.GVA_DB != nil {
+		// 一次性数据升级 需在加载jwt黑名单与casbin策略之前执行
+		if err := system.RunMigrations(); err != nil {
+			global.GVA_LOG.Error("数据升级失败!", zap.Error(err))
+		}
 		system.LoadAll()
+		system.WatchBlacklist(context.Background())
+		system.WatchUserStatus(context.Background())
+		system.WatchCasbin(context.Background())
+		response.DataFilter = system.AuthorityFieldServiceApp.FilterResponse
+		system.StartOperationRecordWriter()
 	}
 
 	Router := initialize.Routers()
@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -56,5 +57,13 @@ func initServer(address string, router *gin.Engine, readTimeout, writeTimeout ti
 		zap.L().Fatal("WEB服务关闭异常", zap.Error(err))
 	}
 
+	// 请求处理完毕后写完队列中的操作记录
+	if err := system.StopOperationRecordWriter(ctx); err != nil {
+		zap.L().Error("写入剩余操作记录超时", zap.Error(err))
+	}
+	if err := system.StopApiTokenUsage(ctx); err != nil {
+		zap.L().Error("写入剩余API Token使用记录超时", zap.Error(err))
+	}
+
 	zap.L().Info("WEB服务已关闭")
 }
@@ -19,6 +19,181 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jwt"
+                ],
+                "summary": "获取jwt验签公钥(JWKS)",
+                "responses": {
+                    "200": {
+                        "description": "当前有效的验签公钥",
+                        "schema": {
+                            "$ref": "#/definitions/utils.JWKS"
+                        }
+                    }
+                }
+            }
+        },
+        "/accessKey/createAccessKey": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AccessKey"
+                ],
+                "summary": "签发AK/SK SecretKey只返回一次",
+                "parameters": [
+                    {
+                        "description": "用户ID,角色ID,有效天数,备注",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAccessKey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "返回AccessKey与SecretKey",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.CreateAccessKeyResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/accessKey/deleteAccessKey": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AccessKey"
+                ],
+                "summary": "作废AK/SK",
+                "parameters": [
+                    {
+                        "description": "AK/SK记录ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "作废AK/SK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/accessKey/getAccessKeyList": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AccessKey"
+                ],
+                "summary": "分页获取AK/SK列表",
+                "parameters": [
+                    {
+                        "description": "页码, 每页大小, 用户ID, 状态",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SysAccessKeySearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取AK/SK列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/api/createApi": {
             "post": {
                 "security": [
@@ -674,6 +849,59 @@ const docTemplate = `{
                 }
             }
         },
+        "/auditLog/verifyAuditLog": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AuditLog"
+                ],
+                "summary": "分批校验操作记录与登录日志的审计哈希链 完整校验与归档文件校验使用命令行 audit verify",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "起始位置 检查点或上一批返回的lastSeq 0为从头开始",
+                        "name": "fromSeq",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "本批校验的记录数 最多10000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "校验一批审计哈希链,返回是否通过,校验到的位置,是否到链尾与发现的问题",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysAuditVerifyResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/authority/copyAuthority": {
             "post": {
                 "security": [
@@ -1128,7 +1356,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/autoCode/addFunc": {
+        "/authorityField/getAuthorityField": {
             "post": {
                 "security": [
                     {
@@ -1142,61 +1370,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AddFunc"
+                    "AuthorityField"
                 ],
-                "summary": "增加方法",
+                "summary": "获取模型字段与角色的字段权限",
                 "parameters": [
                     {
-                        "description": "增加方法",
+                        "description": "角色id, 模型",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AutoCode"
+                            "$ref": "#/definitions/request.SysAuthorityFieldReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
+                        "description": "返回字段与规则",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysAuthorityFieldRes"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/autoCode/createPackage": {
-            "post": {
+        "/authorityField/getFieldModels": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AutoCodePackage"
-                ],
-                "summary": "创建package",
-                "parameters": [
-                    {
-                        "description": "创建package",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SysAutoCodePackageCreate"
-                        }
-                    }
+                    "AuthorityField"
                 ],
+                "summary": "获取可配置字段权限的模型",
                 "responses": {
                     "200": {
-                        "description": "创建package成功",
+                        "description": "返回模型列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -1206,8 +1435,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SysFieldModel"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -1220,7 +1451,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/autoCode/createTemp": {
+        "/authorityField/setAuthorityField": {
             "post": {
                 "security": [
                     {
@@ -1234,29 +1465,171 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AutoCodeTemplate"
+                    "AuthorityField"
                 ],
-                "summary": "自动代码模板",
+                "summary": "设置角色的字段权限",
                 "parameters": [
                     {
-                        "description": "创建自动代码",
+                        "description": "角色id, 模型, 字段规则",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AutoCode"
+                            "$ref": "#/definitions/request.SysAuthorityFieldReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
+                        "description": "设置字段权限",
                         "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/autoCode/addFunc": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AddFunc"
+                ],
+                "summary": "增加方法",
+                "parameters": [
+                    {
+                        "description": "增加方法",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AutoCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/autoCode/createPackage": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AutoCodePackage"
+                ],
+                "summary": "创建package",
+                "parameters": [
+                    {
+                        "description": "创建package",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SysAutoCodePackageCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建package成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/autoCode/createTemp": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AutoCodeTemplate"
+                ],
+                "summary": "自动代码模板",
+                "parameters": [
+                    {
+                        "description": "创建自动代码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AutoCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
         },
         "/autoCode/delPackage": {
             "post": {
@@ -1545,6 +1918,45 @@ const docTemplate = `{
                 }
             }
         },
+        "/autoCode/getPluginList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AutoCodePlugin"
+                ],
+                "summary": "获取插件列表",
+                "responses": {
+                    "200": {
+                        "description": "获取插件列表成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PluginInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/autoCode/getSysHistory": {
             "post": {
                 "security": [
@@ -1727,6 +2139,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/autoCode/initDictionary": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AutoCodePlugin"
+                ],
+                "summary": "打包插件",
+                "responses": {
+                    "200": {
+                        "description": "打包插件成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/autoCode/initMenu": {
             "post": {
                 "security": [
@@ -1809,9 +2264,7 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "type": "array",
-                                            "items": {
-                                                "type": "object"
-                                            }
+                                            "items": {}
                                         },
                                         "msg": {
                                             "type": "string"
@@ -1824,7 +2277,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/autoCode/preview": {
+        "/autoCode/mcp": {
             "post": {
                 "security": [
                     {
@@ -1838,47 +2291,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AutoCodeTemplate"
+                    "mcp"
                 ],
-                "summary": "预览创建后的代码",
+                "summary": "自动McpTool",
                 "parameters": [
                     {
-                        "description": "预览创建代码",
+                        "description": "创建自动代码",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AutoCode"
+                            "$ref": "#/definitions/request.AutoMcpTool"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "预览创建后的代码",
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/autoCode/pubPlug": {
+        "/autoCode/mcpList": {
             "post": {
                 "security": [
                     {
@@ -1892,45 +2329,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AutoCodePlugin"
+                    "mcp"
                 ],
-                "summary": "打包插件",
+                "summary": "自动McpTool",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "插件名称",
-                        "name": "plugName",
-                        "in": "query",
-                        "required": true
+                        "description": "创建自动代码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AutoMcpTool"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "打包插件成功",
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/autoCode/rollback": {
+        "/autoCode/mcpTest": {
             "post": {
                 "security": [
                     {
@@ -1944,43 +2367,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AutoCode"
+                    "mcp"
                 ],
-                "summary": "回滚自动生成代码",
+                "summary": "测试McpTool",
                 "parameters": [
                     {
-                        "description": "请求参数",
+                        "description": "调用MCP Tool的参数",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.SysAutoHistoryRollBack"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "回滚自动生成代码",
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"测试成功\"}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
             }
         },
-        "/base/captcha": {
+        "/autoCode/preview": {
             "post": {
                 "security": [
                     {
@@ -1994,12 +2405,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Base"
+                    "AutoCodeTemplate"
+                ],
+                "summary": "预览创建后的代码",
+                "parameters": [
+                    {
+                        "description": "预览创建代码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AutoCode"
+                        }
+                    }
                 ],
-                "summary": "生成验证码",
                 "responses": {
                     "200": {
-                        "description": "生成验证码,返回包括随机数id,base64,验证码长度,是否开启验证码",
+                        "description": "预览创建后的代码",
                         "schema": {
                             "allOf": [
                                 {
@@ -2009,7 +2431,8 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.SysCaptchaResponse"
+                                            "type": "object",
+                                            "additionalProperties": true
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2022,29 +2445,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/base/login": {
+        "/autoCode/pubPlug": {
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Base"
+                    "AutoCodePlugin"
                 ],
-                "summary": "用户登录",
+                "summary": "打包插件",
                 "parameters": [
                     {
-                        "description": "用户名, 密码, 验证码",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.Login"
-                        }
+                        "type": "string",
+                        "description": "插件名称",
+                        "name": "plugName",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "返回包括用户信息,token,过期时间",
+                        "description": "打包插件成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2054,7 +2483,8 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.LoginResponse"
+                                            "type": "object",
+                                            "additionalProperties": true
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2067,37 +2497,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/casbin/UpdateCasbin": {
+        "/autoCode/removePlugin": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Casbin"
+                    "AutoCodePlugin"
                 ],
-                "summary": "更新角色api权限",
+                "summary": "删除插件",
                 "parameters": [
                     {
-                        "description": "权限id, 权限模型列表",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CasbinInReceive"
-                        }
+                        "type": "string",
+                        "description": "插件名称",
+                        "name": "pluginName",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "插件类型",
+                        "name": "pluginType",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新角色api权限",
+                        "description": "删除插件成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2117,7 +2549,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/casbin/getPolicyPathByAuthorityId": {
+        "/autoCode/rollback": {
             "post": {
                 "security": [
                     {
@@ -2131,23 +2563,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Casbin"
+                    "AutoCode"
                 ],
-                "summary": "获取权限列表",
+                "summary": "回滚自动生成代码",
                 "parameters": [
                     {
-                        "description": "权限id, 权限模型列表",
+                        "description": "请求参数",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CasbinInReceive"
+                            "$ref": "#/definitions/request.SysAutoHistoryRollBack"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取权限列表,返回包括casbin详情列表",
+                        "description": "回滚自动生成代码",
                         "schema": {
                             "allOf": [
                                 {
@@ -2156,9 +2588,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.PolicyPathResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -2170,8 +2599,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/customer": {
-            "get": {
+        "/base/captcha": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2184,56 +2613,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ExaCustomer"
-                ],
-                "summary": "获取单一客户信息",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "客户名",
-                        "name": "customerName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "客户手机号",
-                        "name": "customerPhoneData",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "管理角色ID",
-                        "name": "sysUserAuthorityID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "管理ID",
-                        "name": "sysUserId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
-                    }
+                    "Base"
                 ],
+                "summary": "生成验证码",
                 "responses": {
                     "200": {
-                        "description": "获取单一客户信息,返回包括客户详情",
+                        "description": "生成验证码,返回包括随机数id,base64,验证码长度,是否开启验证码",
                         "schema": {
                             "allOf": [
                                 {
@@ -2243,7 +2628,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.ExaCustomerResponse"
+                                            "$ref": "#/definitions/response.SysCaptchaResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2254,37 +2639,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/base/enrollTwoFactor": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaCustomer"
+                    "Base"
                 ],
-                "summary": "更新客户信息",
+                "summary": "登录时绑定两步验证(角色强制且用户未绑定)",
                 "parameters": [
                     {
-                        "description": "客户ID, 客户信息",
+                        "description": "challenge token",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/example.ExaCustomer"
+                            "$ref": "#/definitions/request.TwoFactorChallengeReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新客户信息",
+                        "description": "返回密钥,otpauth地址与恢复码",
                         "schema": {
                             "allOf": [
                                 {
@@ -2293,6 +2672,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.TwoFactorSetupResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -2302,13 +2684,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/base/forgotPassword": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2316,23 +2695,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ExaCustomer"
+                    "Base"
                 ],
-                "summary": "创建客户",
+                "summary": "找回密码 向邮箱发送重置密码链接",
                 "parameters": [
                     {
-                        "description": "客户用户名, 客户手机号码",
+                        "description": "邮箱, 验证码",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/example.ExaCustomer"
+                            "$ref": "#/definitions/request.ForgotPassword"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建客户",
+                        "description": "发送重置密码邮件",
                         "schema": {
                             "allOf": [
                                 {
@@ -2350,37 +2729,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/base/identityProviders": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaCustomer"
+                    "Base"
                 ],
-                "summary": "删除客户",
-                "parameters": [
-                    {
-                        "description": "客户ID",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/example.ExaCustomer"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "删除客户",
+                "summary": "获取已启用的外部身份提供方",
+                "responses": {
+                    "200": {
+                        "description": "返回提供方名称,类型,展示名称",
                         "schema": {
                             "allOf": [
                                 {
@@ -2389,6 +2751,12 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/identity.ProviderInfo"
+                                            }
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -2400,46 +2768,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/customerList": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+        "/base/login": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaCustomer"
+                    "Base"
                 ],
-                "summary": "分页获取权限客户列表",
+                "summary": "用户登录",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "用户名, 密码, 验证码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Login"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "分页获取权限客户列表,返回包括列表,总数,页码,每页数量",
+                        "description": "返回包括用户信息,token,过期时间",
                         "schema": {
                             "allOf": [
                                 {
@@ -2449,7 +2800,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PageResult"
+                                            "$ref": "#/definitions/response.LoginResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2462,94 +2813,119 @@ const docTemplate = `{
                 }
             }
         },
-        "/email/emailTest": {
+        "/base/refresh": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Base"
+                ],
+                "summary": "使用refresh token换取新的令牌对",
+                "parameters": [
+                    {
+                        "description": "refresh token",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshTokenReq"
+                        }
+                    }
                 ],
-                "summary": "发送测试邮件",
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"发送成功\"}",
+                        "description": "返回新的access token与refresh token",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/email/sendEmail": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
+        "/base/registrationOptions": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Base"
                 ],
-                "summary": "发送邮件",
+                "summary": "获取注册页信息 是否开放注册以及邀请链接信息",
                 "parameters": [
                     {
-                        "description": "发送邮件必须的参数",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/response.Email"
-                        }
+                        "type": "string",
+                        "description": "邀请令牌",
+                        "name": "invitation",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"发送成功\"}",
+                        "description": "注册页信息",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.RegistrationOptions"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/fileUploadAndDownload/breakpointContinue": {
+        "/base/resetPasswordByToken": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Base"
                 ],
-                "summary": "断点续传到服务器",
+                "summary": "通过邮件中的链接重置密码",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "an example for breakpoint resume, 断点续传示例",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "链接令牌, 新密码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordByToken"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "断点续传到服务器",
+                        "description": "重置密码",
                         "schema": {
                             "allOf": [
                                 {
@@ -2569,34 +2945,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/fileUploadAndDownload/deleteFile": {
+        "/base/selfRegister": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Base"
                 ],
-                "summary": "删除文件",
+                "summary": "自助注册或邀请注册 提交后需完成邮箱验证",
                 "parameters": [
                     {
-                        "description": "传入文件里面id即可",
+                        "description": "用户名, 昵称, 密码, 邮箱, 邀请令牌, 验证码",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/example.ExaFileUploadAndDownload"
+                            "$ref": "#/definitions/request.SelfRegister"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除文件",
+                        "description": "发送验证邮件",
                         "schema": {
                             "allOf": [
                                 {
@@ -2616,35 +2990,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/fileUploadAndDownload/findFile": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/base/ssoAuthorize": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Base"
                 ],
-                "summary": "查找文件",
+                "summary": "发起单点登录 返回身份提供方授权地址",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Find the file, 查找文件",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "身份提供方名称",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SSOAuthorizeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查找文件,返回包括文件详情",
+                        "description": "返回授权地址",
                         "schema": {
                             "allOf": [
                                 {
@@ -2654,7 +3022,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.FileResponse"
+                                            "$ref": "#/definitions/response.SSOAuthorizeResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2665,35 +3033,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/base/ssoCallback": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "multipart/form-data"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Base"
                 ],
-                "summary": "创建文件",
+                "summary": "单点登录回调 登录时返回token 绑定时返回绑定结果",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "上传文件完成",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "code, state",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SSOCallbackReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建文件,返回包括文件路径",
+                        "description": "返回包括用户信息,token,过期时间",
                         "schema": {
                             "allOf": [
                                 {
@@ -2703,7 +3067,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.FilePathResponse"
+                                            "$ref": "#/definitions/response.LoginResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2716,13 +3080,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/fileUploadAndDownload/getFileList": {
+        "/base/verifyRegistration": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -2730,23 +3089,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Base"
                 ],
-                "summary": "分页文件列表",
+                "summary": "验证注册邮箱 验证通过后创建用户",
                 "parameters": [
                     {
-                        "description": "页码, 每页大小, 分类id",
+                        "description": "验证令牌",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ExaAttachmentCategorySearch"
+                            "$ref": "#/definitions/request.VerifyRegistration"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "分页文件列表,返回包括列表,总数,页码,每页数量",
+                        "description": "完成注册",
                         "schema": {
                             "allOf": [
                                 {
@@ -2755,9 +3114,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.PageResult"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -2769,34 +3125,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/fileUploadAndDownload/importURL": {
+        "/base/verifyTwoFactor": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Base"
                 ],
-                "summary": "导入URL",
+                "summary": "登录二次验证",
                 "parameters": [
                     {
-                        "description": "对象",
+                        "description": "challenge token, 验证码或恢复码",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/example.ExaFileUploadAndDownload"
+                            "$ref": "#/definitions/request.TwoFactorVerifyReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "导入URL",
+                        "description": "返回包括用户信息,token,过期时间",
                         "schema": {
                             "allOf": [
                                 {
@@ -2805,6 +3156,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -2816,7 +3170,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/fileUploadAndDownload/removeChunk": {
+        "/casbin/UpdateCasbin": {
             "post": {
                 "security": [
                     {
@@ -2824,27 +3178,29 @@ const docTemplate = `{
                     }
                 ],
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Casbin"
                 ],
-                "summary": "删除切片",
+                "summary": "更新角色api权限",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "删除缓存切片",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "权限id, 权限模型列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CasbinInReceive"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除切片",
+                        "description": "更新角色api权限",
                         "schema": {
                             "allOf": [
                                 {
@@ -2864,7 +3220,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/fileUploadAndDownload/upload": {
+        "/casbin/explainPermission": {
             "post": {
                 "security": [
                     {
@@ -2872,27 +3228,29 @@ const docTemplate = `{
                     }
                 ],
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ExaFileUploadAndDownload"
+                    "Casbin"
                 ],
-                "summary": "上传文件示例",
+                "summary": "权限诊断 返回用户或角色访问指定接口的鉴权结果 命中的策略以及菜单和按钮权限",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "上传文件示例",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "用户ID或角色ID, 方法, 路径",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PermissionExplain"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "上传文件示例,返回包括文件详情",
+                        "description": "权限诊断结果",
                         "schema": {
                             "allOf": [
                                 {
@@ -2902,7 +3260,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.ExaFileResponse"
+                                            "$ref": "#/definitions/response.PermissionExplainResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -2915,7 +3273,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/info/createInfo": {
+        "/casbin/exportPermissionMatrix": {
             "post": {
                 "security": [
                     {
@@ -2926,47 +3284,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Info"
+                    "Casbin"
                 ],
-                "summary": "创建公告",
+                "summary": "导出权限矩阵Excel",
                 "parameters": [
                     {
-                        "description": "创建公告",
+                        "description": "用户ID或角色ID",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Info"
+                            "$ref": "#/definitions/request.PermissionMatrix"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/info/deleteInfo": {
-            "delete": {
+        "/casbin/getPermissionMatrix": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -2979,23 +3322,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "Casbin"
                 ],
-                "summary": "删除公告",
+                "summary": "获取用户或角色对全部api的实际权限",
                 "parameters": [
                     {
-                        "description": "删除公告",
+                        "description": "用户ID或角色ID",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Info"
+                            "$ref": "#/definitions/request.PermissionMatrix"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "权限矩阵",
                         "schema": {
                             "allOf": [
                                 {
@@ -3004,6 +3347,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PermissionMatrixResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3015,8 +3361,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/info/deleteInfoByIds": {
-            "delete": {
+        "/casbin/getPolicyPathByAuthorityId": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -3029,12 +3375,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "Casbin"
+                ],
+                "summary": "获取权限列表",
+                "parameters": [
+                    {
+                        "description": "权限id, 权限模型列表",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CasbinInReceive"
+                        }
+                    }
                 ],
-                "summary": "批量删除公告",
                 "responses": {
                     "200": {
-                        "description": "批量删除成功",
+                        "description": "获取权限列表,返回包括casbin详情列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -3043,6 +3400,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PolicyPathResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3054,7 +3414,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/info/findInfo": {
+        "/changeHistory/getChangeHistoryList": {
             "get": {
                 "security": [
                     {
@@ -3068,87 +3428,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "ChangeHistory"
                 ],
-                "summary": "用id查询公告",
+                "summary": "分页获取变更历史 传入表名与记录主键时为该记录的时间线",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
+                        "description": "页码",
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "内容",
-                        "name": "content",
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
+                        "description": "记录主键",
+                        "name": "recordId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "标题",
-                        "name": "title",
+                        "description": "请求ID",
+                        "name": "requestId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
+                        "description": "表名",
+                        "name": "table",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "作者",
-                        "name": "userID",
+                        "description": "操作人",
+                        "name": "userId",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.Info"
-                                        },
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/info/getInfoDataSource": {
-            "get": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Info"
-                ],
-                "summary": "获取Info的数据源",
-                "responses": {
-                    "200": {
-                        "description": "查询成功",
+                        "description": "分页获取变更历史,返回包括列表,总数,页码,每页数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -3158,7 +3487,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/response.PageResult"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -3171,7 +3500,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/info/getInfoList": {
+        "/customer/customer": {
             "get": {
                 "security": [
                     {
@@ -3185,42 +3514,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "ExaCustomer"
                 ],
-                "summary": "分页获取公告列表",
+                "summary": "获取单一客户信息",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "name": "endCreatedAt",
+                        "description": "创建时间",
+                        "name": "createdAt",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
+                        "description": "客户名",
+                        "name": "customerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户手机号",
+                        "name": "customerPhoneData",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "页码",
-                        "name": "page",
+                        "description": "管理角色ID",
+                        "name": "sysUserAuthorityID",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
+                        "description": "管理ID",
+                        "name": "sysUserId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "name": "startCreatedAt",
+                        "description": "更新时间",
+                        "name": "updatedAt",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "获取单一客户信息,返回包括客户详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -3230,7 +3573,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PageResult"
+                                            "$ref": "#/definitions/response.ExaCustomerResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -3241,10 +3584,13 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/info/getInfoPublic": {
-            "get": {
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -3252,42 +3598,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "ExaCustomer"
                 ],
-                "summary": "不需要鉴权的公告接口",
+                "summary": "更新客户信息",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "endCreatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "startCreatedAt",
-                        "in": "query"
+                        "description": "客户ID, 客户信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example.ExaCustomer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "更新客户信息",
                         "schema": {
                             "allOf": [
                                 {
@@ -3296,9 +3623,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3308,11 +3632,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/info/updateInfo": {
-            "put": {
-                "security": [
+            },
+            "post": {
+                "security": [
                     {
                         "ApiKeyAuth": []
                     }
@@ -3324,23 +3646,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Info"
+                    "ExaCustomer"
                 ],
-                "summary": "更新公告",
+                "summary": "创建客户",
                 "parameters": [
                     {
-                        "description": "更新公告",
+                        "description": "客户用户名, 客户手机号码",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Info"
+                            "$ref": "#/definitions/example.ExaCustomer"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "创建客户",
                         "schema": {
                             "allOf": [
                                 {
@@ -3358,20 +3680,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/init/checkdb": {
-            "post": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "CheckDB"
+                    "ExaCustomer"
+                ],
+                "summary": "删除客户",
+                "parameters": [
+                    {
+                        "description": "客户ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/example.ExaCustomer"
+                        }
+                    }
                 ],
-                "summary": "初始化用户数据库",
                 "responses": {
                     "200": {
-                        "description": "初始化用户数据库",
+                        "description": "删除客户",
                         "schema": {
                             "allOf": [
                                 {
@@ -3380,10 +3719,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3395,29 +3730,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/init/initdb": {
-            "post": {
+        "/customer/customerList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "InitDB"
+                    "ExaCustomer"
                 ],
-                "summary": "初始化用户数据库",
+                "summary": "分页获取权限客户列表",
                 "parameters": [
                     {
-                        "description": "初始化数据库参数",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.InitDB"
-                        }
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "初始化用户数据库",
+                        "description": "分页获取权限客户列表,返回包括列表,总数,页码,每页数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -3427,6 +3779,9 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
                                             "type": "string"
                                         }
                                     }
@@ -3437,96 +3792,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/jwt/jsonInBlacklist": {
+        "/email/emailTest": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jwt"
+                    "System"
                 ],
-                "summary": "jwt加入黑名单",
+                "summary": "发送测试邮件",
                 "responses": {
                     "200": {
-                        "description": "jwt加入黑名单",
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"发送成功\"}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/menu/addBaseMenu": {
+        "/email/sendEmail": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu"
+                    "System"
                 ],
-                "summary": "新增菜单",
+                "summary": "发送邮件",
                 "parameters": [
                     {
-                        "description": "路由path, 父菜单ID, 路由name, 对应前端文件路径, 排序标记",
+                        "description": "发送邮件必须的参数",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysBaseMenu"
+                            "$ref": "#/definitions/response.Email"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "新增菜单",
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"发送成功\"}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "msg": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/menu/addMenuAuthority": {
+        "/fileUploadAndDownload/breakpointContinue": {
             "post": {
                 "security": [
                     {
@@ -3534,29 +3859,27 @@ const docTemplate = `{
                     }
                 ],
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AuthorityMenu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "增加menu和角色关联关系",
+                "summary": "断点续传到服务器",
                 "parameters": [
                     {
-                        "description": "角色ID",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.AddMenuAuthorityInfo"
-                        }
+                        "type": "file",
+                        "description": "an example for breakpoint resume, 断点续传示例",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "增加menu和角色关联关系",
+                        "description": "断点续传到服务器",
                         "schema": {
                             "allOf": [
                                 {
@@ -3576,37 +3899,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/menu/deleteBaseMenu": {
+        "/fileUploadAndDownload/deleteFile": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "删除菜单",
+                "summary": "删除文件",
                 "parameters": [
                     {
-                        "description": "菜单id",
+                        "description": "传入文件里面id即可",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.GetById"
+                            "$ref": "#/definitions/example.ExaFileUploadAndDownload"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除菜单",
+                        "description": "删除文件",
                         "schema": {
                             "allOf": [
                                 {
@@ -3626,37 +3946,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/menu/getBaseMenuById": {
-            "post": {
+        "/fileUploadAndDownload/findFile": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "根据id获取菜单",
+                "summary": "查找文件",
                 "parameters": [
                     {
-                        "description": "菜单id",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.GetById"
-                        }
+                        "type": "file",
+                        "description": "Find the file, 查找文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "根据id获取菜单,返回包括系统菜单列表",
+                        "description": "查找文件,返回包括文件详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -3666,7 +3984,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.SysBaseMenuResponse"
+                                            "$ref": "#/definitions/response.FileResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -3677,36 +3995,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/menu/getBaseMenuTree": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AuthorityMenu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "获取用户动态路由",
+                "summary": "创建文件",
                 "parameters": [
                     {
-                        "description": "空",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.Empty"
-                        }
+                        "type": "file",
+                        "description": "上传文件完成",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取用户动态路由,返回包括系统菜单列表",
+                        "description": "创建文件,返回包括文件路径",
                         "schema": {
                             "allOf": [
                                 {
@@ -3716,7 +4033,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.SysBaseMenusResponse"
+                                            "$ref": "#/definitions/response.FilePathResponse"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -3729,34 +4046,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/menu/getMenu": {
+        "/fileUploadAndDownload/getFileList": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AuthorityMenu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "获取用户动态路由",
+                "summary": "分页文件列表",
                 "parameters": [
                     {
-                        "description": "空",
+                        "description": "页码, 每页大小, 分类id",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.Empty"
+                            "$ref": "#/definitions/request.ExaAttachmentCategorySearch"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取用户动态路由,返回包括系统菜单详情列表",
+                        "description": "分页文件列表,返回包括列表,总数,页码,每页数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -3766,7 +4086,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.SysMenusResponse"
+                                            "$ref": "#/definitions/response.PageResult"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -3779,37 +4099,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/menu/getMenuAuthority": {
+        "/fileUploadAndDownload/importURL": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AuthorityMenu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "获取指定角色menu",
+                "summary": "导入URL",
                 "parameters": [
                     {
-                        "description": "角色ID",
+                        "description": "对象",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.GetAuthorityId"
+                            "$ref": "#/definitions/example.ExaFileUploadAndDownload"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取指定角色menu",
+                        "description": "导入URL",
                         "schema": {
                             "allOf": [
                                 {
@@ -3818,10 +4135,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3833,7 +4146,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/menu/getMenuList": {
+        "/fileUploadAndDownload/removeChunk": {
             "post": {
                 "security": [
                     {
@@ -3841,29 +4154,27 @@ const docTemplate = `{
                     }
                 ],
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "分页获取基础menu列表",
+                "summary": "删除切片",
                 "parameters": [
                     {
-                        "description": "页码, 每页大小",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.PageInfo"
-                        }
+                        "type": "file",
+                        "description": "删除缓存切片",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "分页获取基础menu列表,返回包括列表,总数,页码,每页数量",
+                        "description": "删除切片",
                         "schema": {
                             "allOf": [
                                 {
@@ -3872,9 +4183,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.PageResult"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3886,7 +4194,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/menu/updateBaseMenu": {
+        "/fileUploadAndDownload/upload": {
             "post": {
                 "security": [
                     {
@@ -3894,29 +4202,27 @@ const docTemplate = `{
                     }
                 ],
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu"
+                    "ExaFileUploadAndDownload"
                 ],
-                "summary": "更新菜单",
+                "summary": "上传文件示例",
                 "parameters": [
                     {
-                        "description": "路由path, 父菜单ID, 路由name, 对应前端文件路径, 排序标记",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/system.SysBaseMenu"
-                        }
+                        "type": "file",
+                        "description": "上传文件示例",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新菜单",
+                        "description": "上传文件示例,返回包括文件详情",
                         "schema": {
                             "allOf": [
                                 {
@@ -3925,6 +4231,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ExaFileResponse"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -3936,7 +4245,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionary/createSysDictionary": {
+        "/info/createInfo": {
             "post": {
                 "security": [
                     {
@@ -3950,23 +4259,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionary"
+                    "Info"
                 ],
-                "summary": "创建SysDictionary",
+                "summary": "创建公告",
                 "parameters": [
                     {
-                        "description": "SysDictionary模型",
+                        "description": "创建公告",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysDictionary"
+                            "$ref": "#/definitions/model.Info"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建SysDictionary",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3986,7 +4295,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionary/deleteSysDictionary": {
+        "/info/deleteInfo": {
             "delete": {
                 "security": [
                     {
@@ -4000,23 +4309,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionary"
+                    "Info"
                 ],
-                "summary": "删除SysDictionary",
+                "summary": "删除公告",
                 "parameters": [
                     {
-                        "description": "SysDictionary模型",
+                        "description": "删除公告",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysDictionary"
+                            "$ref": "#/definitions/model.Info"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除SysDictionary",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4036,7 +4345,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionary/findSysDictionary": {
+        "/info/deleteInfoByIds": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Info"
+                ],
+                "summary": "批量删除公告",
+                "responses": {
+                    "200": {
+                        "description": "批量删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/info/findInfo": {
             "get": {
                 "security": [
                     {
@@ -4050,9 +4398,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionary"
+                    "Info"
                 ],
-                "summary": "用id查询SysDictionary",
+                "summary": "用id查询公告",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4062,44 +4410,38 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
+                        "description": "内容",
+                        "name": "content",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "描述",
-                        "name": "desc",
+                        "description": "创建时间",
+                        "name": "createdAt",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "字典名（中）",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "状态",
-                        "name": "status",
+                        "description": "标题",
+                        "name": "title",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "字典名（英）",
-                        "name": "type",
+                        "description": "更新时间",
+                        "name": "updatedAt",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
+                        "type": "integer",
+                        "description": "作者",
+                        "name": "userID",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "用id查询SysDictionary",
+                        "description": "查询成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4109,8 +4451,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "$ref": "#/definitions/model.Info"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -4123,13 +4464,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionary/getSysDictionaryList": {
+        "/info/getInfoDataSource": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -4137,12 +4473,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionary"
+                    "Info"
                 ],
-                "summary": "分页获取SysDictionary列表",
+                "summary": "获取Info的数据源",
                 "responses": {
                     "200": {
-                        "description": "分页获取SysDictionary列表,返回包括列表,总数,页码,每页数量",
+                        "description": "查询成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4152,7 +4488,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PageResult"
+                                            "type": "object"
                                         },
                                         "msg": {
                                             "type": "string"
@@ -4165,8 +4501,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionary/updateSysDictionary": {
-            "put": {
+        "/info/getInfoList": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4179,23 +4515,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionary"
+                    "Info"
                 ],
-                "summary": "更新SysDictionary",
+                "summary": "分页获取公告列表",
                 "parameters": [
                     {
-                        "description": "SysDictionary模型",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/system.SysDictionary"
-                        }
+                        "type": "string",
+                        "name": "endCreatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "startCreatedAt",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新SysDictionary",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4204,6 +4559,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -4215,13 +4573,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionaryDetail/createSysDictionaryDetail": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
+        "/info/getInfoPublic": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -4229,31 +4582,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionaryDetail"
+                    "Info"
                 ],
-                "summary": "创建SysDictionaryDetail",
+                "summary": "不需要鉴权的公告接口",
                 "parameters": [
                     {
-                        "description": "SysDictionaryDetail模型",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/system.SysDictionaryDetail"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "创建SysDictionaryDetail",
-                        "schema": {
-                            "allOf": [
+                        "type": "string",
+                        "name": "endCreatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "startCreatedAt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
                                 {
                                     "$ref": "#/definitions/response.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -4265,8 +4640,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionaryDetail/deleteSysDictionaryDetail": {
-            "delete": {
+        "/info/updateInfo": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4279,23 +4654,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionaryDetail"
+                    "Info"
                 ],
-                "summary": "删除SysDictionaryDetail",
+                "summary": "更新公告",
                 "parameters": [
                     {
-                        "description": "SysDictionaryDetail模型",
+                        "description": "更新公告",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysDictionaryDetail"
+                            "$ref": "#/definitions/model.Info"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除SysDictionaryDetail",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4315,82 +4690,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionaryDetail/findSysDictionaryDetail": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+        "/init/checkdb": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionaryDetail"
-                ],
-                "summary": "用id查询SysDictionaryDetail",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "扩展值",
-                        "name": "extend",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "展示值",
-                        "name": "label",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "排序标记",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "启用状态",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "关联标记",
-                        "name": "sysDictionaryID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "字典值",
-                        "name": "value",
-                        "in": "query"
-                    }
+                    "CheckDB"
                 ],
+                "summary": "初始化用户数据库",
                 "responses": {
                     "200": {
-                        "description": "用id查询SysDictionaryDetail",
+                        "description": "初始化用户数据库",
                         "schema": {
                             "allOf": [
                                 {
@@ -4414,100 +4725,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionaryDetail/getSysDictionaryDetailList": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
+        "/init/initdb": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionaryDetail"
+                    "InitDB"
                 ],
-                "summary": "分页获取SysDictionaryDetail列表",
+                "summary": "初始化用户数据库",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "扩展值",
-                        "name": "extend",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "展示值",
-                        "name": "label",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "排序标记",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "启用状态",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "关联标记",
-                        "name": "sysDictionaryID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "字典值",
-                        "name": "value",
-                        "in": "query"
+                        "description": "初始化数据库参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InitDB"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "分页获取SysDictionaryDetail列表,返回包括列表,总数,页码,每页数量",
+                        "description": "初始化用户数据库",
                         "schema": {
                             "allOf": [
                                 {
@@ -4517,9 +4757,6 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PageResult"
-                                        },
-                                        "msg": {
                                             "type": "string"
                                         }
                                     }
@@ -4530,8 +4767,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysDictionaryDetail/updateSysDictionaryDetail": {
-            "put": {
+        "/jwt/jsonInBlacklist": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4544,23 +4781,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysDictionaryDetail"
-                ],
-                "summary": "更新SysDictionaryDetail",
-                "parameters": [
-                    {
-                        "description": "更新SysDictionaryDetail",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/system.SysDictionaryDetail"
-                        }
-                    }
+                    "Jwt"
                 ],
+                "summary": "jwt加入黑名单",
                 "responses": {
                     "200": {
-                        "description": "更新SysDictionaryDetail",
+                        "description": "jwt加入黑名单",
                         "schema": {
                             "allOf": [
                                 {
@@ -4580,8 +4806,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysExportTemplate/ExportTemplate": {
-            "get": {
+        "/menu/addBaseMenu": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4594,52 +4820,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysExportTemplate"
+                    "Menu"
                 ],
-                "summary": "导出表格模板",
-                "responses": {}
-            }
-        },
-        "/sysExportTemplate/createSysExportTemplate": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SysExportTemplate"
-                ],
-                "summary": "创建导出模板",
+                "summary": "新增菜单",
                 "parameters": [
                     {
-                        "description": "创建导出模板",
+                        "description": "路由path, 父菜单ID, 路由name, 对应前端文件路径, 排序标记",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysExportTemplate"
+                            "$ref": "#/definitions/system.SysBaseMenu"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
+                        "description": "新增菜单",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sysExportTemplate/deleteSysExportTemplate": {
-            "delete": {
+        "/menu/addMenuAuthority": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4652,32 +4870,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysExportTemplate"
+                    "AuthorityMenu"
                 ],
-                "summary": "删除导出模板",
+                "summary": "增加menu和角色关联关系",
                 "parameters": [
                     {
-                        "description": "删除导出模板",
+                        "description": "角色ID",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysExportTemplate"
+                            "$ref": "#/definitions/request.AddMenuAuthorityInfo"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"删除成功\"}",
+                        "description": "增加menu和角色关联关系",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sysExportTemplate/deleteSysExportTemplateByIds": {
-            "delete": {
+        "/menu/deleteBaseMenu": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4690,32 +4920,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysExportTemplate"
+                    "Menu"
                 ],
-                "summary": "批量删除导出模板",
+                "summary": "删除菜单",
                 "parameters": [
                     {
-                        "description": "批量删除导出模板",
+                        "description": "菜单id",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.IdsReq"
+                            "$ref": "#/definitions/request.GetById"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"批量删除成功\"}",
+                        "description": "删除菜单",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sysExportTemplate/exportExcel": {
-            "get": {
+        "/menu/getBaseMenuById": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -4728,215 +4970,146 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysExportTemplate"
+                    "Menu"
                 ],
-                "summary": "导出表格",
-                "responses": {}
+                "summary": "根据id获取菜单",
+                "parameters": [
+                    {
+                        "description": "菜单id",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "根据id获取菜单,返回包括系统菜单列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysBaseMenuResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
             }
         },
-        "/sysExportTemplate/findSysExportTemplate": {
-            "get": {
+        "/menu/getBaseMenuTree": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysExportTemplate"
+                    "AuthorityMenu"
                 ],
-                "summary": "用id查询导出模板",
+                "summary": "获取用户动态路由",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "数据库名称",
-                        "name": "dbName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模板名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "表名称",
-                        "name": "tableName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模板标识",
-                        "name": "templateID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模板信息",
-                        "name": "templateInfo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
+                        "description": "空",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Empty"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"查询成功\"}",
+                        "description": "获取用户动态路由,返回包括系统菜单列表",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysBaseMenusResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sysExportTemplate/getSysExportTemplateList": {
-            "get": {
+        "/menu/getMenu": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysExportTemplate"
+                    "AuthorityMenu"
                 ],
-                "summary": "分页获取导出模板列表",
+                "summary": "获取用户动态路由",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "数据库名称",
-                        "name": "dbName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "endCreatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模板名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "startCreatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "表名称",
-                        "name": "tableName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模板标识",
-                        "name": "templateID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "模板信息",
-                        "name": "templateInfo",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
+                        "description": "空",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Empty"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"获取成功\"}",
+                        "description": "获取用户动态路由,返回包括系统菜单详情列表",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysMenusResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sysExportTemplate/importExcel": {
+        "/menu/getMenuAuthority": {
             "post": {
                 "security": [
                     {
@@ -4950,51 +5123,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysImportTemplate"
-                ],
-                "summary": "导入表格",
-                "responses": {}
-            }
-        },
-        "/sysExportTemplate/updateSysExportTemplate": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "SysExportTemplate"
+                    "AuthorityMenu"
                 ],
-                "summary": "更新导出模板",
+                "summary": "获取指定角色menu",
                 "parameters": [
                     {
-                        "description": "更新导出模板",
+                        "description": "角色ID",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysExportTemplate"
+                            "$ref": "#/definitions/request.GetAuthorityId"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{\"success\":true,\"data\":{},\"msg\":\"更新成功\"}",
+                        "description": "获取指定角色menu",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sysOperationRecord/createSysOperationRecord": {
+        "/menu/getMenuList": {
             "post": {
                 "security": [
                     {
@@ -5008,23 +5177,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysOperationRecord"
+                    "Menu"
                 ],
-                "summary": "创建SysOperationRecord",
+                "summary": "分页获取基础menu列表",
                 "parameters": [
                     {
-                        "description": "创建SysOperationRecord",
+                        "description": "页码, 每页大小",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysOperationRecord"
+                            "$ref": "#/definitions/request.PageInfo"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建SysOperationRecord",
+                        "description": "分页获取基础menu列表,返回包括列表,总数,页码,每页数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -5033,6 +5202,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -5044,8 +5216,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysOperationRecord/deleteSysOperationRecord": {
-            "delete": {
+        "/menu/updateBaseMenu": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5058,23 +5230,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysOperationRecord"
+                    "Menu"
                 ],
-                "summary": "删除SysOperationRecord",
+                "summary": "更新菜单",
                 "parameters": [
                     {
-                        "description": "SysOperationRecord模型",
+                        "description": "路由path, 父菜单ID, 路由name, 对应前端文件路径, 排序标记",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysOperationRecord"
+                            "$ref": "#/definitions/system.SysBaseMenu"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除SysOperationRecord",
+                        "description": "更新菜单",
                         "schema": {
                             "allOf": [
                                 {
@@ -5094,8 +5266,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysOperationRecord/deleteSysOperationRecordByIds": {
-            "delete": {
+        "/session/forceLogout": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5108,23 +5280,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysOperationRecord"
+                    "Session"
                 ],
-                "summary": "批量删除SysOperationRecord",
+                "summary": "管理员强制下线 会话ID为空时下线该用户的全部会话",
                 "parameters": [
                     {
-                        "description": "批量删除SysOperationRecord",
+                        "description": "用户ID, 会话ID",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.IdsReq"
+                            "$ref": "#/definitions/request.SessionReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "批量删除SysOperationRecord",
+                        "description": "强制下线",
                         "schema": {
                             "allOf": [
                                 {
@@ -5144,106 +5316,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysOperationRecord/findSysOperationRecord": {
+        "/session/getSelfSessions": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysOperationRecord"
-                ],
-                "summary": "用id查询SysOperationRecord",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "代理",
-                        "name": "agent",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求Body",
-                        "name": "body",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "错误信息",
-                        "name": "error_message",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求ip",
-                        "name": "ip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "延迟",
-                        "name": "latency",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求方法",
-                        "name": "method",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求路径",
-                        "name": "path",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "响应Body",
-                        "name": "resp",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "请求状态",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "用户id",
-                        "name": "user_id",
-                        "in": "query"
-                    }
+                    "Session"
                 ],
+                "summary": "获取当前用户的在线会话",
                 "responses": {
                     "200": {
-                        "description": "用id查询SysOperationRecord",
+                        "description": "获取当前用户的在线会话",
                         "schema": {
                             "allOf": [
                                 {
@@ -5253,8 +5342,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SysSessionResponse"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5267,8 +5358,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysOperationRecord/getSysOperationRecordList": {
-            "get": {
+        "/session/getUserSessions": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5281,110 +5372,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysOperationRecord"
+                    "Session"
                 ],
-                "summary": "分页获取SysOperationRecord列表",
+                "summary": "管理员获取指定用户的在线会话",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "主键ID",
-                        "name": "ID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "代理",
-                        "name": "agent",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求Body",
-                        "name": "body",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "创建时间",
-                        "name": "createdAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "错误信息",
-                        "name": "error_message",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求ip",
-                        "name": "ip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "延迟",
-                        "name": "latency",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求方法",
-                        "name": "method",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "请求路径",
-                        "name": "path",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "响应Body",
-                        "name": "resp",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "请求状态",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "用户id",
-                        "name": "user_id",
-                        "in": "query"
+                        "description": "用户ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SessionReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "分页获取SysOperationRecord列表,返回包括列表,总数,页码,每页数量",
+                        "description": "获取指定用户的在线会话",
                         "schema": {
                             "allOf": [
                                 {
@@ -5394,7 +5398,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.PageResult"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SysSessionResponse"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5407,7 +5414,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/createSysParams": {
+        "/session/revokeSelfSession": {
             "post": {
                 "security": [
                     {
@@ -5421,23 +5428,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "Session"
                 ],
-                "summary": "创建参数",
+                "summary": "注销当前用户的某个会话",
                 "parameters": [
                     {
-                        "description": "创建参数",
+                        "description": "会话ID",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysParams"
+                            "$ref": "#/definitions/request.SessionReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "注销当前用户的某个会话",
                         "schema": {
                             "allOf": [
                                 {
@@ -5457,8 +5464,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/deleteSysParams": {
-            "delete": {
+        "/sysDictionary/createSysDictionary": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5471,23 +5478,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "SysDictionary"
                 ],
-                "summary": "删除参数",
+                "summary": "创建SysDictionary",
                 "parameters": [
                     {
-                        "description": "删除参数",
+                        "description": "SysDictionary模型",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysParams"
+                            "$ref": "#/definitions/system.SysDictionary"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "创建SysDictionary",
                         "schema": {
                             "allOf": [
                                 {
@@ -5507,7 +5514,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/deleteSysParamsByIds": {
+        "/sysDictionary/deleteSysDictionary": {
             "delete": {
                 "security": [
                     {
@@ -5521,12 +5528,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "SysDictionary"
+                ],
+                "summary": "删除SysDictionary",
+                "parameters": [
+                    {
+                        "description": "SysDictionary模型",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysDictionary"
+                        }
+                    }
                 ],
-                "summary": "批量删除参数",
                 "responses": {
                     "200": {
-                        "description": "批量删除成功",
+                        "description": "删除SysDictionary",
                         "schema": {
                             "allOf": [
                                 {
@@ -5546,7 +5564,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/findSysParams": {
+        "/sysDictionary/exportSysDictionary": {
             "get": {
                 "security": [
                     {
@@ -5560,9 +5578,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "SysDictionary"
                 ],
-                "summary": "用id查询参数",
+                "summary": "导出字典JSON（包含字典详情）",
                 "parameters": [
                     {
                         "type": "integer",
@@ -5578,41 +5596,50 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "参数说明",
+                        "description": "描述",
                         "name": "desc",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "参数键",
-                        "name": "key",
-                        "in": "query",
-                        "required": true
+                        "description": "字典名（中）",
+                        "name": "name",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "参数名称",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "父级字典ID",
+                        "name": "parentID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "所属租户",
+                        "name": "tenantId",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "更新时间",
-                        "name": "updatedAt",
+                        "description": "字典名（英）",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "参数值",
-                        "name": "value",
-                        "in": "query",
-                        "required": true
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "导出字典JSON",
                         "schema": {
                             "allOf": [
                                 {
@@ -5622,7 +5649,8 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/system.SysParams"
+                                            "type": "object",
+                                            "additionalProperties": true
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5635,7 +5663,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/getSysParam": {
+        "/sysDictionary/findSysDictionary": {
             "get": {
                 "security": [
                     {
@@ -5649,21 +5677,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "SysDictionary"
                 ],
-                "summary": "根据key获取参数value",
+                "summary": "用id查询SysDictionary",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "key",
-                        "name": "key",
-                        "in": "query",
-                        "required": true
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "描述",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字典名（中）",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "父级字典ID",
+                        "name": "parentID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "所属租户",
+                        "name": "tenantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字典名（英）",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "用id查询SysDictionary",
                         "schema": {
                             "allOf": [
                                 {
@@ -5673,7 +5748,8 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/system.SysParams"
+                                            "type": "object",
+                                            "additionalProperties": true
                                         },
                                         "msg": {
                                             "type": "string"
@@ -5686,7 +5762,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/getSysParamsList": {
+        "/sysDictionary/getSysDictionaryList": {
             "get": {
                 "security": [
                     {
@@ -5700,52 +5776,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "SysDictionary"
                 ],
-                "summary": "分页获取参数列表",
+                "summary": "分页获取SysDictionary列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "endCreatedAt",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "key",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键字",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
+                        "description": "字典名（中）",
                         "name": "name",
                         "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页大小",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "startCreatedAt",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "分页获取SysDictionary列表,返回包括列表,总数,页码,每页数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -5768,8 +5812,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/sysParams/updateSysParams": {
-            "put": {
+        "/sysDictionary/importSysDictionary": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5782,23 +5826,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysParams"
+                    "SysDictionary"
                 ],
-                "summary": "更新参数",
+                "summary": "导入字典JSON（包含字典详情）",
                 "parameters": [
                     {
-                        "description": "更新参数",
+                        "description": "字典JSON数据",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysParams"
+                            "$ref": "#/definitions/request.ImportSysDictionaryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "导入字典",
                         "schema": {
                             "allOf": [
                                 {
@@ -5818,23 +5862,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/system/getServerInfo": {
-            "post": {
+        "/sysDictionary/updateSysDictionary": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "SysDictionary"
+                ],
+                "summary": "更新SysDictionary",
+                "parameters": [
+                    {
+                        "description": "SysDictionary模型",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysDictionary"
+                        }
+                    }
                 ],
-                "summary": "获取服务器信息",
                 "responses": {
                     "200": {
-                        "description": "获取服务器信息",
+                        "description": "更新SysDictionary",
                         "schema": {
                             "allOf": [
                                 {
@@ -5843,10 +5901,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -5858,23 +5912,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/system/getSystemConfig": {
+        "/sysDictionaryDetail/createSysDictionaryDetail": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "SysDictionaryDetail"
+                ],
+                "summary": "创建SysDictionaryDetail",
+                "parameters": [
+                    {
+                        "description": "SysDictionaryDetail模型",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysDictionaryDetail"
+                        }
+                    }
                 ],
-                "summary": "获取配置文件内容",
                 "responses": {
                     "200": {
-                        "description": "获取配置文件内容,返回包括系统配置",
+                        "description": "创建SysDictionaryDetail",
                         "schema": {
                             "allOf": [
                                 {
@@ -5883,9 +5951,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.SysConfigResponse"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -5897,23 +5962,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/system/reloadSystem": {
-            "post": {
+        "/sysDictionaryDetail/deleteSysDictionaryDetail": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "SysDictionaryDetail"
+                ],
+                "summary": "删除SysDictionaryDetail",
+                "parameters": [
+                    {
+                        "description": "SysDictionaryDetail模型",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysDictionaryDetail"
+                        }
+                    }
                 ],
-                "summary": "重启系统",
                 "responses": {
                     "200": {
-                        "description": "重启系统",
+                        "description": "删除SysDictionaryDetail",
                         "schema": {
                             "allOf": [
                                 {
@@ -5933,34 +6012,106 @@ const docTemplate = `{
                 }
             }
         },
-        "/system/setSystemConfig": {
-            "post": {
+        "/sysDictionaryDetail/findSysDictionaryDetail": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "SysDictionaryDetail"
                 ],
-                "summary": "设置配置文件内容",
+                "summary": "用id查询SysDictionaryDetail",
                 "parameters": [
                     {
-                        "description": "设置配置文件内容",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/system.System"
-                        }
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "禁用状态，根据status字段动态计算",
+                        "name": "disabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "扩展值",
+                        "name": "extend",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "展示值",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "层级深度，从0开始",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "父级字典详情ID",
+                        "name": "parentID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "层级路径，如 \"1,2,3\"",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "排序标记",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "启用状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "关联标记",
+                        "name": "sysDictionaryID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字典值",
+                        "name": "value",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置配置文件内容",
+                        "description": "用id查询SysDictionaryDetail",
                         "schema": {
                             "allOf": [
                                 {
@@ -5970,6 +6121,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
                                             "type": "string"
                                         }
                                     }
@@ -5980,8 +6135,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/SetSelfInfo": {
-            "put": {
+        "/sysDictionaryDetail/getDictionaryDetailsByParent": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -5994,23 +6149,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysDictionaryDetail"
                 ],
-                "summary": "设置用户信息",
+                "summary": "根据父级ID获取字典详情",
                 "parameters": [
                     {
-                        "description": "ID, 用户名, 昵称, 头像链接",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/system.SysUser"
-                        }
+                        "type": "boolean",
+                        "description": "是否包含子级数据",
+                        "name": "includeChildren",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "父级字典详情ID，为空时获取顶级",
+                        "name": "parentID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "字典ID",
+                        "name": "sysDictionaryID",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置用户信息",
+                        "description": "获取字典详情列表",
                         "schema": {
                             "allOf": [
                                 {
@@ -6020,8 +6185,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.SysDictionaryDetail"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -6034,8 +6201,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/SetSelfSetting": {
-            "put": {
+        "/sysDictionaryDetail/getDictionaryPath": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -6048,24 +6215,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysDictionaryDetail"
                 ],
-                "summary": "设置用户配置",
+                "summary": "获取字典详情的完整路径",
                 "parameters": [
                     {
-                        "description": "用户配置数据",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "type": "integer",
+                        "description": "字典详情ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置用户配置",
+                        "description": "获取字典详情路径",
                         "schema": {
                             "allOf": [
                                 {
@@ -6075,8 +6239,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.SysDictionaryDetail"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -6089,29 +6255,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/admin_register": {
-            "post": {
+        "/sysDictionaryDetail/getDictionaryTreeList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysDictionaryDetail"
                 ],
-                "summary": "用户注册账号",
+                "summary": "获取字典详情树形结构",
                 "parameters": [
                     {
-                        "description": "用户名, 昵称, 密码, 角色ID",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.Register"
-                        }
+                        "type": "integer",
+                        "description": "字典ID",
+                        "name": "sysDictionaryID",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "用户注册账号,返回包括用户信息",
+                        "description": "获取字典详情树形结构",
                         "schema": {
                             "allOf": [
                                 {
@@ -6121,7 +6293,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/response.SysUserResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.SysDictionaryDetail"
+                                            }
                                         },
                                         "msg": {
                                             "type": "string"
@@ -6134,34 +6309,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/changePassword": {
-            "post": {
+        "/sysDictionaryDetail/getDictionaryTreeListByType": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysDictionaryDetail"
                 ],
-                "summary": "用户修改密码",
+                "summary": "根据字典类型获取字典详情树形结构",
                 "parameters": [
                     {
-                        "description": "用户名, 原密码, 新密码",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.ChangePasswordReq"
-                        }
+                        "type": "string",
+                        "description": "字典类型",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "用户修改密码",
+                        "description": "获取字典详情树形结构",
                         "schema": {
                             "allOf": [
                                 {
@@ -6170,6 +6346,12 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.SysDictionaryDetail"
+                                            }
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6181,8 +6363,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/deleteUser": {
-            "delete": {
+        "/sysDictionaryDetail/getSysDictionaryDetailList": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -6195,23 +6377,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysDictionaryDetail"
                 ],
-                "summary": "删除用户",
+                "summary": "分页获取SysDictionaryDetail列表",
                 "parameters": [
                     {
-                        "description": "用户ID",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.GetById"
-                        }
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "禁用状态，根据status字段动态计算",
+                        "name": "disabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "扩展值",
+                        "name": "extend",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "展示值",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "层级深度，用于查询指定层级的数据",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "父级字典详情ID，用于查询指定父级下的子项",
+                        "name": "parentID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "层级路径，如 \"1,2,3\"",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "排序标记",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "启用状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "关联标记",
+                        "name": "sysDictionaryID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字典值",
+                        "name": "value",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除用户",
+                        "description": "分页获取SysDictionaryDetail列表,返回包括列表,总数,页码,每页数量",
                         "schema": {
                             "allOf": [
                                 {
@@ -6220,6 +6489,9 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6231,8 +6503,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/getUserInfo": {
-            "get": {
+        "/sysDictionaryDetail/updateSysDictionaryDetail": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -6245,12 +6517,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysDictionaryDetail"
+                ],
+                "summary": "更新SysDictionaryDetail",
+                "parameters": [
+                    {
+                        "description": "更新SysDictionaryDetail",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysDictionaryDetail"
+                        }
+                    }
                 ],
-                "summary": "获取用户信息",
                 "responses": {
                     "200": {
-                        "description": "获取用户信息",
+                        "description": "更新SysDictionaryDetail",
                         "schema": {
                             "allOf": [
                                 {
@@ -6259,10 +6542,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6274,7 +6553,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/getUserList": {
+        "/sysError/createSysError": {
             "post": {
                 "security": [
                     {
@@ -6288,23 +6567,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysError"
                 ],
-                "summary": "分页获取用户列表",
+                "summary": "创建错误日志",
                 "parameters": [
                     {
-                        "description": "页码, 每页大小",
+                        "description": "创建错误日志",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.GetUserList"
+                            "$ref": "#/definitions/system.SysError"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "分页获取用户列表,返回包括列表,总数,页码,每页数量",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6313,9 +6592,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/response.PageResult"
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6327,34 +6603,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/resetPassword": {
-            "post": {
+        "/sysError/deleteSysError": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysError"
                 ],
-                "summary": "重置用户密码",
+                "summary": "删除错误日志",
                 "parameters": [
                     {
-                        "description": "ID",
+                        "description": "删除错误日志",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysUser"
+                            "$ref": "#/definitions/system.SysError"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "重置用户密码",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6374,8 +6653,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/setUserAuthorities": {
-            "post": {
+        "/sysError/deleteSysErrorByIds": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -6388,23 +6667,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
-                ],
-                "summary": "设置用户权限",
-                "parameters": [
-                    {
-                        "description": "用户UUID, 角色ID",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SetUserAuthorities"
-                        }
-                    }
+                    "SysError"
                 ],
+                "summary": "批量删除错误日志",
                 "responses": {
                     "200": {
-                        "description": "设置用户权限",
+                        "description": "批量删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6424,8 +6692,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/setUserAuthority": {
-            "post": {
+        "/sysError/findSysError": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -6438,23 +6706,153 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysError"
                 ],
-                "summary": "更改用户权限",
+                "summary": "用id查询错误日志",
                 "parameters": [
                     {
-                        "description": "用户UUID, 角色ID",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SetUserAuth"
+                        "type": "integer",
+                        "description": "用id查询错误日志",
+                        "name": "ID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/system.SysError"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysError/getSysErrorList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysError"
+                ],
+                "summary": "分页获取错误日志列表",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "createdAtRange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "form",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "info",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
+                }
+            }
+        },
+        "/sysError/getSysErrorSolution": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysError"
+                ],
+                "summary": "根据ID触发处理：标记为处理中，1分钟后自动改为处理完成",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "错误日志ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置用户权限",
+                        "description": "处理已提交",
                         "schema": {
                             "allOf": [
                                 {
@@ -6474,7 +6872,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/setUserInfo": {
+        "/sysError/updateSysError": {
             "put": {
                 "security": [
                     {
@@ -6488,23 +6886,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "SysUser"
+                    "SysError"
                 ],
-                "summary": "设置用户信息",
+                "summary": "更新错误日志",
                 "parameters": [
                     {
-                        "description": "ID, 用户名, 昵称, 头像链接",
+                        "description": "更新错误日志",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/system.SysUser"
+                            "$ref": "#/definitions/system.SysError"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "设置用户信息",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -6513,10 +6911,6 @@ const docTemplate = `{
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
                                         "msg": {
                                             "type": "string"
                                         }
@@ -6527,1517 +6921,6503 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "common.JSONMap": {
-            "type": "object",
-            "additionalProperties": true
         },
-        "config.AliyunOSS": {
-            "type": "object",
-            "properties": {
-                "access-key-id": {
-                    "type": "string"
-                },
-                "access-key-secret": {
-                    "type": "string"
-                },
-                "base-path": {
-                    "type": "string"
-                },
-                "bucket-name": {
-                    "type": "string"
-                },
-                "bucket-url": {
-                    "type": "string"
-                },
-                "endpoint": {
-                    "type": "string"
+        "/sysExportTemplate/createSysExportTemplate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "创建导出模板",
+                "parameters": [
+                    {
+                        "description": "创建导出模板",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysExportTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"创建成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "config.Autocode": {
-            "type": "object",
-            "properties": {
-                "ai-path": {
-                    "type": "string"
-                },
-                "module": {
-                    "type": "string"
-                },
-                "root": {
-                    "type": "string"
-                },
-                "server": {
-                    "type": "string"
-                },
-                "web": {
-                    "type": "string"
+        "/sysExportTemplate/deleteSysExportTemplate": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "删除导出模板",
+                "parameters": [
+                    {
+                        "description": "删除导出模板",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysExportTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"删除成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "config.AwsS3": {
-            "type": "object",
-            "properties": {
-                "base-url": {
-                    "type": "string"
-                },
-                "bucket": {
-                    "type": "string"
-                },
-                "disable-ssl": {
-                    "type": "boolean"
-                },
+        "/sysExportTemplate/deleteSysExportTemplateByIds": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "批量删除导出模板",
+                "parameters": [
+                    {
+                        "description": "批量删除导出模板",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.IdsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"批量删除成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sysExportTemplate/exportExcel": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "导出表格",
+                "responses": {}
+            }
+        },
+        "/sysExportTemplate/exportExcelByToken": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExportExcelByToken"
+                ],
+                "summary": "导出表格",
+                "responses": {}
+            }
+        },
+        "/sysExportTemplate/exportTemplate": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "导出表格模板",
+                "responses": {}
+            }
+        },
+        "/sysExportTemplate/exportTemplateByToken": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExportTemplateByToken"
+                ],
+                "summary": "通过token导出表格模板",
+                "responses": {}
+            }
+        },
+        "/sysExportTemplate/findSysExportTemplate": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "用id查询导出模板",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "数据库名称",
+                        "name": "dbName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "自定义导入SQL",
+                        "name": "importSql",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "自定义导出SQL",
+                        "name": "sql",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "表名称",
+                        "name": "tableName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模板标识",
+                        "name": "templateID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模板信息",
+                        "name": "templateInfo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"查询成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sysExportTemplate/getSysExportTemplateList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "分页获取导出模板列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "数据库名称",
+                        "name": "dbName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "endCreatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "自定义导入SQL",
+                        "name": "importSql",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模板名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "自定义导出SQL",
+                        "name": "sql",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "startCreatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "表名称",
+                        "name": "tableName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模板标识",
+                        "name": "templateID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "模板信息",
+                        "name": "templateInfo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"获取成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sysExportTemplate/importExcel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysImportTemplate"
+                ],
+                "summary": "导入表格",
+                "responses": {}
+            }
+        },
+        "/sysExportTemplate/previewSQL": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "预览最终生成的SQL（不执行查询，仅返回SQL字符串）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "导出模板ID",
+                        "name": "templateID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询参数编码字符串，参考 ExportExcel 组件",
+                        "name": "params",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysExportTemplate/updateSysExportTemplate": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysExportTemplate"
+                ],
+                "summary": "更新导出模板",
+                "parameters": [
+                    {
+                        "description": "更新导出模板",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysExportTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"success\":true,\"data\":{},\"msg\":\"更新成功\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sysOperationRecord/findSysOperationRecord": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysOperationRecord"
+                ],
+                "summary": "用id查询SysOperationRecord",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "代理",
+                        "name": "agent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求Body",
+                        "name": "body",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "错误信息",
+                        "name": "error_message",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求Header 已脱敏",
+                        "name": "header",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "模拟登录期间的操作 user_id为被模拟的用户 impersonator_id为实际操作人",
+                        "name": "impersonator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求ip",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "延迟",
+                        "name": "latency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求方法",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求路径",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求ID 可据此查询该请求产生的变更历史",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "响应Body",
+                        "name": "resp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "请求状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用id查询SysOperationRecord",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysOperationRecord/getOperationRecordStats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysOperationRecord"
+                ],
+                "summary": "获取操作记录后台写入的统计",
+                "responses": {
+                    "200": {
+                        "description": "获取操作记录后台写入的统计,返回包括队列长度,已写入,丢弃与失败的记录数",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysOperationRecordStats"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysOperationRecord/getSysOperationRecordList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysOperationRecord"
+                ],
+                "summary": "分页获取SysOperationRecord列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "代理",
+                        "name": "agent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求Body",
+                        "name": "body",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "错误信息",
+                        "name": "error_message",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求Header 已脱敏",
+                        "name": "header",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "模拟登录期间的操作 user_id为被模拟的用户 impersonator_id为实际操作人",
+                        "name": "impersonator_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求ip",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "延迟",
+                        "name": "latency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求方法",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求路径",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求ID 可据此查询该请求产生的变更历史",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "响应Body",
+                        "name": "resp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "请求状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取SysOperationRecord列表,返回包括列表,总数,页码,每页数量",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/createSysParams": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "创建参数",
+                "parameters": [
+                    {
+                        "description": "创建参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/deleteSysParams": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "删除参数",
+                "parameters": [
+                    {
+                        "description": "删除参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/deleteSysParamsByIds": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "批量删除参数",
+                "responses": {
+                    "200": {
+                        "description": "批量删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/findSysParams": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "用id查询参数",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "主键ID",
+                        "name": "ID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "创建时间",
+                        "name": "createdAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "参数说明",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "参数键",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "参数名称",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "所属租户",
+                        "name": "tenantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间",
+                        "name": "updatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "参数值",
+                        "name": "value",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/system.SysParams"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/getSysParam": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "根据key获取参数value",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/system.SysParams"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/getSysParamsList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "分页获取参数列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "endCreatedAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "startCreatedAt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysParams/updateSysParams": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysParams"
+                ],
+                "summary": "更新参数",
+                "parameters": [
+                    {
+                        "description": "更新参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysParams"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/deleteSysVersion": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "删除版本管理",
+                "parameters": [
+                    {
+                        "description": "删除版本管理",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysVersion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/deleteSysVersionByIds": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "批量删除版本管理",
+                "responses": {
+                    "200": {
+                        "description": "批量删除成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/downloadVersionJson": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "下载版本JSON数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "版本ID",
+                        "name": "ID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "下载成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/exportVersion": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "创建发版数据",
+                "parameters": [
+                    {
+                        "description": "创建发版数据",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ExportVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/findSysVersion": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "用id查询版本管理",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用id查询版本管理",
+                        "name": "ID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/system.SysVersion"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/getSysVersionList": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "分页获取版本管理列表",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "createdAtRange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页大小",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "versionCode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "versionName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/getSysVersionPublic": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "不需要鉴权的版本管理接口",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sysVersion/importVersion": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysVersion"
+                ],
+                "summary": "导入版本数据",
+                "parameters": [
+                    {
+                        "description": "版本JSON数据",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ImportVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "导入成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/system/getServerInfo": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取服务器信息",
+                "responses": {
+                    "200": {
+                        "description": "获取服务器信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/system/getSystemConfig": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "获取配置文件内容",
+                "responses": {
+                    "200": {
+                        "description": "获取配置文件内容,返回包括系统配置",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysConfigResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/system/reloadSystem": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "重载系统",
+                "responses": {
+                    "200": {
+                        "description": "重载系统",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/system/setSystemConfig": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "设置配置文件内容",
+                "parameters": [
+                    {
+                        "description": "设置配置文件内容",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.System"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置配置文件内容",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/tenant/createTenant": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysTenant"
+                ],
+                "summary": "创建租户",
+                "parameters": [
+                    {
+                        "description": "租户名称, 租户编码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysTenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建租户",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/tenant/deleteTenant": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysTenant"
+                ],
+                "summary": "删除租户",
+                "parameters": [
+                    {
+                        "description": "租户ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除租户",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/tenant/getTenantList": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysTenant"
+                ],
+                "summary": "分页获取租户列表",
+                "parameters": [
+                    {
+                        "description": "页码, 每页大小, 关键字",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PageInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取租户列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/tenant/updateTenant": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysTenant"
+                ],
+                "summary": "更新租户",
+                "parameters": [
+                    {
+                        "description": "租户ID, 租户名称, 租户编码, 是否启用",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysTenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新租户",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/SetSelfInfo": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "设置用户信息",
+                "parameters": [
+                    {
+                        "description": "ID, 用户名, 昵称, 头像链接",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置用户信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/SetSelfSetting": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "设置用户配置",
+                "parameters": [
+                    {
+                        "description": "用户配置数据",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置用户配置",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/admin_register": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "用户注册账号",
+                "parameters": [
+                    {
+                        "description": "用户名, 昵称, 密码, 角色ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.Register"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用户注册账号,返回包括用户信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SysUserResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/approveAuthorityGrant": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "审批通过临时角色授权 审批人不能是发起人",
+                "parameters": [
+                    {
+                        "description": "临时授权ID, 审批意见",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthorityGrantAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "审批通过临时角色授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/changePassword": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "用户修改密码",
+                "parameters": [
+                    {
+                        "description": "用户名, 原密码, 新密码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangePasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "用户修改密码",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/createAuthorityGrant": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "发起临时角色授权 到达开始时间后生效 到期自动回收",
+                "parameters": [
+                    {
+                        "description": "用户ID, 角色ID, 开始时间, 结束时间, 原因",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAuthorityGrant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "发起临时角色授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/system.SysUserAuthorityGrant"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/createInvitation": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "生成注册邀请链接 链接只返回一次",
+                "parameters": [
+                    {
+                        "description": "角色ID, 邮箱域名, 有效天数, 备注",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateInvitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "返回邀请链接",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.CreateInvitationResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/deleteUser": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "description": "用户ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除用户",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/disableTwoFactor": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "关闭两步验证",
+                "parameters": [
+                    {
+                        "description": "验证码或恢复码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "关闭两步验证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/enableTwoFactor": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "确认绑定两步验证",
+                "parameters": [
+                    {
+                        "description": "验证码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "启用两步验证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/getAuthorityAuditList": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "分页获取用户角色授权审计记录",
+                "parameters": [
+                    {
+                        "description": "页码, 每页大小, 用户ID, 角色ID, 动作",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthorityAuditSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取用户角色授权审计记录",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/getAuthorityGrantList": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "分页获取临时角色授权",
+                "parameters": [
+                    {
+                        "description": "页码, 每页大小, 用户ID, 状态",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthorityGrantSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取临时角色授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/getIdentities": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "获取当前用户绑定的外部身份",
+                "responses": {
+                    "200": {
+                        "description": "获取当前用户绑定的外部身份",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.SysUserIdentity"
+                                            }
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/getInvitationList": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "分页获取注册邀请",
+                "parameters": [
+                    {
+                        "description": "页码, 每页大小",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SysUserInvitationSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取注册邀请",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/getUserInfo": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "获取用户信息",
+                "responses": {
+                    "200": {
+                        "description": "获取用户信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/getUserList": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "分页获取用户列表",
+                "parameters": [
+                    {
+                        "description": "页码, 每页大小",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetUserList"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分页获取用户列表,返回包括列表,总数,页码,每页数量",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.PageResult"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "模拟登录 以目标用户身份访问系统 期间的操作记录会标记实际操作人",
+                "parameters": [
+                    {
+                        "description": "目标用户ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "返回目标用户信息,模拟登录token,过期时间",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.LoginResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/linkIdentity": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "绑定外部身份 LDAP直接校验账号密码 OIDC返回授权地址",
+                "parameters": [
+                    {
+                        "description": "身份提供方,账号,密码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LinkIdentityReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "绑定外部身份",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.SSOAuthorizeResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/rejectAuthorityGrant": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "拒绝临时角色授权",
+                "parameters": [
+                    {
+                        "description": "临时授权ID, 审批意见",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthorityGrantAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "拒绝临时角色授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/resetPassword": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "重置用户密码",
+                "parameters": [
+                    {
+                        "description": "ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重置用户密码",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/resetTwoFactor": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "管理员重置用户两步验证",
+                "parameters": [
+                    {
+                        "description": "用户ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重置两步验证",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/revokeAuthorityGrant": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "撤销临时角色授权 已生效的立即回收",
+                "parameters": [
+                    {
+                        "description": "临时授权ID, 撤销原因",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AuthorityGrantAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "撤销临时角色授权",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/revokeInvitation": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "作废注册邀请",
+                "parameters": [
+                    {
+                        "description": "邀请ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "作废注册邀请",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/setUserAuthorities": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "设置用户权限",
+                "parameters": [
+                    {
+                        "description": "用户UUID, 角色ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserAuthorities"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置用户权限",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/setUserAuthority": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "更改用户权限",
+                "parameters": [
+                    {
+                        "description": "用户UUID, 角色ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetUserAuth"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置用户权限",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/setUserInfo": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "设置用户信息",
+                "parameters": [
+                    {
+                        "description": "ID, 用户名, 昵称, 头像链接",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/system.SysUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置用户信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/setupTwoFactor": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "获取两步验证绑定信息",
+                "responses": {
+                    "200": {
+                        "description": "返回密钥,otpauth地址与恢复码",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.TwoFactorSetupResponse"
+                                        },
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/stopImpersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "退出模拟登录 作废当前模拟登录token",
+                "responses": {
+                    "200": {
+                        "description": "退出模拟登录",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/unlinkIdentity": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "解除外部身份绑定",
+                "parameters": [
+                    {
+                        "description": "绑定记录ID",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GetById"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解除外部身份绑定",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/unlockUser": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SysUser"
+                ],
+                "summary": "解除用户登录锁定",
+                "parameters": [
+                    {
+                        "description": "用户名",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UnlockUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "解除用户登录锁定",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "msg": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "common.JSONMap": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "config.AccessKey": {
+            "type": "object",
+            "properties": {
+                "clock-skew": {
+                    "description": "签名请求允许的时间偏差 同时也是nonce的防重放窗口 单位:s(秒)",
+                    "type": "integer"
+                },
+                "max-body-size": {
+                    "description": "签名请求体的最大长度 计算签名需要读入整个请求体 单位:MB 默认10",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.AliyunOSS": {
+            "type": "object",
+            "properties": {
+                "access-key-id": {
+                    "type": "string"
+                },
+                "access-key-secret": {
+                    "type": "string"
+                },
+                "base-path": {
+                    "type": "string"
+                },
+                "bucket-name": {
+                    "type": "string"
+                },
+                "bucket-url": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.Audit": {
+            "type": "object",
+            "properties": {
+                "archive-dir": {
+                    "description": "归档文件目录",
+                    "type": "string"
+                },
+                "checkpoint-interval": {
+                    "description": "生成检查点的间隔 如1h",
+                    "type": "string"
+                },
+                "retention-days": {
+                    "description": "审计日志在库中保留的天数 超出后归档 0为不归档",
+                    "type": "integer"
+                },
+                "signing-key": {
+                    "description": "检查点签名密钥 必须单独配置且不能与jwt的签名密钥相同 为空时不生成检查点",
+                    "type": "string"
+                }
+            }
+        },
+        "config.AuthorityGrant": {
+            "type": "object",
+            "properties": {
+                "default-authority-id": {
+                    "description": "回收临时角色后用户没有其他角色时切换到的角色 为0时回收失败",
+                    "type": "integer"
+                },
+                "interval": {
+                    "description": "定时生效与回收临时授权的间隔 为空时1m",
+                    "type": "string"
+                },
+                "max-duration": {
+                    "description": "单次临时授权的最长时长 为空表示不限制",
+                    "type": "string"
+                },
+                "require-approval": {
+                    "description": "临时授权需要另一名管理员审批后才会生效",
+                    "type": "boolean"
+                }
+            }
+        },
+        "config.Autocode": {
+            "type": "object",
+            "properties": {
+                "ai-path": {
+                    "type": "string"
+                },
+                "module": {
+                    "type": "string"
+                },
+                "root": {
+                    "type": "string"
+                },
+                "server": {
+                    "type": "string"
+                },
+                "web": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.AwsS3": {
+            "type": "object",
+            "properties": {
+                "base-url": {
+                    "type": "string"
+                },
+                "bucket": {
+                    "type": "string"
+                },
+                "disable-ssl": {
+                    "type": "boolean"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "path-prefix": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "s3-force-path-style": {
+                    "type": "boolean"
+                },
+                "secret-id": {
+                    "type": "string"
+                },
+                "secret-key": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.CORS": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string"
+                },
+                "whitelist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.CORSWhitelist"
+                    }
+                }
+            }
+        },
+        "config.CORSWhitelist": {
+            "type": "object",
+            "properties": {
+                "allow-credentials": {
+                    "type": "boolean"
+                },
+                "allow-headers": {
+                    "type": "string"
+                },
+                "allow-methods": {
+                    "type": "string"
+                },
+                "allow-origin": {
+                    "type": "string"
+                },
+                "expose-headers": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.Captcha": {
+            "type": "object",
+            "properties": {
+                "img-height": {
+                    "description": "验证码高度",
+                    "type": "integer"
+                },
+                "img-width": {
+                    "description": "验证码宽度",
+                    "type": "integer"
+                },
+                "key-long": {
+                    "description": "验证码长度",
+                    "type": "integer"
+                },
+                "open-captcha": {
+                    "description": "防爆破验证码开启此数，0代表每次登录都需要验证码，其他数字代表错误密码次数，如3代表错误三次后出现验证码",
+                    "type": "integer"
+                },
+                "open-captcha-timeout": {
+                    "description": "防爆破验证码超时时间，单位：s(秒)",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.CloudflareR2": {
+            "type": "object",
+            "properties": {
+                "access-key-id": {
+                    "type": "string"
+                },
+                "account-id": {
+                    "type": "string"
+                },
+                "base-url": {
+                    "type": "string"
+                },
+                "bucket": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "secret-access-key": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.DiskList": {
+            "type": "object",
+            "properties": {
+                "mount-point": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.Excel": {
+            "type": "object",
+            "properties": {
+                "dir": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.HuaWeiObs": {
+            "type": "object",
+            "properties": {
+                "access-key": {
+                    "type": "string"
+                },
+                "bucket": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "secret-key": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.Identity": {
+            "type": "object",
+            "properties": {
+                "providers": {
+                    "description": "外部身份提供方",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.IdentityProvider"
+                    }
+                }
+            }
+        },
+        "config.IdentityGroupMapping": {
+            "type": "object",
+            "properties": {
+                "authority-id": {
+                    "description": "映射到的角色ID",
+                    "type": "integer"
+                },
+                "group": {
+                    "description": "IdP组名或LDAP组cn",
+                    "type": "string"
+                }
+            }
+        },
+        "config.IdentityProvider": {
+            "type": "object",
+            "properties": {
+                "auto-create": {
+                    "description": "首次登录时自动创建本地用户",
+                    "type": "boolean"
+                },
+                "auto-link": {
+                    "description": "首次登录时按用户名自动关联已有本地用户",
+                    "type": "boolean"
+                },
+                "default-authority-id": {
+                    "description": "没有匹配的组映射时自动创建用户使用的角色",
+                    "type": "integer"
+                },
+                "display-name": {
+                    "description": "登录页展示名称",
+                    "type": "string"
+                },
+                "enable": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "group-mappings": {
+                    "description": "组与角色的映射",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.IdentityGroupMapping"
+                    }
+                },
+                "ldap": {
+                    "$ref": "#/definitions/config.LDAPProvider"
+                },
+                "name": {
+                    "description": "唯一标识 登录时指定",
+                    "type": "string"
+                },
+                "oidc": {
+                    "$ref": "#/definitions/config.OIDCProvider"
+                },
+                "sync-authorities": {
+                    "description": "每次登录按组映射同步用户角色",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "类型: oidc | ldap",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Impersonation": {
+            "type": "object",
+            "properties": {
+                "authority-ids": {
+                    "description": "允许模拟登录的角色 为空表示关闭模拟登录 这些角色的用户也不能被模拟",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "expires-time": {
+                    "description": "模拟登录token有效期 到期后需重新发起 为空时1h",
+                    "type": "string"
+                }
+            }
+        },
+        "config.JWT": {
+            "type": "object",
+            "properties": {
+                "blacklist-poll": {
+                    "description": "未开启redis时从数据库同步其他实例拉黑的token的间隔 为空时10s",
+                    "type": "string"
+                },
+                "expires-time": {
+                    "description": "access token过期时间",
+                    "type": "string"
+                },
+                "issuer": {
+                    "description": "签发者",
+                    "type": "string"
+                },
+                "keys": {
+                    "description": "非对称签名密钥列表 按kid区分",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.JWTKey"
+                    }
+                },
+                "refresh-expires-time": {
+                    "description": "refresh token过期时间",
+                    "type": "string"
+                },
+                "rotation-grace": {
+                    "description": "密钥轮换后旧密钥仍可验签的宽限期 为空时等于过期时间",
+                    "type": "string"
+                },
+                "signing-key": {
+                    "description": "jwt签名",
+                    "type": "string"
+                },
+                "signing-method": {
+                    "description": "签名算法:HS256(默认)|RS256|ES256|EdDSA",
+                    "type": "string"
+                }
+            }
+        },
+        "config.JWTKey": {
+            "type": "object",
+            "properties": {
+                "active-from": {
+                    "description": "开始用于签名的时间(RFC3339) 为空表示立即生效",
+                    "type": "string"
+                },
+                "kid": {
+                    "description": "密钥ID 写入token头部",
+                    "type": "string"
+                },
+                "private-key": {
+                    "description": "PEM格式私钥文件路径或PEM内容(PKCS8/PKCS1/SEC1)",
+                    "type": "string"
+                }
+            }
+        },
+        "config.LDAPProvider": {
+            "type": "object",
+            "properties": {
+                "base-dn": {
+                    "description": "用户查询根",
+                    "type": "string"
+                },
+                "bind-dn": {
+                    "description": "查询用户使用的服务账号 为空时匿名查询",
+                    "type": "string"
+                },
+                "bind-password": {
+                    "description": "服务账号密码",
+                    "type": "string"
+                },
+                "email-attr": {
+                    "description": "邮箱属性 默认 mail",
+                    "type": "string"
+                },
+                "group-attr": {
+                    "description": "组名属性 默认 cn",
+                    "type": "string"
+                },
+                "group-base-dn": {
+                    "description": "组查询根 为空时不查询组",
+                    "type": "string"
+                },
+                "group-filter": {
+                    "description": "组查询条件 %s为用户DN 默认 (member=%s)",
+                    "type": "string"
+                },
+                "insecure-skip-verify": {
+                    "description": "跳过证书校验 仅测试环境使用",
+                    "type": "boolean"
+                },
+                "name-attr": {
+                    "description": "昵称属性 默认 cn",
+                    "type": "string"
+                },
+                "phone-attr": {
+                    "description": "手机号属性 默认 telephoneNumber",
+                    "type": "string"
+                },
+                "start-tls": {
+                    "description": "是否使用StartTLS",
+                    "type": "boolean"
+                },
+                "url": {
+                    "description": "ldap://host:389 或 ldaps://host:636",
+                    "type": "string"
+                },
+                "user-filter": {
+                    "description": "用户查询条件 %s为用户名 默认 (uid=%s)",
+                    "type": "string"
+                },
+                "username-attr": {
+                    "description": "用户名属性 默认 uid",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Local": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "description": "本地文件访问路径",
+                    "type": "string"
+                },
+                "store-path": {
+                    "description": "本地文件存储路径",
+                    "type": "string"
+                }
+            }
+        },
+        "config.LoginLock": {
+            "type": "object",
+            "properties": {
+                "lock-duration": {
+                    "description": "首次锁定时长 之后每次锁定翻倍 单位:s(秒)",
+                    "type": "integer"
+                },
+                "max-attempts": {
+                    "description": "连续失败多少次后锁定账号 0表示不锁定",
+                    "type": "integer"
+                },
+                "max-duration": {
+                    "description": "最长锁定时长 单位:s(秒)",
+                    "type": "integer"
+                },
+                "reset-after": {
+                    "description": "超过该时间未再被锁定则锁定时长重新从首次锁定时长计算 单位:s(秒)",
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "失败次数统计窗口 单位:s(秒)",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.MCP": {
+            "type": "object",
+            "properties": {
+                "addr": {
+                    "description": "独立MCP服务端口",
+                    "type": "integer"
+                },
+                "message_path": {
+                    "description": "消息路径",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "MCP名称",
+                    "type": "string"
+                },
+                "separate": {
+                    "description": "是否独立运行MCP服务",
+                    "type": "boolean"
+                },
+                "sse_path": {
+                    "description": "SSE路径",
+                    "type": "string"
+                },
+                "url_prefix": {
+                    "description": "URL前缀",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "MCP版本",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Minio": {
+            "type": "object",
+            "properties": {
+                "access-key-id": {
+                    "type": "string"
+                },
+                "access-key-secret": {
+                    "type": "string"
+                },
+                "base-path": {
+                    "type": "string"
+                },
+                "bucket-name": {
+                    "type": "string"
+                },
+                "bucket-url": {
+                    "type": "string"
+                },
                 "endpoint": {
                     "type": "string"
                 },
-                "path-prefix": {
+                "use-ssl": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "config.Mongo": {
+            "type": "object",
+            "properties": {
+                "auth-source": {
+                    "description": "验证数据库",
+                    "type": "string"
+                },
+                "coll": {
+                    "description": "collection name",
+                    "type": "string"
+                },
+                "connect-timeout-ms": {
+                    "description": "连接超时时间",
+                    "type": "integer"
+                },
+                "database": {
+                    "description": "database name",
+                    "type": "string"
+                },
+                "hosts": {
+                    "description": "主机列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.MongoHost"
+                    }
+                },
+                "is-zap": {
+                    "description": "是否开启zap日志",
+                    "type": "boolean"
+                },
+                "max-pool-size": {
+                    "description": "最大连接池",
+                    "type": "integer"
+                },
+                "min-pool-size": {
+                    "description": "最小连接池",
+                    "type": "integer"
+                },
+                "options": {
+                    "description": "mongodb options",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "socket-timeout-ms": {
+                    "description": "socket超时时间",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "config.MongoHost": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "description": "ip地址",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "端口",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Mssql": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "高级配置",
+                    "type": "string"
+                },
+                "db-name": {
+                    "description": "数据库名",
+                    "type": "string"
+                },
+                "engine": {
+                    "description": "数据库引擎，默认InnoDB",
+                    "type": "string",
+                    "default": "InnoDB"
+                },
+                "log-mode": {
+                    "description": "是否开启Gorm全局日志",
+                    "type": "string"
+                },
+                "log-zap": {
+                    "description": "是否通过zap写入日志文件",
+                    "type": "boolean"
+                },
+                "max-idle-conns": {
+                    "description": "空闲中的最大连接数",
+                    "type": "integer"
+                },
+                "max-open-conns": {
+                    "description": "打开到数据库的最大连接数",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "数据库密码",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "数据库地址",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "数据库端口",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "数据库前缀",
+                    "type": "string"
+                },
+                "singular": {
+                    "description": "是否开启全局禁用复数，true表示开启",
+                    "type": "boolean"
+                },
+                "username": {
+                    "description": "数据库账号",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Mysql": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "高级配置",
+                    "type": "string"
+                },
+                "db-name": {
+                    "description": "数据库名",
+                    "type": "string"
+                },
+                "engine": {
+                    "description": "数据库引擎，默认InnoDB",
+                    "type": "string",
+                    "default": "InnoDB"
+                },
+                "log-mode": {
+                    "description": "是否开启Gorm全局日志",
+                    "type": "string"
+                },
+                "log-zap": {
+                    "description": "是否通过zap写入日志文件",
+                    "type": "boolean"
+                },
+                "max-idle-conns": {
+                    "description": "空闲中的最大连接数",
+                    "type": "integer"
+                },
+                "max-open-conns": {
+                    "description": "打开到数据库的最大连接数",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "数据库密码",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "数据库地址",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "数据库端口",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "数据库前缀",
+                    "type": "string"
+                },
+                "singular": {
+                    "description": "是否开启全局禁用复数，true表示开启",
+                    "type": "boolean"
+                },
+                "username": {
+                    "description": "数据库账号",
+                    "type": "string"
+                }
+            }
+        },
+        "config.OIDCProvider": {
+            "type": "object",
+            "properties": {
+                "client-id": {
+                    "description": "客户端ID",
+                    "type": "string"
+                },
+                "client-secret": {
+                    "description": "客户端密钥 公共客户端可为空",
+                    "type": "string"
+                },
+                "groups-claim": {
+                    "description": "组字段 默认 groups",
+                    "type": "string"
+                },
+                "issuer": {
+                    "description": "签发者地址 用于发现配置",
+                    "type": "string"
+                },
+                "redirect-url": {
+                    "description": "回调地址 指向前端登录页",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "默认 openid profile email",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "username-claim": {
+                    "description": "用户名字段 默认 preferred_username",
+                    "type": "string"
+                }
+            }
+        },
+        "config.OperationRecord": {
+            "type": "object",
+            "properties": {
+                "batch-size": {
+                    "description": "每批写入的记录数",
+                    "type": "integer"
+                },
+                "drop-policy": {
+                    "description": "队列已满时的丢弃策略 newest:丢弃新记录 oldest:丢弃队列中最早的记录",
+                    "type": "string"
+                },
+                "flush-interval": {
+                    "description": "不足一批时的最长写入间隔 单位:ms(毫秒)",
+                    "type": "integer"
+                },
+                "queue-size": {
+                    "description": "内存队列长度",
+                    "type": "integer"
+                }
+            }
+        },
+        "config.Oracle": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "高级配置",
+                    "type": "string"
+                },
+                "db-name": {
+                    "description": "数据库名",
+                    "type": "string"
+                },
+                "engine": {
+                    "description": "数据库引擎，默认InnoDB",
+                    "type": "string",
+                    "default": "InnoDB"
+                },
+                "log-mode": {
+                    "description": "是否开启Gorm全局日志",
+                    "type": "string"
+                },
+                "log-zap": {
+                    "description": "是否通过zap写入日志文件",
+                    "type": "boolean"
+                },
+                "max-idle-conns": {
+                    "description": "空闲中的最大连接数",
+                    "type": "integer"
+                },
+                "max-open-conns": {
+                    "description": "打开到数据库的最大连接数",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "数据库密码",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "数据库地址",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "数据库端口",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "数据库前缀",
+                    "type": "string"
+                },
+                "singular": {
+                    "description": "是否开启全局禁用复数，true表示开启",
+                    "type": "boolean"
+                },
+                "username": {
+                    "description": "数据库账号",
+                    "type": "string"
+                }
+            }
+        },
+        "config.PasswordPolicy": {
+            "type": "object",
+            "properties": {
+                "banned-passwords": {
+                    "description": "禁止使用的弱密码 不区分大小写",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "change-on-first-login": {
+                    "description": "管理员创建的用户首次登录必须修改密码",
+                    "type": "boolean"
+                },
+                "history-count": {
+                    "description": "不允许与最近N次使用过的密码相同 0表示不限制",
+                    "type": "integer"
+                },
+                "max-age": {
+                    "description": "密码有效期 单位:天 0表示永不过期",
+                    "type": "integer"
+                },
+                "min-length": {
+                    "description": "最小长度 0表示不限制",
+                    "type": "integer"
+                },
+                "require-digit": {
+                    "description": "必须包含数字",
+                    "type": "boolean"
+                },
+                "require-lower": {
+                    "description": "必须包含小写字母",
+                    "type": "boolean"
+                },
+                "require-symbol": {
+                    "description": "必须包含特殊字符",
+                    "type": "boolean"
+                },
+                "require-upper": {
+                    "description": "必须包含大写字母",
+                    "type": "boolean"
+                }
+            }
+        },
+        "config.Pgsql": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "高级配置",
+                    "type": "string"
+                },
+                "db-name": {
+                    "description": "数据库名",
+                    "type": "string"
+                },
+                "engine": {
+                    "description": "数据库引擎，默认InnoDB",
+                    "type": "string",
+                    "default": "InnoDB"
+                },
+                "log-mode": {
+                    "description": "是否开启Gorm全局日志",
+                    "type": "string"
+                },
+                "log-zap": {
+                    "description": "是否通过zap写入日志文件",
+                    "type": "boolean"
+                },
+                "max-idle-conns": {
+                    "description": "空闲中的最大连接数",
+                    "type": "integer"
+                },
+                "max-open-conns": {
+                    "description": "打开到数据库的最大连接数",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "数据库密码",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "数据库地址",
+                    "type": "string"
+                },
+                "port": {
+                    "description": "数据库端口",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "数据库前缀",
+                    "type": "string"
+                },
+                "singular": {
+                    "description": "是否开启全局禁用复数，true表示开启",
+                    "type": "boolean"
+                },
+                "username": {
+                    "description": "数据库账号",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Qiniu": {
+            "type": "object",
+            "properties": {
+                "access-key": {
+                    "description": "秘钥AK",
+                    "type": "string"
+                },
+                "bucket": {
+                    "description": "空间名称",
+                    "type": "string"
+                },
+                "img-path": {
+                    "description": "CDN加速域名",
+                    "type": "string"
+                },
+                "secret-key": {
+                    "description": "秘钥SK",
+                    "type": "string"
+                },
+                "use-cdn-domains": {
+                    "description": "上传是否使用CDN上传加速",
+                    "type": "boolean"
+                },
+                "use-https": {
+                    "description": "是否使用https",
+                    "type": "boolean"
+                },
+                "zone": {
+                    "description": "存储区域",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Redaction": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "description": "json字段 不含\".\"时匹配任意层级的同名字段 含\".\"时为从根开始的路径",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "headers": {
+                    "description": "请求头名称",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mask": {
+                    "description": "替换内容 默认******",
+                    "type": "string"
+                },
+                "patterns": {
+                    "description": "正则 有分组时只替换第一个分组",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "config.Redis": {
+            "type": "object",
+            "properties": {
+                "addr": {
+                    "description": "服务器地址:端口",
+                    "type": "string"
+                },
+                "clusterAddrs": {
+                    "description": "集群模式下的节点地址列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "db": {
+                    "description": "单实例模式下redis的哪个数据库",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "代表当前实例的名字",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "useCluster": {
+                    "description": "是否使用集群模式",
+                    "type": "boolean"
+                }
+            }
+        },
+        "config.SelfService": {
+            "type": "object",
+            "properties": {
+                "reset-expires": {
+                    "description": "找回密码链接有效期 为空时30m",
+                    "type": "string"
+                },
+                "site-url": {
+                    "description": "前端访问地址 用于拼接邮件中的链接",
+                    "type": "string"
+                },
+                "verify-expires": {
+                    "description": "注册邮箱验证链接有效期 为空时24h",
+                    "type": "string"
+                }
+            }
+        },
+        "config.Server": {
+            "type": "object",
+            "properties": {
+                "access-key": {
+                    "description": "AK/SK 签名鉴权",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.AccessKey"
+                        }
+                    ]
+                },
+                "aliyun-oss": {
+                    "$ref": "#/definitions/config.AliyunOSS"
+                },
+                "audit": {
+                    "description": "审计日志哈希链 检查点与归档",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Audit"
+                        }
+                    ]
+                },
+                "authority-grant": {
+                    "description": "临时授权",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.AuthorityGrant"
+                        }
+                    ]
+                },
+                "autocode": {
+                    "description": "auto",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Autocode"
+                        }
+                    ]
+                },
+                "aws-s3": {
+                    "$ref": "#/definitions/config.AwsS3"
+                },
+                "captcha": {
+                    "$ref": "#/definitions/config.Captcha"
+                },
+                "cloudflare-r2": {
+                    "$ref": "#/definitions/config.CloudflareR2"
+                },
+                "cors": {
+                    "description": "跨域配置",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.CORS"
+                        }
+                    ]
+                },
+                "db-list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.SpecializedDB"
+                    }
+                },
+                "disk-list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.DiskList"
+                    }
+                },
+                "email": {
+                    "$ref": "#/definitions/github_com_flipped-aurora_gin-vue-admin_server_config.Email"
+                },
+                "excel": {
+                    "$ref": "#/definitions/config.Excel"
+                },
+                "hua-wei-obs": {
+                    "$ref": "#/definitions/config.HuaWeiObs"
+                },
+                "identity": {
+                    "description": "外部身份提供方",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Identity"
+                        }
+                    ]
+                },
+                "impersonation": {
+                    "description": "模拟登录",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Impersonation"
+                        }
+                    ]
+                },
+                "jwt": {
+                    "$ref": "#/definitions/config.JWT"
+                },
+                "local": {
+                    "description": "oss",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Local"
+                        }
+                    ]
+                },
+                "login-lock": {
+                    "description": "登录失败锁定",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.LoginLock"
+                        }
+                    ]
+                },
+                "mcp": {
+                    "description": "MCP配置",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.MCP"
+                        }
+                    ]
+                },
+                "minio": {
+                    "$ref": "#/definitions/config.Minio"
+                },
+                "mongo": {
+                    "$ref": "#/definitions/config.Mongo"
+                },
+                "mssql": {
+                    "$ref": "#/definitions/config.Mssql"
+                },
+                "mysql": {
+                    "description": "gorm",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Mysql"
+                        }
+                    ]
+                },
+                "operation-record": {
+                    "description": "操作记录异步写入",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.OperationRecord"
+                        }
+                    ]
+                },
+                "oracle": {
+                    "$ref": "#/definitions/config.Oracle"
+                },
+                "password-policy": {
+                    "description": "密码策略",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.PasswordPolicy"
+                        }
+                    ]
+                },
+                "pgsql": {
+                    "$ref": "#/definitions/config.Pgsql"
+                },
+                "qiniu": {
+                    "$ref": "#/definitions/config.Qiniu"
+                },
+                "redaction": {
+                    "description": "敏感数据脱敏",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Redaction"
+                        }
+                    ]
+                },
+                "redis": {
+                    "$ref": "#/definitions/config.Redis"
+                },
+                "redis-list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Redis"
+                    }
+                },
+                "self-service": {
+                    "description": "找回密码 邀请注册与自助注册",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.SelfService"
+                        }
+                    ]
+                },
+                "sqlite": {
+                    "$ref": "#/definitions/config.Sqlite"
+                },
+                "system": {
+                    "$ref": "#/definitions/config.System"
+                },
+                "tenant": {
+                    "description": "多租户",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/config.Tenant"
+                        }
+                    ]
+                },
+                "tencent-cos": {
+                    "$ref": "#/definitions/config.TencentCOS"
+                },
+                "zap": {
+                    "$ref": "#/definitions/config.Zap"
+                }
+            }
+        },
+        "config.SpecializedDB": {
+            "type": "object",
+            "properties": {
+                "alias-name": {
+                    "type": "string"
+                },
+                "config": {
+                    "description": "高级配置",
                     "type": "string"
                 },
-                "region": {
+                "db-name": {
+                    "description": "数据库名",
                     "type": "string"
                 },
-                "s3-force-path-style": {
+                "disable": {
                     "type": "boolean"
                 },
-                "secret-id": {
+                "engine": {
+                    "description": "数据库引擎，默认InnoDB",
+                    "type": "string",
+                    "default": "InnoDB"
+                },
+                "log-mode": {
+                    "description": "是否开启Gorm全局日志",
                     "type": "string"
                 },
-                "secret-key": {
+                "log-zap": {
+                    "description": "是否通过zap写入日志文件",
+                    "type": "boolean"
+                },
+                "max-idle-conns": {
+                    "description": "空闲中的最大连接数",
+                    "type": "integer"
+                },
+                "max-open-conns": {
+                    "description": "打开到数据库的最大连接数",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "数据库密码",
                     "type": "string"
-                }
-            }
-        },
-        "config.CORS": {
-            "type": "object",
-            "properties": {
-                "mode": {
+                },
+                "path": {
+                    "description": "数据库地址",
                     "type": "string"
                 },
-                "whitelist": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/config.CORSWhitelist"
-                    }
+                "port": {
+                    "description": "数据库端口",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "数据库前缀",
+                    "type": "string"
+                },
+                "singular": {
+                    "description": "是否开启全局禁用复数，true表示开启",
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "数据库账号",
+                    "type": "string"
                 }
             }
         },
-        "config.CORSWhitelist": {
+        "config.Sqlite": {
             "type": "object",
             "properties": {
-                "allow-credentials": {
+                "config": {
+                    "description": "高级配置",
+                    "type": "string"
+                },
+                "db-name": {
+                    "description": "数据库名",
+                    "type": "string"
+                },
+                "engine": {
+                    "description": "数据库引擎，默认InnoDB",
+                    "type": "string",
+                    "default": "InnoDB"
+                },
+                "log-mode": {
+                    "description": "是否开启Gorm全局日志",
+                    "type": "string"
+                },
+                "log-zap": {
+                    "description": "是否通过zap写入日志文件",
                     "type": "boolean"
                 },
-                "allow-headers": {
+                "max-idle-conns": {
+                    "description": "空闲中的最大连接数",
+                    "type": "integer"
+                },
+                "max-open-conns": {
+                    "description": "打开到数据库的最大连接数",
+                    "type": "integer"
+                },
+                "password": {
+                    "description": "数据库密码",
                     "type": "string"
                 },
-                "allow-methods": {
+                "path": {
+                    "description": "数据库地址",
                     "type": "string"
                 },
-                "allow-origin": {
+                "port": {
+                    "description": "数据库端口",
                     "type": "string"
                 },
-                "expose-headers": {
+                "prefix": {
+                    "description": "数据库前缀",
+                    "type": "string"
+                },
+                "singular": {
+                    "description": "是否开启全局禁用复数，true表示开启",
+                    "type": "boolean"
+                },
+                "username": {
+                    "description": "数据库账号",
                     "type": "string"
                 }
             }
         },
-        "config.Captcha": {
+        "config.System": {
             "type": "object",
             "properties": {
-                "img-height": {
-                    "description": "验证码高度",
+                "addr": {
+                    "description": "端口值",
                     "type": "integer"
                 },
-                "img-width": {
-                    "description": "验证码宽度",
-                    "type": "integer"
+                "casbin-poll": {
+                    "description": "未开启redis时从数据库同步其他实例casbin策略变更的间隔 为空时5s",
+                    "type": "string"
                 },
-                "key-long": {
-                    "description": "验证码长度",
+                "data-scope-sql": {
+                    "description": "允许角色使用自定义SQL数据权限 条件会直接拼接进查询 默认关闭",
+                    "type": "boolean"
+                },
+                "db-type": {
+                    "description": "数据库类型:mysql(默认)|sqlite|sqlserver|postgresql",
+                    "type": "string"
+                },
+                "disable-auto-migrate": {
+                    "description": "自动迁移数据库表结构，生产环境建议设为false，手动迁移",
+                    "type": "boolean"
+                },
+                "iplimit-count": {
                     "type": "integer"
                 },
-                "open-captcha": {
-                    "description": "防爆破验证码开启此数，0代表每次登录都需要验证码，其他数字代表错误密码此数，如3代表错误三次后出现验证码",
+                "iplimit-time": {
                     "type": "integer"
                 },
-                "open-captcha-timeout": {
-                    "description": "防爆破验证码超时时间，单位：s(秒)",
+                "max-sessions": {
+                    "description": "每个用户默认最大同时在线会话数 0为不限制 角色可单独配置",
                     "type": "integer"
+                },
+                "oss-type": {
+                    "description": "Oss类型",
+                    "type": "string"
+                },
+                "router-prefix": {
+                    "type": "string"
+                },
+                "use-mongo": {
+                    "description": "使用mongo",
+                    "type": "boolean"
+                },
+                "use-multipoint": {
+                    "description": "多点登录拦截 兼容旧配置 未配置max-sessions时等价于max-sessions为1",
+                    "type": "boolean"
+                },
+                "use-redis": {
+                    "description": "使用redis",
+                    "type": "boolean"
+                },
+                "use-strict-auth": {
+                    "description": "使用树形角色分配模式",
+                    "type": "boolean"
                 }
             }
         },
-        "config.CloudflareR2": {
+        "config.Tenant": {
             "type": "object",
             "properties": {
-                "access-key-id": {
+                "domain": {
+                    "description": "按子域名解析租户时的主域名 如example.com时acme.example.com解析为编码acme的租户 为空不按子域名解析",
                     "type": "string"
                 },
-                "account-id": {
-                    "type": "string"
+                "enable": {
+                    "description": "开启多租户 关闭时所有数据都属于平台租户",
+                    "type": "boolean"
                 },
+                "header": {
+                    "description": "携带租户编码的请求头 用于未登录时指定租户与平台管理员切换租户 为空时x-tenant",
+                    "type": "string"
+                }
+            }
+        },
+        "config.TencentCOS": {
+            "type": "object",
+            "properties": {
                 "base-url": {
                     "type": "string"
                 },
                 "bucket": {
                     "type": "string"
                 },
-                "path": {
+                "path-prefix": {
                     "type": "string"
                 },
-                "secret-access-key": {
+                "region": {
+                    "type": "string"
+                },
+                "secret-id": {
+                    "type": "string"
+                },
+                "secret-key": {
                     "type": "string"
                 }
             }
         },
-        "config.DiskList": {
+        "config.Zap": {
             "type": "object",
             "properties": {
-                "mount-point": {
+                "director": {
+                    "description": "日志文件夹",
+                    "type": "string"
+                },
+                "encode-level": {
+                    "description": "编码级",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "输出",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "级别",
+                    "type": "string"
+                },
+                "log-in-console": {
+                    "description": "输出控制台",
+                    "type": "boolean"
+                },
+                "prefix": {
+                    "description": "日志前缀",
+                    "type": "string"
+                },
+                "retention-day": {
+                    "description": "日志保留天数",
+                    "type": "integer"
+                },
+                "show-line": {
+                    "description": "显示行",
+                    "type": "boolean"
+                },
+                "stacktrace-key": {
+                    "description": "栈名",
                     "type": "string"
                 }
             }
         },
-        "config.Excel": {
+        "example.ExaAttachmentCategory": {
             "type": "object",
             "properties": {
-                "dir": {
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example.ExaAttachmentCategory"
+                    }
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "config.HuaWeiObs": {
+        "example.ExaCustomer": {
             "type": "object",
             "properties": {
-                "access-key": {
-                    "type": "string"
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
                 },
-                "bucket": {
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "endpoint": {
+                "customerName": {
+                    "description": "客户名",
                     "type": "string"
                 },
-                "path": {
+                "customerPhoneData": {
+                    "description": "客户手机号",
                     "type": "string"
                 },
-                "secret-key": {
+                "sysUser": {
+                    "description": "管理详情",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/system.SysUser"
+                        }
+                    ]
+                },
+                "sysUserAuthorityID": {
+                    "description": "管理角色ID",
+                    "type": "integer"
+                },
+                "sysUserId": {
+                    "description": "管理ID",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "config.JWT": {
+        "example.ExaFile": {
             "type": "object",
             "properties": {
-                "buffer-time": {
-                    "description": "缓冲时间",
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "chunkTotal": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "expires-time": {
-                    "description": "过期时间",
+                "exaFileChunk": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/example.ExaFileChunk"
+                    }
+                },
+                "fileMd5": {
                     "type": "string"
                 },
-                "issuer": {
-                    "description": "签发者",
+                "fileName": {
                     "type": "string"
                 },
-                "signing-key": {
-                    "description": "jwt签名",
+                "filePath": {
+                    "type": "string"
+                },
+                "isFinish": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "config.Local": {
+        "example.ExaFileChunk": {
             "type": "object",
             "properties": {
-                "path": {
-                    "description": "本地文件访问路径",
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "store-path": {
-                    "description": "本地文件存储路径",
+                "exaFileID": {
+                    "type": "integer"
+                },
+                "fileChunkNumber": {
+                    "type": "integer"
+                },
+                "fileChunkPath": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "config.Minio": {
+        "example.ExaFileUploadAndDownload": {
             "type": "object",
             "properties": {
-                "access-key-id": {
-                    "type": "string"
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
                 },
-                "access-key-secret": {
+                "classId": {
+                    "description": "分类id",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "base-path": {
+                "key": {
+                    "description": "编号",
                     "type": "string"
                 },
-                "bucket-name": {
+                "name": {
+                    "description": "文件名",
                     "type": "string"
                 },
-                "bucket-url": {
+                "tag": {
+                    "description": "文件标签",
                     "type": "string"
                 },
-                "endpoint": {
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 },
-                "use-ssl": {
-                    "type": "boolean"
+                "url": {
+                    "description": "文件地址",
+                    "type": "string"
                 }
             }
         },
-        "config.Mongo": {
+        "github_com_flipped-aurora_gin-vue-admin_server_config.Email": {
             "type": "object",
             "properties": {
-                "auth-source": {
-                    "description": "验证数据库",
-                    "type": "string"
-                },
-                "coll": {
-                    "description": "collection name",
+                "from": {
+                    "description": "发件人  你自己要发邮件的邮箱",
                     "type": "string"
                 },
-                "connect-timeout-ms": {
-                    "description": "连接超时时间",
-                    "type": "integer"
-                },
-                "database": {
-                    "description": "database name",
+                "host": {
+                    "description": "服务器地址 例如 smtp.qq.com  请前往QQ或者你要发邮件的邮箱查看其smtp协议",
                     "type": "string"
                 },
-                "hosts": {
-                    "description": "主机列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/config.MongoHost"
-                    }
+                "is-loginauth": {
+                    "description": "是否LoginAuth   是否使用LoginAuth认证方式（适用于IBM、微软邮箱服务器等）",
+                    "type": "boolean"
                 },
-                "is-zap": {
-                    "description": "是否开启zap日志",
+                "is-ssl": {
+                    "description": "是否SSL   是否开启SSL",
                     "type": "boolean"
                 },
-                "max-pool-size": {
-                    "description": "最大连接池",
-                    "type": "integer"
+                "nickname": {
+                    "description": "昵称    发件人昵称 通常为自己的邮箱",
+                    "type": "string"
                 },
-                "min-pool-size": {
-                    "description": "最小连接池",
+                "port": {
+                    "description": "端口     请前往QQ或者你要发邮件的邮箱查看其smtp协议 大多为 465",
                     "type": "integer"
                 },
-                "options": {
-                    "description": "mongodb options",
-                    "type": "string"
-                },
-                "password": {
-                    "description": "密码",
+                "secret": {
+                    "description": "密钥    用于登录的密钥 最好不要用邮箱密码 去邮箱smtp申请一个用于登录的密钥",
                     "type": "string"
                 },
-                "socket-timeout-ms": {
-                    "description": "socket超时时间",
-                    "type": "integer"
-                },
-                "username": {
-                    "description": "用户名",
+                "to": {
+                    "description": "收件人:多个以英文逗号分隔 例：a@qq.com b@qq.com 正式开发中请把此项目作为参数使用",
                     "type": "string"
                 }
             }
         },
-        "config.MongoHost": {
+        "identity.ProviderInfo": {
             "type": "object",
             "properties": {
-                "host": {
-                    "description": "ip地址",
+                "displayName": {
                     "type": "string"
                 },
-                "port": {
-                    "description": "端口",
+                "name": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "config.Mssql": {
+        "model.Info": {
             "type": "object",
             "properties": {
-                "config": {
-                    "description": "高级配置",
-                    "type": "string"
-                },
-                "db-name": {
-                    "description": "数据库名",
-                    "type": "string"
-                },
-                "engine": {
-                    "description": "数据库引擎，默认InnoDB",
-                    "type": "string",
-                    "default": "InnoDB"
-                },
-                "log-mode": {
-                    "description": "是否开启Gorm全局日志",
-                    "type": "string"
-                },
-                "log-zap": {
-                    "description": "是否通过zap写入日志文件",
-                    "type": "boolean"
-                },
-                "max-idle-conns": {
-                    "description": "空闲中的最大连接数",
+                "ID": {
+                    "description": "主键ID",
                     "type": "integer"
                 },
-                "max-open-conns": {
-                    "description": "打开到数据库的最大连接数",
-                    "type": "integer"
+                "attachments": {
+                    "description": "附件",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
                 },
-                "password": {
-                    "description": "数据库密码",
+                "content": {
+                    "description": "内容",
                     "type": "string"
                 },
-                "path": {
-                    "description": "数据库地址",
+                "createdAt": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "port": {
-                    "description": "数据库端口",
+                "title": {
+                    "description": "标题",
                     "type": "string"
                 },
-                "prefix": {
-                    "description": "数据库前缀",
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 },
-                "singular": {
-                    "description": "是否开启全局禁用复数，true表示开启",
-                    "type": "boolean"
+                "userID": {
+                    "description": "作者",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AddMenuAuthorityInfo": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "description": "角色ID",
+                    "type": "integer"
                 },
-                "username": {
-                    "description": "数据库账号",
-                    "type": "string"
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysBaseMenu"
+                    }
                 }
             }
         },
-        "config.Mysql": {
+        "request.AuthorityAuditSearch": {
             "type": "object",
             "properties": {
-                "config": {
-                    "description": "高级配置",
-                    "type": "string"
-                },
-                "db-name": {
-                    "description": "数据库名",
+                "action": {
                     "type": "string"
                 },
-                "engine": {
-                    "description": "数据库引擎，默认InnoDB",
-                    "type": "string",
-                    "default": "InnoDB"
+                "authorityId": {
+                    "type": "integer"
                 },
-                "log-mode": {
-                    "description": "是否开启Gorm全局日志",
+                "keyword": {
+                    "description": "关键字",
                     "type": "string"
                 },
-                "log-zap": {
-                    "description": "是否通过zap写入日志文件",
-                    "type": "boolean"
-                },
-                "max-idle-conns": {
-                    "description": "空闲中的最大连接数",
+                "page": {
+                    "description": "页码",
                     "type": "integer"
                 },
-                "max-open-conns": {
-                    "description": "打开到数据库的最大连接数",
+                "pageSize": {
+                    "description": "每页大小",
                     "type": "integer"
                 },
-                "password": {
-                    "description": "数据库密码",
-                    "type": "string"
-                },
-                "path": {
-                    "description": "数据库地址",
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AuthorityGrantAction": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "detail": {
+                    "description": "审批意见或撤销原因",
                     "type": "string"
                 },
-                "port": {
-                    "description": "数据库端口",
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AuthorityGrantSearch": {
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "description": "关键字",
                     "type": "string"
                 },
-                "prefix": {
-                    "description": "数据库前缀",
-                    "type": "string"
+                "page": {
+                    "description": "页码",
+                    "type": "integer"
                 },
-                "singular": {
-                    "description": "是否开启全局禁用复数，true表示开启",
-                    "type": "boolean"
+                "pageSize": {
+                    "description": "每页大小",
+                    "type": "integer"
                 },
-                "username": {
-                    "description": "数据库账号",
+                "status": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "config.Oracle": {
+        "request.AutoCode": {
             "type": "object",
             "properties": {
-                "config": {
-                    "description": "高级配置",
-                    "type": "string"
+                "abbreviation": {
+                    "description": "Struct简称",
+                    "type": "string",
+                    "example": "Struct简称"
                 },
-                "db-name": {
-                    "description": "数据库名",
-                    "type": "string"
+                "autoCreateApiToSql": {
+                    "description": "是否自动创建api",
+                    "type": "boolean",
+                    "example": false
                 },
-                "engine": {
-                    "description": "数据库引擎，默认InnoDB",
+                "autoCreateBtnAuth": {
+                    "description": "是否自动创建按钮权限",
+                    "type": "boolean",
+                    "example": false
+                },
+                "autoCreateMenuToSql": {
+                    "description": "是否自动创建menu",
+                    "type": "boolean",
+                    "example": false
+                },
+                "autoCreateResource": {
+                    "description": "是否自动创建资源标识",
+                    "type": "boolean",
+                    "example": false
+                },
+                "autoMigrate": {
+                    "description": "是否自动迁移表结构",
+                    "type": "boolean",
+                    "example": false
+                },
+                "businessDB": {
+                    "description": "业务数据库",
                     "type": "string",
-                    "default": "InnoDB"
+                    "example": "业务数据库"
                 },
-                "log-mode": {
-                    "description": "是否开启Gorm全局日志",
-                    "type": "string"
+                "changeHistory": {
+                    "description": "是否记录变更历史",
+                    "type": "boolean",
+                    "example": false
                 },
-                "log-zap": {
-                    "description": "是否通过zap写入日志文件",
-                    "type": "boolean"
+                "description": {
+                    "description": "Struct中文名称",
+                    "type": "string",
+                    "example": "Struct中文名称"
                 },
-                "max-idle-conns": {
-                    "description": "空闲中的最大连接数",
-                    "type": "integer"
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request.AutoCodeField"
+                    }
                 },
-                "max-open-conns": {
-                    "description": "打开到数据库的最大连接数",
-                    "type": "integer"
+                "generateServer": {
+                    "description": "是否生成server",
+                    "type": "boolean",
+                    "example": true
                 },
-                "password": {
-                    "description": "数据库密码",
-                    "type": "string"
+                "generateWeb": {
+                    "description": "是否生成web",
+                    "type": "boolean",
+                    "example": true
                 },
-                "path": {
-                    "description": "数据库地址",
-                    "type": "string"
+                "gvaModel": {
+                    "description": "是否使用gva默认Model",
+                    "type": "boolean",
+                    "example": false
                 },
-                "port": {
-                    "description": "数据库端口",
-                    "type": "string"
+                "humpPackageName": {
+                    "description": "go文件名称",
+                    "type": "string",
+                    "example": "go文件名称"
                 },
-                "prefix": {
-                    "description": "数据库前缀",
-                    "type": "string"
+                "isAdd": {
+                    "description": "是否新增",
+                    "type": "boolean",
+                    "example": false
                 },
-                "singular": {
-                    "description": "是否开启全局禁用复数，true表示开启",
-                    "type": "boolean"
+                "isTree": {
+                    "description": "是否树形结构",
+                    "type": "boolean",
+                    "example": false
                 },
-                "username": {
-                    "description": "数据库账号",
+                "onlyTemplate": {
+                    "description": "是否只生成模板",
+                    "type": "boolean",
+                    "example": false
+                },
+                "package": {
                     "type": "string"
+                },
+                "packageName": {
+                    "description": "文件名称",
+                    "type": "string",
+                    "example": "文件名称"
+                },
+                "primaryField": {
+                    "$ref": "#/definitions/request.AutoCodeField"
+                },
+                "structName": {
+                    "description": "Struct名称",
+                    "type": "string",
+                    "example": "Struct名称"
+                },
+                "tableName": {
+                    "description": "表名",
+                    "type": "string",
+                    "example": "表名"
+                },
+                "treeJson": {
+                    "description": "展示的树json字段",
+                    "type": "string",
+                    "example": "展示的树json字段"
                 }
             }
         },
-        "config.Pgsql": {
+        "request.AutoCodeField": {
             "type": "object",
             "properties": {
-                "config": {
-                    "description": "高级配置",
-                    "type": "string"
-                },
-                "db-name": {
-                    "description": "数据库名",
-                    "type": "string"
+                "checkDataSource": {
+                    "description": "是否检查数据源",
+                    "type": "boolean"
                 },
-                "engine": {
-                    "description": "数据库引擎，默认InnoDB",
-                    "type": "string",
-                    "default": "InnoDB"
+                "clearable": {
+                    "description": "是否可清空",
+                    "type": "boolean"
                 },
-                "log-mode": {
-                    "description": "是否开启Gorm全局日志",
+                "columnName": {
+                    "description": "数据库字段",
                     "type": "string"
                 },
-                "log-zap": {
-                    "description": "是否通过zap写入日志文件",
-                    "type": "boolean"
+                "comment": {
+                    "description": "数据库字段描述",
+                    "type": "string"
                 },
-                "max-idle-conns": {
-                    "description": "空闲中的最大连接数",
-                    "type": "integer"
+                "dataSource": {
+                    "description": "数据源",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/request.DataSource"
+                        }
+                    ]
                 },
-                "max-open-conns": {
-                    "description": "打开到数据库的最大连接数",
-                    "type": "integer"
+                "dataTypeLong": {
+                    "description": "数据库字段长度",
+                    "type": "string"
                 },
-                "password": {
-                    "description": "数据库密码",
+                "defaultValue": {
+                    "description": "是否必填",
                     "type": "string"
                 },
-                "path": {
-                    "description": "数据库地址",
-                    "type": "string"
+                "desc": {
+                    "description": "是否前端详情",
+                    "type": "boolean"
                 },
-                "port": {
-                    "description": "数据库端口",
+                "dictType": {
+                    "description": "字典",
                     "type": "string"
                 },
-                "prefix": {
-                    "description": "数据库前缀",
+                "errorText": {
+                    "description": "校验失败文字",
                     "type": "string"
                 },
-                "singular": {
-                    "description": "是否开启全局禁用复数，true表示开启",
+                "excel": {
+                    "description": "是否导入/导出",
                     "type": "boolean"
                 },
-                "username": {
-                    "description": "数据库账号",
-                    "type": "string"
-                }
-            }
-        },
-        "config.Qiniu": {
-            "type": "object",
-            "properties": {
-                "access-key": {
-                    "description": "秘钥AK",
+                "fieldDesc": {
+                    "description": "中文名",
                     "type": "string"
                 },
-                "bucket": {
-                    "description": "空间名称",
+                "fieldIndexType": {
+                    "description": "索引类型",
                     "type": "string"
                 },
-                "img-path": {
-                    "description": "CDN加速域名",
+                "fieldJson": {
+                    "description": "FieldJson",
                     "type": "string"
                 },
-                "secret-key": {
-                    "description": "秘钥SK",
+                "fieldName": {
+                    "description": "Field名",
                     "type": "string"
                 },
-                "use-cdn-domains": {
-                    "description": "上传是否使用CDN上传加速",
-                    "type": "boolean"
-                },
-                "use-https": {
-                    "description": "是否使用https",
+                "fieldSearchHide": {
+                    "description": "是否隐藏查询条件",
                     "type": "boolean"
                 },
-                "zone": {
-                    "description": "存储区域",
+                "fieldSearchType": {
+                    "description": "搜索条件",
                     "type": "string"
-                }
-            }
-        },
-        "config.Redis": {
-            "type": "object",
-            "properties": {
-                "addr": {
-                    "description": "服务器地址:端口",
+                },
+                "fieldType": {
+                    "description": "Field数据类型",
                     "type": "string"
                 },
-                "clusterAddrs": {
-                    "description": "集群模式下的节点地址列表",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "form": {
+                    "description": "Front           bool        ` + "`" + `json:\"front\"` + "`" + `           // 是否前端可见",
+                    "type": "boolean"
                 },
-                "db": {
-                    "description": "单实例模式下redis的哪个数据库",
-                    "type": "integer"
+                "primaryKey": {
+                    "description": "是否主键",
+                    "type": "boolean"
                 },
-                "name": {
-                    "description": "代表当前实例的名字",
-                    "type": "string"
+                "require": {
+                    "description": "是否必填",
+                    "type": "boolean"
                 },
-                "password": {
-                    "description": "密码",
-                    "type": "string"
+                "sort": {
+                    "description": "是否增加排序",
+                    "type": "boolean"
                 },
-                "useCluster": {
-                    "description": "是否使用集群模式",
+                "table": {
+                    "description": "是否前端表格列",
                     "type": "boolean"
                 }
             }
         },
-        "config.Server": {
+        "request.AutoMcpTool": {
             "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
             "properties": {
-                "aliyun-oss": {
-                    "$ref": "#/definitions/config.AliyunOSS"
-                },
-                "autocode": {
-                    "description": "auto",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/config.Autocode"
-                        }
-                    ]
-                },
-                "aws-s3": {
-                    "$ref": "#/definitions/config.AwsS3"
-                },
-                "captcha": {
-                    "$ref": "#/definitions/config.Captcha"
-                },
-                "cloudflare-r2": {
-                    "$ref": "#/definitions/config.CloudflareR2"
+                "description": {
+                    "type": "string"
                 },
-                "cors": {
-                    "description": "跨域配置",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/config.CORS"
-                        }
-                    ]
+                "name": {
+                    "type": "string"
                 },
-                "db-list": {
+                "params": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config.SpecializedDB"
-                    }
-                },
-                "disk-list": {
+                        "type": "object",
+                        "required": [
+                            "description",
+                            "name",
+                            "type"
+                        ],
+                        "properties": {
+                            "default": {
+                                "type": "string"
+                            },
+                            "description": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            },
+                            "required": {
+                                "type": "boolean"
+                            },
+                            "type": {
+                                "description": "string, number, boolean, object, array",
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "response": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config.DiskList"
-                    }
-                },
-                "email": {
-                    "$ref": "#/definitions/github_com_flipped-aurora_gin-vue-admin_server_config.Email"
-                },
-                "excel": {
-                    "$ref": "#/definitions/config.Excel"
-                },
-                "hua-wei-obs": {
-                    "$ref": "#/definitions/config.HuaWeiObs"
-                },
-                "jwt": {
-                    "$ref": "#/definitions/config.JWT"
-                },
-                "local": {
-                    "description": "oss",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/config.Local"
-                        }
-                    ]
-                },
-                "minio": {
-                    "$ref": "#/definitions/config.Minio"
-                },
-                "mongo": {
-                    "$ref": "#/definitions/config.Mongo"
-                },
-                "mssql": {
-                    "$ref": "#/definitions/config.Mssql"
-                },
-                "mysql": {
-                    "description": "gorm",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/config.Mysql"
+                        "type": "object",
+                        "required": [
+                            "type"
+                        ],
+                        "properties": {
+                            "type": {
+                                "description": "text, image",
+                                "type": "string"
+                            }
                         }
-                    ]
-                },
-                "oracle": {
-                    "$ref": "#/definitions/config.Oracle"
-                },
-                "pgsql": {
-                    "$ref": "#/definitions/config.Pgsql"
-                },
-                "qiniu": {
-                    "$ref": "#/definitions/config.Qiniu"
-                },
-                "redis": {
-                    "$ref": "#/definitions/config.Redis"
+                    }
+                }
+            }
+        },
+        "request.CasbinInReceive": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "description": "权限id",
+                    "type": "integer"
                 },
-                "redis-list": {
+                "casbinInfos": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/config.Redis"
+                        "$ref": "#/definitions/request.CasbinInfo"
                     }
-                },
-                "sqlite": {
-                    "$ref": "#/definitions/config.Sqlite"
-                },
-                "system": {
-                    "$ref": "#/definitions/config.System"
-                },
-                "tencent-cos": {
-                    "$ref": "#/definitions/config.TencentCOS"
-                },
-                "zap": {
-                    "$ref": "#/definitions/config.Zap"
                 }
             }
         },
-        "config.SpecializedDB": {
+        "request.CasbinInfo": {
             "type": "object",
             "properties": {
-                "alias-name": {
-                    "type": "string"
-                },
-                "config": {
-                    "description": "高级配置",
-                    "type": "string"
-                },
-                "db-name": {
-                    "description": "数据库名",
-                    "type": "string"
-                },
-                "disable": {
-                    "type": "boolean"
-                },
-                "engine": {
-                    "description": "数据库引擎，默认InnoDB",
-                    "type": "string",
-                    "default": "InnoDB"
-                },
-                "log-mode": {
-                    "description": "是否开启Gorm全局日志",
+                "effect": {
+                    "description": "效果 allow或deny 为空时按allow处理",
                     "type": "string"
                 },
-                "log-zap": {
-                    "description": "是否通过zap写入日志文件",
-                    "type": "boolean"
-                },
-                "max-idle-conns": {
-                    "description": "空闲中的最大连接数",
-                    "type": "integer"
-                },
-                "max-open-conns": {
-                    "description": "打开到数据库的最大连接数",
-                    "type": "integer"
-                },
-                "password": {
-                    "description": "数据库密码",
+                "method": {
+                    "description": "方法",
                     "type": "string"
                 },
                 "path": {
-                    "description": "数据库地址",
-                    "type": "string"
-                },
-                "port": {
-                    "description": "数据库端口",
+                    "description": "路径",
+                    "type": "string"
+                }
+            }
+        },
+        "request.ChangePasswordReq": {
+            "type": "object",
+            "properties": {
+                "newPassword": {
+                    "description": "新密码",
                     "type": "string"
                 },
-                "prefix": {
-                    "description": "数据库前缀",
+                "password": {
+                    "description": "密码",
                     "type": "string"
+                }
+            }
+        },
+        "request.CreateAccessKey": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "type": "integer"
                 },
-                "singular": {
-                    "description": "是否开启全局禁用复数，true表示开启",
-                    "type": "boolean"
+                "days": {
+                    "description": "有效天数 0为不过期",
+                    "type": "integer"
                 },
-                "type": {
+                "remark": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "数据库账号",
-                    "type": "string"
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "config.Sqlite": {
+        "request.CreateAuthorityGrant": {
             "type": "object",
+            "required": [
+                "authorityId",
+                "endAt",
+                "reason",
+                "startAt",
+                "userId"
+            ],
             "properties": {
-                "config": {
-                    "description": "高级配置",
-                    "type": "string"
+                "authorityId": {
+                    "type": "integer"
                 },
-                "db-name": {
-                    "description": "数据库名",
+                "endAt": {
                     "type": "string"
                 },
-                "engine": {
-                    "description": "数据库引擎，默认InnoDB",
-                    "type": "string",
-                    "default": "InnoDB"
-                },
-                "log-mode": {
-                    "description": "是否开启Gorm全局日志",
+                "reason": {
                     "type": "string"
                 },
-                "log-zap": {
-                    "description": "是否通过zap写入日志文件",
-                    "type": "boolean"
+                "startAt": {
+                    "type": "string"
                 },
-                "max-idle-conns": {
-                    "description": "空闲中的最大连接数",
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CreateInvitation": {
+            "type": "object",
+            "required": [
+                "authorityId"
+            ],
+            "properties": {
+                "authorityId": {
                     "type": "integer"
                 },
-                "max-open-conns": {
-                    "description": "打开到数据库的最大连接数",
+                "days": {
+                    "description": "有效天数 默认7天",
                     "type": "integer"
                 },
-                "password": {
-                    "description": "数据库密码",
+                "emailDomain": {
+                    "description": "限定邮箱域名 如 example.com",
                     "type": "string"
                 },
-                "path": {
-                    "description": "数据库地址",
+                "remark": {
                     "type": "string"
+                }
+            }
+        },
+        "request.DataSource": {
+            "type": "object",
+            "properties": {
+                "association": {
+                    "description": "关联关系 1 一对一 2 一对多",
+                    "type": "integer"
                 },
-                "port": {
-                    "description": "数据库端口",
+                "dbName": {
                     "type": "string"
                 },
-                "prefix": {
-                    "description": "数据库前缀",
+                "hasDeletedAt": {
+                    "type": "boolean"
+                },
+                "label": {
                     "type": "string"
                 },
-                "singular": {
-                    "description": "是否开启全局禁用复数，true表示开启",
-                    "type": "boolean"
+                "table": {
+                    "type": "string"
                 },
-                "username": {
-                    "description": "数据库账号",
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "config.System": {
+        "request.Empty": {
+            "type": "object"
+        },
+        "request.ExaAttachmentCategorySearch": {
             "type": "object",
             "properties": {
-                "addr": {
-                    "description": "端口值",
+                "classId": {
                     "type": "integer"
                 },
-                "db-type": {
-                    "description": "数据库类型:mysql(默认)|sqlite|sqlserver|postgresql",
+                "keyword": {
+                    "description": "关键字",
                     "type": "string"
                 },
-                "iplimit-count": {
+                "page": {
+                    "description": "页码",
                     "type": "integer"
                 },
-                "iplimit-time": {
+                "pageSize": {
+                    "description": "每页大小",
                     "type": "integer"
+                }
+            }
+        },
+        "request.ExportVersionRequest": {
+            "type": "object",
+            "required": [
+                "versionCode",
+                "versionName"
+            ],
+            "properties": {
+                "apiIds": {
+                    "description": "选中的API ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "oss-type": {
-                    "description": "Oss类型",
-                    "type": "string"
-                },
-                "router-prefix": {
+                "description": {
+                    "description": "版本描述",
                     "type": "string"
                 },
-                "use-mongo": {
-                    "description": "使用mongo",
-                    "type": "boolean"
+                "dictIds": {
+                    "description": "选中的字典ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "use-multipoint": {
-                    "description": "多点登录拦截",
-                    "type": "boolean"
+                "menuIds": {
+                    "description": "选中的菜单ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "use-redis": {
-                    "description": "使用redis",
-                    "type": "boolean"
+                "versionCode": {
+                    "description": "版本号",
+                    "type": "string"
                 },
-                "use-strict-auth": {
-                    "description": "使用树形角色分配模式",
-                    "type": "boolean"
+                "versionName": {
+                    "description": "版本名称",
+                    "type": "string"
                 }
             }
         },
-        "config.TencentCOS": {
+        "request.ForgotPassword": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
-                "base-url": {
+                "captcha": {
                     "type": "string"
                 },
-                "bucket": {
+                "captchaId": {
                     "type": "string"
                 },
-                "path-prefix": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.GetAuthorityId": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "description": "角色ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.GetById": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.GetUserList": {
+            "type": "object",
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "region": {
+                "keyword": {
+                    "description": "关键字",
                     "type": "string"
                 },
-                "secret-id": {
+                "nickName": {
                     "type": "string"
                 },
-                "secret-key": {
+                "page": {
+                    "description": "页码",
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "description": "每页大小",
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "config.Zap": {
+        "request.IdsReq": {
             "type": "object",
             "properties": {
-                "director": {
-                    "description": "日志文件夹",
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.ImportSysDictionaryRequest": {
+            "type": "object",
+            "required": [
+                "json"
+            ],
+            "properties": {
+                "json": {
+                    "description": "JSON字符串",
+                    "type": "string"
+                }
+            }
+        },
+        "request.ImportVersionRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "apis": {
+                    "description": "API数据，直接复用SysApi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysApi"
+                    }
+                },
+                "dictionaries": {
+                    "description": "字典数据，直接复用SysDictionary",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysDictionary"
+                    }
+                },
+                "menus": {
+                    "description": "菜单数据，直接复用SysBaseMenu",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysBaseMenu"
+                    }
+                },
+                "version": {
+                    "description": "版本信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/request.VersionInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "request.InitDB": {
+            "type": "object",
+            "required": [
+                "adminPassword",
+                "dbName"
+            ],
+            "properties": {
+                "adminPassword": {
                     "type": "string"
                 },
-                "encode-level": {
-                    "description": "编码级",
+                "dbName": {
+                    "description": "数据库名",
                     "type": "string"
                 },
-                "format": {
-                    "description": "输出",
+                "dbPath": {
+                    "description": "sqlite数据库文件路径",
                     "type": "string"
                 },
-                "level": {
-                    "description": "级别",
+                "dbType": {
+                    "description": "数据库类型",
                     "type": "string"
                 },
-                "log-in-console": {
-                    "description": "输出控制台",
-                    "type": "boolean"
+                "host": {
+                    "description": "服务器地址",
+                    "type": "string"
                 },
-                "prefix": {
-                    "description": "日志前缀",
+                "password": {
+                    "description": "数据库密码",
                     "type": "string"
                 },
-                "retention-day": {
-                    "description": "日志保留天数",
-                    "type": "integer"
+                "port": {
+                    "description": "数据库连接端口",
+                    "type": "string"
                 },
-                "show-line": {
-                    "description": "显示行",
-                    "type": "boolean"
+                "template": {
+                    "description": "postgresql指定template",
+                    "type": "string"
                 },
-                "stacktrace-key": {
-                    "description": "栈名",
+                "userName": {
+                    "description": "数据库用户名",
                     "type": "string"
                 }
             }
         },
-        "example.ExaAttachmentCategory": {
+        "request.LinkIdentityReq": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "description": "主键ID",
-                    "type": "integer"
-                },
-                "children": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/example.ExaAttachmentCategory"
-                    }
-                },
-                "createdAt": {
-                    "description": "创建时间",
+                "password": {
+                    "description": "提供方密码",
                     "type": "string"
                 },
-                "name": {
+                "provider": {
+                    "description": "身份提供方名称",
                     "type": "string"
                 },
-                "pid": {
-                    "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
+                "username": {
+                    "description": "提供方用户名",
                     "type": "string"
                 }
             }
         },
-        "example.ExaCustomer": {
+        "request.Login": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "description": "主键ID",
-                    "type": "integer"
+                "captcha": {
+                    "description": "验证码",
+                    "type": "string"
                 },
-                "createdAt": {
-                    "description": "创建时间",
+                "captchaId": {
+                    "description": "验证码ID",
                     "type": "string"
                 },
-                "customerName": {
-                    "description": "客户名",
+                "password": {
+                    "description": "密码",
                     "type": "string"
                 },
-                "customerPhoneData": {
-                    "description": "客户手机号",
+                "provider": {
+                    "description": "身份提供方 为空时使用本地账号",
                     "type": "string"
                 },
-                "sysUser": {
-                    "description": "管理详情",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/system.SysUser"
-                        }
-                    ]
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "request.PageInfo": {
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "description": "关键字",
+                    "type": "string"
                 },
-                "sysUserAuthorityID": {
-                    "description": "管理角色ID",
+                "page": {
+                    "description": "页码",
                     "type": "integer"
                 },
-                "sysUserId": {
-                    "description": "管理ID",
+                "pageSize": {
+                    "description": "每页大小",
                     "type": "integer"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
                 }
             }
         },
-        "example.ExaFile": {
+        "request.PermissionExplain": {
             "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
             "properties": {
-                "ID": {
-                    "description": "主键ID",
-                    "type": "integer"
-                },
-                "chunkTotal": {
+                "authorityId": {
                     "type": "integer"
                 },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "exaFileChunk": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/example.ExaFileChunk"
-                    }
-                },
-                "fileMd5": {
-                    "type": "string"
-                },
-                "fileName": {
+                "method": {
                     "type": "string"
                 },
-                "filePath": {
+                "path": {
                     "type": "string"
                 },
-                "isFinish": {
-                    "type": "boolean"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "example.ExaFileChunk": {
+        "request.PermissionMatrix": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "description": "主键ID",
-                    "type": "integer"
-                },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "exaFileID": {
+                "authorityId": {
                     "type": "integer"
                 },
-                "fileChunkNumber": {
+                "userId": {
                     "type": "integer"
-                },
-                "fileChunkPath": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "description": "更新时间",
+                }
+            }
+        },
+        "request.RefreshTokenReq": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
                     "type": "string"
                 }
             }
         },
-        "example.ExaFileUploadAndDownload": {
+        "request.Register": {
             "type": "object",
             "properties": {
-                "ID": {
-                    "description": "主键ID",
-                    "type": "integer"
+                "authorityId": {
+                    "type": "string",
+                    "example": "int 角色id"
                 },
-                "classId": {
-                    "description": "分类id",
-                    "type": "integer"
+                "authorityIds": {
+                    "type": "string",
+                    "example": "[]uint 角色id"
                 },
-                "createdAt": {
-                    "description": "创建时间",
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "example": "电子邮箱"
                 },
-                "key": {
-                    "description": "编号",
-                    "type": "string"
+                "enable": {
+                    "type": "string",
+                    "example": "int 是否启用"
                 },
-                "name": {
-                    "description": "文件名",
-                    "type": "string"
+                "headerImg": {
+                    "type": "string",
+                    "example": "头像链接"
                 },
-                "tag": {
-                    "description": "文件标签",
-                    "type": "string"
+                "nickName": {
+                    "type": "string",
+                    "example": "昵称"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
+                "passWord": {
+                    "type": "string",
+                    "example": "密码"
                 },
-                "url": {
-                    "description": "文件地址",
-                    "type": "string"
+                "phone": {
+                    "type": "string",
+                    "example": "电话号码"
+                },
+                "userName": {
+                    "type": "string",
+                    "example": "用户名"
                 }
             }
         },
-        "github_com_flipped-aurora_gin-vue-admin_server_config.Email": {
+        "request.ResetPasswordByToken": {
             "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
             "properties": {
-                "from": {
-                    "description": "发件人  你自己要发邮件的邮箱",
+                "password": {
                     "type": "string"
                 },
-                "host": {
-                    "description": "服务器地址 例如 smtp.qq.com  请前往QQ或者你要发邮件的邮箱查看其smtp协议",
+                "token": {
                     "type": "string"
-                },
-                "is-ssl": {
-                    "description": "是否SSL   是否开启SSL",
-                    "type": "boolean"
-                },
-                "nickname": {
-                    "description": "昵称    发件人昵称 通常为自己的邮箱",
+                }
+            }
+        },
+        "request.SSOAuthorizeReq": {
+            "type": "object",
+            "properties": {
+                "provider": {
+                    "description": "身份提供方名称",
                     "type": "string"
-                },
-                "port": {
-                    "description": "端口     请前往QQ或者你要发邮件的邮箱查看其smtp协议 大多为 465",
-                    "type": "integer"
-                },
-                "secret": {
-                    "description": "密钥    用于登录的密钥 最好不要用邮箱密码 去邮箱smtp申请一个用于登录的密钥",
+                }
+            }
+        },
+        "request.SSOCallbackReq": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "to": {
-                    "description": "收件人:多个以英文逗号分隔 例：a@qq.com b@qq.com 正式开发中请把此项目作为参数使用",
+                "state": {
                     "type": "string"
                 }
             }
         },
-        "model.Info": {
+        "request.SearchApiParams": {
             "type": "object",
             "properties": {
                 "ID": {
                     "description": "主键ID",
                     "type": "integer"
                 },
-                "attachments": {
-                    "description": "附件",
-                    "type": "array",
-                    "items": {
-                        "type": "object"
-                    }
-                },
-                "content": {
-                    "description": "内容",
+                "apiGroup": {
+                    "description": "api组",
                     "type": "string"
                 },
                 "createdAt": {
                     "description": "创建时间",
                     "type": "string"
                 },
-                "title": {
-                    "description": "标题",
+                "desc": {
+                    "description": "排序方式:升序false(默认)|降序true",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "api中文描述",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
+                "keyword": {
+                    "description": "关键字",
                     "type": "string"
                 },
-                "userID": {
-                    "description": "作者",
+                "method": {
+                    "description": "方法:创建POST(默认)|查看GET|更新PUT|删除DELETE",
+                    "type": "string"
+                },
+                "orderKey": {
+                    "description": "排序",
+                    "type": "string"
+                },
+                "page": {
+                    "description": "页码",
                     "type": "integer"
-                }
-            }
-        },
-        "request.AddMenuAuthorityInfo": {
-            "type": "object",
-            "properties": {
-                "authorityId": {
-                    "description": "角色ID",
+                },
+                "pageSize": {
+                    "description": "每页大小",
                     "type": "integer"
                 },
-                "menus": {
+                "path": {
+                    "description": "api路径",
+                    "type": "string"
+                },
+                "redactFields": {
+                    "description": "操作记录中额外脱敏的内容 在配置与内置规则基础上追加",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/system.SysBaseMenu"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "request.AutoCode": {
-            "type": "object",
-            "properties": {
-                "abbreviation": {
-                    "description": "Struct简称",
-                    "type": "string",
-                    "example": "Struct简称"
-                },
-                "autoCreateApiToSql": {
-                    "description": "是否自动创建api",
-                    "type": "boolean",
-                    "example": false
-                },
-                "autoCreateBtnAuth": {
-                    "description": "是否自动创建按钮权限",
-                    "type": "boolean",
-                    "example": false
-                },
-                "autoCreateMenuToSql": {
-                    "description": "是否自动创建menu",
-                    "type": "boolean",
-                    "example": false
-                },
-                "autoCreateResource": {
-                    "description": "是否自动创建资源标识",
-                    "type": "boolean",
-                    "example": false
-                },
-                "autoMigrate": {
-                    "description": "是否自动迁移表结构",
-                    "type": "boolean",
-                    "example": false
-                },
-                "businessDB": {
-                    "description": "业务数据库",
-                    "type": "string",
-                    "example": "业务数据库"
-                },
-                "description": {
-                    "description": "Struct中文名称",
-                    "type": "string",
-                    "example": "Struct中文名称"
                 },
-                "fields": {
+                "redactHeaders": {
+                    "description": "请求头名称",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/request.AutoCodeField"
+                        "type": "string"
                     }
                 },
-                "generateServer": {
-                    "description": "是否生成server",
-                    "type": "boolean",
-                    "example": true
-                },
-                "generateWeb": {
-                    "description": "是否生成web",
-                    "type": "boolean",
-                    "example": true
-                },
-                "gvaModel": {
-                    "description": "是否使用gva默认Model",
-                    "type": "boolean",
-                    "example": false
-                },
-                "humpPackageName": {
-                    "description": "go文件名称",
-                    "type": "string",
-                    "example": "go文件名称"
-                },
-                "isAdd": {
-                    "description": "是否新增",
-                    "type": "boolean",
-                    "example": false
-                },
-                "isTree": {
-                    "description": "是否树形结构",
-                    "type": "boolean",
-                    "example": false
-                },
-                "onlyTemplate": {
-                    "description": "是否只生成模板",
-                    "type": "boolean",
-                    "example": false
+                "redactPatterns": {
+                    "description": "正则 有分组时只替换第一个分组",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "package": {
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
-                },
-                "packageName": {
-                    "description": "文件名称",
-                    "type": "string",
-                    "example": "文件名称"
-                },
-                "primaryField": {
-                    "$ref": "#/definitions/request.AutoCodeField"
-                },
-                "structName": {
-                    "description": "Struct名称",
-                    "type": "string",
-                    "example": "Struct名称"
-                },
-                "tableName": {
-                    "description": "表名",
-                    "type": "string",
-                    "example": "表名"
-                },
-                "treeJson": {
-                    "description": "展示的树json字段",
-                    "type": "string",
-                    "example": "展示的树json字段"
                 }
             }
         },
-        "request.AutoCodeField": {
+        "request.SelfRegister": {
             "type": "object",
+            "required": [
+                "email",
+                "passWord",
+                "userName"
+            ],
             "properties": {
-                "checkDataSource": {
-                    "description": "是否检查数据源",
-                    "type": "boolean"
-                },
-                "clearable": {
-                    "description": "是否可清空",
-                    "type": "boolean"
-                },
-                "columnName": {
-                    "description": "数据库字段",
-                    "type": "string"
-                },
-                "comment": {
-                    "description": "数据库字段描述",
-                    "type": "string"
-                },
-                "dataSource": {
-                    "description": "数据源",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/request.DataSource"
-                        }
-                    ]
-                },
-                "dataTypeLong": {
-                    "description": "数据库字段长度",
+                "captcha": {
                     "type": "string"
                 },
-                "defaultValue": {
-                    "description": "是否必填",
+                "captchaId": {
                     "type": "string"
                 },
-                "desc": {
-                    "description": "是否前端详情",
-                    "type": "boolean"
-                },
-                "dictType": {
-                    "description": "字典",
+                "email": {
                     "type": "string"
                 },
-                "errorText": {
-                    "description": "校验失败文字",
+                "invitation": {
+                    "description": "邀请令牌 为空时按自助注册处理",
                     "type": "string"
                 },
-                "excel": {
-                    "description": "是否导入/导出",
-                    "type": "boolean"
-                },
-                "fieldDesc": {
-                    "description": "中文名",
+                "nickName": {
                     "type": "string"
                 },
-                "fieldIndexType": {
-                    "description": "索引类型",
+                "passWord": {
                     "type": "string"
                 },
-                "fieldJson": {
-                    "description": "FieldJson",
+                "userName": {
                     "type": "string"
-                },
-                "fieldName": {
-                    "description": "Field名",
+                }
+            }
+        },
+        "request.SessionReq": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "会话ID",
                     "type": "string"
                 },
-                "fieldSearchHide": {
-                    "description": "是否隐藏查询条件",
-                    "type": "boolean"
-                },
-                "fieldSearchType": {
-                    "description": "搜索条件",
-                    "type": "string"
+                "userId": {
+                    "description": "用户ID 仅管理员接口使用",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.SetUserAuth": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "description": "角色ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.SetUserAuthorities": {
+            "type": "object",
+            "properties": {
+                "authorityIds": {
+                    "description": "角色ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "fieldType": {
-                    "description": "Field数据类型",
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.SysAccessKeySearch": {
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "description": "关键字",
                     "type": "string"
                 },
-                "form": {
-                    "description": "Front           bool        ` + "`" + `json:\"front\"` + "`" + `           // 是否前端可见",
-                    "type": "boolean"
-                },
-                "primaryKey": {
-                    "description": "是否主键",
-                    "type": "boolean"
+                "page": {
+                    "description": "页码",
+                    "type": "integer"
                 },
-                "require": {
-                    "description": "是否必填",
-                    "type": "boolean"
+                "pageSize": {
+                    "description": "每页大小",
+                    "type": "integer"
                 },
-                "sort": {
-                    "description": "是否增加排序",
+                "status": {
                     "type": "boolean"
                 },
-                "table": {
-                    "description": "是否前端表格列",
-                    "type": "boolean"
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CasbinInReceive": {
+        "request.SysAuthorityBtnReq": {
             "type": "object",
             "properties": {
                 "authorityId": {
-                    "description": "权限id",
                     "type": "integer"
                 },
-                "casbinInfos": {
+                "menuID": {
+                    "type": "integer"
+                },
+                "selected": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/request.CasbinInfo"
+                        "type": "integer"
                     }
                 }
             }
         },
-        "request.CasbinInfo": {
+        "request.SysAuthorityFieldReq": {
             "type": "object",
             "properties": {
-                "method": {
-                    "description": "方法",
-                    "type": "string"
+                "authorityId": {
+                    "type": "integer"
                 },
-                "path": {
-                    "description": "路径",
+                "model": {
                     "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysAuthorityField"
+                    }
                 }
             }
         },
-        "request.ChangePasswordReq": {
+        "request.SysAutoCodePackageCreate": {
             "type": "object",
             "properties": {
-                "newPassword": {
-                    "description": "新密码",
-                    "type": "string"
+                "desc": {
+                    "type": "string",
+                    "example": "描述"
                 },
-                "password": {
-                    "description": "密码",
-                    "type": "string"
+                "label": {
+                    "type": "string",
+                    "example": "展示名"
+                },
+                "packageName": {
+                    "type": "string",
+                    "example": "包名"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "模版"
                 }
             }
         },
-        "request.DataSource": {
+        "request.SysAutoHistoryRollBack": {
             "type": "object",
             "properties": {
-                "association": {
-                    "description": "关联关系 1 一对一 2 一对多",
-                    "type": "integer"
-                },
-                "dbName": {
-                    "type": "string"
-                },
-                "hasDeletedAt": {
+                "deleteApi": {
+                    "description": "是否删除接口",
                     "type": "boolean"
                 },
-                "label": {
-                    "type": "string"
+                "deleteMenu": {
+                    "description": "是否删除菜单",
+                    "type": "boolean"
                 },
-                "table": {
-                    "type": "string"
+                "deleteTable": {
+                    "description": "是否删除表",
+                    "type": "boolean"
                 },
-                "value": {
-                    "type": "string"
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
                 }
             }
         },
-        "request.Empty": {
-            "type": "object"
-        },
-        "request.ExaAttachmentCategorySearch": {
+        "request.SysUserInvitationSearch": {
             "type": "object",
             "properties": {
-                "classId": {
-                    "type": "integer"
-                },
                 "keyword": {
                     "description": "关键字",
                     "type": "string"
@@ -8052,57 +13432,126 @@ const docTemplate = `{
                 }
             }
         },
-        "request.GetAuthorityId": {
+        "request.TwoFactorChallengeReq": {
             "type": "object",
             "properties": {
-                "authorityId": {
-                    "description": "角色ID",
-                    "type": "integer"
+                "challengeToken": {
+                    "description": "密码校验通过后返回的challenge token",
+                    "type": "string"
                 }
             }
         },
-        "request.GetById": {
+        "request.TwoFactorCodeReq": {
             "type": "object",
             "properties": {
-                "id": {
-                    "description": "主键ID",
-                    "type": "integer"
+                "code": {
+                    "description": "认证器验证码",
+                    "type": "string"
+                },
+                "recoveryCode": {
+                    "description": "恢复码 仅关闭时可用",
+                    "type": "string"
                 }
             }
         },
-        "request.GetUserList": {
+        "request.TwoFactorVerifyReq": {
             "type": "object",
             "properties": {
-                "email": {
+                "challengeToken": {
+                    "description": "密码校验通过后返回的challenge token",
                     "type": "string"
                 },
-                "keyword": {
-                    "description": "关键字",
+                "code": {
+                    "description": "认证器验证码",
                     "type": "string"
                 },
-                "nickName": {
+                "recoveryCode": {
+                    "description": "恢复码",
+                    "type": "string"
+                }
+            }
+        },
+        "request.UnlockUser": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "description": "被锁定的用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "request.VerifyRegistration": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.VersionInfo": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "description": "版本号",
                     "type": "string"
                 },
-                "page": {
-                    "description": "页码",
-                    "type": "integer"
-                },
-                "pageSize": {
-                    "description": "每页大小",
-                    "type": "integer"
+                "description": {
+                    "description": "版本描述",
+                    "type": "string"
                 },
-                "phone": {
+                "exportTime": {
+                    "description": "导出时间",
                     "type": "string"
                 },
-                "username": {
+                "name": {
+                    "description": "版本名称",
                     "type": "string"
                 }
             }
         },
-        "request.IdsReq": {
+        "response.AuthorityExplain": {
             "type": "object",
             "properties": {
-                "ids": {
+                "allowed": {
+                    "type": "boolean"
+                },
+                "authorityId": {
+                    "type": "integer"
+                },
+                "authorityName": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "用户当前使用的角色",
+                    "type": "boolean"
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PermissionMenu"
+                    }
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PermissionPolicy"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "roles": {
+                    "description": "参与鉴权的角色 自身在前 父角色依次在后",
                     "type": "array",
                     "items": {
                         "type": "integer"
@@ -8110,130 +13559,163 @@ const docTemplate = `{
                 }
             }
         },
-        "request.InitDB": {
+        "response.CreateAccessKeyResponse": {
+            "type": "object",
+            "properties": {
+                "accessKey": {
+                    "type": "string"
+                },
+                "secretKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CreateInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.Email": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "邮件内容",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "邮件标题",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "邮件发送给谁",
+                    "type": "string"
+                }
+            }
+        },
+        "response.ExaCustomerResponse": {
+            "type": "object",
+            "properties": {
+                "customer": {
+                    "$ref": "#/definitions/example.ExaCustomer"
+                }
+            }
+        },
+        "response.ExaFileResponse": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "$ref": "#/definitions/example.ExaFileUploadAndDownload"
+                }
+            }
+        },
+        "response.FilePathResponse": {
+            "type": "object",
+            "properties": {
+                "filePath": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.FileResponse": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "$ref": "#/definitions/example.ExaFile"
+                }
+            }
+        },
+        "response.InvitationInfo": {
             "type": "object",
-            "required": [
-                "adminPassword",
-                "dbName"
-            ],
             "properties": {
-                "adminPassword": {
-                    "type": "string"
-                },
-                "dbName": {
-                    "description": "数据库名",
-                    "type": "string"
-                },
-                "dbPath": {
-                    "description": "sqlite数据库文件路径",
-                    "type": "string"
-                },
-                "dbType": {
-                    "description": "数据库类型",
-                    "type": "string"
-                },
-                "host": {
-                    "description": "服务器地址",
-                    "type": "string"
-                },
-                "password": {
-                    "description": "数据库密码",
-                    "type": "string"
-                },
-                "port": {
-                    "description": "数据库连接端口",
-                    "type": "string"
-                },
-                "template": {
-                    "description": "postgresql指定template",
+                "authorityName": {
                     "type": "string"
                 },
-                "userName": {
-                    "description": "数据库用户名",
+                "emailDomain": {
                     "type": "string"
                 }
             }
         },
-        "request.Login": {
+        "response.LoginResponse": {
             "type": "object",
             "properties": {
-                "captcha": {
-                    "description": "验证码",
-                    "type": "string"
+                "expiresAt": {
+                    "type": "integer"
                 },
-                "captchaId": {
-                    "description": "验证码ID",
-                    "type": "string"
+                "refreshExpiresAt": {
+                    "type": "integer"
                 },
-                "password": {
-                    "description": "密码",
+                "refreshToken": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "用户名",
+                "token": {
                     "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/system.SysUser"
                 }
             }
         },
-        "request.PageInfo": {
+        "response.PageResult": {
             "type": "object",
             "properties": {
-                "keyword": {
-                    "description": "关键字",
-                    "type": "string"
-                },
+                "list": {},
                 "page": {
-                    "description": "页码",
                     "type": "integer"
                 },
                 "pageSize": {
-                    "description": "每页大小",
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "request.Register": {
+        "response.PermissionExplainResponse": {
             "type": "object",
             "properties": {
-                "authorityId": {
-                    "type": "string",
-                    "example": "int 角色id"
-                },
-                "authorityIds": {
-                    "type": "string",
-                    "example": "[]uint 角色id"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "电子邮箱"
-                },
-                "enable": {
-                    "type": "string",
-                    "example": "int 是否启用"
-                },
-                "headerImg": {
-                    "type": "string",
-                    "example": "头像链接"
+                "api": {
+                    "description": "与请求匹配的api定义 为空时说明api未登记",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/system.SysApi"
+                        }
+                    ]
                 },
-                "nickName": {
-                    "type": "string",
-                    "example": "昵称"
+                "authorities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuthorityExplain"
+                    }
                 },
-                "passWord": {
-                    "type": "string",
-                    "example": "密码"
+                "user": {
+                    "$ref": "#/definitions/system.SysUser"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "电话号码"
+                "userActive": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.PermissionMatrixResponse": {
+            "type": "object",
+            "properties": {
+                "authorities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysAuthority"
+                    }
                 },
-                "userName": {
-                    "type": "string",
-                    "example": "用户名"
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PermissionMatrixRow"
+                    }
                 }
             }
         },
-        "request.SearchApiParams": {
+        "response.PermissionMatrixRow": {
             "type": "object",
             "properties": {
                 "ID": {
@@ -8248,202 +13730,122 @@ const docTemplate = `{
                     "description": "创建时间",
                     "type": "string"
                 },
-                "desc": {
-                    "description": "排序方式:升序false(默认)|降序true",
-                    "type": "boolean"
-                },
                 "description": {
                     "description": "api中文描述",
                     "type": "string"
                 },
-                "keyword": {
-                    "description": "关键字",
-                    "type": "string"
+                "effects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "method": {
                     "description": "方法:创建POST(默认)|查看GET|更新PUT|删除DELETE",
                     "type": "string"
                 },
-                "orderKey": {
-                    "description": "排序",
-                    "type": "string"
-                },
-                "page": {
-                    "description": "页码",
-                    "type": "integer"
-                },
-                "pageSize": {
-                    "description": "每页大小",
-                    "type": "integer"
-                },
                 "path": {
                     "description": "api路径",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "description": "更新时间",
-                    "type": "string"
-                }
-            }
-        },
-        "request.SetUserAuth": {
-            "type": "object",
-            "properties": {
-                "authorityId": {
-                    "description": "角色ID",
-                    "type": "integer"
-                }
-            }
-        },
-        "request.SetUserAuthorities": {
-            "type": "object",
-            "properties": {
-                "authorityIds": {
-                    "description": "角色ID",
+                "redactFields": {
+                    "description": "操作记录中额外脱敏的内容 在配置与内置规则基础上追加",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "request.SysAuthorityBtnReq": {
-            "type": "object",
-            "properties": {
-                "authorityId": {
-                    "type": "integer"
-                },
-                "menuID": {
-                    "type": "integer"
-                },
-                "selected": {
+                "redactHeaders": {
+                    "description": "请求头名称",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "request.SysAutoCodePackageCreate": {
-            "type": "object",
-            "properties": {
-                "desc": {
-                    "type": "string",
-                    "example": "描述"
-                },
-                "label": {
-                    "type": "string",
-                    "example": "展示名"
-                },
-                "packageName": {
-                    "type": "string",
-                    "example": "包名"
-                },
-                "template": {
-                    "type": "string",
-                    "example": "模版"
-                }
-            }
-        },
-        "request.SysAutoHistoryRollBack": {
-            "type": "object",
-            "properties": {
-                "deleteApi": {
-                    "description": "是否删除接口",
-                    "type": "boolean"
                 },
-                "deleteMenu": {
-                    "description": "是否删除菜单",
-                    "type": "boolean"
-                },
-                "deleteTable": {
-                    "description": "是否删除表",
-                    "type": "boolean"
-                },
-                "id": {
-                    "description": "主键ID",
-                    "type": "integer"
-                }
-            }
-        },
-        "response.Email": {
-            "type": "object",
-            "properties": {
-                "body": {
-                    "description": "邮件内容",
-                    "type": "string"
-                },
-                "subject": {
-                    "description": "邮件标题",
-                    "type": "string"
+                "redactPatterns": {
+                    "description": "正则 有分组时只替换第一个分组",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "to": {
-                    "description": "邮件发送给谁",
+                "updatedAt": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "response.ExaCustomerResponse": {
-            "type": "object",
-            "properties": {
-                "customer": {
-                    "$ref": "#/definitions/example.ExaCustomer"
-                }
-            }
-        },
-        "response.ExaFileResponse": {
-            "type": "object",
-            "properties": {
-                "file": {
-                    "$ref": "#/definitions/example.ExaFileUploadAndDownload"
-                }
-            }
-        },
-        "response.FilePathResponse": {
+        "response.PermissionMenu": {
             "type": "object",
             "properties": {
-                "filePath": {
+                "buttons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "response.FileResponse": {
-            "type": "object",
-            "properties": {
-                "file": {
-                    "$ref": "#/definitions/example.ExaFile"
-                }
-            }
-        },
-        "response.LoginResponse": {
+        "response.PermissionPolicy": {
             "type": "object",
             "properties": {
-                "expiresAt": {
+                "authorityId": {
                     "type": "integer"
                 },
-                "token": {
+                "effect": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/system.SysUser"
+                "inherited": {
+                    "description": "从父角色继承",
+                    "type": "boolean"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
                 }
             }
         },
-        "response.PageResult": {
+        "response.PluginInfo": {
             "type": "object",
             "properties": {
-                "list": {},
-                "page": {
-                    "type": "integer"
+                "apis": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysApi"
+                    }
                 },
-                "pageSize": {
-                    "type": "integer"
+                "dictionaries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysDictionary"
+                    }
                 },
-                "total": {
-                    "type": "integer"
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysBaseMenu"
+                    }
+                },
+                "pluginName": {
+                    "type": "string"
+                },
+                "pluginType": {
+                    "description": "web, server, full",
+                    "type": "string"
                 }
             }
         },
@@ -8458,6 +13860,23 @@ const docTemplate = `{
                 }
             }
         },
+        "response.RegistrationOptions": {
+            "type": "object",
+            "properties": {
+                "invitation": {
+                    "description": "携带有效邀请令牌时返回",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.InvitationInfo"
+                        }
+                    ]
+                },
+                "selfRegistration": {
+                    "description": "是否开启自助注册",
+                    "type": "boolean"
+                }
+            }
+        },
         "response.Response": {
             "type": "object",
             "properties": {
@@ -8470,6 +13889,14 @@ const docTemplate = `{
                 }
             }
         },
+        "response.SSOAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "response.SysAPIListResponse": {
             "type": "object",
             "properties": {
@@ -8489,6 +13916,58 @@ const docTemplate = `{
                 }
             }
         },
+        "response.SysAuditIssue": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "sourceId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.SysAuditVerifyResult": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "其中源记录已归档的记录数",
+                    "type": "integer"
+                },
+                "checked": {
+                    "description": "校验的链上记录数",
+                    "type": "integer"
+                },
+                "checkpoints": {
+                    "description": "校验的检查点数",
+                    "type": "integer"
+                },
+                "complete": {
+                    "description": "是否已校验到链尾 分批校验未到链尾时以lastSeq继续",
+                    "type": "boolean"
+                },
+                "issues": {
+                    "description": "最多返回前100个问题",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SysAuditIssue"
+                    }
+                },
+                "lastSeq": {
+                    "description": "校验到的链上序号",
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
         "response.SysAuthorityBtnRes": {
             "type": "object",
             "properties": {
@@ -8512,6 +13991,24 @@ const docTemplate = `{
                 }
             }
         },
+        "response.SysAuthorityFieldRes": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysModelField"
+                    }
+                },
+                "rules": {
+                    "description": "字段json名 =\u003e 规则",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "response.SysAuthorityResponse": {
             "type": "object",
             "properties": {
@@ -8564,6 +14061,17 @@ const docTemplate = `{
                 }
             }
         },
+        "response.SysFieldModel": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
         "response.SysMenusResponse": {
             "type": "object",
             "properties": {
@@ -8575,6 +14083,71 @@ const docTemplate = `{
                 }
             }
         },
+        "response.SysOperationRecordStats": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "队列长度",
+                    "type": "integer"
+                },
+                "dropped": {
+                    "description": "队列已满被丢弃的记录数",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "是否启用后台写入",
+                    "type": "boolean"
+                },
+                "failed": {
+                    "description": "写入失败的记录数",
+                    "type": "integer"
+                },
+                "queued": {
+                    "description": "队列中待写入的记录数",
+                    "type": "integer"
+                },
+                "written": {
+                    "description": "已写入的记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "response.SysSessionResponse": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "type": "integer"
+                },
+                "current": {
+                    "description": "是否为发起请求的会话",
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "loginAt": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "response.SysUserResponse": {
             "type": "object",
             "properties": {
@@ -8583,6 +14156,24 @@ const docTemplate = `{
                 }
             }
         },
+        "response.TwoFactorSetupResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "description": "otpauth:// 地址 前端生成二维码",
+                    "type": "string"
+                }
+            }
+        },
         "system.Condition": {
             "type": "object",
             "properties": {
@@ -8666,6 +14257,10 @@ const docTemplate = `{
                 "title": {
                     "description": "菜单名",
                     "type": "string"
+                },
+                "transitionType": {
+                    "description": "路由切换动画",
+                    "type": "string"
                 }
             }
         },
@@ -8696,6 +14291,27 @@ const docTemplate = `{
                     "description": "api路径",
                     "type": "string"
                 },
+                "redactFields": {
+                    "description": "操作记录中额外脱敏的内容 在配置与内置规则基础上追加",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "redactHeaders": {
+                    "description": "请求头名称",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "redactPatterns": {
+                    "description": "正则 有分组时只替换第一个分组",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "updatedAt": {
                     "description": "更新时间",
                     "type": "string"
@@ -8729,6 +14345,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/system.SysAuthority"
                     }
                 },
+                "dataScope": {
+                    "description": "数据权限范围 见DataScope常量",
+                    "type": "string"
+                },
+                "dataScopeSql": {
+                    "description": "DataScope为sql时使用的查询条件",
+                    "type": "string"
+                },
                 "defaultRouter": {
                     "description": "默认菜单(默认dashboard)",
                     "type": "string"
@@ -8736,6 +14360,14 @@ const docTemplate = `{
                 "deletedAt": {
                     "type": "string"
                 },
+                "inheritParent": {
+                    "description": "开启后通过casbin的g规则继承父角色的全部策略",
+                    "type": "boolean"
+                },
+                "maxSessions": {
+                    "description": "最大同时在线会话数",
+                    "type": "integer"
+                },
                 "menus": {
                     "type": "array",
                     "items": {
@@ -8746,12 +14378,37 @@ const docTemplate = `{
                     "description": "父角色ID",
                     "type": "integer"
                 },
+                "requireTwoFactor": {
+                    "description": "是否强制该角色用户启用两步验证",
+                    "type": "boolean"
+                },
+                "tenantId": {
+                    "description": "所属租户 角色的casbin策略属于该租户的域",
+                    "type": "integer"
+                },
                 "updatedAt": {
                     "description": "更新时间",
                     "type": "string"
                 }
             }
         },
+        "system.SysAuthorityField": {
+            "type": "object",
+            "properties": {
+                "authorityId": {
+                    "type": "integer"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
         "system.SysBaseMenu": {
             "type": "object",
             "properties": {
@@ -8832,6 +14489,13 @@ const docTemplate = `{
                     "description": "主键ID",
                     "type": "integer"
                 },
+                "apis": {
+                    "description": "按钮调用的api 只有被授予该按钮的角色才能调用",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysApi"
+                    }
+                },
                 "createdAt": {
                     "description": "创建时间",
                     "type": "string"
@@ -8890,6 +14554,13 @@ const docTemplate = `{
                     "description": "主键ID",
                     "type": "integer"
                 },
+                "children": {
+                    "description": "子字典",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysDictionary"
+                    }
+                },
                 "createdAt": {
                     "description": "创建时间",
                     "type": "string"
@@ -8902,6 +14573,10 @@ const docTemplate = `{
                     "description": "字典名（中）",
                     "type": "string"
                 },
+                "parentID": {
+                    "description": "父级字典ID",
+                    "type": "integer"
+                },
                 "status": {
                     "description": "状态",
                     "type": "boolean"
@@ -8912,6 +14587,10 @@ const docTemplate = `{
                         "$ref": "#/definitions/system.SysDictionaryDetail"
                     }
                 },
+                "tenantId": {
+                    "description": "所属租户",
+                    "type": "integer"
+                },
                 "type": {
                     "description": "字典名（英）",
                     "type": "string"
@@ -8929,10 +14608,21 @@ const docTemplate = `{
                     "description": "主键ID",
                     "type": "integer"
                 },
+                "children": {
+                    "description": "子字典详情",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/system.SysDictionaryDetail"
+                    }
+                },
                 "createdAt": {
                     "description": "创建时间",
                     "type": "string"
                 },
+                "disabled": {
+                    "description": "禁用状态，根据status字段动态计算",
+                    "type": "boolean"
+                },
                 "extend": {
                     "description": "扩展值",
                     "type": "string"
@@ -8941,6 +14631,18 @@ const docTemplate = `{
                     "description": "展示值",
                     "type": "string"
                 },
+                "level": {
+                    "description": "层级深度，从0开始",
+                    "type": "integer"
+                },
+                "parentID": {
+                    "description": "父级字典详情ID",
+                    "type": "integer"
+                },
+                "path": {
+                    "description": "层级路径，如 \"1,2,3\"",
+                    "type": "string"
+                },
                 "sort": {
                     "description": "排序标记",
                     "type": "integer"
@@ -8963,6 +14665,45 @@ const docTemplate = `{
                 }
             }
         },
+        "system.SysError": {
+            "type": "object",
+            "required": [
+                "form"
+            ],
+            "properties": {
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "form": {
+                    "description": "错误来源",
+                    "type": "string"
+                },
+                "info": {
+                    "description": "错误内容",
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "solution": {
+                    "description": "解决方案",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "处理状态：未处理/处理中/处理完成",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
         "system.SysExportTemplate": {
             "type": "object",
             "properties": {
@@ -8984,6 +14725,10 @@ const docTemplate = `{
                     "description": "数据库名称",
                     "type": "string"
                 },
+                "importSql": {
+                    "description": "自定义导入SQL",
+                    "type": "string"
+                },
                 "joinTemplate": {
                     "type": "array",
                     "items": {
@@ -9000,6 +14745,10 @@ const docTemplate = `{
                 "order": {
                     "type": "string"
                 },
+                "sql": {
+                    "description": "自定义导出SQL",
+                    "type": "string"
+                },
                 "tableName": {
                     "description": "表名称",
                     "type": "string"
@@ -9100,63 +14849,44 @@ const docTemplate = `{
                 }
             }
         },
-        "system.SysOperationRecord": {
+        "system.SysModelField": {
             "type": "object",
             "properties": {
                 "ID": {
                     "description": "主键ID",
                     "type": "integer"
                 },
-                "agent": {
-                    "description": "代理",
-                    "type": "string"
-                },
-                "body": {
-                    "description": "请求Body",
+                "column": {
+                    "description": "数据库列名",
                     "type": "string"
                 },
                 "createdAt": {
                     "description": "创建时间",
                     "type": "string"
                 },
-                "error_message": {
-                    "description": "错误信息",
-                    "type": "string"
-                },
-                "ip": {
-                    "description": "请求ip",
+                "description": {
+                    "description": "模型描述",
                     "type": "string"
                 },
-                "latency": {
-                    "description": "延迟",
+                "field": {
+                    "description": "字段json名",
                     "type": "string"
                 },
-                "method": {
-                    "description": "请求方法",
+                "label": {
+                    "description": "字段描述",
                     "type": "string"
                 },
-                "path": {
-                    "description": "请求路径",
+                "model": {
+                    "description": "模型 如 model/example.ExaCustomer",
                     "type": "string"
                 },
-                "resp": {
-                    "description": "响应Body",
+                "table": {
+                    "description": "数据库表名 导出Excel时按列匹配字段权限",
                     "type": "string"
                 },
-                "status": {
-                    "description": "请求状态",
-                    "type": "integer"
-                },
                 "updatedAt": {
                     "description": "更新时间",
                     "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/system.SysUser"
-                },
-                "user_id": {
-                    "description": "用户id",
-                    "type": "integer"
                 }
             }
         },
@@ -9188,6 +14918,10 @@ const docTemplate = `{
                     "description": "参数名称",
                     "type": "string"
                 },
+                "tenantId": {
+                    "description": "所属租户",
+                    "type": "integer"
+                },
                 "updatedAt": {
                     "description": "更新时间",
                     "type": "string"
@@ -9198,6 +14932,39 @@ const docTemplate = `{
                 }
             }
         },
+        "system.SysTenant": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "enable": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
         "system.SysUser": {
             "type": "object",
             "properties": {
@@ -9240,6 +15007,10 @@ const docTemplate = `{
                     "description": "用户头像",
                     "type": "string"
                 },
+                "mustChangePassword": {
+                    "description": "管理员重置或首次登录 修改密码前只能访问修改密码接口",
+                    "type": "boolean"
+                },
                 "nickName": {
                     "description": "用户昵称",
                     "type": "string"
@@ -9252,10 +15023,22 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "passwordChangedAt": {
+                    "description": "密码修改时间 用于计算密码是否过期",
+                    "type": "string"
+                },
                 "phone": {
                     "description": "用户手机号",
                     "type": "string"
                 },
+                "tenantId": {
+                    "description": "所属租户 0为平台租户",
+                    "type": "integer"
+                },
+                "totpEnabled": {
+                    "description": "是否启用两步验证",
+                    "type": "boolean"
+                },
                 "updatedAt": {
                     "description": "更新时间",
                     "type": "string"
@@ -9270,6 +15053,134 @@ const docTemplate = `{
                 }
             }
         },
+        "system.SysUserAuthorityGrant": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "approvedAt": {
+                    "type": "string"
+                },
+                "approvedBy": {
+                    "type": "integer"
+                },
+                "authority": {
+                    "$ref": "#/definitions/system.SysAuthority"
+                },
+                "authorityId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "endAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requestedBy": {
+                    "type": "integer"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "revokedBy": {
+                    "type": "integer"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/system.SysUser"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "system.SysUserIdentity": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "lastLoginAt": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "system.SysVersion": {
+            "type": "object",
+            "required": [
+                "versionCode",
+                "versionName"
+            ],
+            "properties": {
+                "ID": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "版本描述",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "versionCode": {
+                    "description": "版本号",
+                    "type": "string"
+                },
+                "versionData": {
+                    "description": "版本数据",
+                    "type": "string"
+                },
+                "versionName": {
+                    "description": "版本名称",
+                    "type": "string"
+                }
+            }
+        },
         "system.System": {
             "type": "object",
             "properties": {
@@ -9277,6 +15188,49 @@ const docTemplate = `{
                     "$ref": "#/definitions/config.Server"
                 }
             }
+        },
+        "utils.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.JWK"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
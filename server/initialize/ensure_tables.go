@@ -47,6 +47,7 @@ func (e *ensureTables) MigrateTable(ctx context.Context) (context.Context, error
 		sysModel.SysDictionaryDetail{},
 		sysModel.SysBaseMenuParameter{},
 		sysModel.SysBaseMenuBtn{},
+		sysModel.SysBaseMenuBtnApi{},
 		sysModel.SysAuthorityBtn{},
 		sysModel.SysAutoCodePackage{},
 		sysModel.SysExportTemplate{},
@@ -57,6 +58,23 @@ func (e *ensureTables) MigrateTable(ctx context.Context) (context.Context, error
 		sysModel.SysError{},
 		sysModel.SysLoginLog{},
 		sysModel.SysApiToken{},
+		sysModel.SysAccessKey{},
+		sysModel.SysUserInvitation{},
+		sysModel.SysRefreshToken{},
+		sysModel.SysUserIdentity{},
+		sysModel.SysPasswordHistory{},
+		sysModel.SysLoginLock{},
+		sysModel.SysCasbinRevision{},
+		sysModel.SysModelField{},
+		sysModel.SysAuthorityField{},
+		sysModel.SysUserAuthorityGrant{},
+		sysModel.SysUserAuthorityAudit{},
+		sysModel.SysTenant{},
+		sysModel.SysChangeHistory{},
+		sysModel.SysAuditLog{},
+		sysModel.SysAuditHead{},
+		sysModel.SysAuditCheckpoint{},
+		sysModel.SysMigration{},
 		adapter.CasbinRule{},
 
 		example.ExaFile{},
@@ -92,6 +110,7 @@ func (e *ensureTables) TableCreated(ctx context.Context) bool {
 		sysModel.SysDictionaryDetail{},
 		sysModel.SysBaseMenuParameter{},
 		sysModel.SysBaseMenuBtn{},
+		sysModel.SysBaseMenuBtnApi{},
 		sysModel.SysAuthorityBtn{},
 		sysModel.SysAutoCodePackage{},
 		sysModel.SysExportTemplate{},
@@ -55,6 +55,7 @@ func RegisterTables() {
 		system.SysDictionaryDetail{},
 		system.SysBaseMenuParameter{},
 		system.SysBaseMenuBtn{},
+		system.SysBaseMenuBtnApi{},
 		system.SysAuthorityBtn{},
 		system.SysAutoCodePackage{},
 		system.SysExportTemplate{},
@@ -64,7 +65,24 @@ func RegisterTables() {
 		system.SysVersion{},
 		system.SysError{},
 		system.SysApiToken{},
+		system.SysAccessKey{},
+		system.SysUserInvitation{},
+		system.SysRefreshToken{},
+		system.SysUserIdentity{},
+		system.SysPasswordHistory{},
+		system.SysLoginLock{},
 		system.SysLoginLog{},
+		system.SysCasbinRevision{},
+		system.SysModelField{},
+		system.SysAuthorityField{},
+		system.SysUserAuthorityGrant{},
+		system.SysUserAuthorityAudit{},
+		system.SysTenant{},
+		system.SysChangeHistory{},
+		system.SysAuditLog{},
+		system.SysAuditHead{},
+		system.SysAuditCheckpoint{},
+		system.SysMigration{},
 
 		example.ExaFile{},
 		example.ExaCustomer{},
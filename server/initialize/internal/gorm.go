@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/history"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
@@ -27,5 +29,7 @@ func (g *_gorm) Config(general config.GeneralDB) *gorm.Config {
 			SingularTable: general.Singular,
 		},
 		DisableForeignKeyConstraintWhenMigrating: true,
+		// 按请求context中的租户隔离数据 记录开启了变更历史的模型的字段变化
+		Plugins: map[string]gorm.Plugin{tenant.Plugin{}.Name(): tenant.Plugin{}, history.Plugin{}.Name(): history.Plugin{}},
 	}
 }
@@ -15,11 +15,19 @@ func OtherInit() {
 	if err != nil {
 		panic(err)
 	}
-	_, err = utils.ParseDuration(global.GVA_CONFIG.JWT.BufferTime)
+	// 升级前的配置没有refresh-expires-time 使用默认值
+	if global.GVA_CONFIG.JWT.RefreshExpiresTime == "" {
+		global.GVA_CONFIG.JWT.RefreshExpiresTime = "30d"
+	}
+	_, err = utils.ParseDuration(global.GVA_CONFIG.JWT.RefreshExpiresTime)
 	if err != nil {
 		panic(err)
 	}
 
+	if err = utils.InitJWTKeySet(global.GVA_CONFIG.JWT); err != nil {
+		panic(err)
+	}
+
 	global.BlackCache = local_cache.NewCache(
 		local_cache.SetDefaultExpire(dr),
 	)
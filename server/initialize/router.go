@@ -37,6 +37,8 @@ func Routers() *gin.Engine {
 	Router := gin.New()
 	// 使用自定义的 Recovery 中间件，记录 panic 并入库
 	Router.Use(middleware.GinRecovery(true))
+	// 请求ID 关联操作记录与变更历史
+	Router.Use(middleware.RequestID())
 	if gin.Mode() == gin.DebugMode {
 		Router.Use(gin.Logger())
 	}
@@ -78,7 +80,8 @@ func Routers() *gin.Engine {
 	PublicGroup := Router.Group(global.GVA_CONFIG.System.RouterPrefix)
 	PrivateGroup := Router.Group(global.GVA_CONFIG.System.RouterPrefix)
 
-	PrivateGroup.Use(middleware.JWTAuth()).Use(middleware.CasbinHandler())
+	PublicGroup.Use(middleware.TenantResolver())
+	PrivateGroup.Use(middleware.JWTOrSignatureAuth()).Use(middleware.TenantResolver()).Use(middleware.CasbinHandler())
 
 	{
 		// 健康监测
@@ -93,7 +96,7 @@ func Routers() *gin.Engine {
 
 	{
 		systemRouter.InitApiRouter(PrivateGroup, PublicGroup)               // 注册功能api路由
-		systemRouter.InitJwtRouter(PrivateGroup)                            // jwt相关路由
+		systemRouter.InitJwtRouter(PrivateGroup, PublicGroup)               // jwt相关路由
 		systemRouter.InitUserRouter(PrivateGroup)                           // 注册用户路由
 		systemRouter.InitMenuRouter(PrivateGroup)                           // 注册menu路由
 		systemRouter.InitSystemRouter(PrivateGroup)                         // system相关路由
@@ -106,12 +109,18 @@ func Routers() *gin.Engine {
 		systemRouter.InitSysOperationRecordRouter(PrivateGroup)             // 操作记录
 		systemRouter.InitSysDictionaryDetailRouter(PrivateGroup)            // 字典详情管理
 		systemRouter.InitAuthorityBtnRouterRouter(PrivateGroup)             // 按钮权限管理
+		systemRouter.InitAuthorityFieldRouter(PrivateGroup)                 // 字段权限管理
 		systemRouter.InitSysExportTemplateRouter(PrivateGroup, PublicGroup) // 导出模板
 		systemRouter.InitSysParamsRouter(PrivateGroup, PublicGroup)         // 参数管理
 		systemRouter.InitSysErrorRouter(PrivateGroup, PublicGroup)          // 错误日志
 		systemRouter.InitLoginLogRouter(PrivateGroup)                       // 登录日志
 		systemRouter.InitApiTokenRouter(PrivateGroup)                       // apiToken签发
+		systemRouter.InitAccessKeyRouter(PrivateGroup)                      // AK/SK签发
 		systemRouter.InitSkillsRouter(PrivateGroup)                         // Skills 定义器
+		systemRouter.InitSessionRouter(PrivateGroup)                        // 在线会话管理
+		systemRouter.InitTenantRouter(PrivateGroup)                         // 租户管理
+		systemRouter.InitChangeHistoryRouter(PrivateGroup)                  // 变更历史
+		systemRouter.InitAuditLogRouter(PrivateGroup)                       // 审计日志校验
 		exampleRouter.InitCustomerRouter(PrivateGroup)                      // 客户路由
 		exampleRouter.InitFileUploadAndDownloadRouter(PrivateGroup)         // 文件上传下载功能路由
 		exampleRouter.InitAttachmentCategoryRouterRouter(PrivateGroup)      // 文件上传下载分类
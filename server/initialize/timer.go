@@ -5,8 +5,10 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/task"
 
 	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
 )
 
 func Timer() {
@@ -24,6 +26,48 @@ func Timer() {
 			fmt.Println("add timer error:", err)
 		}
 
+		// 临时角色授权到期回收与定时生效
+		interval := global.GVA_CONFIG.AuthorityGrant.Interval
+		if interval == "" {
+			interval = "1m"
+		}
+		_, err = global.GVA_Timer.AddTaskByFunc("AuthorityGrant", "@every "+interval, func() {
+			if global.GVA_DB != nil {
+				system.UserServiceApp.ProcessAuthorityGrants()
+			}
+		}, "临时角色授权生效与到期回收", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
+		// 审计日志检查点与超出保留天数的归档
+		checkpoint := global.GVA_CONFIG.Audit.CheckpointInterval
+		if checkpoint == "" {
+			checkpoint = "1h"
+		}
+		_, err = global.GVA_Timer.AddTaskByFunc("AuditCheckpoint", "@every "+checkpoint, func() {
+			if global.GVA_DB == nil {
+				return
+			}
+			if err := system.AuditLogServiceApp.CreateAuditCheckpoint(); err != nil {
+				global.GVA_LOG.Error("生成审计日志检查点失败!", zap.Error(err))
+			}
+		}, "审计日志检查点", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+		_, err = global.GVA_Timer.AddTaskByFunc("AuditArchive", "@daily", func() {
+			if global.GVA_DB == nil {
+				return
+			}
+			if count, err := system.AuditLogServiceApp.ArchiveAuditLog(); err != nil {
+				global.GVA_LOG.Error("归档审计日志失败!", zap.Error(err), zap.Int64("archived", count))
+			}
+		}, "归档超出保留天数的操作记录与登录日志", option...)
+		if err != nil {
+			fmt.Println("add timer error:", err)
+		}
+
 		// 其他定时任务定在这里 参考上方使用方法
 
 		//_, err := global.GVA_Timer.AddTaskByFunc("定时任务标识", "corn表达式", func() {
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"flag"
+	"os"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/core"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/initialize"
@@ -30,6 +33,10 @@ import (
 func main() {
 	// 初始化系统
 	initializeSystem()
+	// 命令行子命令 如 audit verify 执行后退出 不启动服务
+	if flag.NArg() > 0 {
+		os.Exit(core.RunCommand(flag.Args()))
+	}
 	// 运行服务器
 	core.RunServer()
 }
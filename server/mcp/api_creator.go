@@ -126,7 +126,7 @@ func (a *ApiCreator) Handle(ctx context.Context, request mcp.CallToolRequest) (*
 			Method:      apiReq.Method,
 		}
 
-		err := apiService.CreateApi(api)
+		err := apiService.CreateApi(ctx, api)
 		if err != nil {
 			global.GVA_LOG.Warn("创建API失败",
 				zap.String("path", apiReq.Path),
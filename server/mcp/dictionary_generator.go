@@ -166,7 +166,7 @@ func (d *DictionaryOptionsGenerator) createDictionaryWithOptions(ctx context.Con
 		Desc:   req.Description,
 	}
 
-	err = dictionaryService.CreateSysDictionary(dictionary)
+	err = dictionaryService.CreateSysDictionary(ctx, dictionary)
 	if err != nil {
 		return nil, fmt.Errorf("创建字典失败: %v", err)
 	}
@@ -103,7 +103,7 @@ func (d *DictionaryQuery) Handle(ctx context.Context, request mcp.CallToolReques
 			status = &[]bool{true}[0]
 		}
 
-		sysDictionary, err := dictionaryService.GetSysDictionary(dictType, 0, status)
+		sysDictionary, err := dictionaryService.GetSysDictionary(ctx, dictType, 0, status)
 		if err != nil {
 			global.GVA_LOG.Error("查询字典失败", zap.Error(err))
 			return &mcp.CallToolResult{
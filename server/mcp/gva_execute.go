@@ -709,7 +709,7 @@ func (g *GVAExecutor) collectExpectedFilePaths(plan *ExecutionPlan) []string {
 // checkDictionaryExists 检查字典是否存在
 func (g *GVAExecutor) checkDictionaryExists(dictType string) (bool, error) {
 	dictionaryService := service.ServiceGroupApp.SystemServiceGroup.DictionaryService
-	_, err := dictionaryService.GetSysDictionary(dictType, 0, nil)
+	_, err := dictionaryService.GetSysDictionary(context.Background(), dictType, 0, nil)
 	if err != nil {
 		// 如果是记录不存在的错误，返回false
 		if strings.Contains(err.Error(), "record not found") {
@@ -746,7 +746,7 @@ func (g *GVAExecutor) createDictionariesFromInfo(ctx context.Context, dictionari
 				Desc:   dictInfo.Description,
 			}
 
-			err = dictionaryService.CreateSysDictionary(dictionary)
+			err = dictionaryService.CreateSysDictionary(ctx, dictionary)
 			if err != nil {
 				messages = append(messages, fmt.Sprintf("创建字典 %s 失败: %v; ", dictInfo.DictType, err))
 				continue
@@ -1,14 +1,20 @@
 package middleware
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
 	"github.com/gin-gonic/gin"
-	"strconv"
-	"strings"
+	"go.uber.org/zap"
 )
 
+var authorityBtnService = service.ServiceGroupApp.SystemServiceGroup.AuthorityBtnService
+
 // CasbinHandler 拦截器
 func CasbinHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -20,13 +26,30 @@ func CasbinHandler() gin.HandlerFunc {
 		act := c.Request.Method
 		// 获取用户的角色
 		sub := strconv.Itoa(int(waitUse.AuthorityId))
+		// 模拟登录时按被模拟用户的角色鉴权 但无论该角色是否有权限都允许退出模拟登录
+		if waitUse.ImpersonatorID != 0 && act == "POST" && obj == "/user/stopImpersonate" {
+			c.Next()
+			return
+		}
 		e := utils.GetCasbin() // 判断策略中是否存在
-		success, _ := e.Enforce(sub, obj, act)
+		// 在用户所属租户的域内鉴权
+		dom := tenant.Domain(waitUse.TenantID)
+		success, _ := e.Enforce(sub, dom, obj, act)
 		if !success {
 			response.FailWithDetailed(gin.H{}, "权限不足", c)
 			c.Abort()
 			return
 		}
+		// 关联了按钮的api还需要被授予对应的按钮 避免绕过前端直接调用
+		granted, err := authorityBtnService.CheckBtnApi(waitUse.AuthorityId, dom, obj, act)
+		if err != nil {
+			global.GVA_LOG.Error("校验按钮权限失败!", zap.Error(err))
+		}
+		if !granted {
+			response.FailWithDetailed(gin.H{}, "权限不足 未被授予该操作的按钮", c)
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
@@ -33,12 +33,14 @@ func ErrorToEmail() gin.HandlerFunc {
 		body, _ := io.ReadAll(c.Request.Body)
 		// 再重新写回请求体body中，ioutil.ReadAll会清空c.Request.Body中的数据
 		c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+		// 邮件内容与操作记录使用相同的脱敏规则
+		redactor := operationRecordService.Redactor(c.Request.Method, c.Request.URL.Path)
 		record := system.SysOperationRecord{
 			Ip:     c.ClientIP(),
 			Method: c.Request.Method,
 			Path:   c.Request.URL.Path,
 			Agent:  c.Request.UserAgent(),
-			Body:   string(body),
+			Body:   redactor.Body(string(body)),
 		}
 		now := time.Now()
 
@@ -46,7 +48,7 @@ func ErrorToEmail() gin.HandlerFunc {
 
 		latency := time.Since(now)
 		status := c.Writer.Status()
-		record.ErrorMessage = c.Errors.ByType(gin.ErrorTypePrivate).String()
+		record.ErrorMessage = redactor.Text(c.Errors.ByType(gin.ErrorTypePrivate).String())
 		str := "接收到的请求为" + record.Body + "\n" + "请求方式为" + record.Method + "\n" + "报错信息如下" + record.ErrorMessage + "\n" + "耗时" + latency.String() + "\n"
 		if status != 200 {
 			subject := username + "" + record.Ip + "调用了" + record.Path + "报错了"
@@ -2,15 +2,27 @@ package middleware
 
 import (
 	"errors"
-	"strconv"
-	"time"
+	"strings"
+	"sync"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/session"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var (
+	sessionService  = service.ServiceGroupApp.SystemServiceGroup.SessionService
+	userService     = service.ServiceGroupApp.SystemServiceGroup.UserService
+	jwtService      = service.ServiceGroupApp.SystemServiceGroup.JwtService
+	apiTokenService = service.ServiceGroupApp.SystemServiceGroup.ApiTokenService
 )
 
 func JWTAuth() gin.HandlerFunc {
@@ -28,52 +40,132 @@ func JWTAuth() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		j := utils.NewJWT()
-		// parseToken 解析token包含的信息
-		claims, err := j.ParseToken(token)
-		if err != nil {
-			if errors.Is(err, utils.TokenExpired) {
-				response.NoAuth("登录已过期，请重新登录", c)
+		var claims *request.CustomClaims
+		var err error
+		isApiToken := strings.HasPrefix(token, system.ApiTokenPrefix)
+		if isApiToken {
+			// API Token 只保存哈希 需要查库校验有效期 IP段与可访问的接口
+			path := strings.TrimPrefix(c.Request.URL.Path, global.GVA_CONFIG.System.RouterPrefix)
+			claims, err = apiTokenService.Authenticate(token, c.ClientIP(), c.Request.Method, path)
+			if err != nil {
+				if !errors.Is(err, systemService.ErrApiTokenInvalid) && !errors.Is(err, systemService.ErrApiTokenIpDenied) &&
+					!errors.Is(err, systemService.ErrApiTokenForbidden) {
+					global.GVA_LOG.Error("校验API Token失败!", zap.Error(err))
+				}
+				response.NoAuth(err.Error(), c)
+				c.Abort()
+				return
+			}
+		} else {
+			j := utils.NewJWT()
+			// parseToken 解析token包含的信息
+			claims, err = j.ParseToken(token)
+			if err != nil {
+				if errors.Is(err, utils.TokenExpired) {
+					response.NoAuth("登录已过期，请重新登录", c)
+					utils.ClearToken(c)
+					c.Abort()
+					return
+				}
+				response.NoAuth(err.Error(), c)
 				utils.ClearToken(c)
 				c.Abort()
 				return
 			}
-			response.NoAuth(err.Error(), c)
+		}
+
+		// 会话被注销 强制下线或refresh token族被注销后 该会话此前签发的access token一并失效
+		// 模拟登录token不关联登录会话 由有效期与实际操作人状态约束 读取会话失败时同样拒绝
+		if !isApiToken && claims.ImpersonatorID == 0 {
+			if err = sessionService.CheckSession(claims); err != nil {
+				if !errors.Is(err, session.ErrSessionNotFound) {
+					global.GVA_LOG.Error("获取登录会话失败!", zap.Error(err))
+				}
+				response.NoAuth("登录会话已失效，请重新登录", c)
+				utils.ClearToken(c)
+				c.Abort()
+				return
+			}
+		}
+
+		// 已登录用户被管理员禁用或删除 需要使该用户的jwt立即失效 用户状态有缓存 变更时主动清除
+		status, err := userService.GetUserStatus(claims.BaseClaims.ID)
+		if err != nil {
+			global.GVA_LOG.Error("获取用户状态失败!", zap.Error(err))
+			response.FailWithMessage("获取用户状态失败", c)
+			c.Abort()
+			return
+		}
+		if !status.Active() {
+			if !isApiToken {
+				revokeToken(token)
+			}
+			response.NoAuth("用户已被禁用或删除", c)
 			utils.ClearToken(c)
 			c.Abort()
 			return
 		}
-
-		// 已登录用户被管理员禁用 需要使该用户的jwt失效 此处比较消耗性能 如果需要 请自行打开
-		// 用户被删除的逻辑 需要优化 此处比较消耗性能 如果需要 请自行打开
-
-		//if user, err := userService.FindUserByUuid(claims.UUID.String()); err != nil || user.Enable == 2 {
-		//	_ = jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: token})
-		//	response.FailWithDetailed(gin.H{"reload": true}, err.Error(), c)
-		//	c.Abort()
-		//}
-		c.Set("claims", claims)
-		if claims.ExpiresAt.Unix()-time.Now().Unix() < claims.BufferTime {
-			dr, _ := utils.ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
-			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(dr))
-			newToken, _ := j.CreateTokenByOldToken(token, *claims)
-			newClaims, _ := j.ParseToken(newToken)
-			c.Header("new-token", newToken)
-			c.Header("new-expires-at", strconv.FormatInt(newClaims.ExpiresAt.Unix(), 10))
-			utils.SetToken(c, newToken, int(dr.Seconds()/60))
-			if global.GVA_CONFIG.System.UseMultipoint {
-				// 记录新的活跃jwt
-				_ = utils.SetRedisJWT(newToken, newClaims.Username)
+		// 模拟登录的实际操作人被禁用或删除后 模拟登录token同样失效
+		if claims.ImpersonatorID != 0 {
+			impersonator, err := userService.GetUserStatus(claims.ImpersonatorID)
+			if err != nil || !impersonator.Active() {
+				revokeToken(token)
+				response.NoAuth("模拟登录已失效", c)
+				utils.ClearToken(c)
+				c.Abort()
+				return
 			}
 		}
-		c.Next()
-
-		if newToken, exists := c.Get("new-token"); exists {
-			c.Header("new-token", newToken.(string))
+		// 角色被移除后立即生效 不必等待token过期
+		if !status.HasAuthority(claims.AuthorityId) {
+			// API Token 绑定签发时的角色 角色被移除后直接失效
+			if isApiToken {
+				response.NoAuth("API Token所属角色已被移除", c)
+				c.Abort()
+				return
+			}
+			claims.AuthorityId = status.AuthorityId
 		}
-		if newExpiresAt, exists := c.Get("new-expires-at"); exists {
-			c.Header("new-expires-at", newExpiresAt.(string))
+		// 管理员重置密码 首次登录或密码过期的用户 修改密码前只能访问少量接口
+		if status.MustChangePassword && !passwordChangeAllowed(c) {
+			response.FailWithDetailed(gin.H{"mustChangePassword": true}, "请先修改密码", c)
+			c.Abort()
+			return
 		}
+		// access token 不再在缓冲期内静默续期 过期后由前端使用 refresh token 调用 /base/refresh 换取新的令牌对
+		utils.SetClaims(c, claims)
+		sessionService.TouchSession(claims)
+		c.Next()
+	}
+}
+
+// mustChangePasswordAllowed 需要修改密码时仍可访问的接口
+var mustChangePasswordAllowed = map[string]bool{
+	"POST /user/changePassword": true,
+	"GET /user/getUserInfo":     true,
+	"POST /menu/getMenu":        true,
+	"POST /jwt/jsonInBlacklist": true,
+}
+
+func passwordChangeAllowed(c *gin.Context) bool {
+	path := strings.TrimPrefix(c.Request.URL.Path, global.GVA_CONFIG.System.RouterPrefix)
+	return mustChangePasswordAllowed[c.Request.Method+" "+path]
+}
+
+// revoking 正在拉黑的token 同一token的并发请求只写入一次
+var revoking sync.Map
+
+// revokeToken 拉黑已失效用户的token 已在黑名单中时不再写库
+func revokeToken(token string) {
+	if isBlacklist(token) {
+		return
+	}
+	if _, loaded := revoking.LoadOrStore(token, struct{}{}); loaded {
+		return
+	}
+	defer revoking.Delete(token)
+	if err := jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: token}); err != nil {
+		global.GVA_LOG.Error("拉黑jwt失败!", zap.Error(err))
 	}
 }
 
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// setupJWTTest 返回签发token与携带token请求的函数
+func setupJWTTest(t *testing.T) (db *gorm.DB, newToken func(request.BaseClaims) string, call func(string) string) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_CONFIG.JWT.SigningKey = "test"
+	global.GVA_CONFIG.JWT.ExpiresTime = "1h"
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysUserAuthority{}, &system.JwtBlacklist{}, &system.SysRefreshToken{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", Enable: 1, AuthorityId: 888})
+	db.Create(&system.SysUserAuthority{SysUserId: 1, SysAuthorityAuthorityId: 888})
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 2}, Username: "admin", Enable: 1, AuthorityId: 888})
+	userService.InvalidateUserStatus(1, 2)
+
+	j := utils.NewJWT()
+	newToken = func(claims request.BaseClaims) string {
+		token, err := j.CreateToken(j.CreateClaims(claims))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", JWTAuth(), func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	call = func(token string) string {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("x-token", token)
+		r.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+	return db, newToken, call
+}
+
+func TestJWTAuthUserStatus(t *testing.T) {
+	db, newToken, call := setupJWTTest(t)
+	user := system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", AuthorityId: 888}
+	if err := sessionService.CreateSession(user, "status", "", "", "", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	blacklisted := func(token string) (n int64) {
+		db.Model(&system.JwtBlacklist{}).Where("jwt = ?", token).Count(&n)
+		return
+	}
+
+	token := newToken(request.BaseClaims{ID: 1, Username: "u", AuthorityId: 888, SessionID: "status"})
+	if body := call(token); body != "pong" {
+		t.Fatalf("active user: %s", body)
+	}
+
+	// 用户被禁用后 token立即失效且只拉黑一次
+	db.Model(&system.SysUser{}).Where("id = 1").Update("enable", 2)
+	userService.InvalidateUserStatus(1)
+	for i := 0; i < 3; i++ {
+		if body := call(token); body == "pong" {
+			t.Fatalf("request %d from a disabled user should be rejected", i+1)
+		}
+	}
+	if n := blacklisted(token); n != 1 {
+		t.Errorf("token should be blacklisted once, got %d rows", n)
+	}
+
+	// 重新启用后 已拉黑的token仍然无效
+	db.Model(&system.SysUser{}).Where("id = 1").Update("enable", 1)
+	userService.InvalidateUserStatus(1)
+	if body := call(token); !strings.Contains(body, "令牌失效") {
+		t.Errorf("blacklisted token after re-enable: %s", body)
+	}
+	fresh := newToken(request.BaseClaims{ID: 1, Username: "u", NickName: "fresh", AuthorityId: 888, SessionID: "status"})
+	if body := call(fresh); body != "pong" {
+		t.Errorf("new token after re-enable: %s", body)
+	}
+
+	// 模拟登录的实际操作人被禁用后 模拟登录token失效
+	impersonated := newToken(request.BaseClaims{ID: 1, Username: "u", AuthorityId: 888, ImpersonatorID: 2})
+	if body := call(impersonated); body != "pong" {
+		t.Fatalf("impersonation token: %s", body)
+	}
+	db.Model(&system.SysUser{}).Where("id = 2").Update("enable", 2)
+	userService.InvalidateUserStatus(2)
+	if body := call(impersonated); body == "pong" {
+		t.Error("impersonation token should be rejected once the impersonator is disabled")
+	}
+	if n := blacklisted(impersonated); n != 1 {
+		t.Errorf("impersonation token should be blacklisted once, got %d rows", n)
+	}
+}
+
+// TestJWTAuthRevokedSession 会话注销后 刷新前签发的access token同样失效
+func TestJWTAuthRevokedSession(t *testing.T) {
+	_, newToken, call := setupJWTTest(t)
+	user := system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", AuthorityId: 888}
+	base := request.BaseClaims{ID: 1, Username: "u", AuthorityId: 888, SessionID: "device"}
+	earlier := newToken(base)
+	if err := sessionService.CreateSession(user, "device", earlier, "", "", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	base.NickName = "refreshed"
+	refreshed := newToken(base)
+	if err := sessionService.RenewSession(request.CustomClaims{BaseClaims: base}, refreshed, "", "", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if body := call(refreshed); body != "pong" {
+		t.Fatalf("refreshed token: %s", body)
+	}
+
+	if err := sessionService.RevokeSession(1, "device"); err != nil {
+		t.Fatal(err)
+	}
+	for name, token := range map[string]string{"earlier": earlier, "refreshed": refreshed} {
+		if body := call(token); body == "pong" {
+			t.Errorf("%s token of a revoked session should be rejected", name)
+		}
+	}
+	if body := call(newToken(request.BaseClaims{ID: 1, Username: "u", AuthorityId: 888})); body == "pong" {
+		t.Error("token without a login session should be rejected")
+	}
+}
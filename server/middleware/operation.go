@@ -15,11 +15,13 @@ import (
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 var respPool sync.Pool
+var operationRecordService = service.ServiceGroupApp.SystemServiceGroup.OperationRecordService
 var bufferSize = 1024
 
 func init() {
@@ -71,6 +73,17 @@ func OperationRecord() gin.HandlerFunc {
 			Body:   "",
 			UserID: userId,
 		}
+		record.RequestID = utils.GetRequestIDFromContext(c.Request.Context())
+		// 模拟登录期间的操作同时记录实际操作人
+		if claims != nil {
+			record.ImpersonatorID = int(claims.ImpersonatorID)
+		}
+
+		// 密码 token等敏感内容脱敏后再记录
+		redactor := operationRecordService.Redactor(c.Request.Method, c.Request.URL.Path)
+		if header, err := json.Marshal(redactor.Header(c.Request.Header)); err == nil {
+			record.Header = string(header)
+		}
 
 		// 上传文件时候 中间件日志进行裁断操作
 		if strings.Contains(c.GetHeader("Content-Type"), "multipart/form-data") {
@@ -79,7 +92,7 @@ func OperationRecord() gin.HandlerFunc {
 			if len(body) > bufferSize {
 				record.Body = "[超出记录长度]"
 			} else {
-				record.Body = string(body)
+				record.Body = redactor.Body(string(body))
 			}
 		}
 
@@ -93,10 +106,10 @@ func OperationRecord() gin.HandlerFunc {
 		c.Next()
 
 		latency := time.Since(now)
-		record.ErrorMessage = c.Errors.ByType(gin.ErrorTypePrivate).String()
+		record.ErrorMessage = redactor.Text(c.Errors.ByType(gin.ErrorTypePrivate).String())
 		record.Status = c.Writer.Status()
 		record.Latency = latency
-		record.Resp = writer.body.String()
+		record.Resp = redactor.Body(writer.body.String())
 
 		if strings.Contains(c.Writer.Header().Get("Pragma"), "public") ||
 			strings.Contains(c.Writer.Header().Get("Expires"), "0") ||
@@ -112,9 +125,7 @@ func OperationRecord() gin.HandlerFunc {
 				record.Body = "超出记录长度"
 			}
 		}
-		if err := global.GVA_DB.Create(&record).Error; err != nil {
-			global.GVA_LOG.Error("create operation record error:", zap.Error(err))
-		}
+		operationRecordService.RecordOperation(record)
 	}
 }
 
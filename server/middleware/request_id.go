@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDPattern 沿用网关传入的请求ID 不合规时重新生成
+var requestIDPattern = regexp.MustCompile(`^[\w.-]{1,64}$`)
+
+// RequestID 为每个请求分配ID 写入响应头 操作记录与变更历史据此关联
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(utils.RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+		utils.SetRequestID(c, id)
+		c.Header(utils.RequestIDHeader, id)
+		c.Next()
+	}
+}
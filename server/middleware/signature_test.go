@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestSignatureAuthBodyLimit(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.AccessKey.MaxBodySize = 1
+	defer func() { global.GVA_CONFIG.AccessKey.MaxBodySize = 0 }()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/upload", SignatureAuth(), func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewReader(make([]byte, 2<<20)))
+	req.Header.Set(utils.SignAccessKeyHeader, "ak")
+	r.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "请求体过大") {
+		t.Errorf("oversized body should be rejected before reading it all: %s", w.Body.String())
+	}
+}
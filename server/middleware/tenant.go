@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TenantResolver 解析当前请求所属租户并写入请求的context 之后携带该context的查询自动按租户隔离
+// 登录用户固定使用其所属租户 平台用户在有租户管理权限时可通过请求头或子域名切换到指定租户
+// 未登录的请求只在指定了租户编码时按租户隔离 例如在租户子域名下登录
+func TenantResolver() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !global.GVA_CONFIG.Tenant.Enable {
+			c.Next()
+			return
+		}
+		code := tenantCode(c)
+		var tenantID uint
+		var resolved bool
+		if v, ok := c.Get("claims"); ok {
+			claims := v.(*systemReq.CustomClaims)
+			tenantID, resolved = claims.TenantID, true
+			if err := systemService.TenantServiceApp.CheckTenant(tenantID); err != nil {
+				abortTenant(err, c)
+				return
+			}
+			if tenantID == tenant.Platform && code != "" && canSwitchTenant(claims) {
+				id, err := systemService.TenantServiceApp.ResolveTenant(code)
+				if err != nil {
+					abortTenant(err, c)
+					return
+				}
+				tenantID = id
+			}
+		} else if code != "" {
+			id, err := systemService.TenantServiceApp.ResolveTenant(code)
+			if err != nil {
+				abortTenant(err, c)
+				return
+			}
+			tenantID, resolved = id, true
+		}
+		if resolved {
+			c.Request = c.Request.WithContext(tenant.WithTenant(c.Request.Context(), tenantID))
+		}
+		c.Next()
+	}
+}
+
+// tenantCode 请求头中的租户编码优先 其次为配置的主域名下的子域名
+func tenantCode(c *gin.Context) string {
+	header := global.GVA_CONFIG.Tenant.Header
+	if header == "" {
+		header = "x-tenant"
+	}
+	if code := strings.TrimSpace(c.GetHeader(header)); code != "" {
+		return code
+	}
+	domain := strings.TrimPrefix(global.GVA_CONFIG.Tenant.Domain, ".")
+	if domain == "" {
+		return ""
+	}
+	host := c.Request.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if sub, ok := strings.CutSuffix(host, "."+domain); ok && !strings.Contains(sub, ".") {
+		return sub
+	}
+	return ""
+}
+
+// canSwitchTenant 平台用户可管理租户时才允许切换租户
+func canSwitchTenant(claims *systemReq.CustomClaims) bool {
+	ok, _ := utils.GetCasbin().Enforce(strconv.Itoa(int(claims.AuthorityId)), tenant.Domain(tenant.Platform), "/tenant/getTenantList", "POST")
+	return ok
+}
+
+func abortTenant(err error, c *gin.Context) {
+	if !errors.Is(err, systemService.ErrTenantInvalid) {
+		global.GVA_LOG.Error("解析租户失败!", zap.Error(err))
+	}
+	response.FailWithMessage(systemService.ErrTenantInvalid.Error(), c)
+	c.Abort()
+}
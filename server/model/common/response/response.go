@@ -17,7 +17,13 @@ const (
 	SUCCESS = 0
 )
 
+// DataFilter 成功返回数据前的处理 启动时注册为按角色字段权限隐藏或脱敏字段
+var DataFilter func(c *gin.Context, data interface{}) interface{}
+
 func Result(code int, data interface{}, msg string, c *gin.Context) {
+	if code == SUCCESS && DataFilter != nil {
+		data = DataFilter(c, data)
+	}
 	c.JSON(http.StatusOK, Response{
 		code,
 		data,
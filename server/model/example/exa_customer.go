@@ -13,3 +13,13 @@ type ExaCustomer struct {
 	SysUserAuthorityID uint           `json:"sysUserAuthorityID" form:"sysUserAuthorityID" gorm:"comment:管理角色ID"` // 管理角色ID
 	SysUser            system.SysUser `json:"sysUser" form:"sysUser" gorm:"comment:管理详情"`                         // 管理详情
 }
+
+// DataScopeColumns 按管理人与管理角色过滤数据权限
+func (ExaCustomer) DataScopeColumns() (string, string) {
+	return "sys_user_id", "sys_user_authority_id"
+}
+
+// TrackHistory 记录客户的变更历史
+func (ExaCustomer) TrackHistory() bool {
+	return true
+}
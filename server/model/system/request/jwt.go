@@ -8,14 +8,18 @@ import (
 // CustomClaims structure
 type CustomClaims struct {
 	BaseClaims
-	BufferTime int64
 	jwt.RegisteredClaims
 }
 
 type BaseClaims struct {
-	UUID        uuid.UUID
-	ID          uint
-	Username    string
-	NickName    string
-	AuthorityId uint
+	UUID               uuid.UUID
+	ID                 uint
+	Username           string
+	NickName           string
+	AuthorityId        uint
+	SessionID          string // 登录会话ID 与refresh token族ID一致
+	MustChangePassword bool   // 签发时是否需要修改密码 仅供前端提示 鉴权以用户状态为准
+	ImpersonatorID     uint   // 模拟登录时的实际操作人 为0表示非模拟登录
+	ImpersonatorName   string // 模拟登录时的实际操作人用户名
+	TenantID           uint   // 用户所属租户 casbin按该租户的域鉴权
 }
@@ -0,0 +1,19 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type SysAccessKeySearch struct {
+	request.PageInfo
+	UserID uint  `json:"userId" form:"userId"`
+	Status *bool `json:"status" form:"status"`
+}
+
+// CreateAccessKey 签发AK/SK
+type CreateAccessKey struct {
+	UserID      uint   `json:"userId"`
+	AuthorityID uint   `json:"authorityId"`
+	Days        int    `json:"days"` // 有效天数 0为不过期
+	Remark      string `json:"remark"`
+}
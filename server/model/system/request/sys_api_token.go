@@ -10,3 +10,13 @@ type SysApiTokenSearch struct {
 	request.PageInfo
     Status *bool `json:"status" form:"status"`
 }
+
+// CreateApiToken 签发API Token
+type CreateApiToken struct {
+	UserID       uint   `json:"userId"`
+	AuthorityID  uint   `json:"authorityId"`
+	Days         int    `json:"days"`         // 有效天数 1-365
+	ApiIds       []uint `json:"apiIds"`       // 允许访问的api 必须在角色权限范围内
+	AllowedCIDRs string `json:"allowedCidrs"` // 允许访问的IP或IP段 逗号分隔 为空不限制
+	Remark       string `json:"remark"`
+}
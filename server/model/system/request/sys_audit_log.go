@@ -0,0 +1,7 @@
+package request
+
+// SysAuditVerify 分批校验审计哈希链
+type SysAuditVerify struct {
+	FromSeq uint64 `json:"fromSeq" form:"fromSeq"` // 起始位置 检查点或上一批返回的lastSeq 0为从头开始
+	Limit   int    `json:"limit" form:"limit"`     // 本批校验的记录数 最多10000
+}
@@ -0,0 +1,9 @@
+package request
+
+import "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+
+type SysAuthorityFieldReq struct {
+	AuthorityId uint                       `json:"authorityId"`
+	Model       string                     `json:"model"`
+	Rules       []system.SysAuthorityField `json:"rules"`
+}
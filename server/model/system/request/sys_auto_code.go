@@ -23,6 +23,7 @@ type AutoCode struct {
 	GvaModel            bool                   `json:"gvaModel" example:"false"`            // 是否使用gva默认Model
 	AutoMigrate         bool                   `json:"autoMigrate" example:"false"`         // 是否自动迁移表结构
 	AutoCreateResource  bool                   `json:"autoCreateResource" example:"false"`  // 是否自动创建资源标识
+	ChangeHistory       bool                   `json:"changeHistory" example:"false"`       // 是否记录变更历史
 	AutoCreateApiToSql  bool                   `json:"autoCreateApiToSql" example:"false"`  // 是否自动创建api
 	AutoCreateMenuToSql bool                   `json:"autoCreateMenuToSql" example:"false"` // 是否自动创建menu
 	AutoCreateBtnAuth   bool                   `json:"autoCreateBtnAuth" example:"false"`   // 是否自动创建按钮权限
@@ -99,6 +100,18 @@ func (r *AutoCode) Apis() []model.SysApi {
 	}
 }
 
+// BtnApis 自动创建的按钮关联的api路径 导出导入使用公共接口不做关联
+func (r *AutoCode) BtnApis() map[string][]string {
+	prefix := "/" + r.Abbreviation + "/"
+	return map[string][]string{
+		"add":         {prefix + "create" + r.StructName},
+		"batchDelete": {prefix + "delete" + r.StructName + "ByIds"},
+		"delete":      {prefix + "delete" + r.StructName},
+		"edit":        {prefix + "update" + r.StructName, prefix + "find" + r.StructName},
+		"info":        {prefix + "find" + r.StructName},
+	}
+}
+
 func (r *AutoCode) Menu(template string) model.SysBaseMenu {
 	component := fmt.Sprintf("view/%s/%s/%s.vue", r.Package, r.PackageName, r.PackageName)
 	if template != "package" {
@@ -1,9 +1,24 @@
 package request
 
+// 策略效果 同一接口命中任一deny策略即拒绝访问 包括从父角色继承的策略
+const (
+	CasbinAllow = "allow"
+	CasbinDeny  = "deny"
+)
+
 // CasbinInfo Casbin info structure
 type CasbinInfo struct {
 	Path   string `json:"path"`   // 路径
 	Method string `json:"method"` // 方法
+	Effect string `json:"effect"` // 效果 allow或deny 为空时按allow处理
+}
+
+// EffectOrDefault 返回策略效果 未指定时为allow
+func (c CasbinInfo) EffectOrDefault() string {
+	if c.Effect == CasbinDeny {
+		return CasbinDeny
+	}
+	return CasbinAllow
 }
 
 // CasbinInReceive Casbin structure for input parameters
@@ -25,3 +40,17 @@ func DefaultCasbin() []CasbinInfo {
 		{Path: "/sysDictionary/findSysDictionary", Method: "GET"},
 	}
 }
+
+// PermissionExplain 权限诊断 指定用户时诊断其拥有的全部角色 指定角色时只诊断该角色
+type PermissionExplain struct {
+	UserID      uint   `json:"userId"`
+	AuthorityId uint   `json:"authorityId"`
+	Method      string `json:"method" binding:"required"`
+	Path        string `json:"path" binding:"required"`
+}
+
+// PermissionMatrix 计算用户或角色对全部api的实际权限
+type PermissionMatrix struct {
+	UserID      uint `json:"userId"`
+	AuthorityId uint `json:"authorityId"`
+}
@@ -0,0 +1,13 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+type SysChangeHistorySearch struct {
+	Table     string `json:"table" form:"table"`         // 表名
+	RecordID  string `json:"recordId" form:"recordId"`   // 记录主键
+	RequestID string `json:"requestId" form:"requestId"` // 请求ID
+	UserID    uint   `json:"userId" form:"userId"`       // 操作人
+	request.PageInfo
+}
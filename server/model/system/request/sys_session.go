@@ -0,0 +1,7 @@
+package request
+
+// SessionReq 会话操作 ID为空时表示该用户的全部会话
+type SessionReq struct {
+	UserID uint   `json:"userId" form:"userId"` // 用户ID 仅管理员接口使用
+	ID     string `json:"id" form:"id"`         // 会话ID
+}
@@ -0,0 +1,19 @@
+package request
+
+// TwoFactorChallengeReq 登录过程中绑定两步验证
+type TwoFactorChallengeReq struct {
+	ChallengeToken string `json:"challengeToken"` // 密码校验通过后返回的challenge token
+}
+
+// TwoFactorVerifyReq 登录二次验证 验证码与恢复码二选一
+type TwoFactorVerifyReq struct {
+	ChallengeToken string `json:"challengeToken"` // 密码校验通过后返回的challenge token
+	Code           string `json:"code"`           // 认证器验证码
+	RecoveryCode   string `json:"recoveryCode"`   // 恢复码
+}
+
+// TwoFactorCodeReq 已登录用户确认绑定或关闭两步验证
+type TwoFactorCodeReq struct {
+	Code         string `json:"code"`         // 认证器验证码
+	RecoveryCode string `json:"recoveryCode"` // 恢复码 仅关闭时可用
+}
@@ -24,6 +24,12 @@ type Login struct {
 	Password  string `json:"password"`  // 密码
 	Captcha   string `json:"captcha"`   // 验证码
 	CaptchaId string `json:"captchaId"` // 验证码ID
+	Provider  string `json:"provider"`  // 身份提供方 为空时使用本地账号
+}
+
+// RefreshTokenReq 使用refresh token换取新的令牌对
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refreshToken"`
 }
 
 // ChangePasswordReq Modify password structure
@@ -38,6 +44,11 @@ type ResetPassword struct {
 	Password string `json:"password" form:"password" gorm:"comment:用户登录密码"` // 用户登录密码
 }
 
+// UnlockUser 解除登录锁定
+type UnlockUser struct {
+	Username string `json:"username" form:"username" binding:"required"` // 被锁定的用户名
+}
+
 // SetUserAuth Modify user's auth structure
 type SetUserAuth struct {
 	AuthorityId uint `json:"authorityId"` // 角色ID
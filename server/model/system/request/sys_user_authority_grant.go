@@ -0,0 +1,35 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+// CreateAuthorityGrant 发起临时授权
+type CreateAuthorityGrant struct {
+	UserID      uint      `json:"userId" binding:"required"`
+	AuthorityId uint      `json:"authorityId" binding:"required"`
+	StartAt     time.Time `json:"startAt" binding:"required"`
+	EndAt       time.Time `json:"endAt" binding:"required"`
+	Reason      string    `json:"reason" binding:"required"`
+}
+
+// AuthorityGrantAction 审批 拒绝或撤销临时授权
+type AuthorityGrantAction struct {
+	ID     uint   `json:"id" binding:"required"`
+	Detail string `json:"detail"` // 审批意见或撤销原因
+}
+
+type AuthorityGrantSearch struct {
+	request.PageInfo
+	UserID uint   `json:"userId" form:"userId"`
+	Status string `json:"status" form:"status"`
+}
+
+type AuthorityAuditSearch struct {
+	request.PageInfo
+	UserID      uint   `json:"userId" form:"userId"`
+	AuthorityId uint   `json:"authorityId" form:"authorityId"`
+	Action      string `json:"action" form:"action"`
+}
@@ -0,0 +1,19 @@
+package request
+
+// SSOAuthorizeReq 发起跳转类身份提供方的登录或绑定
+type SSOAuthorizeReq struct {
+	Provider string `json:"provider"` // 身份提供方名称
+}
+
+// SSOCallbackReq 身份提供方回调参数
+type SSOCallbackReq struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// LinkIdentityReq 绑定外部身份 用户名密码类提供方需要填写账号密码
+type LinkIdentityReq struct {
+	Provider string `json:"provider"` // 身份提供方名称
+	Username string `json:"username"` // 提供方用户名
+	Password string `json:"password"` // 提供方密码
+}
@@ -0,0 +1,44 @@
+package request
+
+import "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+
+// ForgotPassword 找回密码 无论邮箱是否存在都返回相同结果
+type ForgotPassword struct {
+	Email     string `json:"email" binding:"required"`
+	Captcha   string `json:"captcha"`
+	CaptchaId string `json:"captchaId"`
+}
+
+// ResetPasswordByToken 通过邮件中的链接重置密码
+type ResetPasswordByToken struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// SelfRegister 自助注册或邀请注册 提交后发送邮箱验证邮件
+type SelfRegister struct {
+	Username   string `json:"userName" binding:"required"`
+	NickName   string `json:"nickName"`
+	Password   string `json:"passWord" binding:"required"`
+	Email      string `json:"email" binding:"required"`
+	Invitation string `json:"invitation"` // 邀请令牌 为空时按自助注册处理
+	Captcha    string `json:"captcha"`
+	CaptchaId  string `json:"captchaId"`
+}
+
+// VerifyRegistration 邮箱验证后创建用户
+type VerifyRegistration struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// CreateInvitation 生成注册邀请
+type CreateInvitation struct {
+	AuthorityId uint   `json:"authorityId" binding:"required"`
+	EmailDomain string `json:"emailDomain"` // 限定邮箱域名 如 example.com
+	Days        int    `json:"days"`        // 有效天数 默认7天
+	Remark      string `json:"remark"`
+}
+
+type SysUserInvitationSearch struct {
+	request.PageInfo
+}
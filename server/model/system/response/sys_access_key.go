@@ -0,0 +1,7 @@
+package response
+
+// CreateAccessKeyResponse SecretKey只在签发时返回一次
+type CreateAccessKeyResponse struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
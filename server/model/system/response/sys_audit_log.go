@@ -0,0 +1,20 @@
+package response
+
+// SysAuditVerifyResult 审计日志校验结果
+type SysAuditVerifyResult struct {
+	Valid       bool            `json:"valid"`
+	Checked     int64           `json:"checked"`     // 校验的链上记录数
+	Archived    int64           `json:"archived"`    // 其中源记录已归档的记录数
+	LastSeq     uint64          `json:"lastSeq"`     // 校验到的链上序号
+	Complete    bool            `json:"complete"`    // 是否已校验到链尾 分批校验未到链尾时以lastSeq继续
+	Checkpoints int64           `json:"checkpoints"` // 校验的检查点数
+	Issues      []SysAuditIssue `json:"issues"`      // 最多返回前100个问题
+}
+
+// SysAuditIssue 校验发现的问题
+type SysAuditIssue struct {
+	Seq      uint64 `json:"seq"`
+	Source   string `json:"source"`
+	SourceID uint   `json:"sourceId"`
+	Reason   string `json:"reason"`
+}
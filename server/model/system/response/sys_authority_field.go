@@ -0,0 +1,13 @@
+package response
+
+import "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+
+type SysAuthorityFieldRes struct {
+	Fields []system.SysModelField `json:"fields"`
+	Rules  map[string]string      `json:"rules"` // 字段json名 => 规则
+}
+
+type SysFieldModel struct {
+	Model       string `json:"model"`
+	Description string `json:"description"`
+}
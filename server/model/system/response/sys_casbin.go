@@ -1,9 +1,60 @@
 package response
 
 import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 )
 
 type PolicyPathResponse struct {
 	Paths []request.CasbinInfo `json:"paths"`
 }
+
+// PermissionPolicy 与请求匹配的策略
+type PermissionPolicy struct {
+	AuthorityId uint   `json:"authorityId"`
+	Path        string `json:"path"`
+	Method      string `json:"method"`
+	Effect      string `json:"effect"`
+	Inherited   bool   `json:"inherited"` // 从父角色继承
+}
+
+// PermissionMenu 角色可见的菜单及该菜单下已授权的按钮
+type PermissionMenu struct {
+	ID      uint     `json:"id"`
+	Name    string   `json:"name"`
+	Title   string   `json:"title"`
+	Path    string   `json:"path"`
+	Buttons []string `json:"buttons"`
+}
+
+// AuthorityExplain 单个角色的诊断结果
+type AuthorityExplain struct {
+	AuthorityId   uint               `json:"authorityId"`
+	AuthorityName string             `json:"authorityName"`
+	Current       bool               `json:"current"` // 用户当前使用的角色
+	Allowed       bool               `json:"allowed"`
+	Reason        string             `json:"reason"`
+	Roles         []uint             `json:"roles"` // 参与鉴权的角色 自身在前 父角色依次在后
+	Policies      []PermissionPolicy `json:"policies"`
+	Menus         []PermissionMenu   `json:"menus"`
+}
+
+// PermissionExplainResponse 权限诊断结果
+type PermissionExplainResponse struct {
+	User        *system.SysUser    `json:"user"`
+	UserActive  bool               `json:"userActive"`
+	Api         *system.SysApi     `json:"api"` // 与请求匹配的api定义 为空时说明api未登记
+	Authorities []AuthorityExplain `json:"authorities"`
+}
+
+// PermissionMatrixRow 单个api在各角色下的实际权限 Effects与Authorities顺序一致 取值allow deny或空
+type PermissionMatrixRow struct {
+	system.SysApi
+	Effects []string `json:"effects"`
+}
+
+// PermissionMatrixResponse 权限矩阵
+type PermissionMatrixResponse struct {
+	Authorities []system.SysAuthority `json:"authorities"`
+	Rows        []PermissionMatrixRow `json:"rows"`
+}
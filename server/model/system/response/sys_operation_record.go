@@ -0,0 +1,10 @@
+package response
+
+type SysOperationRecordStats struct {
+	Enabled  bool   `json:"enabled"`  // 是否启用后台写入
+	Queued   int    `json:"queued"`   // 队列中待写入的记录数
+	Capacity int    `json:"capacity"` // 队列长度
+	Written  uint64 `json:"written"`  // 已写入的记录数
+	Dropped  uint64 `json:"dropped"`  // 队列已满被丢弃的记录数
+	Failed   uint64 `json:"failed"`   // 写入失败的记录数
+}
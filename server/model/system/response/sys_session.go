@@ -0,0 +1,8 @@
+package response
+
+import "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+
+type SysSessionResponse struct {
+	system.SysSession
+	Current bool `json:"current"` // 是否为发起请求的会话
+}
@@ -0,0 +1,16 @@
+package response
+
+// TwoFactorChallengeResponse 密码校验通过但需要二次验证时返回 此时尚未签发token
+type TwoFactorChallengeResponse struct {
+	NeedTwoFactor  bool   `json:"needTwoFactor"`
+	NeedEnroll     bool   `json:"needEnroll"` // 角色强制两步验证但用户尚未绑定
+	ChallengeToken string `json:"challengeToken"`
+	ExpiresAt      int64  `json:"expiresAt"`
+}
+
+// TwoFactorSetupResponse 待绑定的密钥 恢复码只在此时明文返回一次
+type TwoFactorSetupResponse struct {
+	Secret        string   `json:"secret"`
+	Uri           string   `json:"uri"` // otpauth:// 地址 前端生成二维码
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
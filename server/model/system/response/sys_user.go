@@ -9,7 +9,15 @@ type SysUserResponse struct {
 }
 
 type LoginResponse struct {
-	User      system.SysUser `json:"user"`
-	Token     string         `json:"token"`
-	ExpiresAt int64          `json:"expiresAt"`
+	User             system.SysUser `json:"user"`
+	Token            string         `json:"token"`
+	ExpiresAt        int64          `json:"expiresAt"`
+	RefreshToken     string         `json:"refreshToken"`
+	RefreshExpiresAt int64          `json:"refreshExpiresAt"`
+}
+
+// Impersonator 模拟登录时的实际操作人 getUserInfo返回该字段时前端显示模拟登录标识
+type Impersonator struct {
+	ID       uint   `json:"id"`
+	Username string `json:"userName"`
 }
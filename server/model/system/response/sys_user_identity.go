@@ -0,0 +1,11 @@
+package response
+
+// SSOAuthorizeResponse 前端需跳转到的授权地址
+type SSOAuthorizeResponse struct {
+	Url string `json:"url"`
+}
+
+// SSOCallbackResponse 绑定流程的回调结果 登录流程返回 LoginResponse 或 TwoFactorChallengeResponse
+type SSOCallbackResponse struct {
+	Linked bool `json:"linked"`
+}
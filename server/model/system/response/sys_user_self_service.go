@@ -0,0 +1,17 @@
+package response
+
+// CreateInvitationResponse 邀请链接只在生成时返回一次
+type CreateInvitationResponse struct {
+	Url string `json:"url"`
+}
+
+// RegistrationOptions 注册页需要的信息
+type RegistrationOptions struct {
+	SelfRegistration bool            `json:"selfRegistration"` // 是否开启自助注册
+	Invitation       *InvitationInfo `json:"invitation"`       // 携带有效邀请令牌时返回
+}
+
+type InvitationInfo struct {
+	AuthorityName string `json:"authorityName"`
+	EmailDomain   string `json:"emailDomain"`
+}
@@ -0,0 +1,21 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"time"
+)
+
+// SysAccessKey 机器客户端使用的AK/SK凭证 请求使用SecretKey签名 SecretKey本身不随请求传输
+type SysAccessKey struct {
+	global.GVA_MODEL
+	UserID      uint       `json:"userId" gorm:"comment:用户ID"`
+	User        SysUser    `json:"user" gorm:"foreignKey:UserID;"`
+	AuthorityID uint       `json:"authorityId" gorm:"comment:角色ID"`
+	AccessKey   string     `json:"accessKey" gorm:"uniqueIndex;size:64;comment:AccessKey"`
+	SecretKey   string     `json:"-" gorm:"size:128;comment:SecretKey"`   // 服务端需要原文计算签名 不对外返回
+	Status      bool       `json:"status" gorm:"default:true;comment:状态"` // true有效 false无效
+	ExpiresAt   *time.Time `json:"expiresAt" gorm:"comment:过期时间 为空不过期"`
+	LastUsedAt  *time.Time `json:"lastUsedAt" gorm:"comment:最后使用时间"`
+	LastUsedIp  string     `json:"lastUsedIp" gorm:"size:64;comment:最后使用IP"`
+	Remark      string     `json:"remark" gorm:"comment:备注"`
+}
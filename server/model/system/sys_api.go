@@ -10,12 +10,21 @@ type SysApi struct {
 	Description string `json:"description" gorm:"comment:api中文描述"`    // api中文描述
 	ApiGroup    string `json:"apiGroup" gorm:"comment:api组"`          // api组
 	Method      string `json:"method" gorm:"default:POST;comment:方法"` // 方法:创建POST(默认)|查看GET|更新PUT|删除DELETE
+	// 操作记录中额外脱敏的内容 在配置与内置规则基础上追加
+	RedactFields   []string `json:"redactFields" gorm:"serializer:json;type:text;comment:脱敏的json字段"` // json字段 含"."时为从根开始的路径
+	RedactHeaders  []string `json:"redactHeaders" gorm:"serializer:json;type:text;comment:脱敏的请求头"`   // 请求头名称
+	RedactPatterns []string `json:"redactPatterns" gorm:"serializer:json;type:text;comment:脱敏的正则"`   // 正则 有分组时只替换第一个分组
 }
 
 func (SysApi) TableName() string {
 	return "sys_apis"
 }
 
+// TrackHistory 记录api的变更历史
+func (SysApi) TrackHistory() bool {
+	return true
+}
+
 type SysIgnoreApi struct {
 	global.GVA_MODEL
 	Path   string `json:"path" gorm:"comment:api路径"`             // api路径
@@ -5,13 +5,23 @@ import (
 	"time"
 )
 
+// ApiTokenPrefix 不透明API Token的固定前缀 鉴权中间件据此区分API Token与登录jwt
+const ApiTokenPrefix = "gva_"
+
 type SysApiToken struct {
 	global.GVA_MODEL
-	UserID      uint      `json:"userId" gorm:"comment:用户ID"`
-	User        SysUser   `json:"user" gorm:"foreignKey:UserID;"`
-	AuthorityID uint      `json:"authorityId" gorm:"comment:角色ID"`
-	Token       string    `json:"token" gorm:"type:text;comment:Token"`
-	Status      bool      `json:"status" gorm:"default:true;comment:状态"` // true有效 false无效
-	ExpiresAt   time.Time `json:"expiresAt" gorm:"comment:过期时间"`
-	Remark      string    `json:"remark" gorm:"comment:备注"`
+	UserID       uint       `json:"userId" gorm:"comment:用户ID"`
+	User         SysUser    `json:"user" gorm:"foreignKey:UserID;"`
+	AuthorityID  uint       `json:"authorityId" gorm:"comment:角色ID"`
+	Token        string     `json:"-" gorm:"type:text;comment:Token"` // 旧版jwt形式的Token原文 升级时已拉黑并清空 新签发的Token不再保存原文
+	TokenHash    string     `json:"-" gorm:"index;size:64;comment:Token哈希"`
+	TokenPrefix  string     `json:"tokenPrefix" gorm:"size:16;comment:Token前缀"`
+	Apis         []SysApi   `json:"apis" gorm:"many2many:sys_api_token_apis;"`
+	AllowedCIDRs string     `json:"allowedCidrs" gorm:"size:1024;comment:允许访问的IP段 逗号分隔 为空不限制"`
+	Status       bool       `json:"status" gorm:"default:true;comment:状态"` // true有效 false无效
+	ExpiresAt    time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	LastUsedAt   *time.Time `json:"lastUsedAt" gorm:"comment:最后使用时间"`
+	LastUsedIp   string     `json:"lastUsedIp" gorm:"size:64;comment:最后使用IP"`
+	RequestCount int64      `json:"requestCount" gorm:"default:0;comment:请求次数"`
+	Remark       string     `json:"remark" gorm:"comment:备注"`
 }
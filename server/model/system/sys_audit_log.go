@@ -0,0 +1,47 @@
+package system
+
+import (
+	"time"
+)
+
+// SysAuditLog 审计哈希链 操作记录与登录日志入库时在同一事务中追加
+// Digest为源记录内容的摘要 Hash由序号 源记录 摘要与上一条的Hash计算 修改或删除任意一条都会导致校验失败
+type SysAuditLog struct {
+	Seq       uint64    `json:"seq" gorm:"primarykey;autoIncrement:false;comment:序号 连续递增"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	Source    string    `json:"source" gorm:"size:64;index:idx_audit_log_source;comment:源记录表名"`
+	SourceID  uint      `json:"sourceId" gorm:"index:idx_audit_log_source;comment:源记录主键"`
+	Digest    string    `json:"digest" gorm:"size:64;comment:源记录内容摘要"`
+	PrevHash  string    `json:"prevHash" gorm:"size:64;comment:上一条的哈希"`
+	Hash      string    `json:"hash" gorm:"size:64;comment:哈希"`
+	Archive   string    `json:"archive" gorm:"size:128;index;comment:源记录已归档时的归档文件"`
+}
+
+func (SysAuditLog) TableName() string {
+	return "sys_audit_logs"
+}
+
+// SysAuditHead 哈希链的最新位置 追加时加锁保证序号连续
+type SysAuditHead struct {
+	ID        uint      `json:"ID" gorm:"primarykey;autoIncrement:false"`
+	Seq       uint64    `json:"seq"`
+	Hash      string    `json:"hash" gorm:"size:64"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+func (SysAuditHead) TableName() string {
+	return "sys_audit_heads"
+}
+
+// SysAuditCheckpoint 定期对链上位置签名 防止整条链被重新计算或截断
+type SysAuditCheckpoint struct {
+	ID        uint      `json:"ID" gorm:"primarykey"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	Seq       uint64    `json:"seq" gorm:"index;comment:检查点位置"`
+	Hash      string    `json:"hash" gorm:"size:64;comment:该位置的哈希"`
+	Signature string    `json:"signature" gorm:"size:64;comment:签名"`
+}
+
+func (SysAuditCheckpoint) TableName() string {
+	return "sys_audit_checkpoints"
+}
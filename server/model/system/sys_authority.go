@@ -5,19 +5,39 @@ import (
 )
 
 type SysAuthority struct {
-	CreatedAt       time.Time       // 创建时间
-	UpdatedAt       time.Time       // 更新时间
-	DeletedAt       *time.Time      `sql:"index"`
-	AuthorityId     uint            `json:"authorityId" gorm:"not null;unique;primary_key;comment:角色ID;size:90"` // 角色ID
-	AuthorityName   string          `json:"authorityName" gorm:"comment:角色名"`                                    // 角色名
-	ParentId        *uint           `json:"parentId" gorm:"comment:父角色ID"`                                       // 父角色ID
-	DataAuthorityId []*SysAuthority `json:"dataAuthorityId" gorm:"many2many:sys_data_authority_id;"`
-	Children        []SysAuthority  `json:"children" gorm:"-"`
-	SysBaseMenus    []SysBaseMenu   `json:"menus" gorm:"many2many:sys_authority_menus;"`
-	Users           []SysUser       `json:"-" gorm:"many2many:sys_user_authority;"`
-	DefaultRouter   string          `json:"defaultRouter" gorm:"comment:默认菜单;default:dashboard"` // 默认菜单(默认dashboard)
+	CreatedAt        time.Time       // 创建时间
+	UpdatedAt        time.Time       // 更新时间
+	DeletedAt        *time.Time      `sql:"index"`
+	AuthorityId      uint            `json:"authorityId" gorm:"not null;unique;primary_key;comment:角色ID;size:90"` // 角色ID
+	AuthorityName    string          `json:"authorityName" gorm:"comment:角色名"`                                    // 角色名
+	ParentId         *uint           `json:"parentId" gorm:"comment:父角色ID"`                                       // 父角色ID
+	InheritParent    *bool           `json:"inheritParent" gorm:"default:false;comment:是否继承父角色的策略"`               // 开启后通过casbin的g规则继承父角色的全部策略
+	DataAuthorityId  []*SysAuthority `json:"dataAuthorityId" gorm:"many2many:sys_data_authority_id;"`
+	Children         []SysAuthority  `json:"children" gorm:"-"`
+	SysBaseMenus     []SysBaseMenu   `json:"menus" gorm:"many2many:sys_authority_menus;"`
+	Users            []SysUser       `json:"-" gorm:"many2many:sys_user_authority;"`
+	DefaultRouter    string          `json:"defaultRouter" gorm:"comment:默认菜单;default:dashboard"`    // 默认菜单(默认dashboard)
+	MaxSessions      *int            `json:"maxSessions" gorm:"comment:最大同时在线会话数 0或空表示使用系统配置"`       // 最大同时在线会话数
+	RequireTwoFactor *bool           `json:"requireTwoFactor" gorm:"default:false;comment:是否强制两步验证"` // 是否强制该角色用户启用两步验证
+	DataScope        string          `json:"dataScope" gorm:"size:20;default:list;comment:数据权限范围"`   // 数据权限范围 见DataScope常量
+	DataScopeSQL     string          `json:"dataScopeSql" gorm:"type:text;comment:自定义数据权限SQL条件"`     // DataScope为sql时使用的查询条件
+	TenantID         uint            `json:"tenantId" gorm:"index;default:0;comment:租户ID"`           // 所属租户 角色的casbin策略属于该租户的域
 }
 
+// 角色的数据权限范围 按数据的创建者过滤
+const (
+	DataScopeAll       = "all"       // 全部数据
+	DataScopeSelf      = "self"      // 仅本人创建的数据
+	DataScopeAuthority = "authority" // 本角色用户创建的数据
+	DataScopeList      = "list"      // DataAuthorityId中指定角色的用户创建的数据 未指定时仅本人
+	DataScopeSQL       = "sql"       // 自定义SQL条件 可使用@userId与@authorityId参数
+)
+
 func (SysAuthority) TableName() string {
 	return "sys_authorities"
 }
+
+// TrackHistory 记录角色的变更历史
+func (SysAuthority) TrackHistory() bool {
+	return true
+}
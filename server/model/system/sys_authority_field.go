@@ -0,0 +1,34 @@
+package system
+
+import "github.com/flipped-aurora/gin-vue-admin/server/global"
+
+// 字段权限规则 未配置规则的字段可读写
+const (
+	FieldRuleHidden   = "hidden"   // 返回数据中去除该字段 且不可修改
+	FieldRuleMasked   = "masked"   // 返回数据中脱敏展示 且不可修改
+	FieldRuleReadonly = "readonly" // 可查看 不可修改
+)
+
+// SysModelField 可配置字段权限的模型字段 由代码生成器登记
+type SysModelField struct {
+	global.GVA_MODEL
+	Model       string `json:"model" gorm:"size:191;uniqueIndex:idx_model_field;comment:模型 包路径.结构体名"` // 模型 如 model/example.ExaCustomer
+	Description string `json:"description" gorm:"comment:模型描述"`                                       // 模型描述
+	Field       string `json:"field" gorm:"size:191;uniqueIndex:idx_model_field;comment:字段json名"`     // 字段json名
+	Label       string `json:"label" gorm:"comment:字段描述"`                                             // 字段描述
+	Table       string `json:"table" gorm:"column:table_name;size:191;comment:数据库表名"`                 // 数据库表名 导出Excel时按列匹配字段权限
+	Column      string `json:"column" gorm:"column:column_name;size:191;comment:数据库列名"`               // 数据库列名
+}
+
+func (SysModelField) TableName() string {
+	return "sys_model_fields"
+}
+
+// SysAuthorityField 角色的字段权限规则
+type SysAuthorityField struct {
+	ID          uint   `json:"-" gorm:"primarykey"`
+	AuthorityId uint   `json:"authorityId" gorm:"uniqueIndex:idx_authority_model_field;comment:角色ID"`
+	Model       string `json:"model" gorm:"size:191;uniqueIndex:idx_authority_model_field;comment:模型"`
+	Field       string `json:"field" gorm:"size:191;uniqueIndex:idx_authority_model_field;comment:字段json名"`
+	Rule        string `json:"rule" gorm:"size:20;comment:规则 hidden隐藏 masked脱敏 readonly只读"`
+}
@@ -0,0 +1,14 @@
+package system
+
+import "time"
+
+// SysCasbinRevision casbin策略变更记录 未开启redis时各实例轮询该表得知其他实例修改了策略
+type SysCasbinRevision struct {
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `gorm:"index"`
+	Node      string    `gorm:"size:32;comment:发起变更的实例"`
+}
+
+func (SysCasbinRevision) TableName() string {
+	return "sys_casbin_revisions"
+}
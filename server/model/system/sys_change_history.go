@@ -0,0 +1,43 @@
+package system
+
+import (
+	"time"
+)
+
+// 变更历史的操作类型
+const (
+	ChangeActionCreate = "create"
+	ChangeActionUpdate = "update"
+	ChangeActionDelete = "delete"
+)
+
+// SysChangeHistory 开启了变更历史的模型每次增删改的字段变化
+type SysChangeHistory struct {
+	ID             uint          `json:"ID" gorm:"primarykey"`
+	CreatedAt      time.Time     `json:"CreatedAt"`
+	Table          string        `json:"table" gorm:"column:table_name;size:128;index:idx_change_history_record;comment:表名"`
+	RecordID       string        `json:"recordId" gorm:"size:64;index:idx_change_history_record;comment:记录主键"`
+	Action         string        `json:"action" gorm:"size:16;comment:操作 create|update|delete"`
+	Changes        []FieldChange `json:"changes" gorm:"serializer:json;type:text;comment:字段变化"`
+	UserID         uint          `json:"userId" gorm:"index;comment:操作人"`
+	User           SysUser       `json:"user"`
+	ImpersonatorID uint          `json:"impersonatorId" gorm:"comment:模拟登录时的实际操作人"`
+	RequestID      string        `json:"requestId" gorm:"size:64;index;comment:请求ID 与操作记录对应"`
+	TenantID       uint          `json:"tenantId" gorm:"index;default:0;comment:租户ID"`
+}
+
+func (SysChangeHistory) TableName() string {
+	return "sys_change_histories"
+}
+
+// DataScopeColumns 按操作人过滤数据权限
+func (SysChangeHistory) DataScopeColumns() (string, string) {
+	return "user_id", ""
+}
+
+// FieldChange 字段变化 新增时只有After 删除时只有Before
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
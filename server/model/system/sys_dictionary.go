@@ -15,8 +15,19 @@ type SysDictionary struct {
 	ParentID             *uint                 `json:"parentID" form:"parentID" gorm:"column:parent_id;comment:父级字典ID"` // 父级字典ID
 	Children             []SysDictionary       `json:"children" gorm:"foreignKey:ParentID"`                             // 子字典
 	SysDictionaryDetails []SysDictionaryDetail `json:"sysDictionaryDetails" form:"sysDictionaryDetails"`
+	TenantID             uint                  `json:"tenantId" gorm:"index;default:0;comment:租户ID"` // 所属租户
+}
+
+// SharedWithPlatform 租户可以读取平台租户的字典 同类型字典优先使用租户自己的
+func (SysDictionary) SharedWithPlatform() bool {
+	return true
 }
 
 func (SysDictionary) TableName() string {
 	return "sys_dictionaries"
 }
+
+// TrackHistory 记录字典的变更历史
+func (SysDictionary) TrackHistory() bool {
+	return true
+}
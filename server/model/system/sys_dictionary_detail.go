@@ -24,3 +24,8 @@ type SysDictionaryDetail struct {
 func (SysDictionaryDetail) TableName() string {
 	return "sys_dictionary_details"
 }
+
+// TrackHistory 记录字典详情的变更历史
+func (SysDictionaryDetail) TrackHistory() bool {
+	return true
+}
@@ -1,10 +1,13 @@
 package system
 
 import (
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 )
 
 type JwtBlacklist struct {
 	global.GVA_MODEL
-	Jwt string `gorm:"type:text;comment:jwt"`
+	Jwt       string     `gorm:"type:text;comment:jwt"`
+	ExpiresAt *time.Time `gorm:"index;comment:token过期时间 过期后无需再拦截"`
 }
@@ -0,0 +1,22 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysLoginLock 按用户名记录的登录失败次数与锁定状态 未开启redis时使用
+type SysLoginLock struct {
+	global.GVA_MODEL
+	Username    string     `json:"username" gorm:"uniqueIndex;size:191;comment:用户名"`
+	FailCount   int        `json:"failCount" gorm:"default:0;comment:统计窗口内的失败次数"`
+	FirstFailAt *time.Time `json:"firstFailAt" gorm:"comment:统计窗口开始时间"`
+	LockLevel   int        `json:"lockLevel" gorm:"default:0;comment:连续锁定次数 用于计算锁定时长"`
+	LockedAt    *time.Time `json:"lockedAt" gorm:"comment:最近一次锁定时间"`
+	LockedUntil *time.Time `json:"lockedUntil" gorm:"index;comment:锁定截止时间"`
+}
+
+func (SysLoginLock) TableName() string {
+	return "sys_login_locks"
+}
@@ -4,13 +4,27 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 )
 
+// 登录日志事件类型 为空的历史记录按status区分成功失败
+const (
+	LoginEventSuccess  = "success"
+	LoginEventFailed   = "failed"
+	LoginEventLocked   = "locked"   // 因连续失败被锁定或锁定期间尝试登录
+	LoginEventUnlocked = "unlocked" // 管理员解除锁定
+	// 模拟登录 user_id为被模拟的用户 impersonator_id/impersonator_name记录实际操作人
+	LoginEventImpersonate     = "impersonate"
+	LoginEventStopImpersonate = "stop_impersonate"
+)
+
 type SysLoginLog struct {
 	global.GVA_MODEL
-	Username      string  `json:"username" gorm:"column:username;comment:用户名"`
-	Ip            string  `json:"ip" gorm:"column:ip;comment:请求ip"`
-	Status        bool    `json:"status" gorm:"column:status;comment:登录状态"`
-	ErrorMessage  string  `json:"errorMessage" gorm:"column:error_message;comment:错误信息"`
-	Agent         string  `json:"agent" gorm:"column:agent;comment:代理"`
-	UserID        uint    `json:"userId" gorm:"column:user_id;comment:用户id"`
-	User          SysUser `json:"user" gorm:"foreignKey:UserID"`
+	Username         string  `json:"username" gorm:"column:username;comment:用户名"`
+	Ip               string  `json:"ip" gorm:"column:ip;comment:请求ip"`
+	Status           bool    `json:"status" gorm:"column:status;comment:登录状态"`
+	ErrorMessage     string  `json:"errorMessage" gorm:"column:error_message;comment:错误信息"`
+	Agent            string  `json:"agent" gorm:"column:agent;comment:代理"`
+	UserID           uint    `json:"userId" gorm:"column:user_id;comment:用户id"`
+	Event            string  `json:"event" gorm:"column:event;size:32;index;comment:事件类型"`
+	ImpersonatorID   uint    `json:"impersonatorId" gorm:"column:impersonator_id;index;comment:模拟登录的实际操作人id"`
+	ImpersonatorName string  `json:"impersonatorName" gorm:"column:impersonator_name;size:191;comment:模拟登录的实际操作人用户名"`
+	User             SysUser `json:"user" gorm:"foreignKey:UserID"`
 }
@@ -4,7 +4,18 @@ import "github.com/flipped-aurora/gin-vue-admin/server/global"
 
 type SysBaseMenuBtn struct {
 	global.GVA_MODEL
-	Name          string `json:"name" gorm:"comment:按钮关键key"`
-	Desc          string `json:"desc" gorm:"按钮备注"`
-	SysBaseMenuID uint   `json:"sysBaseMenuID" gorm:"comment:菜单ID"`
+	Name          string   `json:"name" gorm:"comment:按钮关键key"`
+	Desc          string   `json:"desc" gorm:"按钮备注"`
+	SysBaseMenuID uint     `json:"sysBaseMenuID" gorm:"comment:菜单ID"`
+	Apis          []SysApi `json:"apis" gorm:"many2many:sys_base_menu_btn_apis;"` // 按钮调用的api 只有被授予该按钮的角色才能调用
+}
+
+// SysBaseMenuBtnApi 按钮与api的关联 关联了按钮的api只允许被授予其中任一按钮的角色调用
+type SysBaseMenuBtnApi struct {
+	SysBaseMenuBtnID uint `gorm:"primaryKey;comment:菜单按钮ID"`
+	SysApiID         uint `gorm:"primaryKey;comment:api ID"`
+}
+
+func (SysBaseMenuBtnApi) TableName() string {
+	return "sys_base_menu_btn_apis"
 }
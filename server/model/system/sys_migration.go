@@ -0,0 +1,13 @@
+package system
+
+import "time"
+
+// SysMigration 已执行的一次性数据升级 多实例同时启动时只有一个实例执行
+type SysMigration struct {
+	Name      string    `gorm:"primarykey;size:128;comment:升级名称"`
+	CreatedAt time.Time `gorm:"comment:执行时间"`
+}
+
+func (SysMigration) TableName() string {
+	return "sys_migrations"
+}
@@ -17,8 +17,14 @@ type SysOperationRecord struct {
 	Latency      time.Duration `json:"latency" form:"latency" gorm:"column:latency;comment:延迟" swaggertype:"string"` // 延迟
 	Agent        string        `json:"agent" form:"agent" gorm:"type:text;column:agent;comment:代理"`                  // 代理
 	ErrorMessage string        `json:"error_message" form:"error_message" gorm:"column:error_message;comment:错误信息"`  // 错误信息
+	Header       string        `json:"header" form:"header" gorm:"type:text;column:header;comment:请求Header"`         // 请求Header 已脱敏
 	Body         string        `json:"body" form:"body" gorm:"type:text;column:body;comment:请求Body"`                 // 请求Body
 	Resp         string        `json:"resp" form:"resp" gorm:"type:text;column:resp;comment:响应Body"`                 // 响应Body
 	UserID       int           `json:"user_id" form:"user_id" gorm:"column:user_id;comment:用户id"`                    // 用户id
 	User         SysUser       `json:"user"`
+	// 模拟登录期间的操作 user_id为被模拟的用户 impersonator_id为实际操作人
+	ImpersonatorID int     `json:"impersonator_id" form:"impersonator_id" gorm:"column:impersonator_id;index;comment:实际操作人id"`
+	Impersonator   SysUser `json:"impersonator" gorm:"foreignKey:ImpersonatorID"`
+	// 请求ID 可据此查询该请求产生的变更历史
+	RequestID string `json:"request_id" form:"request_id" gorm:"column:request_id;size:64;index;comment:请求ID"`
 }
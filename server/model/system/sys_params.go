@@ -8,13 +8,24 @@ import (
 // 参数 结构体  SysParams
 type SysParams struct {
 	global.GVA_MODEL
-	Name  string `json:"name" form:"name" gorm:"column:name;comment:参数名称;" binding:"required"`   //参数名称
-	Key   string `json:"key" form:"key" gorm:"column:key;comment:参数键;" binding:"required"`       //参数键
-	Value string `json:"value" form:"value" gorm:"column:value;comment:参数值;" binding:"required"` //参数值
-	Desc  string `json:"desc" form:"desc" gorm:"column:desc;comment:参数说明;"`                      //参数说明
+	Name     string `json:"name" form:"name" gorm:"column:name;comment:参数名称;" binding:"required"`   //参数名称
+	Key      string `json:"key" form:"key" gorm:"column:key;comment:参数键;" binding:"required"`       //参数键
+	Value    string `json:"value" form:"value" gorm:"column:value;comment:参数值;" binding:"required"` //参数值
+	Desc     string `json:"desc" form:"desc" gorm:"column:desc;comment:参数说明;"`                      //参数说明
+	TenantID uint   `json:"tenantId" gorm:"index;default:0;comment:租户ID"`                           //所属租户
+}
+
+// SharedWithPlatform 租户可以读取平台租户的参数 同名参数优先使用租户自己的
+func (SysParams) SharedWithPlatform() bool {
+	return true
 }
 
 // TableName 参数 SysParams自定义表名 sys_params
 func (SysParams) TableName() string {
 	return "sys_params"
 }
+
+// TrackHistory 记录系统参数的变更历史
+func (SysParams) TrackHistory() bool {
+	return true
+}
@@ -0,0 +1,16 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysPasswordHistory 用户历史密码哈希 用于禁止重复使用最近的密码
+type SysPasswordHistory struct {
+	global.GVA_MODEL
+	UserID   uint   `json:"userId" gorm:"index;comment:用户ID"`
+	Password string `json:"-" gorm:"comment:密码哈希"`
+}
+
+func (SysPasswordHistory) TableName() string {
+	return "sys_password_histories"
+}
@@ -0,0 +1,24 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysRefreshToken 服务端保存的refresh token 只存哈希 每次刷新后旧令牌作废 同一次登录轮换出的令牌属于同一族
+type SysRefreshToken struct {
+	global.GVA_MODEL
+	UserID    uint       `json:"userId" gorm:"index;comment:用户ID"`
+	FamilyID  string     `json:"familyId" gorm:"index;size:64;comment:令牌族ID"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex;size:64;comment:令牌哈希"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"index;comment:过期时间"`
+	UsedAt    *time.Time `json:"usedAt" gorm:"comment:轮换时间"`
+	RevokedAt *time.Time `json:"revokedAt" gorm:"comment:作废时间"`
+	Ip        string     `json:"ip" gorm:"comment:签发ip"`
+	Agent     string     `json:"agent" gorm:"comment:签发代理"`
+}
+
+func (SysRefreshToken) TableName() string {
+	return "sys_refresh_tokens"
+}
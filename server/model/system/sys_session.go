@@ -0,0 +1,17 @@
+package system
+
+import "time"
+
+// SysSession 一次登录产生的在线会话 ID与refresh token族ID一致 存放于redis或进程内存 不落库
+type SysSession struct {
+	ID          string    `json:"id"`
+	UserID      uint      `json:"userId"`
+	Username    string    `json:"username"`
+	AuthorityId uint      `json:"authorityId"`
+	Token       string    `json:"-"` // 当前有效的access token 注销会话时加入黑名单
+	Ip          string    `json:"ip"`
+	UserAgent   string    `json:"userAgent"`
+	LoginAt     time.Time `json:"loginAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,18 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysTenant 租户 平台租户(ID为0)不在表中 开启多租户之前的数据都属于平台租户
+type SysTenant struct {
+	global.GVA_MODEL
+	Name   string `json:"name" gorm:"comment:租户名称" binding:"required"`
+	Code   string `json:"code" gorm:"size:64;uniqueIndex;comment:租户编码 用于子域名与请求头" binding:"required"`
+	Enable *bool  `json:"enable" gorm:"default:true;comment:是否启用 停用后租户用户无法登录与访问"`
+	Remark string `json:"remark" gorm:"comment:备注"`
+}
+
+func (SysTenant) TableName() string {
+	return "sys_tenants"
+}
@@ -1,6 +1,8 @@
 package system
 
 import (
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common"
 	"github.com/google/uuid"
@@ -19,24 +21,36 @@ var _ Login = new(SysUser)
 
 type SysUser struct {
 	global.GVA_MODEL
-	UUID          uuid.UUID      `json:"uuid" gorm:"index;comment:用户UUID"`                                                                   // 用户UUID
-	Username      string         `json:"userName" gorm:"index;comment:用户登录名"`                                                                // 用户登录名
-	Password      string         `json:"-"  gorm:"comment:用户登录密码"`                                                                           // 用户登录密码
-	NickName      string         `json:"nickName" gorm:"default:系统用户;comment:用户昵称"`                                                          // 用户昵称
-	HeaderImg     string         `json:"headerImg" gorm:"default:https://qmplusimg.henrongyi.top/gva_header.jpg;comment:用户头像"`               // 用户头像
-	AuthorityId   uint           `json:"authorityId" gorm:"default:888;comment:用户角色ID"`                                                      // 用户角色ID
-	Authority     SysAuthority   `json:"authority" gorm:"foreignKey:AuthorityId;references:AuthorityId;comment:用户角色"`                        // 用户角色
-	Authorities   []SysAuthority `json:"authorities" gorm:"many2many:sys_user_authority;"`                                                   // 多用户角色
-	Phone         string         `json:"phone"  gorm:"comment:用户手机号"`                                                                        // 用户手机号
-	Email         string         `json:"email"  gorm:"comment:用户邮箱"`                                                                         // 用户邮箱
-	Enable        int            `json:"enable" gorm:"default:1;comment:用户是否被冻结 1正常 2冻结"`                                                    //用户是否被冻结 1正常 2冻结
-	OriginSetting common.JSONMap `json:"originSetting" form:"originSetting" gorm:"type:text;default:null;column:origin_setting;comment:配置;"` //配置
+	UUID               uuid.UUID      `json:"uuid" gorm:"index;comment:用户UUID"`                                                                   // 用户UUID
+	Username           string         `json:"userName" gorm:"index;comment:用户登录名"`                                                                // 用户登录名
+	Password           string         `json:"-"  gorm:"comment:用户登录密码"`                                                                           // 用户登录密码
+	NickName           string         `json:"nickName" gorm:"default:系统用户;comment:用户昵称"`                                                          // 用户昵称
+	HeaderImg          string         `json:"headerImg" gorm:"default:https://qmplusimg.henrongyi.top/gva_header.jpg;comment:用户头像"`               // 用户头像
+	AuthorityId        uint           `json:"authorityId" gorm:"default:888;comment:用户角色ID"`                                                      // 用户角色ID
+	Authority          SysAuthority   `json:"authority" gorm:"foreignKey:AuthorityId;references:AuthorityId;comment:用户角色"`                        // 用户角色
+	Authorities        []SysAuthority `json:"authorities" gorm:"many2many:sys_user_authority;"`                                                   // 多用户角色
+	Phone              string         `json:"phone"  gorm:"comment:用户手机号"`                                                                        // 用户手机号
+	Email              string         `json:"email"  gorm:"comment:用户邮箱"`                                                                         // 用户邮箱
+	Enable             int            `json:"enable" gorm:"default:1;comment:用户是否被冻结 1正常 2冻结"`                                                    //用户是否被冻结 1正常 2冻结
+	OriginSetting      common.JSONMap `json:"originSetting" form:"originSetting" gorm:"type:text;default:null;column:origin_setting;comment:配置;"` //配置
+	TotpEnabled        bool           `json:"totpEnabled" gorm:"default:false;comment:是否启用两步验证"`                                                  // 是否启用两步验证
+	TotpSecret         string         `json:"-" gorm:"size:64;comment:两步验证密钥"`                                                                    // 两步验证密钥
+	TotpLastStep       int64          `json:"-" gorm:"default:0;comment:最近一次通过的验证码时间步"`                                                           // 最近一次通过的验证码时间步 用于拒绝重放
+	RecoveryCodes      string         `json:"-" gorm:"type:text;comment:两步验证恢复码哈希"`                                                               // 两步验证恢复码哈希 逗号分隔 使用后移除
+	MustChangePassword bool           `json:"mustChangePassword" gorm:"default:false;comment:下次登录必须修改密码"`                                         // 管理员重置或首次登录 修改密码前只能访问修改密码接口
+	PasswordChangedAt  *time.Time     `json:"passwordChangedAt" gorm:"comment:密码修改时间"`                                                            // 密码修改时间 用于计算密码是否过期
+	TenantID           uint           `json:"tenantId" gorm:"index;default:0;comment:租户ID"`                                                       // 所属租户 0为平台租户
 }
 
 func (SysUser) TableName() string {
 	return "sys_users"
 }
 
+// TrackHistory 记录用户的变更历史
+func (SysUser) TrackHistory() bool {
+	return true
+}
+
 func (s *SysUser) GetUsername() string {
 	return s.Username
 }
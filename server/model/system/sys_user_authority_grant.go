@@ -0,0 +1,67 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// 临时授权状态
+const (
+	GrantStatusPending   = "pending"   // 等待审批
+	GrantStatusScheduled = "scheduled" // 已批准 等待开始时间
+	GrantStatusActive    = "active"    // 生效中
+	GrantStatusExpired   = "expired"   // 已到期回收
+	GrantStatusRevoked   = "revoked"   // 已提前撤销
+	GrantStatusRejected  = "rejected"  // 审批未通过
+)
+
+// SysUserAuthorityGrant 有起止时间的临时角色授权 生效期间写入sys_user_authority 到期后由定时任务回收
+type SysUserAuthorityGrant struct {
+	global.GVA_MODEL
+	UserID      uint         `json:"userId" gorm:"index;comment:用户ID"`
+	User        SysUser      `json:"user" gorm:"foreignKey:UserID"`
+	AuthorityId uint         `json:"authorityId" gorm:"comment:临时授予的角色ID"`
+	Authority   SysAuthority `json:"authority" gorm:"foreignKey:AuthorityId;references:AuthorityId"`
+	StartAt     time.Time    `json:"startAt" gorm:"comment:开始时间"`
+	EndAt       time.Time    `json:"endAt" gorm:"index;comment:结束时间"`
+	Reason      string       `json:"reason" gorm:"comment:申请原因"`
+	Status      string       `json:"status" gorm:"index;size:20;comment:状态"`
+	RequestedBy uint         `json:"requestedBy" gorm:"comment:发起人"`
+	ApprovedBy  uint         `json:"approvedBy" gorm:"comment:审批人"`
+	ApprovedAt  *time.Time   `json:"approvedAt" gorm:"comment:审批时间"`
+	RevokedBy   uint         `json:"revokedBy" gorm:"comment:撤销人 0为到期自动回收"`
+	RevokedAt   *time.Time   `json:"revokedAt" gorm:"comment:回收时间"`
+}
+
+func (SysUserAuthorityGrant) TableName() string {
+	return "sys_user_authority_grants"
+}
+
+// 角色授权审计动作
+const (
+	AuthorityAuditAssign   = "assign"   // 设置用户角色时新增
+	AuthorityAuditRemove   = "remove"   // 设置用户角色时移除
+	AuthorityAuditRequest  = "request"  // 发起临时授权
+	AuthorityAuditApprove  = "approve"  // 审批通过
+	AuthorityAuditReject   = "reject"   // 审批拒绝
+	AuthorityAuditActivate = "activate" // 临时授权生效
+	AuthorityAuditRevoke   = "revoke"   // 临时授权被撤销
+	AuthorityAuditExpire   = "expire"   // 临时授权到期回收
+)
+
+// SysUserAuthorityAudit 用户角色授予与回收的审计记录 只增不改
+type SysUserAuthorityAudit struct {
+	ID          uint      `json:"ID" gorm:"primarykey"`
+	CreatedAt   time.Time `json:"CreatedAt" gorm:"index"`
+	UserID      uint      `json:"userId" gorm:"index;comment:用户ID"`
+	AuthorityId uint      `json:"authorityId" gorm:"comment:角色ID"`
+	GrantID     uint      `json:"grantId" gorm:"comment:临时授权ID 0为直接设置"`
+	Action      string    `json:"action" gorm:"size:20;comment:动作"`
+	OperatorID  uint      `json:"operatorId" gorm:"comment:操作人 0为系统"`
+	Detail      string    `json:"detail" gorm:"comment:说明"`
+}
+
+func (SysUserAuthorityAudit) TableName() string {
+	return "sys_user_authority_audits"
+}
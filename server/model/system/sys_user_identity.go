@@ -0,0 +1,22 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysUserIdentity 本地用户与外部身份提供方账号的关联
+type SysUserIdentity struct {
+	global.GVA_MODEL
+	UserID      uint       `json:"userId" gorm:"index;comment:用户ID"`
+	Provider    string     `json:"provider" gorm:"uniqueIndex:idx_provider_subject;size:64;comment:身份提供方"`
+	Subject     string     `json:"subject" gorm:"uniqueIndex:idx_provider_subject;size:191;comment:提供方内的用户标识"`
+	Username    string     `json:"username" gorm:"comment:提供方用户名"`
+	Email       string     `json:"email" gorm:"comment:提供方邮箱"`
+	LastLoginAt *time.Time `json:"lastLoginAt" gorm:"comment:最近登录时间"`
+}
+
+func (SysUserIdentity) TableName() string {
+	return "sys_user_identities"
+}
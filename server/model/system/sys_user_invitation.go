@@ -0,0 +1,26 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysUserInvitation 管理员生成的注册邀请 链接只能使用一次 只保存令牌哈希
+type SysUserInvitation struct {
+	global.GVA_MODEL
+	TokenHash   string       `json:"-" gorm:"uniqueIndex;size:64;comment:邀请令牌哈希"`
+	AuthorityId uint         `json:"authorityId" gorm:"comment:注册后的角色ID"`
+	Authority   SysAuthority `json:"authority" gorm:"foreignKey:AuthorityId;references:AuthorityId"`
+	EmailDomain string       `json:"emailDomain" gorm:"size:128;comment:限定的邮箱域名 为空不限制"`
+	ExpiresAt   time.Time    `json:"expiresAt" gorm:"comment:过期时间"`
+	UsedAt      *time.Time   `json:"usedAt" gorm:"comment:使用时间"`
+	UsedBy      uint         `json:"usedBy" gorm:"comment:注册的用户ID"`
+	Revoked     bool         `json:"revoked" gorm:"default:false;comment:是否已作废"`
+	CreatedBy   uint         `json:"createdBy" gorm:"comment:创建人"`
+	Remark      string       `json:"remark" gorm:"comment:备注"`
+}
+
+func (SysUserInvitation) TableName() string {
+	return "sys_user_invitations"
+}
@@ -1,8 +1,12 @@
 {{- $db := "" }}
 {{- if eq .BusinessDB "" }}
- {{- $db = "global.GVA_DB" }}
+ {{- $db = "global.GVA_DB.WithContext(ctx)" }}
 {{- else}}
- {{- $db =  printf "global.MustGetGlobalDBByDBName(\"%s\")" .BusinessDB   }}
+ {{- $db =  printf "global.MustGetGlobalDBByDBName(\"%s\").WithContext(ctx)" .BusinessDB   }}
+{{- end}}
+{{- $scope := "" }}
+{{- if .AutoCreateResource }}
+ {{- $scope = ".Scopes(systemService.DataScopeServiceApp.Scope(ctx))" }}
 {{- end}}
 
 {{- if .IsAdd}}
@@ -45,6 +49,7 @@ import (
     "{{.Module}}/utils"
     "errors"
     {{- end }}
+    systemService "{{.Module}}/service/system"
     {{- if .AutoCreateResource }}
     "gorm.io/gorm"
     {{- end}}
@@ -77,10 +82,10 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}(ctx
 
 	{{- if .AutoCreateResource }}
 	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
-	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).Update("deleted_by", userID).Error; err != nil {
+	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}){{$scope}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).Update("deleted_by", userID).Error; err != nil {
               return err
         }
-        if err = tx.Delete(&{{.Package}}.{{.StructName}}{},"{{.PrimaryField.ColumnName}} = ?",{{.PrimaryField.FieldJson}}).Error; err != nil {
+        if err = tx{{$scope}}.Delete(&{{.Package}}.{{.StructName}}{},"{{.PrimaryField.ColumnName}} = ?",{{.PrimaryField.FieldJson}}).Error; err != nil {
               return err
         }
         return nil
@@ -96,10 +101,10 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}(ctx
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}ByIds(ctx context.Context, {{.PrimaryField.FieldJson}}s []string {{- if .AutoCreateResource }},deleted_by uint{{- end}}) (err error) {
 	{{- if .AutoCreateResource }}
 	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
-	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Update("deleted_by", deleted_by).Error; err != nil {
+	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}){{$scope}}.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Update("deleted_by", deleted_by).Error; err != nil {
             return err
         }
-        if err := tx.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Delete(&{{.Package}}.{{.StructName}}{}).Error; err != nil {
+        if err := tx{{$scope}}.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Delete(&{{.Package}}.{{.StructName}}{}).Error; err != nil {
             return err
         }
         return nil
@@ -113,14 +118,14 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}ById
 // Update{{.StructName}} 更新{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Update{{.StructName}}(ctx context.Context, {{.Abbreviation}} {{.Package}}.{{.StructName}}) (err error) {
-	err = {{$db}}.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
+	err = {{$db}}.Model(&{{.Package}}.{{.StructName}}{}){{$scope}}{{- if .AutoCreateResource }}.Omit("created_by"){{- end }}.Scopes(systemService.AuthorityFieldServiceApp.UpdateScope(ctx)).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
 	return err
 }
 
 // Get{{.StructName}} 根据{{.PrimaryField.FieldJson}}获取{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}(ctx context.Context, {{.PrimaryField.FieldJson}} string) ({{.Abbreviation}} {{.Package}}.{{.StructName}}, err error) {
-	err = {{$db}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
+	err = {{$db}}{{$scope}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
 	return
 }
 
@@ -130,7 +135,7 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}(ctx co
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}InfoList(ctx context.Context) (list []*{{.Package}}.{{.StructName}},err error) {
     // 创建db
-	db := {{$db}}.Model(&{{.Package}}.{{.StructName}}{})
+	db := {{$db}}.Model(&{{.Package}}.{{.StructName}}{}){{$scope}}
     var {{.Abbreviation}}s []*{{.Package}}.{{.StructName}}
 
 	err = db.Find(&{{.Abbreviation}}s).Error
@@ -144,7 +149,7 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}InfoLis
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
     // 创建db
-	db := {{$db}}.Model(&{{.Package}}.{{.StructName}}{})
+	db := {{$db}}.Model(&{{.Package}}.{{.StructName}}{}){{$scope}}
     var {{.Abbreviation}}s []{{.Package}}.{{.StructName}}
     // 如果有条件搜索 下方会自动创建搜索语句
 {{- if .GvaModel }}
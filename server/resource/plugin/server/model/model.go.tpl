@@ -34,6 +34,7 @@ type {{.StructName}} struct {
     CreatedBy  uint   `gorm:"column:created_by;comment:创建者"`
     UpdatedBy  uint   `gorm:"column:updated_by;comment:更新者"`
     DeletedBy  uint   `gorm:"column:deleted_by;comment:删除者"`
+    TenantID   uint   `json:"tenantId" gorm:"index;column:tenant_id;default:0;comment:租户ID"`
     {{- end }}
     {{- if .IsTree }}
     Children   []*{{.StructName}} `json:"children" gorm:"-"`     //子节点
@@ -50,6 +51,13 @@ func ({{.StructName}}) TableName() string {
 {{ end }}
 
 
+{{ if and .ChangeHistory (not .OnlyTemplate) }}
+// TrackHistory 记录{{.Description}}的变更历史
+func ({{.StructName}}) TrackHistory() bool {
+    return true
+}
+{{ end }}
+
 {{if .IsTree }}
 // GetChildren 实现TreeNode接口
 func (s *{{.StructName}}) GetChildren() []*{{.StructName}} {
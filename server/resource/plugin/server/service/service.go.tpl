@@ -1,8 +1,12 @@
 {{- $db := "" }}
 {{- if eq .BusinessDB "" }}
- {{- $db = "global.GVA_DB" }}
+ {{- $db = "global.GVA_DB.WithContext(ctx)" }}
 {{- else}}
- {{- $db =  printf "global.MustGetGlobalDBByDBName(\"%s\")" .BusinessDB   }}
+ {{- $db =  printf "global.MustGetGlobalDBByDBName(\"%s\").WithContext(ctx)" .BusinessDB   }}
+{{- end}}
+{{- $scope := "" }}
+{{- if .AutoCreateResource }}
+ {{- $scope = ".Scopes(systemService.DataScopeServiceApp.Scope(ctx))" }}
 {{- end}}
 
 {{- if .IsAdd}}
@@ -40,6 +44,7 @@ import (
     {{- else }}
     "errors"
     {{- end }}
+    systemService "{{.Module}}/service/system"
     {{- if .AutoCreateResource }}
     "gorm.io/gorm"
     {{- end}}
@@ -55,9 +60,9 @@ type {{.Abbreviation}} struct {}
 
 {{- $db := "" }}
 {{- if eq .BusinessDB "" }}
- {{- $db = "global.GVA_DB" }}
+ {{- $db = "global.GVA_DB.WithContext(ctx)" }}
 {{- else}}
- {{- $db =  printf "global.MustGetGlobalDBByDBName(\"%s\")" .BusinessDB   }}
+ {{- $db =  printf "global.MustGetGlobalDBByDBName(\"%s\").WithContext(ctx)" .BusinessDB   }}
 {{- end}}
 {{- if not .OnlyTemplate }}
 // Create{{.StructName}} 创建{{.Description}}记录
@@ -84,10 +89,10 @@ func (s *{{.Abbreviation}}) Delete{{.StructName}}(ctx context.Context, {{.Primar
 
 	{{- if .AutoCreateResource }}
 	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
-	    if err := tx.Model(&model.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).Update("deleted_by", userID).Error; err != nil {
+	    if err := tx.Model(&model.{{.StructName}}{}){{$scope}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).Update("deleted_by", userID).Error; err != nil {
               return err
         }
-        if err = tx.Delete(&model.{{.StructName}}{},"{{.PrimaryField.ColumnName}} = ?",{{.PrimaryField.FieldJson}}).Error; err != nil {
+        if err = tx{{$scope}}.Delete(&model.{{.StructName}}{},"{{.PrimaryField.ColumnName}} = ?",{{.PrimaryField.FieldJson}}).Error; err != nil {
               return err
         }
         return nil
@@ -103,10 +108,10 @@ func (s *{{.Abbreviation}}) Delete{{.StructName}}(ctx context.Context, {{.Primar
 func (s *{{.Abbreviation}}) Delete{{.StructName}}ByIds(ctx context.Context, {{.PrimaryField.FieldJson}}s []string {{- if .AutoCreateResource }},deleted_by uint{{- end}}) (err error) {
 	{{- if .AutoCreateResource }}
 	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
-	    if err := tx.Model(&model.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Update("deleted_by", deleted_by).Error; err != nil {
+	    if err := tx.Model(&model.{{.StructName}}{}){{$scope}}.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Update("deleted_by", deleted_by).Error; err != nil {
             return err
         }
-        if err := tx.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Delete(&model.{{.StructName}}{}).Error; err != nil {
+        if err := tx{{$scope}}.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Delete(&model.{{.StructName}}{}).Error; err != nil {
             return err
         }
         return nil
@@ -120,14 +125,14 @@ func (s *{{.Abbreviation}}) Delete{{.StructName}}ByIds(ctx context.Context, {{.P
 // Update{{.StructName}} 更新{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func (s *{{.Abbreviation}}) Update{{.StructName}}(ctx context.Context, {{.Abbreviation}} model.{{.StructName}}) (err error) {
-	err = {{$db}}.Model(&model.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
+	err = {{$db}}.Model(&model.{{.StructName}}{}){{$scope}}{{- if .AutoCreateResource }}.Omit("created_by"){{- end }}.Scopes(systemService.AuthorityFieldServiceApp.UpdateScope(ctx)).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
 	return err
 }
 
 // Get{{.StructName}} 根据{{.PrimaryField.FieldJson}}获取{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func (s *{{.Abbreviation}}) Get{{.StructName}}(ctx context.Context, {{.PrimaryField.FieldJson}} string) ({{.Abbreviation}} model.{{.StructName}}, err error) {
-	err = {{$db}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
+	err = {{$db}}{{$scope}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
 	return
 }
 
@@ -137,7 +142,7 @@ func (s *{{.Abbreviation}}) Get{{.StructName}}(ctx context.Context, {{.PrimaryFi
 // Author [yourname](https://github.com/yourname)
 func (s *{{.Abbreviation}}) Get{{.StructName}}InfoList(ctx context.Context) (list []*model.{{.StructName}},err error) {
     // 创建db
-	db := {{$db}}.Model(&model.{{.StructName}}{})
+	db := {{$db}}.Model(&model.{{.StructName}}{}){{$scope}}
     var {{.Abbreviation}}s []*model.{{.StructName}}
 
 	err = db.Find(&{{.Abbreviation}}s).Error
@@ -151,7 +156,7 @@ func (s *{{.Abbreviation}}) Get{{.StructName}}InfoList(ctx context.Context, info
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
     // 创建db
-	db := {{$db}}.Model(&model.{{.StructName}}{})
+	db := {{$db}}.Model(&model.{{.StructName}}{}){{$scope}}
     var {{.Abbreviation}}s []model.{{.StructName}}
     // 如果有条件搜索 下方会自动创建搜索语句
 {{- if .GvaModel }}
@@ -17,13 +17,19 @@ type RouterGroup struct {
 	OperationRecordRouter
 	DictionaryDetailRouter
 	AuthorityBtnRouter
+	AuthorityFieldRouter
 	SysExportTemplateRouter
 	SysParamsRouter
 	SysVersionRouter
 	SysErrorRouter
 	LoginLogRouter
 	ApiTokenRouter
+	AccessKeyRouter
 	SkillsRouter
+	SessionRouter
+	TenantRouter
+	ChangeHistoryRouter
+	AuditLogRouter
 }
 
 var (
@@ -38,6 +44,7 @@ var (
 	apiRouterApi        = api.ApiGroupApp.SystemApiGroup.SystemApiApi
 	dictionaryApi       = api.ApiGroupApp.SystemApiGroup.DictionaryApi
 	authorityBtnApi     = api.ApiGroupApp.SystemApiGroup.AuthorityBtnApi
+	authorityFieldApi   = api.ApiGroupApp.SystemApiGroup.AuthorityFieldApi
 	authorityMenuApi    = api.ApiGroupApp.SystemApiGroup.AuthorityMenuApi
 	autoCodePluginApi   = api.ApiGroupApp.SystemApiGroup.AutoCodePluginApi
 	autocodeHistoryApi  = api.ApiGroupApp.SystemApiGroup.AutoCodeHistoryApi
@@ -49,4 +56,9 @@ var (
 	sysVersionApi       = api.ApiGroupApp.SystemApiGroup.SysVersionApi
 	sysErrorApi         = api.ApiGroupApp.SystemApiGroup.SysErrorApi
 	skillsApi           = api.ApiGroupApp.SystemApiGroup.SkillsApi
+	sessionApi          = api.ApiGroupApp.SystemApiGroup.SessionApi
+	accessKeyApi        = api.ApiGroupApp.SystemApiGroup.AccessKeyApi
+	tenantApi           = api.ApiGroupApp.SystemApiGroup.TenantApi
+	changeHistoryApi    = api.ApiGroupApp.SystemApiGroup.ChangeHistoryApi
+	auditLogApi         = api.ApiGroupApp.SystemApiGroup.AuditLogApi
 )
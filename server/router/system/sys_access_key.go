@@ -0,0 +1,20 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type AccessKeyRouter struct{}
+
+func (s *AccessKeyRouter) InitAccessKeyRouter(Router *gin.RouterGroup) {
+	accessKeyRouter := Router.Group("accessKey").Use(middleware.OperationRecord())
+	accessKeyRouterWithoutRecord := Router.Group("accessKey")
+	{
+		accessKeyRouter.POST("deleteAccessKey", accessKeyApi.DeleteAccessKey) // 作废AK/SK
+	}
+	{
+		accessKeyRouterWithoutRecord.POST("createAccessKey", accessKeyApi.CreateAccessKey)   // 签发AK/SK 响应中包含SecretKey 不记录操作日志
+		accessKeyRouterWithoutRecord.POST("getAccessKeyList", accessKeyApi.GetAccessKeyList) // 获取列表
+	}
+}
@@ -0,0 +1,20 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorityFieldRouter struct{}
+
+func (s *AuthorityFieldRouter) InitAuthorityFieldRouter(Router *gin.RouterGroup) {
+	authorityFieldRouter := Router.Group("authorityField").Use(middleware.OperationRecord())
+	authorityFieldRouterWithoutRecord := Router.Group("authorityField")
+	{
+		authorityFieldRouter.POST("setAuthorityField", authorityFieldApi.SetAuthorityField)
+	}
+	{
+		authorityFieldRouterWithoutRecord.GET("getFieldModels", authorityFieldApi.GetFieldModels)
+		authorityFieldRouterWithoutRecord.POST("getAuthorityField", authorityFieldApi.GetAuthorityField)
+	}
+}
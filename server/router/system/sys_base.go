@@ -11,6 +11,17 @@ func (s *BaseRouter) InitBaseRouter(Router *gin.RouterGroup) (R gin.IRoutes) {
 	{
 		baseRouter.POST("login", baseApi.Login)
 		baseRouter.POST("captcha", baseApi.Captcha)
+		baseRouter.POST("refresh", baseApi.RefreshToken)
+		baseRouter.POST("enrollTwoFactor", baseApi.EnrollTwoFactor)
+		baseRouter.POST("verifyTwoFactor", baseApi.VerifyTwoFactor)
+		baseRouter.GET("identityProviders", baseApi.GetIdentityProviders)
+		baseRouter.POST("ssoAuthorize", baseApi.SSOAuthorize)
+		baseRouter.POST("ssoCallback", baseApi.SSOCallback)
+		baseRouter.POST("forgotPassword", baseApi.ForgotPassword)
+		baseRouter.POST("resetPasswordByToken", baseApi.ResetPasswordByToken)
+		baseRouter.GET("registrationOptions", baseApi.GetRegistrationOptions)
+		baseRouter.POST("selfRegister", baseApi.SelfRegister)
+		baseRouter.POST("verifyRegistration", baseApi.VerifyRegistration)
 	}
 	return baseRouter
 }
@@ -15,5 +15,8 @@ func (s *CasbinRouter) InitCasbinRouter(Router *gin.RouterGroup) {
 	}
 	{
 		casbinRouterWithoutRecord.POST("getPolicyPathByAuthorityId", casbinApi.GetPolicyPathByAuthorityId)
+		casbinRouterWithoutRecord.POST("explainPermission", casbinApi.ExplainPermission)           // 权限诊断
+		casbinRouterWithoutRecord.POST("getPermissionMatrix", casbinApi.GetPermissionMatrix)       // 权限矩阵
+		casbinRouterWithoutRecord.POST("exportPermissionMatrix", casbinApi.ExportPermissionMatrix) // 导出权限矩阵
 	}
 }
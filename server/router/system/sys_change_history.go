@@ -0,0 +1,14 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type ChangeHistoryRouter struct{}
+
+func (s *ChangeHistoryRouter) InitChangeHistoryRouter(Router *gin.RouterGroup) {
+	changeHistoryRouter := Router.Group("changeHistory")
+	{
+		changeHistoryRouter.GET("getChangeHistoryList", changeHistoryApi.GetChangeHistoryList) // 分页获取变更历史
+	}
+}
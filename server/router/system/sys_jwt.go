@@ -6,9 +6,12 @@ import (
 
 type JwtRouter struct{}
 
-func (s *JwtRouter) InitJwtRouter(Router *gin.RouterGroup) {
+func (s *JwtRouter) InitJwtRouter(Router *gin.RouterGroup, RouterPub *gin.RouterGroup) {
 	jwtRouter := Router.Group("jwt")
 	{
 		jwtRouter.POST("jsonInBlacklist", jwtApi.JsonInBlacklist) // jwt加入黑名单
 	}
+	{
+		RouterPub.GET(".well-known/jwks.json", jwtApi.Jwks) // 公开验签公钥
+	}
 }
@@ -9,10 +9,9 @@ type OperationRecordRouter struct{}
 func (s *OperationRecordRouter) InitSysOperationRecordRouter(Router *gin.RouterGroup) {
 	operationRecordRouter := Router.Group("sysOperationRecord")
 	{
-		operationRecordRouter.DELETE("deleteSysOperationRecord", operationRecordApi.DeleteSysOperationRecord)           // 删除SysOperationRecord
-		operationRecordRouter.DELETE("deleteSysOperationRecordByIds", operationRecordApi.DeleteSysOperationRecordByIds) // 批量删除SysOperationRecord
-		operationRecordRouter.GET("findSysOperationRecord", operationRecordApi.FindSysOperationRecord)                  // 根据ID获取SysOperationRecord
-		operationRecordRouter.GET("getSysOperationRecordList", operationRecordApi.GetSysOperationRecordList)            // 获取SysOperationRecord列表
+		operationRecordRouter.GET("findSysOperationRecord", operationRecordApi.FindSysOperationRecord)       // 根据ID获取SysOperationRecord
+		operationRecordRouter.GET("getSysOperationRecordList", operationRecordApi.GetSysOperationRecordList) // 获取SysOperationRecord列表
+		operationRecordRouter.GET("getOperationRecordStats", operationRecordApi.GetOperationRecordStats)     // 获取操作记录后台写入的统计
 
 	}
 }
@@ -0,0 +1,21 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionRouter struct{}
+
+func (s *SessionRouter) InitSessionRouter(Router *gin.RouterGroup) {
+	sessionRouter := Router.Group("session").Use(middleware.OperationRecord())
+	sessionRouterWithoutRecord := Router.Group("session")
+	{
+		sessionRouter.POST("revokeSelfSession", sessionApi.RevokeSelfSession) // 注销自己的会话
+		sessionRouter.POST("forceLogout", sessionApi.ForceLogout)             // 强制下线
+	}
+	{
+		sessionRouterWithoutRecord.GET("getSelfSessions", sessionApi.GetSelfSessions)  // 获取自己的在线会话
+		sessionRouterWithoutRecord.POST("getUserSessions", sessionApi.GetUserSessions) // 获取指定用户的在线会话
+	}
+}
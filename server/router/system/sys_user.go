@@ -11,18 +11,37 @@ func (s *UserRouter) InitUserRouter(Router *gin.RouterGroup) {
 	userRouter := Router.Group("user").Use(middleware.OperationRecord())
 	userRouterWithoutRecord := Router.Group("user")
 	{
-		userRouter.POST("admin_register", baseApi.Register)               // 管理员注册账号
-		userRouter.POST("changePassword", baseApi.ChangePassword)         // 用户修改密码
-		userRouter.POST("setUserAuthority", baseApi.SetUserAuthority)     // 设置用户权限
-		userRouter.DELETE("deleteUser", baseApi.DeleteUser)               // 删除用户
-		userRouter.PUT("setUserInfo", baseApi.SetUserInfo)                // 设置用户信息
-		userRouter.PUT("setSelfInfo", baseApi.SetSelfInfo)                // 设置自身信息
-		userRouter.POST("setUserAuthorities", baseApi.SetUserAuthorities) // 设置用户权限组
-		userRouter.POST("resetPassword", baseApi.ResetPassword)           // 重置用户密码
-		userRouter.PUT("setSelfSetting", baseApi.SetSelfSetting)          // 用户界面配置
+		userRouter.POST("admin_register", baseApi.Register)                     // 管理员注册账号
+		userRouter.POST("changePassword", baseApi.ChangePassword)               // 用户修改密码
+		userRouter.POST("setUserAuthority", baseApi.SetUserAuthority)           // 设置用户权限
+		userRouter.DELETE("deleteUser", baseApi.DeleteUser)                     // 删除用户
+		userRouter.PUT("setUserInfo", baseApi.SetUserInfo)                      // 设置用户信息
+		userRouter.PUT("setSelfInfo", baseApi.SetSelfInfo)                      // 设置自身信息
+		userRouter.POST("setUserAuthorities", baseApi.SetUserAuthorities)       // 设置用户权限组
+		userRouter.POST("resetPassword", baseApi.ResetPassword)                 // 重置用户密码
+		userRouter.POST("unlockUser", baseApi.UnlockUser)                       // 解除用户登录锁定
+		userRouter.PUT("setSelfSetting", baseApi.SetSelfSetting)                // 用户界面配置
+		userRouter.POST("setupTwoFactor", baseApi.SetupTwoFactor)               // 获取两步验证绑定信息
+		userRouter.POST("enableTwoFactor", baseApi.EnableTwoFactor)             // 确认绑定两步验证
+		userRouter.POST("disableTwoFactor", baseApi.DisableTwoFactor)           // 关闭两步验证
+		userRouter.POST("resetTwoFactor", baseApi.ResetTwoFactor)               // 重置用户两步验证
+		userRouter.POST("linkIdentity", baseApi.LinkIdentity)                   // 绑定外部身份
+		userRouter.POST("unlinkIdentity", baseApi.UnlinkIdentity)               // 解除外部身份绑定
+		userRouter.POST("impersonate", baseApi.Impersonate)                     // 模拟登录 响应中的token按默认规则脱敏
+		userRouter.POST("stopImpersonate", baseApi.StopImpersonate)             // 退出模拟登录
+		userRouter.POST("createInvitation", baseApi.CreateInvitation)           // 生成注册邀请 响应中的邀请码按默认规则脱敏
+		userRouter.POST("revokeInvitation", baseApi.RevokeInvitation)           // 作废注册邀请
+		userRouter.POST("createAuthorityGrant", baseApi.CreateAuthorityGrant)   // 发起临时角色授权
+		userRouter.POST("approveAuthorityGrant", baseApi.ApproveAuthorityGrant) // 审批通过临时角色授权
+		userRouter.POST("rejectAuthorityGrant", baseApi.RejectAuthorityGrant)   // 拒绝临时角色授权
+		userRouter.POST("revokeAuthorityGrant", baseApi.RevokeAuthorityGrant)   // 撤销临时角色授权
 	}
 	{
-		userRouterWithoutRecord.POST("getUserList", baseApi.GetUserList) // 分页获取用户列表
-		userRouterWithoutRecord.GET("getUserInfo", baseApi.GetUserInfo)  // 获取自身信息
+		userRouterWithoutRecord.POST("getUserList", baseApi.GetUserList)                     // 分页获取用户列表
+		userRouterWithoutRecord.GET("getUserInfo", baseApi.GetUserInfo)                      // 获取自身信息
+		userRouterWithoutRecord.GET("getIdentities", baseApi.GetIdentities)                  // 获取绑定的外部身份
+		userRouterWithoutRecord.POST("getInvitationList", baseApi.GetInvitationList)         // 分页获取注册邀请
+		userRouterWithoutRecord.POST("getAuthorityGrantList", baseApi.GetAuthorityGrantList) // 分页获取临时角色授权
+		userRouterWithoutRecord.POST("getAuthorityAuditList", baseApi.GetAuthorityAuditList) // 分页获取角色授权审计记录
 	}
 }
@@ -1,10 +1,11 @@
 package example
 
 import (
+	"context"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/example"
-	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
 )
 
@@ -36,12 +37,12 @@ func (exa *CustomerService) DeleteExaCustomer(e example.ExaCustomer) (err error)
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: UpdateExaCustomer
-//@description: 更新客户
-//@param: e *model.ExaCustomer
+//@description: 更新客户 忽略当前角色不可修改的字段
+//@param: ctx context.Context, e *model.ExaCustomer
 //@return: err error
 
-func (exa *CustomerService) UpdateExaCustomer(e *example.ExaCustomer) (err error) {
-	err = global.GVA_DB.Save(e).Error
+func (exa *CustomerService) UpdateExaCustomer(ctx context.Context, e *example.ExaCustomer) (err error) {
+	err = global.GVA_DB.Scopes(systemService.AuthorityFieldServiceApp.UpdateScope(ctx)).Save(e).Error
 	return err
 }
 
@@ -58,30 +59,20 @@ func (exa *CustomerService) GetExaCustomer(id uint) (customer example.ExaCustome
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetCustomerInfoList
-//@description: 分页获取客户列表
-//@param: sysUserAuthorityID string, info request.PageInfo
+//@description: 分页获取客户列表 按当前用户角色的数据权限过滤
+//@param: ctx context.Context, info request.PageInfo
 //@return: list interface{}, total int64, err error
 
-func (exa *CustomerService) GetCustomerInfoList(sysUserAuthorityID uint, info request.PageInfo) (list interface{}, total int64, err error) {
+func (exa *CustomerService) GetCustomerInfoList(ctx context.Context, info request.PageInfo) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.GVA_DB.Model(&example.ExaCustomer{})
-	var a system.SysAuthority
-	a.AuthorityId = sysUserAuthorityID
-	auth, err := systemService.AuthorityServiceApp.GetAuthorityInfo(a)
-	if err != nil {
-		return
-	}
-	var dataId []uint
-	for _, v := range auth.DataAuthorityId {
-		dataId = append(dataId, v.AuthorityId)
-	}
+	db := global.GVA_DB.Model(&example.ExaCustomer{}).Scopes(systemService.DataScopeServiceApp.Scope(ctx))
 	var CustomerList []example.ExaCustomer
-	err = db.Where("sys_user_authority_id in ?", dataId).Count(&total).Error
+	err = db.Count(&total).Error
 	if err != nil {
 		return CustomerList, total, err
 	} else {
-		err = db.Limit(limit).Offset(offset).Preload("SysUser").Where("sys_user_authority_id in ?", dataId).Find(&CustomerList).Error
+		err = db.Limit(limit).Offset(offset).Preload("SysUser").Find(&CustomerList).Error
 	}
 	return CustomerList, total, err
 }
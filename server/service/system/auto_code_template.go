@@ -17,6 +17,7 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	utilsAst "github.com/flipped-aurora/gin-vue-admin/server/utils/ast"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
@@ -136,8 +137,39 @@ func (s *autoCodeTemplate) Create(ctx context.Context, info request.AutoCode) er
 					}
 					entity.MenuBtn = append(entity.MenuBtn, excelBtn...)
 				}
+				// 按钮关联对应的api 服务端按按钮校验接口权限
+				btnApis := info.BtnApis()
+				for i := range entity.MenuBtn {
+					paths := btnApis[entity.MenuBtn[i].Name]
+					if len(paths) == 0 {
+						continue
+					}
+					err = global.GVA_DB.WithContext(ctx).Where("path IN ?", paths).Find(&entity.MenuBtn[i].Apis).Error
+					if err != nil {
+						return errors.Wrap(err, "查询按钮关联的api失败!")
+					}
+				}
 			}
-			err = global.GVA_DB.WithContext(ctx).Create(&entity).Error
+			err = global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Omit("MenuBtn.Apis.*").Create(&entity).Error; err != nil {
+					return err
+				}
+				// 关联了按钮的api只允许被授予按钮的角色调用 新建的按钮授予创建人当前的角色
+				claims, ok := utils.GetClaimsFromContext(ctx)
+				if !ok || len(entity.MenuBtn) == 0 {
+					return nil
+				}
+				authorityBtn := make([]model.SysAuthorityBtn, 0, len(entity.MenuBtn))
+				for _, btn := range entity.MenuBtn {
+					authorityBtn = append(authorityBtn, model.SysAuthorityBtn{
+						AuthorityId:      claims.AuthorityId,
+						SysMenuID:        entity.ID,
+						SysBaseMenuBtnID: btn.ID,
+					})
+				}
+				return tx.Create(&authorityBtn).Error
+			})
+			AuthorityBtnServiceApp.ClearBtnApiCache()
 			id = entity.ID
 			if err != nil {
 				return errors.Wrap(err, "创建菜单失败!")
@@ -146,6 +178,29 @@ func (s *autoCodeTemplate) Create(ctx context.Context, info request.AutoCode) er
 		history.MenuID = id
 	}
 
+	// 登记模型字段 供角色配置字段权限
+	if !info.OnlyTemplate {
+		fieldModel := "model/" + info.Package + "." + info.StructName
+		if autoPkg.Template == "plugin" {
+			fieldModel = "plugin/" + info.Package + "/model." + info.StructName
+		}
+		fields := make([]model.SysModelField, 0, len(info.Fields))
+		for _, field := range info.Fields {
+			fields = append(fields, model.SysModelField{
+				Model:       fieldModel,
+				Description: info.Description,
+				Field:       field.FieldJson,
+				Label:       field.FieldDesc,
+				Table:       info.TableName,
+				Column:      field.ColumnName,
+			})
+		}
+		err = AuthorityFieldServiceApp.RegisterModelFields(ctx, fields)
+		if err != nil {
+			return errors.Wrap(err, "登记模型字段失败!")
+		}
+	}
+
 	if info.HasExcel {
 		dbName := info.BusinessDB
 		name := info.Package + "_" + info.StructName
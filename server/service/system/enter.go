@@ -15,6 +15,7 @@ type ServiceGroup struct {
 	OperationRecordService
 	DictionaryDetailService
 	AuthorityBtnService
+	AuthorityFieldService
 	SysExportTemplateService
 	SysParamsService
 	SysVersionService
@@ -26,4 +27,13 @@ type ServiceGroup struct {
 	SysErrorService
 	LoginLogService
 	ApiTokenService
+	AccessKeyService
+	RefreshTokenService
+	SessionService
+	TwoFactorService
+	UserIdentityService
+	LoginLockService
+	TenantService
+	ChangeHistoryService
+	AuditLogService
 }
@@ -2,51 +2,156 @@ package system
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 )
 
+// jwtBlacklistChannel 开启redis时各实例通过该频道即时同步新拉黑的token
+const jwtBlacklistChannel = "gva:jwt:blacklist"
+
 type JwtService struct{}
 
 var JwtServiceApp = new(JwtService)
 
+// blacklistSyncOverlap 轮询时回看的时长 自增ID与创建时间都不保证按提交顺序可见
+// 回看窗口覆盖较晚提交的事务与实例间的时钟偏差 重复写入缓存没有副作用
+const blacklistSyncOverlap = time.Minute
+
+var (
+	blacklistMu    sync.Mutex
+	blacklistSince time.Time // 下次轮询查询的起始创建时间 首次为零值加载全部未过期记录
+)
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: JsonInBlacklist
-//@description: 拉黑jwt
+//@description: 拉黑jwt 落库后写入本地缓存 开启redis时广播给其他实例
 //@param: jwtList model.JwtBlacklist
 //@return: err error
 
 func (jwtService *JwtService) JsonInBlacklist(jwtList system.JwtBlacklist) (err error) {
+	if exp, ok := utils.TokenExpiresAt(jwtList.Jwt); ok && jwtList.ExpiresAt == nil {
+		jwtList.ExpiresAt = &exp
+	}
 	err = global.GVA_DB.Create(&jwtList).Error
 	if err != nil {
 		return
 	}
-	global.BlackCache.SetDefault(jwtList.Jwt, struct{}{})
+	cacheBlacklist(jwtList.Jwt, jwtList.ExpiresAt)
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		if e := global.GVA_REDIS.Publish(context.Background(), jwtBlacklistChannel, jwtList.Jwt).Err(); e != nil {
+			// 广播失败时其他实例在重连或重启后从数据库补齐
+			global.GVA_LOG.Error("广播jwt黑名单失败!", zap.Error(e))
+		}
+	}
 	return
 }
 
-//@author: [piexlmax](https://github.com/piexlmax)
-//@function: GetRedisJWT
-//@description: 从redis取jwt
-//@param: userName string
-//@return: redisJWT string, err error
-
-func (jwtService *JwtService) GetRedisJWT(userName string) (redisJWT string, err error) {
-	redisJWT, err = global.GVA_REDIS.Get(context.Background(), userName).Result()
-	return redisJWT, err
+func LoadAll() {
+	if err := syncBlacklist(); err != nil {
+		global.GVA_LOG.Error("加载数据库jwt黑名单失败!", zap.Error(err))
+	}
 }
 
-func LoadAll() {
-	var data []string
-	err := global.GVA_DB.Model(&system.JwtBlacklist{}).Select("jwt").Find(&data).Error
+//@function: WatchBlacklist
+//@description: 多实例同步jwt黑名单 开启redis时订阅广播 否则定时轮询数据库中新增的记录
+//@param: ctx context.Context
+
+func WatchBlacklist(ctx context.Context) {
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		go subscribeBlacklist(ctx)
+		return
+	}
+	interval := 10 * time.Second
+	if global.GVA_CONFIG.JWT.BlacklistPoll != "" {
+		d, err := utils.ParseDuration(global.GVA_CONFIG.JWT.BlacklistPoll)
+		if err != nil || d <= 0 {
+			global.GVA_LOG.Error("jwt黑名单轮询间隔配置错误 使用默认值10s", zap.String("blacklist-poll", global.GVA_CONFIG.JWT.BlacklistPoll))
+		} else {
+			interval = d
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := syncBlacklist(); err != nil {
+					global.GVA_LOG.Error("同步jwt黑名单失败!", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// subscribeBlacklist 每次(重新)订阅成功后从数据库补齐断线期间遗漏的记录
+func subscribeBlacklist(ctx context.Context) {
+	pubsub := global.GVA_REDIS.Subscribe(ctx, jwtBlacklistChannel)
+	defer pubsub.Close()
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			global.GVA_LOG.Error("订阅jwt黑名单失败!", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			if m.Kind == "subscribe" {
+				if err = syncBlacklist(); err != nil {
+					global.GVA_LOG.Error("同步jwt黑名单失败!", zap.Error(err))
+				}
+			}
+		case *redis.Message:
+			var exp *time.Time
+			if t, ok := utils.TokenExpiresAt(m.Payload); ok {
+				exp = &t
+			}
+			cacheBlacklist(m.Payload, exp)
+		}
+	}
+}
+
+// syncBlacklist 将数据库中尚未过期且在上次轮询之后(含回看窗口)写入的黑名单记录写入本地缓存
+func syncBlacklist() error {
+	blacklistMu.Lock()
+	defer blacklistMu.Unlock()
+	now := time.Now()
+	var list []system.JwtBlacklist
+	err := global.GVA_DB.Select("id", "jwt", "expires_at").
+		Where("created_at >= ? AND (expires_at IS NULL OR expires_at > ?)", blacklistSince, now).
+		Find(&list).Error
 	if err != nil {
-		global.GVA_LOG.Error("加载数据库jwt黑名单失败!", zap.Error(err))
+		return err
+	}
+	for _, v := range list {
+		cacheBlacklist(v.Jwt, v.ExpiresAt)
+	}
+	blacklistSince = now.Add(-blacklistSyncOverlap)
+	return nil
+}
+
+// cacheBlacklist 本地缓存保留到token过期为止 过期的token本身已无法通过校验
+func cacheBlacklist(token string, expiresAt *time.Time) {
+	if expiresAt == nil {
+		global.BlackCache.SetDefault(token, struct{}{})
+		return
+	}
+	ttl := time.Until(*expiresAt)
+	if ttl <= 0 {
 		return
 	}
-	for i := 0; i < len(data); i++ {
-		global.BlackCache.SetDefault(data[i], struct{}{})
-	} // jwt黑名单 加入 BlackCache 中
+	global.BlackCache.Set(token, struct{}{}, ttl)
 }
@@ -0,0 +1,53 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestSyncBlacklist(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	blacklistSince = time.Time{}
+	if err = db.AutoMigrate(&system.JwtBlacklist{}); err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Hour)
+	db.Create(&system.JwtBlacklist{GVA_MODEL: global.GVA_MODEL{ID: 6}, Jwt: "six", ExpiresAt: &exp})
+	db.Create(&system.JwtBlacklist{GVA_MODEL: global.GVA_MODEL{ID: 7}, Jwt: "old", ExpiresAt: &expired})
+	if err = syncBlacklist(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := global.BlackCache.Get("six"); !ok {
+		t.Error("token six should be loaded")
+	}
+	if _, ok := global.BlackCache.Get("old"); ok {
+		t.Error("expired token should not be loaded")
+	}
+
+	// ID较小的事务在上次轮询之后才提交 仍需被加载
+	late := system.JwtBlacklist{GVA_MODEL: global.GVA_MODEL{ID: 5}, Jwt: "five", ExpiresAt: &exp}
+	db.Create(&late)
+	db.Model(&late).Update("created_at", time.Now().Add(-10*time.Second))
+	if err = syncBlacklist(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := global.BlackCache.Get("five"); !ok {
+		t.Error("row committed out of order should be loaded")
+	}
+}
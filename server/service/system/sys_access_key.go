@@ -0,0 +1,229 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	sysReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AccessKeyService struct{}
+
+const (
+	accessKeyBlackPre = "access_key:"
+	signNoncePre      = "GVA_SIGN_NONCE_"
+	accessKeyCacheTTL = time.Minute
+	// accessKeyTouchInterval 最后使用时间的落库间隔 避免每次请求都写数据库
+	accessKeyTouchInterval = time.Minute
+)
+
+var (
+	ErrAccessKeyInvalid = errors.New("AccessKey无效或已过期")
+	ErrSignatureInvalid = errors.New("签名错误")
+	ErrSignatureExpired = errors.New("请求时间戳超出允许范围")
+	ErrNonceReused      = errors.New("请求已被使用 请勿重放")
+)
+
+var (
+	accessKeyCache = local_cache.NewCache(local_cache.SetDefaultExpire(accessKeyCacheTTL))
+	// signNonceCache 未开启redis时在本实例内做防重放
+	signNonceCache = local_cache.NewCache()
+	signNonceMu    sync.Mutex
+)
+
+//@function: CreateAccessKey
+//@description: 为用户签发AK/SK SecretKey只在签发时返回一次
+//@param: ctx context.Context, req sysReq.CreateAccessKey
+//@return: accessKey string, secretKey string, err error
+
+func (accessKeyService *AccessKeyService) CreateAccessKey(ctx context.Context, req sysReq.CreateAccessKey) (accessKey, secretKey string, err error) {
+	if req.Days < 0 {
+		return "", "", errors.New("有效期不能为负数")
+	}
+	if err = checkUserAuthority(ctx, req.UserID, req.AuthorityID); err != nil {
+		return "", "", err
+	}
+	id, err := utils.RandomToken(15)
+	if err != nil {
+		return "", "", err
+	}
+	secretKey, err = utils.RandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	key := system.SysAccessKey{
+		UserID:      req.UserID,
+		AuthorityID: req.AuthorityID,
+		AccessKey:   "AK" + id,
+		SecretKey:   secretKey,
+		Status:      true,
+		Remark:      req.Remark,
+	}
+	if req.Days > 0 {
+		exp := time.Now().Add(time.Duration(req.Days) * 24 * time.Hour)
+		key.ExpiresAt = &exp
+	}
+	err = global.GVA_DB.Create(&key).Error
+	if err != nil {
+		return "", "", err
+	}
+	return key.AccessKey, secretKey, nil
+}
+
+//@function: GetAccessKeyList
+//@description: 分页获取AK/SK列表 只包含当前租户用户的凭证
+//@param: ctx context.Context, info sysReq.SysAccessKeySearch
+//@return: list []system.SysAccessKey, total int64, err error
+
+func (accessKeyService *AccessKeyService) GetAccessKeyList(ctx context.Context, info sysReq.SysAccessKeySearch) (list []system.SysAccessKey, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysAccessKey{}).Scopes(tenantUserScope(ctx, "user_id")).Preload("User")
+	if info.UserID != 0 {
+		db = db.Where("user_id = ?", info.UserID)
+	}
+	if info.Status != nil {
+		db = db.Where("status = ?", *info.Status)
+	}
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	err = db.Limit(limit).Offset(offset).Order("created_at desc").Find(&list).Error
+	return list, total, err
+}
+
+//@function: DeleteAccessKey
+//@description: 作废AK/SK 写入黑名单后所有实例立即拒绝该AccessKey 不属于当前租户用户的凭证按记录不存在处理
+//@param: ctx context.Context, id uint
+//@return: err error
+
+func (accessKeyService *AccessKeyService) DeleteAccessKey(ctx context.Context, id uint) error {
+	var key system.SysAccessKey
+	err := global.GVA_DB.WithContext(ctx).Scopes(tenantUserScope(ctx, "user_id")).First(&key, id).Error
+	if err != nil {
+		return err
+	}
+	err = JwtServiceApp.JsonInBlacklist(system.JwtBlacklist{Jwt: accessKeyBlackPre + key.AccessKey, ExpiresAt: key.ExpiresAt})
+	if err != nil {
+		return err
+	}
+	accessKeyCache.Delete(key.AccessKey)
+	return global.GVA_DB.Model(&key).Update("status", false).Error
+}
+
+//@function: Authenticate
+//@description: 校验签名请求 依次检查AccessKey状态 时间戳偏差 签名与nonce 通过后返回凭证所属用户的claims
+//@param: accessKey, ip, method, uri, timestamp, nonce string, body []byte, signature string
+//@return: claims *sysReq.CustomClaims, err error
+
+func (accessKeyService *AccessKeyService) Authenticate(accessKey, ip, method, uri, timestamp, nonce string, body []byte, signature string) (*sysReq.CustomClaims, error) {
+	if _, ok := global.BlackCache.Get(accessKeyBlackPre + accessKey); ok {
+		return nil, ErrAccessKeyInvalid
+	}
+	key, err := loadAccessKey(accessKey)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !key.Status || (key.ExpiresAt != nil && now.After(*key.ExpiresAt)) {
+		return nil, ErrAccessKeyInvalid
+	}
+	skew := signClockSkew()
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrSignatureExpired
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > skew || d < -skew {
+		return nil, ErrSignatureExpired
+	}
+	if nonce == "" || !utils.VerifySign(key.SecretKey, method, uri, timestamp, nonce, body, signature) {
+		return nil, ErrSignatureInvalid
+	}
+	// 签名通过后再占用nonce 避免伪造请求耗尽合法客户端的nonce
+	// 时间戳超出偏差的请求已被拒绝 nonce只需保留两倍偏差时长
+	ok, err := useSignNonce(accessKey+":"+nonce, 2*skew)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNonceReused
+	}
+	touchAccessKey(key, ip, now)
+
+	return &sysReq.CustomClaims{
+		BaseClaims: sysReq.BaseClaims{
+			UUID:        key.User.UUID,
+			ID:          key.UserID,
+			Username:    key.User.Username,
+			NickName:    key.User.NickName,
+			AuthorityId: key.AuthorityID,
+			TenantID:    key.User.TenantID,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience: jwt.ClaimStrings{"GVA"},
+			Issuer:   global.GVA_CONFIG.JWT.Issuer,
+		},
+	}, nil
+}
+
+func loadAccessKey(accessKey string) (system.SysAccessKey, error) {
+	if v, ok := accessKeyCache.Get(accessKey); ok {
+		return v.(system.SysAccessKey), nil
+	}
+	var key system.SysAccessKey
+	err := global.GVA_DB.Preload("User").Where("access_key = ?", accessKey).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return key, ErrAccessKeyInvalid
+	}
+	if err != nil {
+		return key, err
+	}
+	accessKeyCache.Set(accessKey, key, accessKeyCacheTTL)
+	return key, nil
+}
+
+func signClockSkew() time.Duration {
+	if s := global.GVA_CONFIG.AccessKey.ClockSkew; s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return 5 * time.Minute
+}
+
+// useSignNonce nonce未被使用过时占用并返回true 开启redis时多实例共享
+func useSignNonce(key string, ttl time.Duration) (bool, error) {
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		return global.GVA_REDIS.SetNX(context.Background(), signNoncePre+key, 1, ttl).Result()
+	}
+	signNonceMu.Lock()
+	defer signNonceMu.Unlock()
+	if _, ok := signNonceCache.Get(key); ok {
+		return false, nil
+	}
+	signNonceCache.Set(key, struct{}{}, ttl)
+	return true, nil
+}
+
+func touchAccessKey(key system.SysAccessKey, ip string, now time.Time) {
+	if key.LastUsedAt != nil && now.Sub(*key.LastUsedAt) < accessKeyTouchInterval && key.LastUsedIp == ip {
+		return
+	}
+	key.LastUsedAt = &now
+	key.LastUsedIp = ip
+	accessKeyCache.Set(key.AccessKey, key, accessKeyCacheTTL)
+	err := global.GVA_DB.Model(&system.SysAccessKey{}).Where("id = ?", key.ID).
+		Updates(map[string]interface{}{"last_used_at": now, "last_used_ip": ip}).Error
+	if err != nil {
+		global.GVA_LOG.Error("更新AccessKey使用记录失败!", zap.Error(err))
+	}
+}
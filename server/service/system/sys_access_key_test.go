@@ -0,0 +1,75 @@
+package system
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestAccessKeyAuthenticate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_CONFIG.AccessKey.ClockSkew = 60
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysAccessKey{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "bot"})
+	db.Create(&system.SysAccessKey{UserID: 1, AuthorityID: 888, AccessKey: "ak", SecretKey: "sk", Status: true})
+	disabled := system.SysAccessKey{UserID: 1, AuthorityID: 888, AccessKey: "ak-off", SecretKey: "sk", Status: true}
+	db.Create(&disabled)
+	db.Model(&disabled).Update("status", false)
+
+	body := []byte(`{"page":1}`)
+	uri := "/api/user/getUserList"
+	auth := func(ak, nonce string, at time.Time, signature string) error {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		if signature == "" {
+			signature = utils.Sign("sk", "POST", uri, ts, nonce, body)
+		}
+		_, err := new(AccessKeyService).Authenticate(ak, "127.0.0.1", "POST", uri, ts, nonce, body, signature)
+		return err
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		ak        string
+		nonce     string
+		at        time.Time
+		signature string
+		want      error
+	}{
+		{"valid", "ak", "n1", now, "", nil},
+		{"replayed nonce", "ak", "n1", now, "", ErrNonceReused},
+		{"bad signature", "ak", "n2", now, "deadbeef", ErrSignatureInvalid},
+		// 签名错误的请求不应占用nonce
+		{"nonce after bad signature", "ak", "n2", now, "", nil},
+		{"timestamp too old", "ak", "n3", now.Add(-2 * time.Minute), "", ErrSignatureExpired},
+		{"timestamp in the future", "ak", "n4", now.Add(2 * time.Minute), "", ErrSignatureExpired},
+		{"within clock skew", "ak", "n5", now.Add(-30 * time.Second), "", nil},
+		{"missing nonce", "ak", "", now, "", ErrSignatureInvalid},
+		{"unknown access key", "nope", "n6", now, "", ErrAccessKeyInvalid},
+		{"disabled access key", "ak-off", "n7", now, "", ErrAccessKeyInvalid},
+	}
+	for _, tt := range tests {
+		if err := auth(tt.ak, tt.nonce, tt.at, tt.signature); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -1,14 +1,17 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"gorm.io/gorm"
 )
 
@@ -22,11 +25,15 @@ type ApiService struct{}
 
 var ApiServiceApp = new(ApiService)
 
-func (apiService *ApiService) CreateApi(api system.SysApi) (err error) {
+func (apiService *ApiService) CreateApi(ctx context.Context, api system.SysApi) (err error) {
 	if !errors.Is(global.GVA_DB.Where("path = ? AND method = ?", api.Path, api.Method).First(&system.SysApi{}).Error, gorm.ErrRecordNotFound) {
 		return errors.New("存在相同api")
 	}
-	return global.GVA_DB.Create(&api).Error
+	if err = checkRedactRules(api); err != nil {
+		return err
+	}
+	defer OperationRecordServiceApp.ClearRedactCache()
+	return global.GVA_DB.WithContext(ctx).Create(&api).Error
 }
 
 func (apiService *ApiService) GetApiGroups() (groups []string, groupApiMap map[string]string, err error) {
@@ -159,17 +166,18 @@ func (apiService *ApiService) EnterSyncApi(syncApis systemRes.SysSyncApis) (err
 //@param: api model.SysApi
 //@return: err error
 
-func (apiService *ApiService) DeleteApi(api system.SysApi) (err error) {
+func (apiService *ApiService) DeleteApi(ctx context.Context, api system.SysApi) (err error) {
 	var entity system.SysApi
 	err = global.GVA_DB.First(&entity, "id = ?", api.ID).Error // 根据id查询api记录
 	if errors.Is(err, gorm.ErrRecordNotFound) {                // api记录不存在
 		return err
 	}
-	err = global.GVA_DB.Delete(&entity).Error
+	err = global.GVA_DB.WithContext(ctx).Delete(&entity).Error
 	if err != nil {
 		return err
 	}
 	CasbinServiceApp.ClearCasbin(1, entity.Path, entity.Method)
+	OperationRecordServiceApp.ClearRedactCache()
 	return nil
 }
 
@@ -243,14 +251,14 @@ func (apiService *ApiService) GetAllApis(authorityID uint) (apis []system.SysApi
 	if parentAuthorityID == 0 || !global.GVA_CONFIG.System.UseStrictAuth {
 		return
 	}
-	paths := CasbinServiceApp.GetPolicyPathByAuthorityId(authorityID)
-	// 挑选 apis里面的path和method也在paths里面的api
+	// 按实际生效的权限挑选 包括从父角色继承的策略并排除被deny的api
+	e := utils.GetCasbin()
+	sub := strconv.Itoa(int(authorityID))
+	dom := CasbinServiceApp.AuthorityDomain(authorityID)
 	var authApis []system.SysApi
 	for i := range apis {
-		for j := range paths {
-			if paths[j].Path == apis[i].Path && paths[j].Method == apis[i].Method {
-				authApis = append(authApis, apis[i])
-			}
+		if ok, _ := e.Enforce(sub, dom, apis[i].Path, apis[i].Method); ok {
+			authApis = append(authApis, apis[i])
 		}
 	}
 	return authApis, err
@@ -273,7 +281,7 @@ func (apiService *ApiService) GetApiById(id int) (api system.SysApi, err error)
 //@param: api model.SysApi
 //@return: err error
 
-func (apiService *ApiService) UpdateApi(api system.SysApi) (err error) {
+func (apiService *ApiService) UpdateApi(ctx context.Context, api system.SysApi) (err error) {
 	var oldA system.SysApi
 	err = global.GVA_DB.First(&oldA, "id = ?", api.ID).Error
 	if oldA.Path != api.Path || oldA.Method != api.Method {
@@ -292,13 +300,17 @@ func (apiService *ApiService) UpdateApi(api system.SysApi) (err error) {
 	if err != nil {
 		return err
 	}
+	if err = checkRedactRules(api); err != nil {
+		return err
+	}
 
 	err = CasbinServiceApp.UpdateCasbinApi(oldA.Path, api.Path, oldA.Method, api.Method)
 	if err != nil {
 		return err
 	}
 
-	return global.GVA_DB.Save(&api).Error
+	defer OperationRecordServiceApp.ClearRedactCache()
+	return global.GVA_DB.WithContext(ctx).Save(&api).Error
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
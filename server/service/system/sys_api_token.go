@@ -1,78 +1,132 @@
 package system
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/util"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	sysReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/golang-jwt/jwt/v5"
-	"time"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type ApiTokenService struct{}
 
-func (apiVersion *ApiTokenService) CreateApiToken(apiToken system.SysApiToken, days int) (string, error) {
-	var user system.SysUser
-	if err := global.GVA_DB.Where("id = ?", apiToken.UserID).First(&user).Error; err != nil {
-		return "", errors.New("用户不存在")
-	}
+const (
+	apiTokenMaxDays = 365
+	// apiTokenBlackPre 作废的Token以哈希写入jwt黑名单 复用黑名单的多实例同步
+	apiTokenBlackPre = "api_token:"
+	apiTokenCacheTTL = time.Minute
+	// apiTokenFlushInterval 使用记录先在内存中累加 定时批量落库 避免每次请求都写数据库
+	apiTokenFlushInterval = 30 * time.Second
+)
 
-	hasAuth := false
-	for _, auth := range user.Authorities {
-		if auth.AuthorityId == apiToken.AuthorityID {
-			hasAuth = true
-			break
-		}
-	}
-	if !hasAuth && user.AuthorityId != apiToken.AuthorityID {
-		return "", errors.New("用户不具备该角色权限")
-	}
+var (
+	ErrApiTokenInvalid   = errors.New("API Token无效或已过期")
+	ErrApiTokenIpDenied  = errors.New("当前IP不允许使用该API Token")
+	ErrApiTokenForbidden = errors.New("API Token无权访问该接口")
+)
+
+var apiTokenCache = local_cache.NewCache(local_cache.SetDefaultExpire(apiTokenCacheTTL))
 
-	j := &utils.JWT{SigningKey: []byte(global.GVA_CONFIG.JWT.SigningKey)} // 唯一不同的部分是过期时间
+type apiTokenUsage struct {
+	count  int64
+	lastAt time.Time
+	lastIp string
+}
+
+var (
+	apiTokenUsageMu   sync.Mutex
+	apiTokenUsageMap  = map[uint]*apiTokenUsage{}
+	apiTokenFlushOnce sync.Once
+	apiTokenStopOnce  sync.Once
+	apiTokenFlushStop = make(chan struct{})
+	apiTokenFlushDone = make(chan struct{})
+)
 
-	expireTime := time.Duration(days) * 24 * time.Hour
-	if days == -1 {
-		expireTime = 100 * 365 * 24 * time.Hour
+//@function: CreateApiToken
+//@description: 签发API Token 仅保存哈希与前缀 原文只在签发时返回一次
+//@param: ctx context.Context, req sysReq.CreateApiToken
+//@return: token string, err error
+
+func (apiVersion *ApiTokenService) CreateApiToken(ctx context.Context, req sysReq.CreateApiToken) (string, error) {
+	if req.Days < 1 || req.Days > apiTokenMaxDays {
+		return "", fmt.Errorf("有效期需在1-%d天之间", apiTokenMaxDays)
+	}
+	if len(req.ApiIds) == 0 {
+		return "", errors.New("请选择允许访问的api")
+	}
+	cidrs, err := normalizeCIDRs(req.AllowedCIDRs)
+	if err != nil {
+		return "", err
 	}
 
-	bf, _ := utils.ParseDuration(global.GVA_CONFIG.JWT.BufferTime)
+	if err = checkUserAuthority(ctx, req.UserID, req.AuthorityID); err != nil {
+		return "", err
+	}
 
-	claims := sysReq.CustomClaims{
-		BaseClaims: sysReq.BaseClaims{
-			UUID:        user.UUID,
-			ID:          user.ID,
-			Username:    user.Username,
-			NickName:    user.NickName,
-			AuthorityId: apiToken.AuthorityID,
-		},
-		BufferTime: int64(bf / time.Second), // 缓冲时间
-		RegisteredClaims: jwt.RegisteredClaims{
-			Audience:  jwt.ClaimStrings{"GVA"},
-			NotBefore: jwt.NewNumericDate(time.Now().Add(-1000)),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expireTime)),
-			Issuer:    global.GVA_CONFIG.JWT.Issuer,
-		},
+	var apis []system.SysApi
+	if err = global.GVA_DB.Where("id in ?", req.ApiIds).Find(&apis).Error; err != nil {
+		return "", err
+	}
+	if len(apis) != len(req.ApiIds) {
+		return "", errors.New("存在无效的api")
+	}
+	e := utils.GetCasbin()
+	sub := strconv.Itoa(int(req.AuthorityID))
+	dom := CasbinServiceApp.AuthorityDomain(req.AuthorityID)
+	for _, api := range apis {
+		if ok, _ := e.Enforce(sub, dom, api.Path, api.Method); !ok {
+			return "", fmt.Errorf("角色无权访问 %s %s", api.Method, api.Path)
+		}
 	}
 
-	token, err := j.CreateToken(claims)
+	secret, err := utils.RandomToken(32)
 	if err != nil {
 		return "", err
 	}
+	token := system.ApiTokenPrefix + secret
 
-	apiToken.Token = token
-	apiToken.Status = true
-	apiToken.ExpiresAt = time.Now().Add(expireTime)
+	apiToken := system.SysApiToken{
+		UserID:       req.UserID,
+		AuthorityID:  req.AuthorityID,
+		TokenHash:    utils.SHA256Hex(token),
+		TokenPrefix:  token[:12],
+		Apis:         apis,
+		AllowedCIDRs: cidrs,
+		Status:       true,
+		ExpiresAt:    time.Now().Add(time.Duration(req.Days) * 24 * time.Hour),
+		Remark:       req.Remark,
+	}
 	err = global.GVA_DB.Create(&apiToken).Error
-	return token, err
+	if err != nil {
+		return "", err
+	}
+	return token, nil
 }
 
-func (apiVersion *ApiTokenService) GetApiTokenList(info sysReq.SysApiTokenSearch) (list []system.SysApiToken, total int64, err error) {
+//@function: GetApiTokenList
+//@description: 分页获取API Token列表 只包含当前租户用户的Token
+//@param: ctx context.Context, info sysReq.SysApiTokenSearch
+//@return: list []system.SysApiToken, total int64, err error
+
+func (apiVersion *ApiTokenService) GetApiTokenList(ctx context.Context, info sysReq.SysApiTokenSearch) (list []system.SysApiToken, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.GVA_DB.Model(&system.SysApiToken{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysApiToken{}).Scopes(tenantUserScope(ctx, "user_id"))
 
-	db = db.Preload("User")
+	db = db.Preload("User").Preload("Apis")
 
 	if info.UserID != 0 {
 		db = db.Where("user_id = ?", info.UserID)
@@ -89,18 +143,211 @@ func (apiVersion *ApiTokenService) GetApiTokenList(info sysReq.SysApiTokenSearch
 	return list, total, err
 }
 
-func (apiVersion *ApiTokenService) DeleteApiToken(id uint) error {
+//@function: DeleteApiToken
+//@description: 作废API Token 写入黑名单后所有实例立即拒绝该Token 不属于当前租户用户的Token按记录不存在处理
+//@param: ctx context.Context, id uint
+//@return: err error
+
+func (apiVersion *ApiTokenService) DeleteApiToken(ctx context.Context, id uint) error {
 	var apiToken system.SysApiToken
-	err := global.GVA_DB.First(&apiToken, id).Error
+	err := global.GVA_DB.WithContext(ctx).Scopes(tenantUserScope(ctx, "user_id")).First(&apiToken, id).Error
 	if err != nil {
 		return err
 	}
 
-	jwtService := JwtService{}
-	err = jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: apiToken.Token})
-	if err != nil {
-		return err
+	// 旧版jwt形式的Token已在升级时拉黑并停用
+	if apiToken.TokenHash != "" {
+		jwtService := JwtService{}
+		err = jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: apiTokenBlackPre + apiToken.TokenHash, ExpiresAt: &apiToken.ExpiresAt})
+		if err != nil {
+			return err
+		}
+		apiTokenCache.Delete(apiToken.TokenHash)
 	}
 
 	return global.GVA_DB.Model(&apiToken).Update("status", false).Error
 }
+
+//@function: Authenticate
+//@description: 校验API Token 检查有效期 黑名单 IP段与接口范围 通过后返回Token所属用户的claims
+//@param: token string, ip string, method string, path string
+//@return: claims *sysReq.CustomClaims, err error
+
+func (apiVersion *ApiTokenService) Authenticate(token, ip, method, path string) (*sysReq.CustomClaims, error) {
+	hash := utils.SHA256Hex(token)
+	if _, ok := global.BlackCache.Get(apiTokenBlackPre + hash); ok {
+		return nil, ErrApiTokenInvalid
+	}
+	var apiToken system.SysApiToken
+	if v, ok := apiTokenCache.Get(hash); ok {
+		apiToken = v.(system.SysApiToken)
+	} else {
+		err := global.GVA_DB.Preload("User").Preload("Apis").Where("token_hash = ?", hash).First(&apiToken).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrApiTokenInvalid
+		}
+		if err != nil {
+			return nil, err
+		}
+		apiTokenCache.Set(hash, apiToken, apiTokenCacheTTL)
+	}
+	if !apiToken.Status || time.Now().After(apiToken.ExpiresAt) {
+		return nil, ErrApiTokenInvalid
+	}
+	if !ipAllowed(apiToken.AllowedCIDRs, ip) {
+		return nil, ErrApiTokenIpDenied
+	}
+	if !apiInScope(apiToken.Apis, method, path) {
+		return nil, ErrApiTokenForbidden
+	}
+	recordApiTokenUsage(apiToken.ID, ip)
+
+	return &sysReq.CustomClaims{
+		BaseClaims: sysReq.BaseClaims{
+			UUID:        apiToken.User.UUID,
+			ID:          apiToken.UserID,
+			Username:    apiToken.User.Username,
+			NickName:    apiToken.User.NickName,
+			AuthorityId: apiToken.AuthorityID,
+			TenantID:    apiToken.User.TenantID,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{"GVA"},
+			ExpiresAt: jwt.NewNumericDate(apiToken.ExpiresAt),
+			Issuer:    global.GVA_CONFIG.JWT.Issuer,
+		},
+	}, nil
+}
+
+// checkUserAuthority 凭证只能以用户已拥有的角色签发 只能为当前租户的用户签发
+func checkUserAuthority(ctx context.Context, userID, authorityID uint) error {
+	var user system.SysUser
+	if err := global.GVA_DB.WithContext(ctx).Preload("Authorities").Where("id = ?", userID).First(&user).Error; err != nil {
+		return errors.New("用户不存在")
+	}
+	if user.AuthorityId == authorityID {
+		return nil
+	}
+	for _, auth := range user.Authorities {
+		if auth.AuthorityId == authorityID {
+			return nil
+		}
+	}
+	return errors.New("用户不具备该角色权限")
+}
+
+// normalizeCIDRs 校验并规范化IP段配置 单个IP按/32或/128处理
+func normalizeCIDRs(s string) (string, error) {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return "", fmt.Errorf("无效的IP: %s", v)
+			}
+			if ip.To4() != nil {
+				v += "/32"
+			} else {
+				v += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			return "", fmt.Errorf("无效的IP段: %s", v)
+		}
+		list = append(list, ipNet.String())
+	}
+	return strings.Join(list, ","), nil
+}
+
+func ipAllowed(cidrs, ip string) bool {
+	if cidrs == "" {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, v := range strings.Split(cidrs, ",") {
+		_, ipNet, err := net.ParseCIDR(v)
+		if err == nil && ipNet.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiInScope 路径匹配规则与casbin一致 支持 /:id 形式的路径参数
+func apiInScope(apis []system.SysApi, method, path string) bool {
+	for _, api := range apis {
+		if api.Method == method && util.KeyMatch2(path, api.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func recordApiTokenUsage(id uint, ip string) {
+	apiTokenFlushOnce.Do(func() {
+		go func() {
+			defer close(apiTokenFlushDone)
+			ticker := time.NewTicker(apiTokenFlushInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					flushApiTokenUsage()
+				case <-apiTokenFlushStop:
+					flushApiTokenUsage()
+					return
+				}
+			}
+		}()
+	})
+	apiTokenUsageMu.Lock()
+	defer apiTokenUsageMu.Unlock()
+	u, ok := apiTokenUsageMap[id]
+	if !ok {
+		u = &apiTokenUsage{}
+		apiTokenUsageMap[id] = u
+	}
+	u.count++
+	u.lastAt = time.Now()
+	u.lastIp = ip
+}
+
+// StopApiTokenUsage 停止定时落库并写入内存中剩余的使用记录 超时后放弃等待
+func StopApiTokenUsage(ctx context.Context) error {
+	apiTokenStopOnce.Do(func() {
+		close(apiTokenFlushStop)
+		// 从未使用过API Token时没有启动落库协程
+		apiTokenFlushOnce.Do(func() { close(apiTokenFlushDone) })
+	})
+	select {
+	case <-apiTokenFlushDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func flushApiTokenUsage() {
+	apiTokenUsageMu.Lock()
+	usage := apiTokenUsageMap
+	apiTokenUsageMap = map[uint]*apiTokenUsage{}
+	apiTokenUsageMu.Unlock()
+	for id, u := range usage {
+		err := global.GVA_DB.Model(&system.SysApiToken{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"request_count": gorm.Expr("request_count + ?", u.count),
+			"last_used_at":  u.lastAt,
+			"last_used_ip":  u.lastIp,
+		}).Error
+		if err != nil {
+			global.GVA_LOG.Error("更新API Token使用记录失败!", zap.Error(err))
+		}
+	}
+}
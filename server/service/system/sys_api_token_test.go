@@ -0,0 +1,31 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func TestApiTokenRestrictions(t *testing.T) {
+	cidrs, err := normalizeCIDRs(" 10.0.0.0/8, 192.168.1.10 ,::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cidrs != "10.0.0.0/8,192.168.1.10/32,::1/128" {
+		t.Errorf("normalizeCIDRs() = %q", cidrs)
+	}
+	if _, err = normalizeCIDRs("10.0.0.300"); err == nil {
+		t.Errorf("normalizeCIDRs() should reject invalid ip")
+	}
+	if !ipAllowed(cidrs, "10.2.3.4") || ipAllowed(cidrs, "192.168.1.11") || !ipAllowed("", "1.1.1.1") {
+		t.Errorf("ipAllowed() mismatch")
+	}
+
+	apis := []system.SysApi{{Path: "/user/getUserList", Method: "POST"}, {Path: "/info/findInfo/:id", Method: "GET"}}
+	if !apiInScope(apis, "POST", "/user/getUserList") || !apiInScope(apis, "GET", "/info/findInfo/12") {
+		t.Errorf("apiInScope() should allow scoped apis")
+	}
+	if apiInScope(apis, "GET", "/user/getUserList") || apiInScope(apis, "POST", "/user/deleteUser") {
+		t.Errorf("apiInScope() should reject apis out of scope")
+	}
+}
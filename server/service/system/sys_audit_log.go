@@ -0,0 +1,427 @@
+package system
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 写入哈希链的源记录表
+const (
+	AuditSourceOperationRecord = "sys_operation_records"
+	AuditSourceLoginLog        = "sys_login_logs"
+)
+
+var auditSources = []string{AuditSourceLoginLog, AuditSourceOperationRecord}
+
+const (
+	auditHeadID    = 1
+	auditBatchSize = 1000
+	auditMaxIssues = 100
+	auditPageSize  = 10000
+)
+
+// ErrAuditSigningKey 未单独配置检查点签名密钥
+var ErrAuditSigningKey = errors.New("未配置audit.signing-key或与jwt的签名密钥相同")
+
+type AuditLogService struct{}
+
+var AuditLogServiceApp = new(AuditLogService)
+
+// appendAuditLog 在写入源记录的事务中追加哈希链 锁定链尾保证多实例写入时序号连续
+// 摘要按写入后从库中读出的内容计算 与校验时读取的内容一致
+// 链尾只有一行 所有实例的登录日志与操作记录写入都在这一行上排队 锁持有到事务提交
+// 操作记录由后台写入器批量写入 每批只加锁一次 登录日志每次登录加锁一次 事务中不应再有其他耗时操作
+func appendAuditLog(tx *gorm.DB, source string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	head, err := lockAuditHead(tx)
+	if err != nil {
+		return err
+	}
+	rows, err := auditRows(tx, source, ids)
+	if err != nil {
+		return err
+	}
+	sorted := append([]uint(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	entries := make([]system.SysAuditLog, 0, len(sorted))
+	for _, id := range sorted {
+		row, ok := rows[auditKey(id)]
+		if !ok {
+			return fmt.Errorf("审计源记录%s %d不存在", source, id)
+		}
+		content, err := auditContent(row)
+		if err != nil {
+			return err
+		}
+		head.Seq++
+		entry := system.SysAuditLog{Seq: head.Seq, Source: source, SourceID: id, Digest: auditDigest(content), PrevHash: head.Hash}
+		entry.Hash = auditHash(entry)
+		head.Hash = entry.Hash
+		entries = append(entries, entry)
+	}
+	if err = tx.CreateInBatches(entries, 100).Error; err != nil {
+		return err
+	}
+	return tx.Model(&head).Updates(map[string]interface{}{"seq": head.Seq, "hash": head.Hash}).Error
+}
+
+// lockAuditHead 锁定链尾 首次写入时创建
+func lockAuditHead(tx *gorm.DB) (head system.SysAuditHead, err error) {
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", auditHeadID).Take(&head).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	// 多个实例同时首次写入时只有一个能创建成功
+	if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&system.SysAuditHead{ID: auditHeadID}).Error; err != nil {
+		return
+	}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", auditHeadID).Take(&head).Error
+	return
+}
+
+//@function: VerifyAuditLog
+//@description: 完整校验审计哈希链 检查序号是否连续 哈希是否匹配 源记录是否被修改或删除 检查点签名与未写入链的源记录 由命令行执行
+//@param: archives bool 同时校验归档文件中的记录
+//@return: res response.SysAuditVerifyResult, err error
+
+func (auditLogService *AuditLogService) VerifyAuditLog(archives bool) (res response.SysAuditVerifyResult, err error) {
+	db := global.GVA_DB
+	res = response.SysAuditVerifyResult{Valid: true, Complete: true, Issues: []response.SysAuditIssue{}}
+	bySeq, err := auditCheckpoints(db, &res, 0, 0)
+	if err != nil {
+		return
+	}
+	prevSeq, prevHash, err := verifyAuditChain(db, &res, bySeq, 0, "", 0)
+	if err != nil {
+		return
+	}
+	res.LastSeq = prevSeq
+	if err = verifyAuditHead(db, &res, prevSeq, prevHash); err != nil {
+		return
+	}
+	for seq := range bySeq {
+		addAuditIssue(&res, response.SysAuditIssue{Seq: seq, Reason: "检查点对应的链上记录不存在"})
+	}
+
+	for _, source := range auditSources {
+		if err = verifyAuditSource(db, source, &res); err != nil {
+			return
+		}
+	}
+	if archives {
+		err = verifyAuditArchives(db, &res)
+	}
+	return
+}
+
+//@function: VerifyAuditLogPage
+//@description: 从检查点或上一批校验到的位置开始校验一批链上记录 供页面分批调用 未写入链的源记录与归档文件只在命令行完整校验时检查
+//@param: info systemReq.SysAuditVerify
+//@return: res response.SysAuditVerifyResult, err error
+
+func (auditLogService *AuditLogService) VerifyAuditLogPage(info systemReq.SysAuditVerify) (res response.SysAuditVerifyResult, err error) {
+	db := global.GVA_DB
+	res = response.SysAuditVerifyResult{Valid: true, Issues: []response.SysAuditIssue{}}
+	limit := info.Limit
+	if limit <= 0 || limit > auditPageSize {
+		limit = auditPageSize
+	}
+	prevHash, err := auditAnchor(db, info.FromSeq)
+	if err != nil {
+		return
+	}
+	bySeq, err := auditCheckpoints(db, &res, info.FromSeq, info.FromSeq+uint64(limit))
+	if err != nil {
+		return
+	}
+	prevSeq, prevHash, err := verifyAuditChain(db, &res, bySeq, info.FromSeq, prevHash, limit)
+	if err != nil {
+		return
+	}
+	res.LastSeq = prevSeq
+	// 不足一批时已到链尾
+	res.Complete = res.Checked < int64(limit)
+	if res.Complete {
+		if err = verifyAuditHead(db, &res, prevSeq, prevHash); err != nil {
+			return
+		}
+	}
+	for seq := range bySeq {
+		if res.Complete || seq <= prevSeq {
+			addAuditIssue(&res, response.SysAuditIssue{Seq: seq, Reason: "检查点对应的链上记录不存在"})
+		}
+	}
+	return
+}
+
+// auditAnchor 分批校验的起点哈希 优先使用签名有效的检查点 否则使用该位置的链上记录 其哈希需与内容一致
+func auditAnchor(db *gorm.DB, seq uint64) (string, error) {
+	if seq == 0 {
+		return "", nil
+	}
+	var cp system.SysAuditCheckpoint
+	if err := db.Where("seq = ?", seq).Order("id desc").Limit(1).Find(&cp).Error; err != nil {
+		return "", err
+	}
+	if signature, err := auditSignature(cp.Seq, cp.Hash); cp.ID != 0 && err == nil && hmac.Equal([]byte(cp.Signature), []byte(signature)) {
+		return cp.Hash, nil
+	}
+	var entry system.SysAuditLog
+	if err := db.Where("seq = ?", seq).Limit(1).Find(&entry).Error; err != nil {
+		return "", err
+	}
+	if entry.Seq == 0 || auditHash(entry) != entry.Hash {
+		return "", fmt.Errorf("位置%d没有有效的检查点或链上记录", seq)
+	}
+	return entry.Hash, nil
+}
+
+// auditCheckpoints 读取(from, to]之间的检查点并校验签名 to为0时不限 按位置返回签名有效的检查点
+func auditCheckpoints(db *gorm.DB, res *response.SysAuditVerifyResult, from, to uint64) (map[uint64][]system.SysAuditCheckpoint, error) {
+	tx := db.Where("seq > ?", from)
+	if to > 0 {
+		tx = tx.Where("seq <= ?", to)
+	}
+	var checkpoints []system.SysAuditCheckpoint
+	if err := tx.Order("seq").Find(&checkpoints).Error; err != nil {
+		return nil, err
+	}
+	bySeq := make(map[uint64][]system.SysAuditCheckpoint, len(checkpoints))
+	for _, cp := range checkpoints {
+		res.Checkpoints++
+		signature, err := auditSignature(cp.Seq, cp.Hash)
+		if err != nil {
+			addAuditIssue(res, response.SysAuditIssue{Seq: cp.Seq, Reason: "无法校验检查点签名: " + err.Error()})
+			continue
+		}
+		if !hmac.Equal([]byte(cp.Signature), []byte(signature)) {
+			addAuditIssue(res, response.SysAuditIssue{Seq: cp.Seq, Reason: "检查点签名无效"})
+			continue
+		}
+		bySeq[cp.Seq] = append(bySeq[cp.Seq], cp)
+	}
+	return bySeq, nil
+}
+
+// verifyAuditChain 从prevSeq之后逐批校验链上记录与源记录 limit为0时校验到链尾 返回最后校验的位置与哈希
+// 校验过的检查点从bySeq中移除
+func verifyAuditChain(db *gorm.DB, res *response.SysAuditVerifyResult, bySeq map[uint64][]system.SysAuditCheckpoint, prevSeq uint64, prevHash string, limit int) (uint64, string, error) {
+	for remaining := limit; limit == 0 || remaining > 0; remaining -= auditBatchSize {
+		size := auditBatchSize
+		if limit > 0 {
+			size = min(size, remaining)
+		}
+		var entries []system.SysAuditLog
+		if err := db.Where("seq > ?", prevSeq).Order("seq").Limit(size).Find(&entries).Error; err != nil {
+			return prevSeq, prevHash, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		live := make(map[string][]uint)
+		for _, e := range entries {
+			if e.Seq != prevSeq+1 {
+				addAuditIssue(res, auditIssue(e, fmt.Sprintf("序号不连续 缺少%d-%d", prevSeq+1, e.Seq-1)))
+			}
+			if e.PrevHash != prevHash {
+				addAuditIssue(res, auditIssue(e, "与上一条记录的哈希不一致"))
+			}
+			if auditHash(e) != e.Hash {
+				addAuditIssue(res, auditIssue(e, "哈希不匹配 链上记录被修改"))
+			}
+			for _, cp := range bySeq[e.Seq] {
+				if cp.Hash != e.Hash {
+					addAuditIssue(res, auditIssue(e, "与检查点的哈希不一致"))
+				}
+			}
+			delete(bySeq, e.Seq)
+			prevSeq, prevHash = e.Seq, e.Hash
+			res.Checked++
+			if e.Archive != "" {
+				res.Archived++
+				continue
+			}
+			live[e.Source] = append(live[e.Source], e.SourceID)
+		}
+		rows := make(map[string]map[string]map[string]interface{}, len(live))
+		for source, ids := range live {
+			var err error
+			if rows[source], err = auditRows(db, source, ids); err != nil {
+				return prevSeq, prevHash, err
+			}
+		}
+		for _, e := range entries {
+			if e.Archive != "" {
+				continue
+			}
+			row, ok := rows[e.Source][auditKey(e.SourceID)]
+			if !ok {
+				addAuditIssue(res, auditIssue(e, "源记录已被删除"))
+				continue
+			}
+			if content, cerr := auditContent(row); cerr != nil || auditDigest(content) != e.Digest {
+				addAuditIssue(res, auditIssue(e, "源记录已被修改"))
+			}
+		}
+		if len(entries) < size {
+			break
+		}
+	}
+	return prevSeq, prevHash, nil
+}
+
+// verifyAuditHead 链尾之后的记录被整体删除时 链本身仍然连续 需要对照链尾
+func verifyAuditHead(db *gorm.DB, res *response.SysAuditVerifyResult, lastSeq uint64, lastHash string) error {
+	var head system.SysAuditHead
+	if err := db.Where("id = ?", auditHeadID).Limit(1).Find(&head).Error; err != nil {
+		return err
+	}
+	if head.Seq != lastSeq || head.Hash != lastHash {
+		addAuditIssue(res, response.SysAuditIssue{Seq: head.Seq, Reason: fmt.Sprintf("链尾位置%d与链上最新记录%d不一致", head.Seq, lastSeq)})
+	}
+	return nil
+}
+
+// verifyAuditSource 检查链开始之后写入但不在链上的源记录
+func verifyAuditSource(db *gorm.DB, source string, res *response.SysAuditVerifyResult) error {
+	var first system.SysAuditLog
+	if err := db.Where("source = ?", source).Order("seq").Limit(1).Find(&first).Error; err != nil {
+		return err
+	}
+	if first.Seq == 0 {
+		return nil
+	}
+	var ids []uint
+	err := db.Table(source).Where("id >= ?", first.SourceID).
+		Where("NOT EXISTS (?)", db.Model(&system.SysAuditLog{}).Select("1").Where("source = ? AND source_id = "+source+".id", source)).
+		Order("id").Limit(auditMaxIssues).Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		addAuditIssue(res, response.SysAuditIssue{Source: source, SourceID: id, Reason: "源记录未写入审计日志"})
+	}
+	return nil
+}
+
+//@function: CreateAuditCheckpoint
+//@description: 对链尾签名生成检查点 链尾没有变化时跳过
+//@return: err error
+
+func (auditLogService *AuditLogService) CreateAuditCheckpoint() (err error) {
+	db := global.GVA_DB
+	var head system.SysAuditHead
+	if err = db.Where("id = ?", auditHeadID).Limit(1).Find(&head).Error; err != nil || head.Seq == 0 {
+		return
+	}
+	var last system.SysAuditCheckpoint
+	if err = db.Order("seq desc").Limit(1).Find(&last).Error; err != nil || last.Seq == head.Seq {
+		return
+	}
+	// 链尾被篡改时不应为其签名
+	var entry system.SysAuditLog
+	if err = db.Where("seq = ?", head.Seq).Limit(1).Find(&entry).Error; err != nil {
+		return
+	}
+	if entry.Hash != head.Hash {
+		return errors.New("链尾与链上记录不一致 请先校验审计日志")
+	}
+	signature, err := auditSignature(head.Seq, head.Hash)
+	if err != nil {
+		return
+	}
+	checkpoint := system.SysAuditCheckpoint{Seq: head.Seq, Hash: head.Hash, Signature: signature}
+	return db.Create(&checkpoint).Error
+}
+
+// addAuditIssue 记录问题 只保留前auditMaxIssues个
+func addAuditIssue(res *response.SysAuditVerifyResult, issue response.SysAuditIssue) {
+	res.Valid = false
+	if len(res.Issues) < auditMaxIssues {
+		res.Issues = append(res.Issues, issue)
+	}
+}
+
+func auditIssue(e system.SysAuditLog, reason string) response.SysAuditIssue {
+	return response.SysAuditIssue{Seq: e.Seq, Source: e.Source, SourceID: e.SourceID, Reason: reason}
+}
+
+// auditRows 按主键读取源记录 包含软删除的记录 以主键字符串为键
+func auditRows(db *gorm.DB, source string, ids []uint) (map[string]map[string]interface{}, error) {
+	rows := make(map[string]map[string]interface{}, len(ids))
+	for start := 0; start < len(ids); start += auditBatchSize {
+		end := min(start+auditBatchSize, len(ids))
+		var list []map[string]interface{}
+		if err := db.Table(source).Where("id IN ?", ids[start:end]).Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range list {
+			rows[fmt.Sprint(row["id"])] = row
+		}
+	}
+	return rows, nil
+}
+
+func auditKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// auditContent 源记录的规范化内容 字段按名称排序 时间统一为UTC
+// 值为NULL的字段不参与摘要 源表后续新增可为空的字段时 已有记录的摘要保持不变
+func auditContent(row map[string]interface{}) ([]byte, error) {
+	normalized := make(map[string]interface{}, len(row))
+	for k, v := range row {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case []byte:
+			v = string(val)
+		case time.Time:
+			v = val.UTC().Format(time.RFC3339Nano)
+		case *time.Time:
+			if val == nil {
+				continue
+			}
+			v = val.UTC().Format(time.RFC3339Nano)
+		}
+		normalized[k] = v
+	}
+	return json.Marshal(normalized)
+}
+
+func auditDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func auditHash(e system.SysAuditLog) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%d|%s|%s", e.Seq, e.Source, e.SourceID, e.Digest, e.PrevHash)))
+	return hex.EncodeToString(sum[:])
+}
+
+// auditSignature 检查点签名 需要单独配置签名密钥 jwt的签名密钥泄露时不能用来伪造检查点
+func auditSignature(seq uint64, hash string) (string, error) {
+	key := global.GVA_CONFIG.Audit.SigningKey
+	if key == "" || key == global.GVA_CONFIG.JWT.SigningKey {
+		return "", ErrAuditSigningKey
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(fmt.Sprintf("%d|%s", seq, hash)))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
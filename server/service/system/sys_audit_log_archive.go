@@ -0,0 +1,247 @@
+package system
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"gorm.io/gorm"
+)
+
+// auditArchiveLine 归档文件每行一条源记录 Row为计算摘要时的规范化内容
+type auditArchiveLine struct {
+	Seq      uint64          `json:"seq"`
+	Source   string          `json:"source"`
+	SourceID uint            `json:"sourceId"`
+	Row      json.RawMessage `json:"row"`
+}
+
+func auditArchiveDir() string {
+	if dir := global.GVA_CONFIG.Audit.ArchiveDir; dir != "" {
+		return dir
+	}
+	return "archive/audit"
+}
+
+//@function: ArchiveAuditLog
+//@description: 将超出保留天数的源记录写入归档文件后从库中删除 链上记录保留并标记归档文件 链的完整性不受影响
+//@return: count int64, err error
+
+func (auditLogService *AuditLogService) ArchiveAuditLog() (count int64, err error) {
+	days := global.GVA_CONFIG.Audit.RetentionDays
+	if days <= 0 {
+		return 0, nil
+	}
+	db := global.GVA_DB
+	cutoff := time.Now().AddDate(0, 0, -days)
+	var f *os.File
+	var name string
+	defer func() {
+		if f == nil {
+			return
+		}
+		f.Close()
+		if count == 0 {
+			os.Remove(f.Name())
+		}
+	}()
+
+	var lastSeq uint64
+	for {
+		var entries []system.SysAuditLog
+		err = db.Where("seq > ? AND archive = ? AND created_at < ?", lastSeq, "", cutoff).Order("seq").Limit(auditBatchSize).Find(&entries).Error
+		if err != nil || len(entries) == 0 {
+			return
+		}
+		lastSeq = entries[len(entries)-1].Seq
+		if f == nil {
+			dir := auditArchiveDir()
+			if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+				return
+			}
+			name = "audit-" + time.Now().Format("20060102150405") + ".jsonl"
+			if f, err = os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644); err != nil {
+				return
+			}
+		}
+		var n int64
+		if n, err = archiveAuditEntries(db, f, name, entries); err != nil {
+			return
+		}
+		count += n
+		if len(entries) < auditBatchSize {
+			return
+		}
+	}
+}
+
+// archiveAuditEntries 先写入并落盘归档文件 再在事务中标记链上记录并删除源记录
+// 事务失败时截掉本批写入的内容 保证归档文件与链上标记一致
+func archiveAuditEntries(db *gorm.DB, f *os.File, name string, entries []system.SysAuditLog) (int64, error) {
+	ids := make(map[string][]uint)
+	for _, e := range entries {
+		ids[e.Source] = append(ids[e.Source], e.SourceID)
+	}
+	rows := make(map[string]map[string]map[string]interface{}, len(ids))
+	for source, list := range ids {
+		var err error
+		if rows[source], err = auditRows(db, source, list); err != nil {
+			return 0, err
+		}
+	}
+
+	var buf bytes.Buffer
+	var seqs []uint64
+	archived := make(map[string][]uint)
+	for _, e := range entries {
+		// 源记录缺失时不归档 留给校验发现
+		row, ok := rows[e.Source][auditKey(e.SourceID)]
+		if !ok {
+			continue
+		}
+		content, err := auditContent(row)
+		if err != nil {
+			return 0, err
+		}
+		line, err := json.Marshal(auditArchiveLine{Seq: e.Seq, Source: e.Source, SourceID: e.SourceID, Row: content})
+		if err != nil {
+			return 0, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		seqs = append(seqs, e.Seq)
+		archived[e.Source] = append(archived[e.Source], e.SourceID)
+	}
+	if len(seqs) == 0 {
+		return 0, nil
+	}
+
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = f.Write(buf.Bytes()); err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			// 其他实例已归档同一批记录时放弃本批
+			result := tx.Model(&system.SysAuditLog{}).Where("seq IN ? AND archive = ?", seqs, "").Update("archive", name)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(seqs)) {
+				return errors.New("审计日志正在被其他实例归档")
+			}
+			for source, list := range archived {
+				if err := tx.Exec("DELETE FROM "+source+" WHERE id IN ?", list).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		if terr := f.Truncate(offset); terr == nil {
+			_, _ = f.Seek(offset, io.SeekStart)
+		}
+		return 0, err
+	}
+	return int64(len(seqs)), nil
+}
+
+// verifyAuditArchives 校验归档文件中的源记录与链上的摘要一致 且链上标记的记录都在归档文件中
+func verifyAuditArchives(db *gorm.DB, res *response.SysAuditVerifyResult) error {
+	var names []string
+	if err := db.Model(&system.SysAuditLog{}).Where("archive <> ?", "").Distinct("archive").Pluck("archive", &names).Error; err != nil {
+		return err
+	}
+	for _, name := range names {
+		var total int64
+		if err := db.Model(&system.SysAuditLog{}).Where("archive = ?", name).Count(&total).Error; err != nil {
+			return err
+		}
+		found, err := verifyAuditArchive(db, name, res)
+		if errors.Is(err, os.ErrNotExist) {
+			addAuditIssue(res, response.SysAuditIssue{Reason: "归档文件" + name + "不存在"})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if found != total {
+			addAuditIssue(res, response.SysAuditIssue{Reason: fmt.Sprintf("归档文件%s中有%d条记录 链上标记了%d条", name, found, total)})
+		}
+	}
+	return nil
+}
+
+func verifyAuditArchive(db *gorm.DB, name string, res *response.SysAuditVerifyResult) (found int64, err error) {
+	f, err := os.Open(filepath.Join(auditArchiveDir(), name))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	check := func(lines []auditArchiveLine) error {
+		seqs := make([]uint64, 0, len(lines))
+		for _, line := range lines {
+			seqs = append(seqs, line.Seq)
+		}
+		var entries []system.SysAuditLog
+		if err := db.Where("seq IN ?", seqs).Find(&entries).Error; err != nil {
+			return err
+		}
+		bySeq := make(map[uint64]system.SysAuditLog, len(entries))
+		for _, e := range entries {
+			bySeq[e.Seq] = e
+		}
+		for _, line := range lines {
+			e, ok := bySeq[line.Seq]
+			if !ok || e.Archive != name || e.Source != line.Source || e.SourceID != line.SourceID {
+				addAuditIssue(res, response.SysAuditIssue{Seq: line.Seq, Source: line.Source, SourceID: line.SourceID, Reason: "归档记录与链上记录不对应"})
+				continue
+			}
+			found++
+			if auditDigest(line.Row) != e.Digest {
+				addAuditIssue(res, auditIssue(e, "归档记录已被修改"))
+			}
+		}
+		return nil
+	}
+
+	r := bufio.NewReader(f)
+	lines := make([]auditArchiveLine, 0, auditBatchSize)
+	for {
+		b, rerr := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(b)) > 0 {
+			var line auditArchiveLine
+			if err = json.Unmarshal(b, &line); err != nil {
+				addAuditIssue(res, response.SysAuditIssue{Reason: "归档文件" + name + "格式错误"})
+				return found, nil
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == auditBatchSize || rerr != nil && len(lines) > 0 {
+			if err = check(lines); err != nil {
+				return
+			}
+			lines = lines[:0]
+		}
+		if rerr == io.EOF {
+			return found, nil
+		}
+		if rerr != nil {
+			return found, rerr
+		}
+	}
+}
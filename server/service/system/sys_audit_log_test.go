@@ -0,0 +1,123 @@
+package system
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAuditLog(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_CONFIG.JWT.SigningKey = "test"
+	global.GVA_CONFIG.Audit.SigningKey = "test"
+	global.GVA_CONFIG.Audit.RetentionDays = 30
+	global.GVA_CONFIG.Audit.ArchiveDir = t.TempDir()
+	if err = db.AutoMigrate(&system.SysOperationRecord{}, &system.SysLoginLog{}, &system.SysAuditLog{}, &system.SysAuditHead{}, &system.SysAuditCheckpoint{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, username := range []string{"admin", "guest"} {
+		if err = LoginLogServiceApp.CreateLoginLog(system.SysLoginLog{Username: username, Status: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	records := []system.SysOperationRecord{{Path: "/a"}, {Path: "/b", Body: `{"id":1}`}, {Path: "/c"}}
+	if err = createOperationRecords(records, 2); err != nil {
+		t.Fatal(err)
+	}
+	// 检查点必须使用单独的签名密钥
+	if err = AuditLogServiceApp.CreateAuditCheckpoint(); !errors.Is(err, ErrAuditSigningKey) {
+		t.Fatalf("signing key equal to the jwt key: want ErrAuditSigningKey, got %v", err)
+	}
+	global.GVA_CONFIG.Audit.SigningKey = "audit"
+	if err = AuditLogServiceApp.CreateAuditCheckpoint(); err != nil {
+		t.Fatal(err)
+	}
+
+	verify := func(name string, archives, valid bool) {
+		t.Helper()
+		res, err := AuditLogServiceApp.VerifyAuditLog(archives)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Valid != valid || res.Checked != 5 || res.Checkpoints != 1 {
+			t.Errorf("%s: valid %v checked %d checkpoints %d issues %+v, want valid %v", name, res.Valid, res.Checked, res.Checkpoints, res.Issues, valid)
+		}
+	}
+	verify("intact", false, true)
+
+	// 分批校验 从检查点或上一批的位置继续
+	page := func(from uint64, limit int) response.SysAuditVerifyResult {
+		t.Helper()
+		res, err := AuditLogServiceApp.VerifyAuditLogPage(systemReq.SysAuditVerify{FromSeq: from, Limit: limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	if res := page(0, 3); !res.Valid || res.Complete || res.Checked != 3 || res.LastSeq != 3 {
+		t.Errorf("first page: %+v", res)
+	}
+	if res := page(3, 3); !res.Valid || !res.Complete || res.Checked != 2 || res.LastSeq != 5 || res.Checkpoints != 1 {
+		t.Errorf("last page: %+v", res)
+	}
+	if res := page(5, 3); !res.Valid || !res.Complete || res.Checked != 0 {
+		t.Errorf("page from the checkpoint: %+v", res)
+	}
+	if _, err = AuditLogServiceApp.VerifyAuditLogPage(systemReq.SysAuditVerify{FromSeq: 9}); err == nil {
+		t.Error("page from a position without a checkpoint or chain record should be rejected")
+	}
+	// 源表升级新增可为空的字段后 已有记录仍然有效
+	db.Exec("ALTER TABLE sys_login_logs ADD COLUMN upgraded_at datetime")
+	verify("column added", false, true)
+
+	db.Exec("UPDATE sys_operation_records SET path = ? WHERE id = ?", "/x", records[1].ID)
+	verify("modified", false, false)
+	db.Exec("UPDATE sys_operation_records SET path = ? WHERE id = ?", "/b", records[1].ID)
+	verify("restored", false, true)
+
+	db.Create(&system.SysOperationRecord{Path: "/forged"})
+	verify("forged", false, false)
+	db.Exec("DELETE FROM sys_operation_records WHERE path = ?", "/forged")
+
+	// 归档后源记录从库中删除 链与归档文件仍可校验
+	db.Exec("UPDATE sys_audit_logs SET created_at = ?", time.Now().AddDate(0, 0, -31))
+	count, err := AuditLogServiceApp.ArchiveAuditLog()
+	if err != nil || count != 5 {
+		t.Fatalf("archived %d records: %v, want 5", count, err)
+	}
+	var left int64
+	db.Model(&system.SysOperationRecord{}).Count(&left)
+	if left != 0 {
+		t.Errorf("%d operation records left after archiving", left)
+	}
+	verify("archived", true, true)
+
+	db.Exec("DELETE FROM sys_audit_logs WHERE seq = ?", 5)
+	res, _ := AuditLogServiceApp.VerifyAuditLog(false)
+	if res.Valid || res.LastSeq != 4 {
+		t.Errorf("truncated chain: valid %v last seq %d, want invalid at 4", res.Valid, res.LastSeq)
+	}
+	if res = page(3, 3); res.Valid || !res.Complete {
+		t.Errorf("truncated chain in the last page: %+v", res)
+	}
+
+	// 签名密钥变化后已有检查点不再有效
+	global.GVA_CONFIG.Audit.SigningKey = "other"
+	if res, _ = AuditLogServiceApp.VerifyAuditLog(false); res.Valid {
+		t.Error("checkpoint signed with another key should be reported")
+	}
+}
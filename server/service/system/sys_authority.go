@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"strconv"
 
@@ -18,20 +19,22 @@ var ErrRoleExistence = errors.New("存在相同角色id")
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: CreateAuthority
 //@description: 创建一个角色
-//@param: auth model.SysAuthority
+//@param: ctx context.Context, auth model.SysAuthority
 //@return: authority system.SysAuthority, err error
 
 type AuthorityService struct{}
 
 var AuthorityServiceApp = new(AuthorityService)
 
-func (authorityService *AuthorityService) CreateAuthority(auth system.SysAuthority) (authority system.SysAuthority, err error) {
-
+func (authorityService *AuthorityService) CreateAuthority(ctx context.Context, auth system.SysAuthority) (authority system.SysAuthority, err error) {
+	// 角色ID在全部租户内唯一
 	if err = global.GVA_DB.Where("authority_id = ?", auth.AuthorityId).First(&system.SysAuthority{}).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
 		return auth, ErrRoleExistence
 	}
+	// 新角色使用默认的数据权限范围 需要通过SetDataAuthority设置
+	auth.DataScope, auth.DataScopeSQL = "", ""
 
-	e := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	e := global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 
 		if err = tx.Create(&auth).Error; err != nil {
 			return err
@@ -45,9 +48,12 @@ func (authorityService *AuthorityService) CreateAuthority(auth system.SysAuthori
 		authorityId := strconv.Itoa(int(auth.AuthorityId))
 		rules := [][]string{}
 		for _, v := range casbinInfos {
-			rules = append(rules, []string{authorityId, v.Path, v.Method})
+			rules = append(rules, []string{authorityId, v.Path, v.Method, v.EffectOrDefault()})
 		}
-		return CasbinServiceApp.AddPolicies(tx, rules)
+		if err = CasbinServiceApp.AddPolicies(tx, rules); err != nil {
+			return err
+		}
+		return CasbinServiceApp.SetAuthorityParent(tx, auth.AuthorityId, inheritedParentID(auth))
 	})
 
 	return auth, e
@@ -56,15 +62,21 @@ func (authorityService *AuthorityService) CreateAuthority(auth system.SysAuthori
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: CopyAuthority
 //@description: 复制一个角色
-//@param: copyInfo response.SysAuthorityCopyResponse
+//@param: ctx context.Context, adminAuthorityID uint, copyInfo response.SysAuthorityCopyResponse
 //@return: authority system.SysAuthority, err error
 
-func (authorityService *AuthorityService) CopyAuthority(adminAuthorityID uint, copyInfo response.SysAuthorityCopyResponse) (authority system.SysAuthority, err error) {
+func (authorityService *AuthorityService) CopyAuthority(ctx context.Context, adminAuthorityID uint, copyInfo response.SysAuthorityCopyResponse) (authority system.SysAuthority, err error) {
 	var authorityBox system.SysAuthority
 	if !errors.Is(global.GVA_DB.Where("authority_id = ?", copyInfo.Authority.AuthorityId).First(&authorityBox).Error, gorm.ErrRecordNotFound) {
 		return authority, ErrRoleExistence
 	}
 	copyInfo.Authority.Children = []system.SysAuthority{}
+	// 数据权限范围沿用被复制的角色
+	var oldAuthority system.SysAuthority
+	if err = global.GVA_DB.WithContext(ctx).Where("authority_id = ?", copyInfo.OldAuthorityId).First(&oldAuthority).Error; err != nil {
+		return
+	}
+	copyInfo.Authority.DataScope, copyInfo.Authority.DataScopeSQL = oldAuthority.DataScope, oldAuthority.DataScopeSQL
 	menus, err := MenuServiceApp.GetMenuAuthority(&request.GetAuthorityId{AuthorityId: copyInfo.OldAuthorityId})
 	if err != nil {
 		return
@@ -76,7 +88,12 @@ func (authorityService *AuthorityService) CopyAuthority(adminAuthorityID uint, c
 		baseMenu = append(baseMenu, v.SysBaseMenu)
 	}
 	copyInfo.Authority.SysBaseMenus = baseMenu
-	err = global.GVA_DB.Create(&copyInfo.Authority).Error
+	err = global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&copyInfo.Authority).Error; err != nil {
+			return err
+		}
+		return CasbinServiceApp.SetAuthorityParent(tx, copyInfo.Authority.AuthorityId, inheritedParentID(copyInfo.Authority))
+	})
 	if err != nil {
 		return
 	}
@@ -97,10 +114,24 @@ func (authorityService *AuthorityService) CopyAuthority(adminAuthorityID uint, c
 			return
 		}
 	}
+
+	var fields []system.SysAuthorityField
+	err = global.GVA_DB.Find(&fields, "authority_id = ?", copyInfo.OldAuthorityId).Error
+	if err != nil {
+		return
+	}
+	if len(fields) > 0 {
+		for i := range fields {
+			fields[i].AuthorityId = copyInfo.Authority.AuthorityId
+		}
+		if err = global.GVA_DB.Create(&fields).Error; err != nil {
+			return
+		}
+	}
 	paths := CasbinServiceApp.GetPolicyPathByAuthorityId(copyInfo.OldAuthorityId)
 	err = CasbinServiceApp.UpdateCasbin(adminAuthorityID, copyInfo.Authority.AuthorityId, paths)
 	if err != nil {
-		_ = authorityService.DeleteAuthority(&copyInfo.Authority)
+		_ = authorityService.DeleteAuthority(ctx, &copyInfo.Authority)
 	}
 	return copyInfo.Authority, err
 }
@@ -108,28 +139,95 @@ func (authorityService *AuthorityService) CopyAuthority(adminAuthorityID uint, c
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: UpdateAuthority
 //@description: 更改一个角色
-//@param: auth model.SysAuthority
+//@param: ctx context.Context, auth model.SysAuthority
 //@return: authority system.SysAuthority, err error
 
-func (authorityService *AuthorityService) UpdateAuthority(auth system.SysAuthority) (authority system.SysAuthority, err error) {
+func (authorityService *AuthorityService) UpdateAuthority(ctx context.Context, auth system.SysAuthority) (authority system.SysAuthority, err error) {
+	db := global.GVA_DB.WithContext(ctx)
 	var oldAuthority system.SysAuthority
-	err = global.GVA_DB.Where("authority_id = ?", auth.AuthorityId).First(&oldAuthority).Error
+	err = db.Where("authority_id = ?", auth.AuthorityId).First(&oldAuthority).Error
 	if err != nil {
 		global.GVA_LOG.Debug(err.Error())
 		return system.SysAuthority{}, errors.New("查询角色数据失败")
 	}
-	err = global.GVA_DB.Model(&oldAuthority).Updates(&auth).Error
+	// 数据权限范围需要校验 只能通过SetDataAuthority设置
+	auth.DataScope, auth.DataScopeSQL = "", ""
+	parentChanged := auth.ParentId != nil && authorityParentID(auth.ParentId) != authorityParentID(oldAuthority.ParentId)
+	inheritChanged := auth.InheritParent != nil && *auth.InheritParent != inheritsParent(oldAuthority)
+	if !parentChanged && !inheritChanged {
+		err = db.Model(&oldAuthority).Updates(&auth).Error
+		return auth, err
+	}
+	if parentChanged {
+		if err = authorityService.checkParent(db, auth.AuthorityId, *auth.ParentId); err != nil {
+			return auth, err
+		}
+	}
+	merged := oldAuthority
+	if auth.ParentId != nil {
+		merged.ParentId = auth.ParentId
+	}
+	if auth.InheritParent != nil {
+		merged.InheritParent = auth.InheritParent
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&oldAuthority).Updates(&auth).Error; err != nil {
+			return err
+		}
+		// Updates会忽略零值 改为顶级角色时需要单独更新
+		if parentChanged && *auth.ParentId == 0 {
+			if err := tx.Model(&oldAuthority).Update("parent_id", 0).Error; err != nil {
+				return err
+			}
+		}
+		return CasbinServiceApp.SetAuthorityParent(tx, auth.AuthorityId, inheritedParentID(merged))
+	})
 	return auth, err
 }
 
+// checkParent 父角色必须存在于同一租户 且不能是角色自身或其下级角色 避免继承关系成环
+func (authorityService *AuthorityService) checkParent(db *gorm.DB, authorityID, parentID uint) error {
+	for id := parentID; id != 0; {
+		if id == authorityID {
+			return errors.New("父角色不能是自身或下级角色")
+		}
+		var parent system.SysAuthority
+		if err := db.Select("authority_id", "parent_id").Where("authority_id = ?", id).First(&parent).Error; err != nil {
+			return errors.New("父角色不存在")
+		}
+		id = authorityParentID(parent.ParentId)
+	}
+	return nil
+}
+
+func authorityParentID(p *uint) uint {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// inheritsParent 角色是否开启了继承父角色 升级前的角色默认不继承 避免权限被意外扩大
+func inheritsParent(auth system.SysAuthority) bool {
+	return auth.InheritParent != nil && *auth.InheritParent
+}
+
+// inheritedParentID 需要通过g规则继承的父角色 未开启继承时为0
+func inheritedParentID(auth system.SysAuthority) uint {
+	if !inheritsParent(auth) {
+		return 0
+	}
+	return authorityParentID(auth.ParentId)
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: DeleteAuthority
 //@description: 删除角色
-//@param: auth *model.SysAuthority
+//@param: ctx context.Context, auth *model.SysAuthority
 //@return: err error
 
-func (authorityService *AuthorityService) DeleteAuthority(auth *system.SysAuthority) error {
-	if errors.Is(global.GVA_DB.Debug().Preload("Users").First(&auth).Error, gorm.ErrRecordNotFound) {
+func (authorityService *AuthorityService) DeleteAuthority(ctx context.Context, auth *system.SysAuthority) error {
+	if errors.Is(global.GVA_DB.WithContext(ctx).Debug().Preload("Users").First(&auth).Error, gorm.ErrRecordNotFound) {
 		return errors.New("该角色不存在")
 	}
 	if len(auth.Users) != 0 {
@@ -166,6 +264,9 @@ func (authorityService *AuthorityService) DeleteAuthority(auth *system.SysAuthor
 		if err = tx.Where("authority_id = ?", auth.AuthorityId).Delete(&[]system.SysAuthorityBtn{}).Error; err != nil {
 			return err
 		}
+		if err = tx.Where("authority_id = ?", auth.AuthorityId).Delete(&[]system.SysAuthorityField{}).Error; err != nil {
+			return err
+		}
 
 		authorityId := strconv.Itoa(int(auth.AuthorityId))
 
@@ -173,24 +274,25 @@ func (authorityService *AuthorityService) DeleteAuthority(auth *system.SysAuthor
 			return err
 		}
 
-		return nil
+		return CasbinServiceApp.SetAuthorityParent(tx, auth.AuthorityId, 0)
 	})
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetAuthorityInfoList
 //@description: 分页获取数据
-//@param: info request.PageInfo
+//@param: ctx context.Context, authorityID uint
 //@return: list interface{}, total int64, err error
 
-func (authorityService *AuthorityService) GetAuthorityInfoList(authorityID uint) (list []system.SysAuthority, err error) {
+func (authorityService *AuthorityService) GetAuthorityInfoList(ctx context.Context, authorityID uint) (list []system.SysAuthority, err error) {
+	// 平台用户切换租户后自身角色不在当前租户内 因此不按租户查询
 	var authority system.SysAuthority
 	err = global.GVA_DB.Where("authority_id = ?", authorityID).First(&authority).Error
 	if err != nil {
 		return nil, err
 	}
 	var authorities []system.SysAuthority
-	db := global.GVA_DB.Model(&system.SysAuthority{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysAuthority{})
 	if global.GVA_CONFIG.System.UseStrictAuth {
 		// 当开启了严格树形结构后
 		if *authority.ParentId == 0 {
@@ -270,11 +372,11 @@ func (authorityService *AuthorityService) GetAuthorityInfo(auth system.SysAuthor
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: SetDataAuthority
-//@description: 设置角色资源权限
-//@param: auth model.SysAuthority
+//@description: 设置角色资源权限与数据权限范围 目标角色与资源角色需属于当前租户
+//@param: ctx context.Context, adminAuthorityID uint, auth model.SysAuthority
 //@return: error
 
-func (authorityService *AuthorityService) SetDataAuthority(adminAuthorityID uint, auth system.SysAuthority) error {
+func (authorityService *AuthorityService) SetDataAuthority(ctx context.Context, adminAuthorityID uint, auth system.SysAuthority) error {
 	var checkIDs []uint
 	checkIDs = append(checkIDs, auth.AuthorityId)
 	for i := range auth.DataAuthorityId {
@@ -288,10 +390,49 @@ func (authorityService *AuthorityService) SetDataAuthority(adminAuthorityID uint
 		}
 	}
 
+	if auth.DataScope == "" {
+		auth.DataScope = system.DataScopeList
+	}
+	if err := DataScopeServiceApp.CheckDataScope(auth.DataScope, auth.DataScopeSQL); err != nil {
+		return err
+	}
+	if auth.DataScope != system.DataScopeSQL {
+		auth.DataScopeSQL = ""
+	}
+
+	db := global.GVA_DB.WithContext(ctx)
 	var s system.SysAuthority
-	global.GVA_DB.Preload("DataAuthorityId").First(&s, "authority_id = ?", auth.AuthorityId)
-	err := global.GVA_DB.Model(&s).Association("DataAuthorityId").Replace(&auth.DataAuthorityId)
-	return err
+	if err := db.Preload("DataAuthorityId").First(&s, "authority_id = ?", auth.AuthorityId).Error; err != nil {
+		return err
+	}
+	dataIDs := make(map[uint]bool, len(auth.DataAuthorityId))
+	for i := range auth.DataAuthorityId {
+		dataIDs[auth.DataAuthorityId[i].AuthorityId] = true
+	}
+	if len(dataIDs) > 0 {
+		ids := make([]uint, 0, len(dataIDs))
+		for id := range dataIDs {
+			ids = append(ids, id)
+		}
+		var count int64
+		if err := db.Model(&system.SysAuthority{}).Where("authority_id IN ?", ids).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(ids)) {
+			return errors.New("资源权限中的角色不存在")
+		}
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&s).Updates(map[string]interface{}{
+			"data_scope":     auth.DataScope,
+			"data_scope_sql": auth.DataScopeSQL,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// 资源角色已校验存在 只写关联表 不回写角色本身
+		return tx.Model(&s).Omit("DataAuthorityId.*").Association("DataAuthorityId").Replace(&auth.DataAuthorityId)
+	})
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -2,13 +2,31 @@ package system
 
 import (
 	"errors"
+	"strconv"
+	"time"
+
+	"github.com/casbin/casbin/v2/util"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/songzhibin97/gkit/cache/local_cache"
 	"gorm.io/gorm"
 )
 
+// btnApiTTL 按钮与api的关联及角色的按钮只缓存在本地 其他实例修改后最多延迟该时间生效
+const btnApiTTL = 10 * time.Second
+
+var btnApiCache = local_cache.NewCache(local_cache.SetDefaultExpire(btnApiTTL))
+
+// btnApi 按钮关联的api
+type btnApi struct {
+	SysBaseMenuBtnID uint
+	Path             string
+	Method           string
+}
+
 type AuthorityBtnService struct{}
 
 var AuthorityBtnServiceApp = new(AuthorityBtnService)
@@ -27,8 +45,15 @@ func (a *AuthorityBtnService) GetAuthorityBtn(req request.SysAuthorityBtnReq) (r
 	return res, err
 }
 
-func (a *AuthorityBtnService) SetAuthorityBtn(req request.SysAuthorityBtnReq) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+// SetAuthorityBtn 设置角色在菜单上的按钮 并为按钮关联的api补充casbin策略
+func (a *AuthorityBtnService) SetAuthorityBtn(adminAuthorityID uint, req request.SysAuthorityBtnReq) (err error) {
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, req.AuthorityId); err != nil {
+		return err
+	}
+	if err = a.checkBtnApiAuth(adminAuthorityID, req.Selected); err != nil {
+		return err
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		var authorityBtn []system.SysAuthorityBtn
 		err = tx.Delete(&[]system.SysAuthorityBtn{}, "authority_id = ? and sys_menu_id = ?", req.AuthorityId, req.MenuID).Error
 		if err != nil {
@@ -49,6 +74,11 @@ func (a *AuthorityBtnService) SetAuthorityBtn(req request.SysAuthorityBtnReq) (e
 		}
 		return err
 	})
+	if err != nil {
+		return err
+	}
+	btnApiCache.Flush()
+	return a.syncBtnPolicies(req.AuthorityId)
 }
 
 func (a *AuthorityBtnService) CanRemoveAuthorityBtn(ID string) (err error) {
@@ -58,3 +88,144 @@ func (a *AuthorityBtnService) CanRemoveAuthorityBtn(ID string) (err error) {
 	}
 	return errors.New("此按钮正在被使用无法删除")
 }
+
+// CheckBtnApi 校验角色能否调用api 关联了按钮的api要求角色或其继承的角色被授予其中任一按钮 未关联按钮的api不做限制
+func (a *AuthorityBtnService) CheckBtnApi(authorityID uint, dom string, path string, method string) (bool, error) {
+	apis, err := a.btnApis()
+	if err != nil {
+		return false, err
+	}
+	var btnIDs []uint
+	for _, v := range apis {
+		if v.Method == method && util.KeyMatch2(path, v.Path) {
+			btnIDs = append(btnIDs, v.SysBaseMenuBtnID)
+		}
+	}
+	if len(btnIDs) == 0 {
+		return true, nil
+	}
+	granted, err := a.grantedBtns(authorityID, dom)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range btnIDs {
+		if granted[id] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ClearBtnApiCache 菜单按钮或其关联的api变化后清除缓存
+func (a *AuthorityBtnService) ClearBtnApiCache() {
+	btnApiCache.Flush()
+}
+
+// btnApis 全部按钮关联的api
+func (a *AuthorityBtnService) btnApis() ([]btnApi, error) {
+	if v, ok := btnApiCache.Get("apis"); ok {
+		return v.([]btnApi), nil
+	}
+	var apis []btnApi
+	err := global.GVA_DB.Model(&system.SysBaseMenuBtnApi{}).
+		Select("sys_base_menu_btn_apis.sys_base_menu_btn_id, sys_apis.path, sys_apis.method").
+		Joins("JOIN sys_apis ON sys_apis.id = sys_base_menu_btn_apis.sys_api_id AND sys_apis.deleted_at IS NULL").
+		Joins("JOIN sys_base_menu_btns ON sys_base_menu_btns.id = sys_base_menu_btn_apis.sys_base_menu_btn_id AND sys_base_menu_btns.deleted_at IS NULL").
+		Scan(&apis).Error
+	if err != nil {
+		return nil, err
+	}
+	btnApiCache.Set("apis", apis, btnApiTTL)
+	return apis, nil
+}
+
+// grantedBtns 角色及其在域内继承的角色被授予的按钮
+func (a *AuthorityBtnService) grantedBtns(authorityID uint, dom string) (map[uint]bool, error) {
+	key := "btns:" + dom + ":" + strconv.Itoa(int(authorityID))
+	if v, ok := btnApiCache.Get(key); ok {
+		return v.(map[uint]bool), nil
+	}
+	chain, err := authorityChain(strconv.Itoa(int(authorityID)), dom)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint
+	err = global.GVA_DB.Model(&system.SysAuthorityBtn{}).Where("authority_id IN ?", chain).
+		Pluck("sys_base_menu_btn_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		granted[id] = true
+	}
+	btnApiCache.Set(key, granted, btnApiTTL)
+	return granted, nil
+}
+
+// checkBtnApiAuth 开启严格鉴权时 按钮关联的api必须在当前角色的权限列表中
+func (a *AuthorityBtnService) checkBtnApiAuth(adminAuthorityID uint, btnIDs []uint) error {
+	if !global.GVA_CONFIG.System.UseStrictAuth || len(btnIDs) == 0 {
+		return nil
+	}
+	var selected []btnApi
+	err := global.GVA_DB.Model(&system.SysBaseMenuBtnApi{}).
+		Select("sys_base_menu_btn_apis.sys_base_menu_btn_id, sys_apis.path, sys_apis.method").
+		Joins("JOIN sys_apis ON sys_apis.id = sys_base_menu_btn_apis.sys_api_id AND sys_apis.deleted_at IS NULL").
+		Where("sys_base_menu_btn_apis.sys_base_menu_btn_id IN ?", btnIDs).
+		Scan(&selected).Error
+	if err != nil || len(selected) == 0 {
+		return err
+	}
+	apis, err := ApiServiceApp.GetAllApis(adminAuthorityID)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool, len(apis))
+	for _, v := range apis {
+		allowed[v.Method+" "+v.Path] = true
+	}
+	for _, v := range selected {
+		if !allowed[v.Method+" "+v.Path] {
+			return errors.New("按钮关联的api不在权限列表中")
+		}
+	}
+	return nil
+}
+
+// syncBtnPolicies 为角色被授予的按钮关联的api补充allow策略 已存在的策略(包括deny)不会被覆盖
+// 取消按钮时不移除策略 策略可能是单独授予的 未授予按钮的角色由CheckBtnApi拒绝
+func (a *AuthorityBtnService) syncBtnPolicies(authorityID uint) error {
+	var granted []btnApi
+	err := global.GVA_DB.Model(&system.SysBaseMenuBtnApi{}).
+		Select("sys_base_menu_btn_apis.sys_base_menu_btn_id, sys_apis.path, sys_apis.method").
+		Joins("JOIN sys_apis ON sys_apis.id = sys_base_menu_btn_apis.sys_api_id AND sys_apis.deleted_at IS NULL").
+		Joins("JOIN sys_authority_btns ON sys_authority_btns.sys_base_menu_btn_id = sys_base_menu_btn_apis.sys_base_menu_btn_id").
+		Where("sys_authority_btns.authority_id = ?", authorityID).
+		Scan(&granted).Error
+	if err != nil || len(granted) == 0 {
+		return err
+	}
+
+	sub := strconv.Itoa(int(authorityID))
+	dom := CasbinServiceApp.AuthorityDomain(authorityID)
+	e := utils.GetCasbin()
+	seen := make(map[string]bool, len(granted))
+	var add [][]string
+	for _, v := range granted {
+		if seen[v.Method+" "+v.Path] {
+			continue
+		}
+		seen[v.Method+" "+v.Path] = true
+		if policies, _ := e.GetFilteredPolicy(0, sub, v.Path, v.Method); len(policies) == 0 {
+			add = append(add, []string{sub, v.Path, v.Method, request.CasbinAllow, dom})
+		}
+	}
+	if len(add) == 0 {
+		return nil
+	}
+	if _, err = e.AddPolicies(add); err != nil {
+		return err
+	}
+	return e.InvalidateCache()
+}
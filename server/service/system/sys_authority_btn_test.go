@@ -0,0 +1,131 @@
+package system
+
+import (
+	"testing"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"gorm.io/gorm"
+)
+
+func setupBtnTest(t *testing.T, rules []gormadapter.CasbinRule) *gorm.DB {
+	db := setupCasbinTest(t, rules, &system.SysAuthority{}, &system.SysApi{}, &system.SysBaseMenuBtn{},
+		&system.SysBaseMenuBtnApi{}, &system.SysAuthorityBtn{})
+	root, admin, user, yes := uint(0), uint(888), uint(8881), true
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 888, AuthorityName: "admin", ParentId: &root},
+		{AuthorityId: 8881, AuthorityName: "user", ParentId: &admin, InheritParent: &yes},
+		{AuthorityId: 8882, AuthorityName: "sub", ParentId: &user},
+		{AuthorityId: 9528, AuthorityName: "test", ParentId: &root},
+	})
+	db.Create(&[]system.SysApi{
+		{GVA_MODEL: global.GVA_MODEL{ID: 1}, Path: "/api/createApi", Method: "POST"},
+		{GVA_MODEL: global.GVA_MODEL{ID: 2}, Path: "/api/deleteApi", Method: "POST"},
+		{GVA_MODEL: global.GVA_MODEL{ID: 3}, Path: "/user/:id", Method: "GET"},
+		{GVA_MODEL: global.GVA_MODEL{ID: 4}, Path: "/menu/getMenu", Method: "POST"},
+	})
+	db.Create(&[]system.SysBaseMenuBtn{
+		{GVA_MODEL: global.GVA_MODEL{ID: 1}, Name: "create", SysBaseMenuID: 1},
+		{GVA_MODEL: global.GVA_MODEL{ID: 2}, Name: "delete", SysBaseMenuID: 1},
+		{GVA_MODEL: global.GVA_MODEL{ID: 3}, Name: "info", SysBaseMenuID: 1},
+	})
+	db.Create(&[]system.SysBaseMenuBtnApi{{SysBaseMenuBtnID: 1, SysApiID: 1}, {SysBaseMenuBtnID: 2, SysApiID: 2}, {SysBaseMenuBtnID: 3, SysApiID: 3}})
+	return db
+}
+
+func TestCheckBtnApi(t *testing.T) {
+	db := setupBtnTest(t, []gormadapter.CasbinRule{{Ptype: "g", V0: "8881", V1: "888", V2: "0"}})
+	db.Create(&[]system.SysAuthorityBtn{
+		{AuthorityId: 888, SysMenuID: 1, SysBaseMenuBtnID: 1},
+		{AuthorityId: 9528, SysMenuID: 1, SysBaseMenuBtnID: 3},
+	})
+
+	tests := []struct {
+		name        string
+		authorityId uint
+		method      string
+		path        string
+		allowed     bool
+	}{
+		{"granted button", 888, "POST", "/api/createApi", true},
+		{"inherited button", 8881, "POST", "/api/createApi", true},
+		{"button not granted", 9528, "POST", "/api/createApi", false},
+		{"button not granted to anyone", 888, "POST", "/api/deleteApi", false},
+		{"path parameter", 9528, "GET", "/user/5", true},
+		{"method mismatch", 888, "DELETE", "/user/5", true},
+		{"api without buttons", 9528, "POST", "/menu/getMenu", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := AuthorityBtnServiceApp.CheckBtnApi(tt.authorityId, "0", tt.path, tt.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.allowed {
+				t.Errorf("CheckBtnApi = %v, want %v", ok, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestSyncBtnPolicies(t *testing.T) {
+	db := setupBtnTest(t, []gormadapter.CasbinRule{
+		{Ptype: "p", V0: "8881", V1: "/api/createApi", V2: "POST", V3: "allow", V4: "0"},
+		{Ptype: "p", V0: "9528", V1: "/api/deleteApi", V2: "POST", V3: "allow", V4: "0"},
+		{Ptype: "p", V0: "9528", V1: "/user/:id", V2: "GET", V3: "deny", V4: "0"},
+	})
+	e := utils.GetCasbin()
+	has := func(sub, path, method, eft string) bool {
+		ok, _ := e.HasPolicy(sub, path, method, eft, "0")
+		return ok
+	}
+
+	req := request.SysAuthorityBtnReq{AuthorityId: 9528, MenuID: 1, Selected: []uint{1, 2, 3}}
+	if err := AuthorityBtnServiceApp.SetAuthorityBtn(888, req); err != nil {
+		t.Fatal(err)
+	}
+	if !has("9528", "/api/createApi", "POST", request.CasbinAllow) {
+		t.Error("granting a button should add an allow policy for its api")
+	}
+	if policies, _ := e.GetFilteredPolicy(0, "9528", "/api/deleteApi", "POST"); len(policies) != 1 {
+		t.Errorf("existing policy should not be duplicated, got %v", policies)
+	}
+	if has("9528", "/user/:id", "GET", request.CasbinAllow) || !has("9528", "/user/:id", "GET", request.CasbinDeny) {
+		t.Error("existing deny policy should not be overridden")
+	}
+
+	// 取消按钮后单独授予的策略保留 接口由按钮校验拒绝
+	req.Selected = nil
+	if err := AuthorityBtnServiceApp.SetAuthorityBtn(888, req); err != nil {
+		t.Fatal(err)
+	}
+	if !has("9528", "/api/deleteApi", "POST", request.CasbinAllow) {
+		t.Error("unticking a button should keep the independently granted policy")
+	}
+	if ok, _ := AuthorityBtnServiceApp.CheckBtnApi(9528, "0", "/api/deleteApi", "POST"); ok {
+		t.Error("api should be rejected once its button is no longer granted")
+	}
+
+	// 严格鉴权时先校验按钮关联的api 不通过时不保存按钮
+	global.GVA_CONFIG.System.UseStrictAuth = true
+	defer func() { global.GVA_CONFIG.System.UseStrictAuth = false }()
+	req = request.SysAuthorityBtnReq{AuthorityId: 8882, MenuID: 1, Selected: []uint{1, 2}}
+	if err := AuthorityBtnServiceApp.SetAuthorityBtn(8881, req); err == nil {
+		t.Fatal("button bound to an api outside the caller's permissions should be rejected")
+	}
+	var n int64
+	db.Model(&system.SysAuthorityBtn{}).Where("authority_id = ?", 8882).Count(&n)
+	if n != 0 || has("8882", "/api/createApi", "POST", request.CasbinAllow) {
+		t.Errorf("rejected request should not save buttons or policies, buttons %d", n)
+	}
+	req.Selected = []uint{1}
+	if err := AuthorityBtnServiceApp.SetAuthorityBtn(8881, req); err != nil {
+		t.Fatal(err)
+	}
+	if !has("8882", "/api/createApi", "POST", request.CasbinAllow) {
+		t.Error("button within the caller's permissions should be granted")
+	}
+}
@@ -0,0 +1,432 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+// fieldRuleTTL 字段权限规则只缓存在本地 其他实例修改规则后最多延迟该时间生效
+const fieldRuleTTL = 10 * time.Second
+
+var fieldRuleCache = local_cache.NewCache(local_cache.SetDefaultExpire(fieldRuleTTL))
+
+// modulePath 项目模块路径 模型标识中去掉该前缀
+var modulePath = strings.TrimSuffix(reflect.TypeOf(global.GVA_MODEL{}).PkgPath(), "/global")
+
+// fieldRules 模型标识 => 字段json名 => 规则
+type fieldRules map[string]map[string]string
+
+type AuthorityFieldService struct{}
+
+var AuthorityFieldServiceApp = new(AuthorityFieldService)
+
+//@function: GetFieldModels
+//@description: 获取已登记字段的模型列表
+//@return: list []response.SysFieldModel, err error
+
+func (a *AuthorityFieldService) GetFieldModels() (list []response.SysFieldModel, err error) {
+	err = global.GVA_DB.Model(&system.SysModelField{}).Select("model, MAX(description) AS description").
+		Group("model").Order("model").Scan(&list).Error
+	return list, err
+}
+
+//@function: GetAuthorityField
+//@description: 获取模型的字段与角色已配置的字段权限
+//@param: req request.SysAuthorityFieldReq
+//@return: res response.SysAuthorityFieldRes, err error
+
+func (a *AuthorityFieldService) GetAuthorityField(req request.SysAuthorityFieldReq) (res response.SysAuthorityFieldRes, err error) {
+	err = global.GVA_DB.Where("model = ?", req.Model).Order("id").Find(&res.Fields).Error
+	if err != nil {
+		return
+	}
+	var rules []system.SysAuthorityField
+	err = global.GVA_DB.Find(&rules, "authority_id = ? AND model = ?", req.AuthorityId, req.Model).Error
+	if err != nil {
+		return
+	}
+	res.Rules = make(map[string]string, len(rules))
+	for _, v := range rules {
+		res.Rules[v.Field] = v.Rule
+	}
+	return res, err
+}
+
+//@function: SetAuthorityField
+//@description: 设置角色在模型上的字段权限 未提交的字段恢复为可读写
+//@param: req request.SysAuthorityFieldReq
+//@return: err error
+
+func (a *AuthorityFieldService) SetAuthorityField(req request.SysAuthorityFieldReq) (err error) {
+	var rules []system.SysAuthorityField
+	seen := make(map[string]bool, len(req.Rules))
+	for _, v := range req.Rules {
+		// 同一字段只保留一条规则
+		if seen[v.Field] {
+			return errors.New("字段权限重复: " + v.Field)
+		}
+		seen[v.Field] = true
+		switch v.Rule {
+		case "":
+			continue
+		case system.FieldRuleHidden, system.FieldRuleMasked, system.FieldRuleReadonly:
+		default:
+			return errors.New("未知的字段权限规则: " + v.Rule)
+		}
+		rules = append(rules, system.SysAuthorityField{
+			AuthorityId: req.AuthorityId,
+			Model:       req.Model,
+			Field:       v.Field,
+			Rule:        v.Rule,
+		})
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&[]system.SysAuthorityField{}, "authority_id = ? AND model = ?", req.AuthorityId, req.Model).Error
+		if err != nil {
+			return err
+		}
+		if len(rules) > 0 {
+			return tx.Create(&rules).Error
+		}
+		return nil
+	})
+	fieldRuleCache.Delete(strconv.FormatUint(uint64(req.AuthorityId), 10))
+	return err
+}
+
+//@function: RegisterModelFields
+//@description: 登记模型字段 供字段权限配置使用 已登记的字段更新描述
+//@param: ctx context.Context, fields []system.SysModelField
+//@return: err error
+
+func (a *AuthorityFieldService) RegisterModelFields(ctx context.Context, fields []system.SysModelField) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return global.GVA_DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "model"}, {Name: "field"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description", "label", "table_name", "column_name", "updated_at"}),
+	}).Create(&fields).Error
+}
+
+//@function: FilterResponse
+//@description: 按当前用户角色的字段权限处理返回数据 隐藏或脱敏字段 注册为response.DataFilter
+//@param: c *gin.Context, data interface{}
+//@return: interface{}
+
+func (a *AuthorityFieldService) FilterResponse(c *gin.Context, data interface{}) interface{} {
+	if data == nil {
+		return data
+	}
+	// 只处理经过鉴权中间件的请求
+	claims, ok := c.Get("claims")
+	if !ok {
+		return data
+	}
+	rules, err := a.authorityRules(claims.(*request.CustomClaims).AuthorityId)
+	if err != nil {
+		// 无法确认字段权限时不返回数据 避免泄露隐藏字段
+		global.GVA_LOG.Error("获取字段权限失败!", zap.Error(err))
+		return nil
+	}
+	if len(rules) == 0 {
+		return data
+	}
+	if filtered, changed := filterFields(reflect.ValueOf(data), rules); changed {
+		return filtered
+	}
+	return data
+}
+
+//@function: ColumnRules
+//@description: 获取角色在数据库列上的字段权限 导出Excel等不经过response.DataFilter的场景按列处理
+//@param: authorityId uint
+//@return: rules map[string]string, err error 键为 表名.列名
+
+func (a *AuthorityFieldService) ColumnRules(authorityId uint) (map[string]string, error) {
+	rules, err := a.authorityRules(authorityId)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	models := make([]string, 0, len(rules))
+	for model := range rules {
+		models = append(models, model)
+	}
+	var fields []system.SysModelField
+	err = global.GVA_DB.Where("model IN ? AND table_name <> '' AND column_name <> ''", models).Find(&fields).Error
+	if err != nil {
+		return nil, err
+	}
+	columnRules := make(map[string]string)
+	for _, v := range fields {
+		if rule, ok := rules[v.Model][v.Field]; ok {
+			columnRules[v.Table+"."+v.Column] = rule
+		}
+	}
+	return columnRules, nil
+}
+
+//@function: UpdateScope
+//@description: 更新时忽略当前用户角色不可修改的字段 隐藏与脱敏的字段同样不可修改
+//@param: ctx context.Context
+//@return: func(db *gorm.DB) *gorm.DB
+
+func (a *AuthorityFieldService) UpdateScope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		claims, ok := utils.GetClaimsFromContext(ctx)
+		if !ok {
+			return db
+		}
+		model := db.Statement.Model
+		if model == nil {
+			model = db.Statement.Dest
+		}
+		if model == nil || db.Statement.Parse(model) != nil || db.Statement.Schema == nil {
+			return db
+		}
+		rules, err := a.authorityRules(claims.AuthorityId)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		modelRules := rules[modelKey(db.Statement.Schema.ModelType)]
+		if len(modelRules) == 0 {
+			return db
+		}
+		for _, field := range db.Statement.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if _, ok := modelRules[jsonName(field.StructField)]; ok {
+				// Omit会覆盖之前设置的字段 这里追加
+				db.Statement.Omits = append(db.Statement.Omits, field.DBName)
+			}
+		}
+		return db
+	}
+}
+
+// authorityRules 读取角色的全部字段权限规则
+func (a *AuthorityFieldService) authorityRules(authorityId uint) (fieldRules, error) {
+	key := strconv.FormatUint(uint64(authorityId), 10)
+	if v, ok := fieldRuleCache.Get(key); ok {
+		return v.(fieldRules), nil
+	}
+	var list []system.SysAuthorityField
+	if err := global.GVA_DB.Find(&list, "authority_id = ?", authorityId).Error; err != nil {
+		return nil, err
+	}
+	rules := make(fieldRules)
+	for _, v := range list {
+		if rules[v.Model] == nil {
+			rules[v.Model] = make(map[string]string)
+		}
+		rules[v.Model][v.Field] = v.Rule
+	}
+	fieldRuleCache.Set(key, rules, fieldRuleTTL)
+	return rules, nil
+}
+
+// modelKey 模型标识 为去掉模块路径的包路径加结构体名 如 model/example.ExaCustomer
+func modelKey(t reflect.Type) string {
+	return strings.TrimPrefix(t.PkgPath(), modulePath+"/") + "." + t.Name()
+}
+
+// jsonName 结构体字段序列化后的名称
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// filterFields 递归查找返回数据中配置了字段权限的模型 转为map后处理 没有变化时返回false
+func filterFields(v reflect.Value, rules fieldRules) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, false
+		}
+		return filterFields(v.Elem(), rules)
+	case reflect.Struct:
+		if modelRules, ok := rules[modelKey(v.Type())]; ok {
+			m, err := structToMap(v)
+			if err != nil {
+				return nil, true
+			}
+			for field, rule := range modelRules {
+				value, ok := m[field]
+				if !ok {
+					continue
+				}
+				switch rule {
+				case system.FieldRuleHidden:
+					delete(m, field)
+				case system.FieldRuleMasked:
+					m[field] = maskValue(value)
+				}
+			}
+			return m, true
+		}
+		var m map[string]interface{}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := jsonName(f)
+			if name == "-" {
+				continue
+			}
+			value, changed := filterFields(v.Field(i), rules)
+			if !changed {
+				continue
+			}
+			if m == nil {
+				var err error
+				if m, err = structToMap(v); err != nil {
+					return nil, true
+				}
+			}
+			if embedded, ok := value.(map[string]interface{}); ok && isEmbeddedStruct(f) {
+				if err := mergeEmbedded(m, t, v.Field(i), embedded); err != nil {
+					return nil, true
+				}
+				continue
+			}
+			m[name] = value
+		}
+		return m, m != nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+		var list []interface{}
+		for i := 0; i < v.Len(); i++ {
+			value, changed := filterFields(v.Index(i), rules)
+			if changed && list == nil {
+				list = make([]interface{}, v.Len())
+				for j := 0; j < i; j++ {
+					list[j] = v.Index(j).Interface()
+				}
+			}
+			if list == nil {
+				continue
+			}
+			if changed {
+				list[i] = value
+			} else {
+				list[i] = v.Index(i).Interface()
+			}
+		}
+		return list, list != nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.IsNil() {
+			return nil, false
+		}
+		var m map[string]interface{}
+		iter := v.MapRange()
+		for iter.Next() {
+			value, changed := filterFields(iter.Value(), rules)
+			if !changed {
+				continue
+			}
+			if m == nil {
+				m = make(map[string]interface{}, v.Len())
+				for _, k := range v.MapKeys() {
+					m[k.String()] = v.MapIndex(k).Interface()
+				}
+			}
+			m[iter.Key().String()] = value
+		}
+		return m, m != nil
+	}
+	return nil, false
+}
+
+// isEmbeddedStruct 没有指定json名称的匿名结构体字段 json序列化时其字段提升到外层
+func isEmbeddedStruct(f reflect.StructField) bool {
+	if !f.Anonymous || f.Tag.Get("json") != "" && !strings.HasPrefix(f.Tag.Get("json"), ",") {
+		return false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// mergeEmbedded 将匿名结构体的处理结果合并到外层map 被隐藏的字段一并删除 外层同名字段优先
+func mergeEmbedded(m map[string]interface{}, t reflect.Type, field reflect.Value, embedded map[string]interface{}) error {
+	origin, err := structToMap(field)
+	if err != nil {
+		return err
+	}
+	own := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && !isEmbeddedStruct(f) {
+			own[jsonName(f)] = true
+		}
+	}
+	for key := range origin {
+		if _, ok := m[key]; !ok || own[key] {
+			continue
+		}
+		if value, ok := embedded[key]; ok {
+			m[key] = value
+		} else {
+			delete(m, key)
+		}
+	}
+	return nil
+}
+
+// structToMap 按json序列化结果转换为map 保持与直接返回结构体时相同的字段名 数字保留为json.Number避免大整数丢失精度
+func structToMap(v reflect.Value) (map[string]interface{}, error) {
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	err = d.Decode(&m)
+	return m, err
+}
+
+// maskValue 字符串保留首尾部分字符 其余类型整体替换
+func maskValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "******"
+	}
+	r := []rune(s)
+	front, back := len(r)/4, len(r)/4
+	if len(r) >= 11 {
+		front, back = 3, 4
+	}
+	return string(r[:front]) + strings.Repeat("*", len(r)-front-back) + string(r[len(r)-back:])
+}
@@ -0,0 +1,189 @@
+package system
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/example"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/glebarez/sqlite"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestFilterFields(t *testing.T) {
+	// 与代码生成器登记的模型标识一致
+	if key := modelKey(reflect.TypeOf(example.ExaCustomer{})); key != "model/example.ExaCustomer" {
+		t.Fatalf("modelKey = %s, want model/example.ExaCustomer", key)
+	}
+
+	user := system.SysUser{Username: "admin", Phone: "13800138000", Email: "admin@example.com"}
+	rules := fieldRules{
+		modelKey(reflect.TypeOf(user)): {
+			"phone":    system.FieldRuleMasked,
+			"email":    system.FieldRuleHidden,
+			"userName": system.FieldRuleReadonly,
+		},
+	}
+	data := response.PageResult{List: []system.SysUser{user}, Total: 1}
+
+	filtered, changed := filterFields(reflect.ValueOf(data), rules)
+	if !changed {
+		t.Fatal("page result containing a ruled model should be filtered")
+	}
+	list := filtered.(map[string]interface{})["list"].([]interface{})
+	got := list[0].(map[string]interface{})
+	if got["phone"] != "138****8000" {
+		t.Errorf("phone = %v, want masked", got["phone"])
+	}
+	if _, ok := got["email"]; ok {
+		t.Error("hidden field email should be removed")
+	}
+	if got["userName"] != "admin" {
+		t.Errorf("readonly field userName = %v, want admin", got["userName"])
+	}
+	if filtered.(map[string]interface{})["total"] != json.Number("1") {
+		t.Error("fields of the page result should be kept")
+	}
+
+	// 匿名嵌入的模型字段提升到外层 同样按字段权限处理 外层同名字段不受影响 大整数不丢失精度
+	type userView struct {
+		system.SysUser
+		Email string `json:"email"`
+		Count uint64 `json:"count"`
+	}
+	embedded := user
+	embedded.ID = 1<<53 + 1
+	filtered, changed = filterFields(reflect.ValueOf([]userView{{SysUser: embedded, Email: "view@example.com", Count: 1<<63 + 1}}), rules)
+	if !changed {
+		t.Fatal("struct embedding a ruled model should be filtered")
+	}
+	got = filtered.([]interface{})[0].(map[string]interface{})
+	if got["phone"] != "138****8000" || got["userName"] != "admin" {
+		t.Errorf("promoted fields = %v %v, want masked phone and userName admin", got["phone"], got["userName"])
+	}
+	if got["email"] != "view@example.com" {
+		t.Errorf("email of the outer struct = %v, want view@example.com", got["email"])
+	}
+	if got["ID"] != json.Number("9007199254740993") || got["count"] != json.Number("9223372036854775809") {
+		t.Errorf("numbers lost precision: ID %v count %v", got["ID"], got["count"])
+	}
+	type hiddenView struct {
+		*system.SysUser
+		Remark string `json:"remark"`
+	}
+	filtered, _ = filterFields(reflect.ValueOf(hiddenView{SysUser: &embedded, Remark: "r"}), rules)
+	got = filtered.(map[string]interface{})
+	if _, ok := got["email"]; ok {
+		t.Error("hidden field of an embedded model should be removed")
+	}
+	if got["remark"] != "r" || got["phone"] != "138****8000" {
+		t.Errorf("got %v, want remark kept and phone masked", got)
+	}
+
+	if _, changed = filterFields(reflect.ValueOf(map[string]interface{}{"api": system.SysApi{}}), rules); changed {
+		t.Error("data without ruled models should be returned as is")
+	}
+}
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want interface{}
+	}{
+		{"13800138000", "138****8000"},
+		{"abcdefgh", "ab****gh"},
+		{"abc", "***"},
+		{12345, "******"},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := maskValue(tt.in); got != tt.want {
+			t.Errorf("maskValue(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportExcelFieldRules(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	err = db.AutoMigrate(&system.SysUser{}, &example.ExaCustomer{}, &system.SysModelField{}, &system.SysAuthorityField{},
+		&system.SysExportTemplate{}, &system.Condition{}, &system.JoinTemplate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&[]system.SysModelField{
+		{Model: "model/example.ExaCustomer", Field: "customerName", Table: "exa_customers", Column: "customer_name"},
+		{Model: "model/example.ExaCustomer", Field: "customerPhoneData", Table: "exa_customers", Column: "customer_phone_data"},
+	})
+	db.Create(&example.ExaCustomer{CustomerName: "张三", CustomerPhoneData: "13800138000", SysUserID: 1})
+	db.Create(&system.SysExportTemplate{
+		Name:         "customer",
+		TableName:    "exa_customers",
+		TemplateID:   "customer",
+		TemplateInfo: `{"customer_name":"客户名","customer_phone_data":"客户手机号","sys_user_id":"管理ID"}`,
+	})
+
+	err = AuthorityFieldServiceApp.SetAuthorityField(request.SysAuthorityFieldReq{
+		AuthorityId: 8881,
+		Model:       "model/example.ExaCustomer",
+		Rules: []system.SysAuthorityField{
+			{Field: "customerName", Rule: system.FieldRuleHidden},
+			{Field: "customerName", Rule: system.FieldRuleMasked},
+		},
+	})
+	if err == nil {
+		t.Fatal("duplicate rules for the same field should be rejected")
+	}
+	err = AuthorityFieldServiceApp.SetAuthorityField(request.SysAuthorityFieldReq{
+		AuthorityId: 8881,
+		Model:       "model/example.ExaCustomer",
+		Rules: []system.SysAuthorityField{
+			{Field: "customerName", Rule: system.FieldRuleHidden},
+			{Field: "customerPhoneData", Rule: system.FieldRuleMasked},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Create(&system.SysAuthorityField{AuthorityId: 8881, Model: "model/example.ExaCustomer", Field: "customerName", Rule: system.FieldRuleReadonly}).Error; err == nil {
+		t.Error("a second rule for the same authority and field should violate the unique index")
+	}
+
+	exportRows := func(authorityId uint) [][]string {
+		file, _, err := SysExportTemplateServiceApp.ExportExcel("customer", url.Values{}, authorityId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		rows, err := f.GetRows("Sheet1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+	want := [][]string{{"客户名", "客户手机号", "管理ID"}, {"张三", "13800138000", "1"}}
+	if rows := exportRows(888); !reflect.DeepEqual(rows, want) {
+		t.Errorf("export without rules:\n got %q\nwant %q", rows, want)
+	}
+	want = [][]string{{"客户手机号", "管理ID"}, {"138****8000", "1"}}
+	if rows := exportRows(8881); !reflect.DeepEqual(rows, want) {
+		t.Errorf("export with rules:\n got %q\nwant %q", rows, want)
+	}
+}
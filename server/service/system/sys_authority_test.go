@@ -0,0 +1,66 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestUpdateAuthorityInheritParent(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysAuthority{}, &gormadapter.CasbinRule{}); err != nil {
+		t.Fatal(err)
+	}
+	root, admin := uint(0), uint(888)
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 888, AuthorityName: "admin", ParentId: &root},
+		{AuthorityId: 8881, AuthorityName: "user", ParentId: &admin},
+	})
+	parent := func() string {
+		var rules []gormadapter.CasbinRule
+		db.Where("ptype = ? AND v0 = ?", "g", "8881").Find(&rules)
+		if len(rules) == 0 {
+			return ""
+		}
+		return rules[0].V1
+	}
+	update := func(auth system.SysAuthority) {
+		if _, err := AuthorityServiceApp.UpdateAuthority(context.Background(), auth); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 有父角色但未开启继承的角色 编辑其他字段时不会获得父角色的权限
+	update(system.SysAuthority{AuthorityId: 8881, AuthorityName: "renamed", ParentId: &admin})
+	if p := parent(); p != "" {
+		t.Errorf("role without inheritance should not get a grouping rule, got parent %s", p)
+	}
+
+	yes, no := true, false
+	update(system.SysAuthority{AuthorityId: 8881, AuthorityName: "renamed", ParentId: &admin, InheritParent: &yes})
+	if p := parent(); p != "888" {
+		t.Errorf("enabling inheritance should add a grouping rule to 888, got %q", p)
+	}
+	update(system.SysAuthority{AuthorityId: 8881, AuthorityName: "renamed", InheritParent: &no})
+	if p := parent(); p != "" {
+		t.Errorf("disabling inheritance should remove the grouping rule, got parent %s", p)
+	}
+	var auth system.SysAuthority
+	db.First(&auth, "authority_id = ?", 8881)
+	if auth.InheritParent == nil || *auth.InheritParent || authorityParentID(auth.ParentId) != 888 {
+		t.Errorf("unexpected authority %+v", auth)
+	}
+}
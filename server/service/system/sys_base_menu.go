@@ -32,6 +32,7 @@ func (baseMenuService *BaseMenuService) DeleteBaseMenu(id int) (err error) {
 	if err == nil {
 		return errors.New("此菜单有角色正在作为首页，不可删除")
 	}
+	defer AuthorityBtnServiceApp.ClearBtnApiCache()
 	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 
 		err = tx.Delete(&system.SysBaseMenu{}, "id = ?", id).Error
@@ -44,6 +45,11 @@ func (baseMenuService *BaseMenuService) DeleteBaseMenu(id int) (err error) {
 			return err
 		}
 
+		err = tx.Where("sys_base_menu_btn_id IN (?)", tx.Model(&system.SysBaseMenuBtn{}).Select("id").Where("sys_base_menu_id = ?", id)).
+			Delete(&system.SysBaseMenuBtnApi{}).Error
+		if err != nil {
+			return err
+		}
 		err = tx.Delete(&system.SysBaseMenuBtn{}, "sys_base_menu_id = ?", id).Error
 		if err != nil {
 			return err
@@ -98,6 +104,13 @@ func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu)
 			global.GVA_LOG.Debug(txErr.Error())
 			return txErr
 		}
+		// 按钮重新创建 关联的api随按钮一起提交
+		txErr = tx.Where("sys_base_menu_btn_id IN (?)", tx.Model(&system.SysBaseMenuBtn{}).Unscoped().Select("id").Where("sys_base_menu_id = ?", menu.ID)).
+			Delete(&system.SysBaseMenuBtnApi{}).Error
+		if txErr != nil {
+			global.GVA_LOG.Debug(txErr.Error())
+			return txErr
+		}
 		txErr = tx.Unscoped().Delete(&system.SysBaseMenuBtn{}, "sys_base_menu_id = ?", menu.ID).Error
 		if txErr != nil {
 			global.GVA_LOG.Debug(txErr.Error())
@@ -118,7 +131,7 @@ func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu)
 			for k := range menu.MenuBtn {
 				menu.MenuBtn[k].SysBaseMenuID = menu.ID
 			}
-			txErr = tx.Create(&menu.MenuBtn).Error
+			txErr = tx.Omit("Apis.*").Create(&menu.MenuBtn).Error
 			if txErr != nil {
 				global.GVA_LOG.Debug(txErr.Error())
 				return txErr
@@ -132,6 +145,7 @@ func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu)
 		}
 		return nil
 	})
+	AuthorityBtnServiceApp.ClearBtnApiCache()
 	return err
 }
 
@@ -142,6 +156,6 @@ func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu)
 //@return: menu system.SysBaseMenu, err error
 
 func (baseMenuService *BaseMenuService) GetBaseMenuById(id int) (menu system.SysBaseMenu, err error) {
-	err = global.GVA_DB.Preload("MenuBtn").Preload("Parameters").Where("id = ?", id).First(&menu).Error
+	err = global.GVA_DB.Preload("MenuBtn.Apis").Preload("Parameters").Where("id = ?", id).First(&menu).Error
 	return
 }
@@ -8,8 +8,10 @@ import (
 
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
 	_ "github.com/go-sql-driver/mysql"
 )
 
@@ -50,17 +52,31 @@ func (casbinService *CasbinService) UpdateCasbin(adminAuthorityID, AuthorityID u
 		}
 	}
 
+	for i := range casbinInfos {
+		if e := casbinInfos[i].Effect; e != "" && e != request.CasbinAllow && e != request.CasbinDeny {
+			return errors.New("策略效果只能为allow或deny")
+		}
+	}
+
 	authorityId := strconv.Itoa(int(AuthorityID))
+	domain, err := authorityDomain(global.GVA_DB, authorityId)
+	if err != nil {
+		return err
+	}
 	casbinService.ClearCasbin(0, authorityId)
 	rules := [][]string{}
-	//做权限去重处理
-	deduplicateMap := make(map[string]bool)
+	//做权限去重处理 同一接口同时提交allow与deny时保留deny
+	deduplicateMap := make(map[string]int)
 	for _, v := range casbinInfos {
 		key := authorityId + v.Path + v.Method
-		if _, ok := deduplicateMap[key]; !ok {
-			deduplicateMap[key] = true
-			rules = append(rules, []string{authorityId, v.Path, v.Method})
+		if i, ok := deduplicateMap[key]; ok {
+			if v.EffectOrDefault() == request.CasbinDeny {
+				rules[i][3] = request.CasbinDeny
+			}
+			continue
 		}
+		deduplicateMap[key] = len(rules)
+		rules = append(rules, []string{authorityId, v.Path, v.Method, v.EffectOrDefault(), domain})
 	}
 	if len(rules) == 0 {
 		return nil
@@ -70,7 +86,8 @@ func (casbinService *CasbinService) UpdateCasbin(adminAuthorityID, AuthorityID u
 	if !success {
 		return errors.New("存在相同api,添加失败,请联系管理员")
 	}
-	return nil
+	// 子角色继承了该角色的策略 只清除该角色自身的缓存并不够
+	return e.InvalidateCache()
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -88,8 +105,7 @@ func (casbinService *CasbinService) UpdateCasbinApi(oldPath string, newPath stri
 		return err
 	}
 
-	e := utils.GetCasbin()
-	return e.LoadPolicy()
+	return casbinService.FreshCasbin()
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -103,10 +119,15 @@ func (casbinService *CasbinService) GetPolicyPathByAuthorityId(AuthorityID uint)
 	authorityId := strconv.Itoa(int(AuthorityID))
 	list, _ := e.GetFilteredPolicy(0, authorityId)
 	for _, v := range list {
-		pathMaps = append(pathMaps, request.CasbinInfo{
+		info := request.CasbinInfo{
 			Path:   v[1],
 			Method: v[2],
-		})
+			Effect: request.CasbinAllow,
+		}
+		if len(v) > 3 && v[3] != "" {
+			info.Effect = v[3]
+		}
+		pathMaps = append(pathMaps, info)
 	}
 	return pathMaps
 }
@@ -120,17 +141,18 @@ func (casbinService *CasbinService) GetPolicyPathByAuthorityId(AuthorityID uint)
 func (casbinService *CasbinService) ClearCasbin(v int, p ...string) bool {
 	e := utils.GetCasbin()
 	success, _ := e.RemoveFilteredPolicy(v, p...)
+	_ = e.InvalidateCache()
 	return success
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: RemoveFilteredPolicy
-//@description: 使用数据库方法清理筛选的politicy 此方法需要调用FreshCasbin方法才可以在系统中即刻生效
+//@description: 使用数据库方法清理筛选的politicy 此方法需要调用FreshCasbin方法才可以在系统中即刻生效 角色继承关系不受影响
 //@param: db *gorm.DB, authorityId string
 //@return: error
 
 func (casbinService *CasbinService) RemoveFilteredPolicy(db *gorm.DB, authorityId string) error {
-	return db.Delete(&gormadapter.CasbinRule{}, "v0 = ?", authorityId).Error
+	return db.Delete(&gormadapter.CasbinRule{}, "ptype = ? AND v0 = ?", "p", authorityId).Error
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -149,25 +171,89 @@ func (casbinService *CasbinService) SyncPolicy(db *gorm.DB, authorityId string,
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: AddPolicies
-//@description: 添加匹配的权限
-//@param: v int, p ...string
-//@return: bool
+//@description: 添加匹配的权限 规则为 [角色ID, 路径, 方法, 效果] 未指定效果时为allow 域为角色所属租户
+//@param: db *gorm.DB, rules [][]string
+//@return: error
 
 func (casbinService *CasbinService) AddPolicies(db *gorm.DB, rules [][]string) error {
 	var casbinRules []gormadapter.CasbinRule
+	domains := make(map[string]string)
 	for i := range rules {
+		eft := request.CasbinAllow
+		if len(rules[i]) > 3 && rules[i][3] != "" {
+			eft = rules[i][3]
+		}
+		domain, ok := domains[rules[i][0]]
+		if !ok {
+			var err error
+			if domain, err = authorityDomain(db, rules[i][0]); err != nil {
+				return err
+			}
+			domains[rules[i][0]] = domain
+		}
 		casbinRules = append(casbinRules, gormadapter.CasbinRule{
 			Ptype: "p",
 			V0:    rules[i][0],
 			V1:    rules[i][1],
 			V2:    rules[i][2],
+			V3:    eft,
+			V4:    domain,
 		})
 	}
 	return db.Create(&casbinRules).Error
 }
 
+//@function: AuthorityDomain
+//@description: 角色在casbin中的域 即角色所属租户
+//@param: authorityID uint
+//@return: string
+
+func (casbinService *CasbinService) AuthorityDomain(authorityID uint) string {
+	domain, err := authorityDomain(global.GVA_DB, strconv.Itoa(int(authorityID)))
+	if err != nil {
+		return tenant.Domain(tenant.Platform)
+	}
+	return domain
+}
+
+// authorityDomain 查询角色所属租户 角色不存在时使用平台租户
+func authorityDomain(db *gorm.DB, authorityId string) (string, error) {
+	var tenantIDs []uint
+	err := db.Model(&system.SysAuthority{}).Where("authority_id = ?", authorityId).Pluck("tenant_id", &tenantIDs).Error
+	if err != nil || len(tenantIDs) == 0 {
+		return tenant.Domain(tenant.Platform), err
+	}
+	return tenant.Domain(tenantIDs[0]), nil
+}
+
+//@function: FreshCasbin
+//@description: 从数据库重新加载策略 并通知其他实例同步加载
+//@return: err error
+
 func (casbinService *CasbinService) FreshCasbin() (err error) {
 	e := utils.GetCasbin()
 	err = e.LoadPolicy()
-	return err
+	if err != nil {
+		return err
+	}
+	notifyCasbinChange()
+	return nil
+}
+
+//@function: SetAuthorityParent
+//@description: 同步角色继承关系 开启继承的子角色通过g规则继承父角色的全部策略 parentId为0时移除继承 继承只在子角色所属租户内生效 此方法需要调用FreshCasbin方法才可以在系统中即刻生效
+//@param: db *gorm.DB, authorityId uint, parentId uint
+//@return: error
+
+func (casbinService *CasbinService) SetAuthorityParent(db *gorm.DB, authorityId, parentId uint) error {
+	child := strconv.Itoa(int(authorityId))
+	err := db.Delete(&gormadapter.CasbinRule{}, "ptype = ? AND v0 = ?", "g", child).Error
+	if err != nil || parentId == 0 {
+		return err
+	}
+	domain, err := authorityDomain(db, child)
+	if err != nil {
+		return err
+	}
+	return db.Create(&gormadapter.CasbinRule{Ptype: "g", V0: child, V1: strconv.Itoa(int(parentId)), V2: domain}).Error
 }
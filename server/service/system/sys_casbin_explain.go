@@ -0,0 +1,297 @@
+package system
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/casbin/casbin/v2/util"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+)
+
+//@function: ExplainPermission
+//@description: 诊断用户或角色能否访问指定接口 返回鉴权结果 命中的策略以及角色的菜单和按钮权限
+//@param: adminAuthorityID uint, req systemReq.PermissionExplain
+//@return: res systemRes.PermissionExplainResponse, err error
+
+func (casbinService *CasbinService) ExplainPermission(adminAuthorityID uint, req systemReq.PermissionExplain) (res systemRes.PermissionExplainResponse, err error) {
+	method := strings.ToUpper(req.Method)
+	path := strings.TrimPrefix(req.Path, global.GVA_CONFIG.System.RouterPrefix)
+	user, authorities, err := permissionSubjects(adminAuthorityID, req.UserID, req.AuthorityId)
+	if err != nil {
+		return res, err
+	}
+	if user != nil {
+		res.User = user
+		res.UserActive = user.Enable == 1
+	}
+
+	var apis []system.SysApi
+	if err = global.GVA_DB.Where("method = ?", method).Find(&apis).Error; err != nil {
+		return res, err
+	}
+	for i := range apis {
+		if util.KeyMatch2(path, apis[i].Path) {
+			res.Api = &apis[i]
+			break
+		}
+	}
+
+	e := utils.GetCasbin()
+	for _, authority := range authorities {
+		sub := strconv.Itoa(int(authority.AuthorityId))
+		item := systemRes.AuthorityExplain{
+			AuthorityId:   authority.AuthorityId,
+			AuthorityName: authority.AuthorityName,
+			Current:       user != nil && user.AuthorityId == authority.AuthorityId,
+		}
+		dom := tenant.Domain(authority.TenantID)
+		item.Roles, err = authorityChain(sub, dom)
+		if err != nil {
+			return res, err
+		}
+		item.Allowed, err = e.Enforce(sub, dom, path, method)
+		if err != nil {
+			return res, err
+		}
+		item.Policies = matchPolicies(item.Roles, path, method)
+		item.Reason = explainReason(item.Allowed, item.Policies)
+		if item.Allowed {
+			// 与CasbinHandler一致 关联了按钮的api还需要被授予对应的按钮
+			if item.Allowed, err = AuthorityBtnServiceApp.CheckBtnApi(authority.AuthorityId, dom, path, method); err != nil {
+				return res, err
+			}
+			if !item.Allowed {
+				item.Reason = "命中允许策略 但未被授予该api关联的按钮"
+			}
+		}
+		item.Menus, err = permissionMenus(authority.AuthorityId)
+		if err != nil {
+			return res, err
+		}
+		res.Authorities = append(res.Authorities, item)
+	}
+	return res, nil
+}
+
+//@function: GetPermissionMatrix
+//@description: 计算用户拥有的每个角色(或指定角色)对全部api的实际权限
+//@param: adminAuthorityID uint, req systemReq.PermissionMatrix
+//@return: res systemRes.PermissionMatrixResponse, err error
+
+func (casbinService *CasbinService) GetPermissionMatrix(adminAuthorityID uint, req systemReq.PermissionMatrix) (res systemRes.PermissionMatrixResponse, err error) {
+	_, authorities, err := permissionSubjects(adminAuthorityID, req.UserID, req.AuthorityId)
+	if err != nil {
+		return res, err
+	}
+	res.Authorities = authorities
+	var apis []system.SysApi
+	if err = global.GVA_DB.Order("api_group, path, method").Find(&apis).Error; err != nil {
+		return res, err
+	}
+	e := utils.GetCasbin()
+	chains := make([][]uint, len(authorities))
+	for i := range authorities {
+		if chains[i], err = authorityChain(strconv.Itoa(int(authorities[i].AuthorityId)), tenant.Domain(authorities[i].TenantID)); err != nil {
+			return res, err
+		}
+	}
+	for _, api := range apis {
+		row := systemRes.PermissionMatrixRow{SysApi: api, Effects: make([]string, len(authorities))}
+		for i := range authorities {
+			dom := tenant.Domain(authorities[i].TenantID)
+			ok, err := e.Enforce(strconv.Itoa(int(authorities[i].AuthorityId)), dom, api.Path, api.Method)
+			if err == nil && ok {
+				ok, err = AuthorityBtnServiceApp.CheckBtnApi(authorities[i].AuthorityId, dom, api.Path, api.Method)
+			}
+			if err != nil {
+				return res, err
+			}
+			switch {
+			case ok:
+				row.Effects[i] = systemReq.CasbinAllow
+			case hasDeny(matchPolicies(chains[i], api.Path, api.Method)):
+				row.Effects[i] = systemReq.CasbinDeny
+			}
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	return res, nil
+}
+
+//@function: ExportPermissionMatrix
+//@description: 导出权限矩阵Excel
+//@param: adminAuthorityID uint, req systemReq.PermissionMatrix
+//@return: file *bytes.Buffer, err error
+
+func (casbinService *CasbinService) ExportPermissionMatrix(adminAuthorityID uint, req systemReq.PermissionMatrix) (file *bytes.Buffer, err error) {
+	matrix, err := casbinService.GetPermissionMatrix(adminAuthorityID, req)
+	if err != nil {
+		return nil, err
+	}
+	title := []string{"API分组", "描述", "方法", "路径"}
+	for _, v := range matrix.Authorities {
+		title = append(title, fmt.Sprintf("%s(%d)", v.AuthorityName, v.AuthorityId))
+	}
+	rows := [][]string{title}
+	effectText := map[string]string{systemReq.CasbinAllow: "允许", systemReq.CasbinDeny: "禁止", "": "无权限"}
+	for _, v := range matrix.Rows {
+		row := []string{v.ApiGroup, v.Description, v.Method, v.Path}
+		for _, eft := range v.Effects {
+			row = append(row, effectText[eft])
+		}
+		rows = append(rows, row)
+	}
+	f := excelize.NewFile()
+	defer f.Close()
+	if err = writeExcelRows(f, "Sheet1", rows); err != nil {
+		return nil, err
+	}
+	return f.WriteToBuffer()
+}
+
+// permissionSubjects 指定用户时返回该用户及其全部角色 否则只返回指定角色 严格角色模式下只能诊断可管理的角色
+func permissionSubjects(adminAuthorityID, userID, authorityID uint) (user *system.SysUser, authorities []system.SysAuthority, err error) {
+	defer func() {
+		for i := 0; err == nil && i < len(authorities); i++ {
+			err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, authorities[i].AuthorityId)
+		}
+	}()
+	if userID != 0 {
+		var u system.SysUser
+		err = global.GVA_DB.Preload("Authorities").Preload("Authority").Where("id = ?", userID).First(&u).Error
+		if err != nil {
+			return nil, nil, errors.New("用户不存在")
+		}
+		for _, v := range u.Authorities {
+			if authorityID == 0 || v.AuthorityId == authorityID {
+				authorities = append(authorities, v)
+			}
+		}
+		if len(authorities) == 0 {
+			if authorityID != 0 {
+				return nil, nil, errors.New("用户不具备该角色")
+			}
+			authorities = []system.SysAuthority{u.Authority}
+		}
+		return &u, authorities, nil
+	}
+	if authorityID == 0 {
+		return nil, nil, errors.New("请指定用户或角色")
+	}
+	var authority system.SysAuthority
+	if err := global.GVA_DB.Where("authority_id = ?", authorityID).First(&authority).Error; err != nil {
+		return nil, nil, errors.New("角色不存在")
+	}
+	return nil, []system.SysAuthority{authority}, nil
+}
+
+// authorityChain 角色自身及在其租户域内通过g规则继承的全部父角色
+func authorityChain(sub, dom string) ([]uint, error) {
+	roles, err := utils.GetCasbin().GetImplicitRolesForUser(sub, dom)
+	if err != nil {
+		return nil, err
+	}
+	chain := make([]uint, 0, len(roles)+1)
+	for _, v := range append([]string{sub}, roles...) {
+		id, err := strconv.Atoi(v)
+		if err == nil {
+			chain = append(chain, uint(id))
+		}
+	}
+	return chain, nil
+}
+
+// matchPolicies 与请求匹配的策略 匹配规则与casbin模型一致
+func matchPolicies(chain []uint, path, method string) (list []systemRes.PermissionPolicy) {
+	e := utils.GetCasbin()
+	for i, id := range chain {
+		policies, _ := e.GetFilteredPolicy(0, strconv.Itoa(int(id)))
+		for _, p := range policies {
+			if len(p) < 3 || p[2] != method || !util.KeyMatch2(path, p[1]) {
+				continue
+			}
+			eft := ""
+			if len(p) > 3 {
+				eft = p[3]
+			}
+			list = append(list, systemRes.PermissionPolicy{
+				AuthorityId: id,
+				Path:        p[1],
+				Method:      p[2],
+				Effect:      eft,
+				Inherited:   i > 0,
+			})
+		}
+	}
+	return list
+}
+
+func hasDeny(list []systemRes.PermissionPolicy) bool {
+	for _, v := range list {
+		if v.Effect == systemReq.CasbinDeny {
+			return true
+		}
+	}
+	return false
+}
+
+func explainReason(allowed bool, list []systemRes.PermissionPolicy) string {
+	for _, v := range list {
+		if v.Effect == systemReq.CasbinDeny {
+			if v.Inherited {
+				return fmt.Sprintf("命中父角色%d的禁止策略 %s %s", v.AuthorityId, v.Method, v.Path)
+			}
+			return fmt.Sprintf("命中禁止策略 %s %s", v.Method, v.Path)
+		}
+	}
+	if allowed {
+		for _, v := range list {
+			if v.Effect == systemReq.CasbinAllow && v.Inherited {
+				return fmt.Sprintf("继承父角色%d的允许策略 %s %s", v.AuthorityId, v.Method, v.Path)
+			}
+		}
+		return "命中允许策略"
+	}
+	if len(list) > 0 {
+		return "匹配的策略未设置效果 请重启服务完成策略升级"
+	}
+	return "没有匹配的允许策略"
+}
+
+// permissionMenus 角色可见的菜单 以及每个菜单下已授权的按钮
+func permissionMenus(authorityID uint) ([]systemRes.PermissionMenu, error) {
+	menus, err := MenuServiceApp.GetMenuAuthority(&request.GetAuthorityId{AuthorityId: authorityID})
+	if err != nil {
+		return nil, err
+	}
+	var btns []system.SysAuthorityBtn
+	if err = global.GVA_DB.Preload("SysBaseMenuBtn").Where("authority_id = ?", authorityID).Find(&btns).Error; err != nil {
+		return nil, err
+	}
+	btnMap := make(map[uint][]string)
+	for _, v := range btns {
+		btnMap[v.SysMenuID] = append(btnMap[v.SysMenuID], v.SysBaseMenuBtn.Name)
+	}
+	list := make([]systemRes.PermissionMenu, 0, len(menus))
+	for _, v := range menus {
+		list = append(list, systemRes.PermissionMenu{
+			ID:      v.MenuId,
+			Name:    v.Name,
+			Title:   v.Title,
+			Path:    v.Path,
+			Buttons: btnMap[v.MenuId],
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,208 @@
+package system
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var casbinTestDB *gorm.DB
+
+// setupCasbinTest casbin实例在进程内只初始化一次 用到casbin的测试共用同一个数据库 每次重建表并重新加载策略
+func setupCasbinTest(t *testing.T, rules []gormadapter.CasbinRule, models ...interface{}) *gorm.DB {
+	if casbinTestDB == nil {
+		db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sqlDB, _ := db.DB()
+		sqlDB.SetMaxOpenConns(1)
+		casbinTestDB = db
+	}
+	db := casbinTestDB
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	models = append(models, &gormadapter.CasbinRule{})
+	if err := db.Migrator().DropTable(models...); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) > 0 {
+		if err := db.Create(&rules).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := utils.GetCasbin().LoadPolicy(); err != nil {
+		t.Fatal(err)
+	}
+	AuthorityBtnServiceApp.ClearBtnApiCache()
+	return db
+}
+
+func setupExplainTest(t *testing.T) {
+	db := setupCasbinTest(t, []gormadapter.CasbinRule{
+		{Ptype: "p", V0: "888", V1: "/user/:id", V2: "GET", V3: "allow", V4: "0"},
+		{Ptype: "p", V0: "888", V1: "/api/deleteApi", V2: "POST", V3: "allow", V4: "0"},
+		{Ptype: "p", V0: "888", V1: "/api/createApi", V2: "POST", V3: "allow", V4: "0"},
+		{Ptype: "p", V0: "8881", V1: "/api/deleteApi", V2: "POST", V3: "deny", V4: "0"},
+		{Ptype: "p", V0: "9528", V1: "/api/createApi", V2: "POST", V3: "allow", V4: "0"},
+		{Ptype: "g", V0: "8881", V1: "888", V2: "0"},
+	}, &system.SysAuthority{}, &system.SysUser{}, &system.SysUserAuthority{}, &system.SysApi{}, &system.SysBaseMenu{},
+		&system.SysAuthorityMenu{}, &system.SysBaseMenuBtn{}, &system.SysBaseMenuBtnApi{}, &system.SysAuthorityBtn{})
+
+	root, admin, yes := uint(0), uint(888), true
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 888, AuthorityName: "admin", ParentId: &root},
+		{AuthorityId: 8881, AuthorityName: "user", ParentId: &admin, InheritParent: &yes},
+		{AuthorityId: 9528, AuthorityName: "test", ParentId: &root},
+	})
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", Enable: 1, AuthorityId: 8881})
+	db.Create(&[]system.SysUserAuthority{{SysUserId: 1, SysAuthorityAuthorityId: 8881}, {SysUserId: 1, SysAuthorityAuthorityId: 9528}})
+	db.Create(&[]system.SysApi{
+		{GVA_MODEL: global.GVA_MODEL{ID: 1}, Path: "/user/:id", Method: "GET", ApiGroup: "user", Description: "获取用户"},
+		{GVA_MODEL: global.GVA_MODEL{ID: 2}, Path: "/api/deleteApi", Method: "POST", ApiGroup: "api", Description: "删除api"},
+		{GVA_MODEL: global.GVA_MODEL{ID: 3}, Path: "/api/createApi", Method: "POST", ApiGroup: "api", Description: "创建api"},
+		{GVA_MODEL: global.GVA_MODEL{ID: 4}, Path: "/menu/getMenu", Method: "POST", ApiGroup: "menu", Description: "获取菜单"},
+	})
+	db.Create(&system.SysBaseMenu{GVA_MODEL: global.GVA_MODEL{ID: 1}, Name: "api", Path: "api"})
+	db.Create(&system.SysAuthorityMenu{MenuId: "1", AuthorityId: "8881"})
+	db.Create(&[]system.SysBaseMenuBtn{
+		{GVA_MODEL: global.GVA_MODEL{ID: 1}, Name: "delete", SysBaseMenuID: 1},
+		{GVA_MODEL: global.GVA_MODEL{ID: 2}, Name: "create", SysBaseMenuID: 1},
+	})
+	db.Create(&[]system.SysBaseMenuBtnApi{{SysBaseMenuBtnID: 1, SysApiID: 2}, {SysBaseMenuBtnID: 2, SysApiID: 3}})
+	// create按钮授予888 8881通过继承获得
+	db.Create(&[]system.SysAuthorityBtn{
+		{AuthorityId: 888, SysMenuID: 1, SysBaseMenuBtnID: 2},
+		{AuthorityId: 8881, SysMenuID: 1, SysBaseMenuBtnID: 1},
+	})
+}
+
+func TestExplainPermission(t *testing.T) {
+	setupExplainTest(t)
+
+	tests := []struct {
+		name        string
+		authorityId uint
+		method      string
+		path        string
+		allowed     bool
+		reason      string
+	}{
+		{"inherited allow", 8881, "get", "/user/1", true, "继承父角色888的允许策略 GET /user/:id"},
+		{"own deny over inherited allow", 8881, "POST", "/api/deleteApi", false, "命中禁止策略 POST /api/deleteApi"},
+		{"button not granted", 9528, "POST", "/api/createApi", false, "命中允许策略 但未被授予该api关联的按钮"},
+		{"inherited button", 8881, "POST", "/api/createApi", true, "继承父角色888的允许策略 POST /api/createApi"},
+		{"no policy", 9528, "POST", "/menu/getMenu", false, "没有匹配的允许策略"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CasbinServiceApp.ExplainPermission(888, systemReq.PermissionExplain{AuthorityId: tt.authorityId, Method: tt.method, Path: tt.path})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Api == nil || res.Api.Method != strings.ToUpper(tt.method) {
+				t.Errorf("request should match a registered api, got %+v", res.Api)
+			}
+			if len(res.Authorities) != 1 {
+				t.Fatalf("want 1 authority, got %d", len(res.Authorities))
+			}
+			item := res.Authorities[0]
+			if item.Allowed != tt.allowed || item.Reason != tt.reason {
+				t.Errorf("got allowed=%v reason=%q", item.Allowed, item.Reason)
+			}
+		})
+	}
+
+	res, err := CasbinServiceApp.ExplainPermission(888, systemReq.PermissionExplain{AuthorityId: 8881, Method: "GET", Path: "/user/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := res.Authorities[0]
+	if !reflect.DeepEqual(item.Roles, []uint{8881, 888}) {
+		t.Errorf("roles should list the authority before its parents, got %v", item.Roles)
+	}
+	if len(item.Menus) != 1 || !reflect.DeepEqual(item.Menus[0].Buttons, []string{"delete"}) {
+		t.Errorf("unexpected menus %+v", item.Menus)
+	}
+
+	// 指定用户时诊断其全部角色
+	res, err = CasbinServiceApp.ExplainPermission(888, systemReq.PermissionExplain{UserID: 1, Method: "POST", Path: "/api/createApi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.User == nil || !res.UserActive || len(res.Authorities) != 2 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	for _, v := range res.Authorities {
+		if v.Current != (v.AuthorityId == 8881) || v.Allowed != (v.AuthorityId == 8881) {
+			t.Errorf("authority %d: current=%v allowed=%v", v.AuthorityId, v.Current, v.Allowed)
+		}
+	}
+
+	if _, err = CasbinServiceApp.ExplainPermission(888, systemReq.PermissionExplain{Method: "GET", Path: "/user/1"}); err == nil {
+		t.Error("a user or an authority is required")
+	}
+	if _, err = CasbinServiceApp.ExplainPermission(888, systemReq.PermissionExplain{UserID: 1, AuthorityId: 888, Method: "GET", Path: "/user/1"}); err == nil {
+		t.Error("the user does not have authority 888")
+	}
+	global.GVA_CONFIG.System.UseStrictAuth = true
+	defer func() { global.GVA_CONFIG.System.UseStrictAuth = false }()
+	if _, err = CasbinServiceApp.ExplainPermission(9528, systemReq.PermissionExplain{AuthorityId: 8881, Method: "GET", Path: "/user/1"}); err == nil {
+		t.Error("strict auth should reject explaining an authority outside the caller's tree")
+	}
+}
+
+func TestPermissionMatrix(t *testing.T) {
+	setupExplainTest(t)
+
+	res, err := CasbinServiceApp.GetPermissionMatrix(888, systemReq.PermissionMatrix{AuthorityId: 8881})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, row := range res.Rows {
+		got = append(got, row.Method+" "+row.Path+" "+row.Effects[0])
+	}
+	want := []string{
+		"POST /api/createApi allow",
+		"POST /api/deleteApi deny",
+		"POST /menu/getMenu ",
+		"GET /user/:id allow",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matrix rows:\n got %q\nwant %q", got, want)
+	}
+
+	file, err := CasbinServiceApp.ExportPermissionMatrix(888, systemReq.PermissionMatrix{UserID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := excelize.OpenReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 || !reflect.DeepEqual(rows[0], []string{"API分组", "描述", "方法", "路径", "user(8881)", "test(9528)"}) {
+		t.Fatalf("unexpected sheet %q", rows)
+	}
+	if !reflect.DeepEqual(rows[1][4:], []string{"允许", "无权限"}) || !reflect.DeepEqual(rows[2][4:], []string{"禁止", "无权限"}) {
+		t.Errorf("unexpected effects %q %q", rows[1], rows[2])
+	}
+}
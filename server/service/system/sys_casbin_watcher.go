@@ -0,0 +1,209 @@
+package system
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+// casbinPolicyChannel 开启redis时各实例通过该频道通知其他实例重新加载casbin策略
+const casbinPolicyChannel = "gva:casbin:policy"
+
+// casbinRevisionTTL 轮询模式下变更记录的保留时长 远大于轮询间隔与回看窗口即可
+const casbinRevisionTTL = time.Hour
+
+// casbinRevisionOverlap 轮询时回看的时长 覆盖较晚提交的事务与实例间的时钟偏差
+const casbinRevisionOverlap = time.Minute
+
+// casbinWatcher 实现casbin的persist.Watcher 本实例修改策略后通知其他实例重新加载
+type casbinWatcher struct {
+	node     string
+	mu       sync.RWMutex
+	callback func(string)
+}
+
+var casbinWatcherApp *casbinWatcher
+
+func (w *casbinWatcher) SetUpdateCallback(f func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = f
+	return nil
+}
+
+// Update 由enforcer在策略变更后调用 此时策略已经落库 通知失败只记录日志 避免变更被误报为失败
+func (w *casbinWatcher) Update() error {
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		if err := global.GVA_REDIS.Publish(context.Background(), casbinPolicyChannel, w.node).Err(); err != nil {
+			global.GVA_LOG.Error("广播casbin策略变更失败!", zap.Error(err))
+		}
+		return nil
+	}
+	if err := global.GVA_DB.Create(&system.SysCasbinRevision{Node: w.node}).Error; err != nil {
+		global.GVA_LOG.Error("记录casbin策略变更失败!", zap.Error(err))
+		return nil
+	}
+	err := global.GVA_DB.Where("created_at < ?", time.Now().Add(-casbinRevisionTTL)).Delete(&system.SysCasbinRevision{}).Error
+	if err != nil {
+		global.GVA_LOG.Error("清理casbin策略变更记录失败!", zap.Error(err))
+	}
+	return nil
+}
+
+func (w *casbinWatcher) Close() {}
+
+// reload 其他实例修改了策略 本实例发起的变更已在内存中生效无需重复加载
+func (w *casbinWatcher) reload(node string) {
+	if node == w.node {
+		return
+	}
+	w.mu.RLock()
+	callback := w.callback
+	w.mu.RUnlock()
+	if callback != nil {
+		callback(node)
+	}
+}
+
+//@function: WatchCasbin
+//@description: 多实例同步casbin策略 开启redis时订阅广播 否则定时轮询数据库中其他实例的变更记录
+//@param: ctx context.Context
+
+func WatchCasbin(ctx context.Context) {
+	e := utils.GetCasbin()
+	if e == nil {
+		return
+	}
+	node, err := utils.RandomToken(8)
+	if err != nil {
+		global.GVA_LOG.Error("初始化casbin同步失败!", zap.Error(err))
+		return
+	}
+	w := &casbinWatcher{node: node}
+	if err = e.SetWatcher(w); err != nil {
+		global.GVA_LOG.Error("初始化casbin同步失败!", zap.Error(err))
+		return
+	}
+	// SetWatcher设置的默认回调不会清除决策缓存 改为调用SyncedCachedEnforcer的LoadPolicy
+	_ = w.SetUpdateCallback(func(string) {
+		if err := e.LoadPolicy(); err != nil {
+			global.GVA_LOG.Error("同步casbin策略失败!", zap.Error(err))
+		}
+	})
+	casbinWatcherApp = w
+
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		go w.subscribe(ctx)
+		return
+	}
+	interval := 5 * time.Second
+	if global.GVA_CONFIG.System.CasbinPoll != "" {
+		d, err := utils.ParseDuration(global.GVA_CONFIG.System.CasbinPoll)
+		if err != nil || d <= 0 {
+			global.GVA_LOG.Error("casbin策略轮询间隔配置错误 使用默认值5s", zap.String("casbin-poll", global.GVA_CONFIG.System.CasbinPoll))
+		} else {
+			interval = d
+		}
+	}
+	go w.poll(ctx, interval)
+}
+
+// subscribe 每次(重新)订阅成功后重新加载策略 补齐断线期间遗漏的变更
+func (w *casbinWatcher) subscribe(ctx context.Context) {
+	pubsub := global.GVA_REDIS.Subscribe(ctx, casbinPolicyChannel)
+	defer pubsub.Close()
+	subscribed := false
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			global.GVA_LOG.Error("订阅casbin策略变更失败!", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			if m.Kind == "subscribe" {
+				// 启动时策略刚刚加载 只有重连时才需要补齐
+				if subscribed {
+					w.reload("")
+				}
+				subscribed = true
+			}
+		case *redis.Message:
+			w.reload(m.Payload)
+		}
+	}
+}
+
+// revisionPoller 按创建时间轮询变更记录 回看窗口内的记录按ID去重 避免重复加载
+type revisionPoller struct {
+	since time.Time
+	seen  map[uint]time.Time
+}
+
+// check 返回是否有其他实例的新变更
+func (p *revisionPoller) check(node string, now time.Time) (bool, error) {
+	var list []system.SysCasbinRevision
+	err := global.GVA_DB.Select("id", "node", "created_at").Where("created_at >= ?", p.since).Find(&list).Error
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	for _, v := range list {
+		if _, ok := p.seen[v.ID]; ok {
+			continue
+		}
+		p.seen[v.ID] = v.CreatedAt
+		changed = changed || v.Node != node
+	}
+	p.since = now.Add(-casbinRevisionOverlap)
+	for id, at := range p.seen {
+		if at.Before(p.since) {
+			delete(p.seen, id)
+		}
+	}
+	return changed, nil
+}
+
+func (w *casbinWatcher) poll(ctx context.Context, interval time.Duration) {
+	// 启动时策略刚刚加载 回看窗口内已有的变更视为已处理
+	p := &revisionPoller{since: time.Now().Add(-casbinRevisionOverlap), seen: map[uint]time.Time{}}
+	if _, err := p.check(w.node, time.Now()); err != nil {
+		global.GVA_LOG.Error("同步casbin策略失败!", zap.Error(err))
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := p.check(w.node, time.Now())
+			if err != nil {
+				global.GVA_LOG.Error("同步casbin策略失败!", zap.Error(err))
+				continue
+			}
+			// 同一轮询周期内的多次变更只需加载一次
+			if changed {
+				w.reload("")
+			}
+		}
+	}
+}
+
+// notifyCasbinChange 绕过enforcer直接修改数据库中的策略后 通知其他实例重新加载
+func notifyCasbinChange() {
+	if casbinWatcherApp != nil {
+		_ = casbinWatcherApp.Update()
+	}
+}
@@ -0,0 +1,55 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestRevisionPoller(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysCasbinRevision{}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	db.Create(&system.SysCasbinRevision{ID: 1, CreatedAt: now.Add(-10 * time.Second), Node: "other"})
+	p := &revisionPoller{since: now.Add(-casbinRevisionOverlap), seen: map[uint]time.Time{}}
+	if _, err = p.check("self", now); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		rev  *system.SysCasbinRevision
+		want bool
+	}{
+		{"no change", nil, false},
+		{"own change", &system.SysCasbinRevision{ID: 3, CreatedAt: now, Node: "self"}, false},
+		{"other change", &system.SysCasbinRevision{ID: 4, CreatedAt: now, Node: "other"}, true},
+		// ID较小的变更较晚提交 创建时间仍在回看窗口内
+		{"committed out of order", &system.SysCasbinRevision{ID: 2, CreatedAt: now.Add(-5 * time.Second), Node: "other"}, true},
+		{"already seen", nil, false},
+	}
+	for _, tt := range tests {
+		if tt.rev != nil {
+			db.Create(tt.rev)
+		}
+		now = now.Add(time.Second)
+		got, err := p.check("self", now)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: changed = %v, err %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"context"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+type ChangeHistoryService struct{}
+
+var ChangeHistoryServiceApp = new(ChangeHistoryService)
+
+//@function: GetChangeHistoryList
+//@description: 分页获取变更历史 按时间倒序 指定表名与记录主键时即为该记录的时间线 按数据权限过滤操作人 按字段权限隐藏或脱敏字段变化
+//@param: ctx context.Context, info systemReq.SysChangeHistorySearch
+//@return: list []system.SysChangeHistory, total int64, err error
+
+func (changeHistoryService *ChangeHistoryService) GetChangeHistoryList(ctx context.Context, info systemReq.SysChangeHistorySearch) (list []system.SysChangeHistory, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysChangeHistory{}).Scopes(DataScopeServiceApp.Scope(ctx))
+	if info.Table != "" {
+		db = db.Where("table_name = ?", info.Table)
+	}
+	if info.RecordID != "" {
+		db = db.Where("record_id = ?", info.RecordID)
+	}
+	if info.RequestID != "" {
+		db = db.Where("request_id = ?", info.RequestID)
+	}
+	if info.UserID != 0 {
+		db = db.Where("user_id = ?", info.UserID)
+	}
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	err = db.Limit(limit).Offset(offset).Order("id desc").Preload("User").Find(&list).Error
+	if err != nil {
+		return
+	}
+	if claims, ok := utils.GetClaimsFromContext(ctx); ok {
+		err = filterChanges(list, claims.AuthorityId)
+	}
+	return list, total, err
+}
+
+// filterChanges 按角色的字段权限去掉隐藏列的变化 脱敏列的前后值
+func filterChanges(list []system.SysChangeHistory, authorityId uint) error {
+	rules, err := AuthorityFieldServiceApp.ColumnRules(authorityId)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	for i := range list {
+		changes := list[i].Changes[:0]
+		for _, c := range list[i].Changes {
+			switch rules[list[i].Table+"."+c.Field] {
+			case system.FieldRuleHidden:
+				continue
+			case system.FieldRuleMasked:
+				c.Before, c.After = maskValue(c.Before), maskValue(c.After)
+			}
+			changes = append(changes, c)
+		}
+		list[i].Changes = changes
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package system
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	commonReq "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// TestChangeHistoryPermissions 变更历史按数据权限过滤操作人 按字段权限隐藏或脱敏字段变化
+func TestChangeHistoryPermissions(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	err = db.AutoMigrate(&system.SysChangeHistory{}, &system.SysUser{}, &system.SysAuthority{}, &system.SysUserAuthority{},
+		&system.SysModelField{}, &system.SysAuthorityField{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := uint(0)
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 300, AuthorityName: "all", ParentId: &root, DataScope: system.DataScopeAll},
+		{AuthorityId: 301, AuthorityName: "self", ParentId: &root, DataScope: system.DataScopeSelf},
+	})
+	db.Create(&[]system.SysModelField{
+		{Model: "model/system.SysUser", Field: "phone", Table: "sys_users", Column: "phone"},
+		{Model: "model/system.SysUser", Field: "email", Table: "sys_users", Column: "email"},
+	})
+	err = AuthorityFieldServiceApp.SetAuthorityField(systemReq.SysAuthorityFieldReq{
+		AuthorityId: 301,
+		Model:       "model/system.SysUser",
+		Rules: []system.SysAuthorityField{
+			{Field: "phone", Rule: system.FieldRuleMasked},
+			{Field: "email", Rule: system.FieldRuleHidden},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := []system.FieldChange{
+		{Field: "phone", Before: "13800138000", After: "13900139000"},
+		{Field: "email", Before: "a@example.com", After: "b@example.com"},
+		{Field: "nick_name", Before: "a", After: "b"},
+	}
+	db.Create(&[]system.SysChangeHistory{
+		{Table: "sys_users", RecordID: "5", Action: system.ChangeActionUpdate, Changes: changes, UserID: 1},
+		{Table: "sys_users", RecordID: "5", Action: system.ChangeActionUpdate, Changes: changes, UserID: 2},
+	})
+
+	list := func(userID, authorityId uint) []system.SysChangeHistory {
+		t.Helper()
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		utils.SetClaims(c, &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: userID, AuthorityId: authorityId}})
+		res, total, err := ChangeHistoryServiceApp.GetChangeHistoryList(c.Request.Context(), systemReq.SysChangeHistorySearch{Table: "sys_users", PageInfo: commonReq.PageInfo{Page: 1, PageSize: 10}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != int64(len(res)) {
+			t.Errorf("total %d does not match list %d", total, len(res))
+		}
+		return res
+	}
+
+	if res := list(9, 300); len(res) != 2 || len(res[0].Changes) != 3 || res[0].Changes[0].Before != "13800138000" {
+		t.Errorf("authority with all data and no field rules should see everything, got %+v", res)
+	}
+	res := list(1, 301)
+	if len(res) != 1 || res[0].UserID != 1 {
+		t.Fatalf("self data scope should only list the caller's changes, got %+v", res)
+	}
+	got := res[0].Changes
+	if len(got) != 2 || got[0].Field != "phone" || got[1].Field != "nick_name" {
+		t.Fatalf("hidden column should be removed, got %+v", got)
+	}
+	if got[0].Before != "138****8000" || got[0].After != "139****9000" || got[1].After != "b" {
+		t.Errorf("masked column should be masked, got %+v", got)
+	}
+}
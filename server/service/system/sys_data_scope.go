@@ -0,0 +1,147 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DataScoper 自定义数据权限过滤使用的列
+// 未实现时使用模型的created_by列作为创建者列 且不使用角色列
+type DataScoper interface {
+	// DataScopeColumns 返回创建者ID列与创建者角色ID列 角色列为空时通过创建者所属角色过滤
+	DataScopeColumns() (creatorColumn string, authorityColumn string)
+}
+
+const defaultCreatorColumn = "created_by"
+
+type DataScopeService struct{}
+
+var DataScopeServiceApp = new(DataScopeService)
+
+//@function: Scope
+//@description: 按当前用户所属角色的数据权限过滤查询 ctx中没有登录信息或模型没有创建者列时不做过滤
+//@param: ctx context.Context
+//@return: func(db *gorm.DB) *gorm.DB
+
+func (dataScopeService *DataScopeService) Scope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		claims, ok := utils.GetClaimsFromContext(ctx)
+		if !ok {
+			return db
+		}
+		creatorColumn, authorityColumn := dataScopeColumns(db)
+		if creatorColumn == "" && authorityColumn == "" {
+			return db
+		}
+		var authority system.SysAuthority
+		err := global.GVA_DB.Preload("DataAuthorityId").Where("authority_id = ?", claims.AuthorityId).First(&authority).Error
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		userID := claims.BaseClaims.ID
+		switch authority.DataScope {
+		case system.DataScopeAll:
+			return db
+		case system.DataScopeAuthority:
+			return dataScopeByAuthorities(db, creatorColumn, authorityColumn, []uint{authority.AuthorityId})
+		case system.DataScopeList:
+			ids := make([]uint, 0, len(authority.DataAuthorityId))
+			for _, v := range authority.DataAuthorityId {
+				ids = append(ids, v.AuthorityId)
+			}
+			if len(ids) > 0 {
+				return dataScopeByAuthorities(db, creatorColumn, authorityColumn, ids)
+			}
+		case system.DataScopeSQL:
+			// 关闭自定义SQL后 已设置的角色按本人处理
+			if global.GVA_CONFIG.System.DataScopeSQL && authority.DataScopeSQL != "" {
+				return db.Where("("+authority.DataScopeSQL+")", map[string]interface{}{
+					"userId":      userID,
+					"authorityId": authority.AuthorityId,
+				})
+			}
+		}
+		// 仅本人 未知的范围同样按本人处理
+		if creatorColumn == "" {
+			db.AddError(errors.New("数据没有创建者列 无法按本人过滤"))
+			return db
+		}
+		return db.Where("? = ?", clause.Column{Table: clause.CurrentTable, Name: creatorColumn}, userID)
+	}
+}
+
+//@function: CheckDataScope
+//@description: 校验角色数据权限范围设置
+//@param: scope string, sql string
+//@return: error
+
+func (dataScopeService *DataScopeService) CheckDataScope(scope string, sql string) error {
+	switch scope {
+	case system.DataScopeAll, system.DataScopeSelf, system.DataScopeAuthority, system.DataScopeList:
+		return nil
+	case system.DataScopeSQL:
+		// 自定义条件会直接拼接进查询 只允许单条条件表达式 且需要在配置中显式开启
+		if !global.GVA_CONFIG.System.DataScopeSQL {
+			return errors.New("未开启自定义SQL数据权限 请在配置中开启system.data-scope-sql")
+		}
+		if strings.TrimSpace(sql) == "" {
+			return errors.New("自定义数据权限条件不能为空")
+		}
+		if strings.Contains(sql, ";") || strings.Contains(sql, "--") || strings.Contains(sql, "/*") {
+			return errors.New("自定义数据权限条件不能包含分号或注释")
+		}
+		return nil
+	}
+	return errors.New("未知的数据权限范围")
+}
+
+// dataScopeColumns 获取查询模型的创建者列与角色列
+func dataScopeColumns(db *gorm.DB) (creatorColumn string, authorityColumn string) {
+	model := db.Statement.Model
+	if model == nil {
+		model = db.Statement.Dest
+	}
+	if model == nil {
+		return "", ""
+	}
+	if err := db.Statement.Parse(model); err != nil || db.Statement.Schema == nil {
+		return "", ""
+	}
+	if scoper, ok := reflect.New(db.Statement.Schema.ModelType).Interface().(DataScoper); ok {
+		return scoper.DataScopeColumns()
+	}
+	if db.Statement.Schema.LookUpField(defaultCreatorColumn) != nil {
+		return defaultCreatorColumn, ""
+	}
+	return "", ""
+}
+
+// dataScopeByAuthorities 按创建者角色过滤 没有角色列时查询拥有这些角色的用户 按创建者过滤
+// 业务表可能不在主库中 因此用户ID在主库查出后再作为条件
+func dataScopeByAuthorities(db *gorm.DB, creatorColumn, authorityColumn string, authorityIDs []uint) *gorm.DB {
+	if authorityColumn != "" {
+		return db.Where("? IN ?", clause.Column{Table: clause.CurrentTable, Name: authorityColumn}, authorityIDs)
+	}
+	var userIDs []uint
+	err := global.GVA_DB.Model(&system.SysUserAuthority{}).Distinct("sys_user_id").
+		Where("sys_authority_authority_id IN ?", authorityIDs).Pluck("sys_user_id", &userIDs).Error
+	if err != nil {
+		global.GVA_LOG.Error("获取角色用户失败!", zap.Error(err))
+		db.AddError(err)
+		return db
+	}
+	if len(userIDs) == 0 {
+		return db.Where("1 = 0")
+	}
+	return db.Where("? IN ?", clause.Column{Table: clause.CurrentTable, Name: creatorColumn}, userIDs)
+}
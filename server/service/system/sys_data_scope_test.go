@@ -0,0 +1,114 @@
+package system
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type scopedRecord struct {
+	ID        uint
+	CreatedBy uint
+}
+
+type customScopedRecord struct {
+	ID          uint
+	OwnerID     uint
+	OwnerAuthID uint
+}
+
+func (customScopedRecord) DataScopeColumns() (string, string) {
+	return "owner_id", "owner_auth_id"
+}
+
+func TestDataScope(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	if err = db.AutoMigrate(&system.SysAuthority{}, &system.SysUserAuthority{}, &scopedRecord{}, &customScopedRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&[]system.SysUserAuthority{{SysUserId: 1, SysAuthorityAuthorityId: 100}, {SysUserId: 2, SysAuthorityAuthorityId: 100}, {SysUserId: 3, SysAuthorityAuthorityId: 200}})
+	db.Create(&[]scopedRecord{{CreatedBy: 1}, {CreatedBy: 2}, {CreatedBy: 3}, {CreatedBy: 3}})
+	db.Create(&[]customScopedRecord{{OwnerID: 1, OwnerAuthID: 100}, {OwnerID: 3, OwnerAuthID: 200}})
+
+	// 通过中间件写入的claims获取当前用户
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	utils.SetClaims(c, &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, AuthorityId: 100}})
+	ctx := c.Request.Context()
+	global.GVA_CONFIG.System.DataScopeSQL = true
+	defer func() { global.GVA_CONFIG.System.DataScopeSQL = false }()
+
+	tests := []struct {
+		scope  string
+		sql    string
+		data   []uint
+		want   int64
+		custom int64
+	}{
+		{system.DataScopeAll, "", nil, 4, 2},
+		{system.DataScopeSelf, "", nil, 1, 1},
+		{system.DataScopeAuthority, "", nil, 2, 1},
+		{system.DataScopeList, "", []uint{200}, 2, 1},
+		{system.DataScopeList, "", nil, 1, 1},
+		{system.DataScopeSQL, "created_by = @userId OR created_by = 3", nil, 3, 0},
+	}
+	for _, tt := range tests {
+		db.Where("1 = 1").Delete(&system.SysAuthority{})
+		auth := system.SysAuthority{AuthorityId: 100, AuthorityName: "test", DataScope: tt.scope, DataScopeSQL: tt.sql}
+		for _, id := range tt.data {
+			auth.DataAuthorityId = append(auth.DataAuthorityId, &system.SysAuthority{AuthorityId: id, AuthorityName: "data"})
+		}
+		db.Create(&auth)
+
+		var total int64
+		if err = db.Model(&scopedRecord{}).Scopes(DataScopeServiceApp.Scope(ctx)).Count(&total).Error; err != nil {
+			t.Fatalf("%s: %v", tt.scope, err)
+		}
+		if total != tt.want {
+			t.Errorf("%s: got %d records, want %d", tt.scope, total, tt.want)
+		}
+		if tt.scope == system.DataScopeSQL {
+			continue
+		}
+		var list []customScopedRecord
+		if err = db.Scopes(DataScopeServiceApp.Scope(ctx)).Find(&list).Error; err != nil {
+			t.Fatalf("%s: %v", tt.scope, err)
+		}
+		if int64(len(list)) != tt.custom {
+			t.Errorf("%s: got %d custom records, want %d", tt.scope, len(list), tt.custom)
+		}
+		db.Exec("DELETE FROM sys_data_authority_id")
+	}
+
+	// 关闭自定义SQL后 已设置SQL的角色按本人过滤 也不能再设置SQL
+	global.GVA_CONFIG.System.DataScopeSQL = false
+	var count int64
+	if err = db.Model(&scopedRecord{}).Scopes(DataScopeServiceApp.Scope(ctx)).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("sql scope while disabled: got %d records, want 1", count)
+	}
+	if err = DataScopeServiceApp.CheckDataScope(system.DataScopeSQL, "created_by = @userId"); err == nil {
+		t.Error("sql scope should be rejected while disabled")
+	}
+
+	// 没有登录信息时不过滤
+	var total int64
+	db.Model(&scopedRecord{}).Scopes(DataScopeServiceApp.Scope(context.Background())).Count(&total)
+	if total != 4 {
+		t.Errorf("without claims: got %d records, want 4", total)
+	}
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -9,42 +10,45 @@ import (
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
 	"gorm.io/gorm"
 )
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: CreateSysDictionary
 //@description: 创建字典数据
-//@param: sysDictionary model.SysDictionary
+//@param: ctx context.Context, sysDictionary model.SysDictionary
 //@return: err error
 
 type DictionaryService struct{}
 
 var DictionaryServiceApp = new(DictionaryService)
 
-func (dictionaryService *DictionaryService) CreateSysDictionary(sysDictionary system.SysDictionary) (err error) {
-	if (!errors.Is(global.GVA_DB.First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound)) {
+func (dictionaryService *DictionaryService) CreateSysDictionary(ctx context.Context, sysDictionary system.SysDictionary) (err error) {
+	// 租户可以创建与平台同类型的字典 覆盖平台的字典
+	if (!errors.Is(global.GVA_DB.WithContext(ctx).Scopes(tenant.Own(ctx)).First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound)) {
 		return errors.New("存在相同的type，不允许创建")
 	}
-	err = global.GVA_DB.Create(&sysDictionary).Error
+	err = global.GVA_DB.WithContext(ctx).Create(&sysDictionary).Error
 	return err
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: DeleteSysDictionary
 //@description: 删除字典数据
-//@param: sysDictionary model.SysDictionary
+//@param: ctx context.Context, sysDictionary model.SysDictionary
 //@return: err error
 
-func (dictionaryService *DictionaryService) DeleteSysDictionary(sysDictionary system.SysDictionary) (err error) {
-	err = global.GVA_DB.Where("id = ?", sysDictionary.ID).Preload("SysDictionaryDetails").First(&sysDictionary).Error
+func (dictionaryService *DictionaryService) DeleteSysDictionary(ctx context.Context, sysDictionary system.SysDictionary) (err error) {
+	// 租户只能删除自己的字典 字典详情不区分租户 需要先确认字典归属
+	err = global.GVA_DB.WithContext(ctx).Scopes(tenant.Own(ctx)).Where("id = ?", sysDictionary.ID).Preload("SysDictionaryDetails").First(&sysDictionary).Error
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return errors.New("请不要搞事")
 	}
 	if err != nil {
 		return err
 	}
-	err = global.GVA_DB.Delete(&sysDictionary).Error
+	err = global.GVA_DB.WithContext(ctx).Delete(&sysDictionary).Error
 	if err != nil {
 		return err
 	}
@@ -58,10 +62,10 @@ func (dictionaryService *DictionaryService) DeleteSysDictionary(sysDictionary sy
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: UpdateSysDictionary
 //@description: 更新字典数据
-//@param: sysDictionary *model.SysDictionary
+//@param: ctx context.Context, sysDictionary *model.SysDictionary
 //@return: err error
 
-func (dictionaryService *DictionaryService) UpdateSysDictionary(sysDictionary *system.SysDictionary) (err error) {
+func (dictionaryService *DictionaryService) UpdateSysDictionary(ctx context.Context, sysDictionary *system.SysDictionary) (err error) {
 	var dict system.SysDictionary
 	sysDictionaryMap := map[string]interface{}{
 		"Name":     sysDictionary.Name,
@@ -70,13 +74,13 @@ func (dictionaryService *DictionaryService) UpdateSysDictionary(sysDictionary *s
 		"Desc":     sysDictionary.Desc,
 		"ParentID": sysDictionary.ParentID,
 	}
-	err = global.GVA_DB.Where("id = ?", sysDictionary.ID).First(&dict).Error
+	err = global.GVA_DB.WithContext(ctx).Scopes(tenant.Own(ctx)).Where("id = ?", sysDictionary.ID).First(&dict).Error
 	if err != nil {
 		global.GVA_LOG.Debug(err.Error())
 		return errors.New("查询字典数据失败")
 	}
 	if dict.Type != sysDictionary.Type {
-		if !errors.Is(global.GVA_DB.First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound) {
+		if !errors.Is(global.GVA_DB.WithContext(ctx).Scopes(tenant.Own(ctx)).First(&system.SysDictionary{}, "type = ?", sysDictionary.Type).Error, gorm.ErrRecordNotFound) {
 			return errors.New("存在相同的type，不允许创建")
 		}
 	}
@@ -88,24 +92,24 @@ func (dictionaryService *DictionaryService) UpdateSysDictionary(sysDictionary *s
 		}
 	}
 
-	err = global.GVA_DB.Model(&dict).Updates(sysDictionaryMap).Error
+	err = global.GVA_DB.WithContext(ctx).Model(&dict).Updates(sysDictionaryMap).Error
 	return err
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetSysDictionary
-//@description: 根据id或者type获取字典单条数据
-//@param: Type string, Id uint
+//@description: 根据id或者type获取字典单条数据 租户与平台存在同类型字典时使用租户自己的
+//@param: ctx context.Context, Type string, Id uint
 //@return: err error, sysDictionary model.SysDictionary
 
-func (dictionaryService *DictionaryService) GetSysDictionary(Type string, Id uint, status *bool) (sysDictionary system.SysDictionary, err error) {
+func (dictionaryService *DictionaryService) GetSysDictionary(ctx context.Context, Type string, Id uint, status *bool) (sysDictionary system.SysDictionary, err error) {
 	var flag = false
 	if status == nil {
 		flag = true
 	} else {
 		flag = *status
 	}
-	err = global.GVA_DB.Where("(type = ? OR id = ?) and status = ?", Type, Id, flag).Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
+	err = global.GVA_DB.WithContext(ctx).Where("(type = ? OR id = ?) and status = ?", Type, Id, flag).Order("tenant_id desc").Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
 		return db.Where("status = ? and deleted_at is null", true).Order("sort")
 	}).First(&sysDictionary).Error
 	return
@@ -120,7 +124,7 @@ func (dictionaryService *DictionaryService) GetSysDictionary(Type string, Id uin
 
 func (dictionaryService *DictionaryService) GetSysDictionaryInfoList(c *gin.Context, req request.SysDictionarySearch) (list interface{}, err error) {
 	var sysDictionarys []system.SysDictionary
-	query := global.GVA_DB.WithContext(c)
+	query := global.GVA_DB.WithContext(c.Request.Context())
 	if req.Name != "" {
 		query = query.Where("name LIKE ? OR type LIKE ?", "%"+req.Name+"%", "%"+req.Name+"%")
 	}
@@ -157,13 +161,13 @@ func (dictionaryService *DictionaryService) checkCircularReference(currentID uin
 //@author: [pixelMax]
 //@function: ExportSysDictionary
 //@description: 导出字典JSON（包含字典详情）
-//@param: id uint
+//@param: ctx context.Context, id uint
 //@return: exportData map[string]interface{}, err error
 
-func (dictionaryService *DictionaryService) ExportSysDictionary(id uint) (exportData map[string]interface{}, err error) {
+func (dictionaryService *DictionaryService) ExportSysDictionary(ctx context.Context, id uint) (exportData map[string]interface{}, err error) {
 	var dictionary system.SysDictionary
 	// 查询字典及其所有详情
-	err = global.GVA_DB.Where("id = ?", id).Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", id).Preload("SysDictionaryDetails", func(db *gorm.DB) *gorm.DB {
 		return db.Order("sort")
 	}).First(&dictionary).Error
 	if err != nil {
@@ -200,10 +204,10 @@ func (dictionaryService *DictionaryService) ExportSysDictionary(id uint) (export
 //@author: [pixelMax]
 //@function: ImportSysDictionary
 //@description: 导入字典JSON（包含字典详情）
-//@param: jsonStr string
+//@param: ctx context.Context, jsonStr string
 //@return: err error
 
-func (dictionaryService *DictionaryService) ImportSysDictionary(jsonStr string) error {
+func (dictionaryService *DictionaryService) ImportSysDictionary(ctx context.Context, jsonStr string) error {
 	// 直接解析到 SysDictionary 结构体
 	var importData system.SysDictionary
 	if err := json.Unmarshal([]byte(jsonStr), &importData); err != nil {
@@ -219,7 +223,7 @@ func (dictionaryService *DictionaryService) ImportSysDictionary(jsonStr string)
 	}
 
 	// 检查字典类型是否已存在
-	if !errors.Is(global.GVA_DB.First(&system.SysDictionary{}, "type = ?", importData.Type).Error, gorm.ErrRecordNotFound) {
+	if !errors.Is(global.GVA_DB.WithContext(ctx).Scopes(tenant.Own(ctx)).First(&system.SysDictionary{}, "type = ?", importData.Type).Error, gorm.ErrRecordNotFound) {
 		return errors.New("存在相同的type，不允许导入")
 	}
 
@@ -232,7 +236,7 @@ func (dictionaryService *DictionaryService) ImportSysDictionary(jsonStr string)
 	}
 
 	// 开启事务
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 创建字典
 		if err := tx.Create(&dictionary).Error; err != nil {
 			return err
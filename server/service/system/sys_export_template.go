@@ -125,9 +125,9 @@ func (sysExportTemplateService *SysExportTemplateService) GetSysExportTemplateIn
 	return sysExportTemplates, total, err
 }
 
-// ExportExcel 导出Excel
+// ExportExcel 导出Excel 按导出人角色的字段权限隐藏或脱敏列
 // Author [piexlmax](https://github.com/piexlmax)
-func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID string, values url.Values) (file *bytes.Buffer, name string, err error) {
+func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID string, values url.Values, authorityId uint) (file *bytes.Buffer, name string, err error) {
 	var params = values.Get("params")
 	paramsValues, err := url.ParseQuery(params)
 	if err != nil {
@@ -159,11 +159,20 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	if err != nil {
 		return nil, "", err
 	}
+	// 导出不经过response.DataFilter 在这里按列应用字段权限
+	columnRules, err := AuthorityFieldServiceApp.ColumnRules(authorityId)
+	if err != nil {
+		return nil, "", err
+	}
 	var tableTitle []string
 	var selectKeyFmt []string
-	for _, key := range columns {
+	columnRule := make([]string, len(columns))
+	for i, key := range columns {
 		selectKeyFmt = append(selectKeyFmt, key)
-		tableTitle = append(tableTitle, templateInfoMap[key])
+		columnRule[i] = columnRules[exportColumnSource(template.TableName, key)]
+		if columnRule[i] != system.FieldRuleHidden {
+			tableTitle = append(tableTitle, templateInfoMap[key])
+		}
 	}
 
 	selects := strings.Join(selectKeyFmt, ", ")
@@ -318,7 +327,10 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	rows = append(rows, tableTitle)
 	for _, exTable := range tableMap {
 		var row []string
-		for _, column := range columns {
+		for i, column := range columns {
+			if columnRule[i] == system.FieldRuleHidden {
+				continue
+			}
 			column = strings.ReplaceAll(column, "\"", "")
 			column = strings.ReplaceAll(column, "`", "")
 			if len(template.JoinTemplate) > 0 {
@@ -333,31 +345,21 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 				}
 			}
 			// 需要对时间类型特殊处理
+			var cell string
 			if t, ok := exTable[column].(time.Time); ok {
-				row = append(row, t.Format("2006-01-02 15:04:05"))
+				cell = t.Format("2006-01-02 15:04:05")
 			} else {
-				row = append(row, fmt.Sprintf("%v", exTable[column]))
+				cell = fmt.Sprintf("%v", exTable[column])
+			}
+			if columnRule[i] == system.FieldRuleMasked {
+				cell = maskValue(cell).(string)
 			}
+			row = append(row, cell)
 		}
 		rows = append(rows, row)
 	}
-	for i, row := range rows {
-		for j, colCell := range row {
-			cell := fmt.Sprintf("%s%d", getColumnName(j+1), i+1)
-
-			var sErr error
-			if v, err := strconv.ParseFloat(colCell, 64); err == nil {
-				sErr = f.SetCellValue("Sheet1", cell, v)
-			} else if v, err := strconv.ParseInt(colCell, 10, 64); err == nil {
-				sErr = f.SetCellValue("Sheet1", cell, v)
-			} else {
-				sErr = f.SetCellValue("Sheet1", cell, colCell)
-			}
-
-			if sErr != nil {
-				return nil, "", sErr
-			}
-		}
+	if err = writeExcelRows(f, "Sheet1", rows); err != nil {
+		return nil, "", err
 	}
 	f.SetActiveSheet(index)
 	file, err = f.WriteToBuffer()
@@ -368,6 +370,18 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	return file, template.Name, nil
 }
 
+// exportColumnSource 导出列对应的 表名.列名 未指定表名的列属于模板主表
+func exportColumnSource(table, column string) string {
+	column = strings.ReplaceAll(column, "\"", "")
+	column = strings.ReplaceAll(column, "`", "")
+	column, _, _ = strings.Cut(column, " as ")
+	column = strings.TrimSpace(column)
+	if strings.Contains(column, ".") {
+		return column
+	}
+	return table + "." + column
+}
+
 // PreviewSQL 预览最终生成的 SQL（不执行查询，仅返回 SQL 字符串）
 // Author [piexlmax](https://github.com/piexlmax) & [trae-ai]
 func (sysExportTemplateService *SysExportTemplateService) PreviewSQL(templateID string, values url.Values) (sqlPreview string, err error) {
@@ -713,6 +727,29 @@ func (sysExportTemplateService *SysExportTemplateService) importByGORM(tx *gorm.
 	return tx.Table(tableName).CreateInBatches(&items, 1000).Error
 }
 
+// writeExcelRows 将二维表逐行写入工作表 数字按数值写入 其余按文本写入
+func writeExcelRows(f *excelize.File, sheet string, rows [][]string) error {
+	for i, row := range rows {
+		for j, colCell := range row {
+			cell := fmt.Sprintf("%s%d", getColumnName(j+1), i+1)
+
+			var sErr error
+			if v, err := strconv.ParseFloat(colCell, 64); err == nil {
+				sErr = f.SetCellValue(sheet, cell, v)
+			} else if v, err := strconv.ParseInt(colCell, 10, 64); err == nil {
+				sErr = f.SetCellValue(sheet, cell, v)
+			} else {
+				sErr = f.SetCellValue(sheet, cell, colCell)
+			}
+
+			if sErr != nil {
+				return sErr
+			}
+		}
+	}
+	return nil
+}
+
 func getColumnName(n int) string {
 	columnName := ""
 	for n > 0 {
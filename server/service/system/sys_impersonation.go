@@ -0,0 +1,58 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+var (
+	ErrImpersonateForbidden = errors.New("当前角色不允许模拟登录")
+	ErrImpersonateNested    = errors.New("模拟登录期间不能再模拟其他用户")
+	ErrImpersonateSelf      = errors.New("不能模拟自己")
+	ErrImpersonateTarget    = errors.New("不能模拟已禁用的用户或同样具备模拟登录权限的用户")
+)
+
+//@function: Impersonate
+//@description: 以目标用户身份签发模拟登录token 目标用户的菜单与权限按其自身角色计算
+//@param: ctx context.Context, operator *systemReq.CustomClaims, userID uint
+//@return: user system.SysUser, token string, claims systemReq.CustomClaims, err error
+
+func (userService *UserService) Impersonate(ctx context.Context, operator *systemReq.CustomClaims, userID uint) (user system.SysUser, token string, claims systemReq.CustomClaims, err error) {
+	conf := global.GVA_CONFIG.Impersonation
+	if !conf.Allowed(operator.AuthorityId) {
+		return user, "", claims, ErrImpersonateForbidden
+	}
+	if operator.ImpersonatorID != 0 {
+		return user, "", claims, ErrImpersonateNested
+	}
+	if operator.BaseClaims.ID == userID {
+		return user, "", claims, ErrImpersonateSelf
+	}
+	err = global.GVA_DB.WithContext(ctx).Preload("Authorities").Preload("Authority").First(&user, "id = ?", userID).Error
+	if err != nil {
+		return user, "", claims, err
+	}
+	// 不允许模拟同样具备模拟权限的用户 避免绕过审计互相模拟
+	if user.Enable != 1 || conf.Allowed(user.AuthorityId) {
+		return user, "", claims, ErrImpersonateTarget
+	}
+	for _, a := range user.Authorities {
+		if conf.Allowed(a.AuthorityId) {
+			return user, "", claims, ErrImpersonateTarget
+		}
+	}
+	expires := time.Hour
+	if conf.ExpiresTime != "" {
+		if d, e := utils.ParseDuration(conf.ExpiresTime); e == nil && d > 0 {
+			expires = d
+		}
+	}
+	token, claims, err = utils.ImpersonateToken(&user, operator, expires)
+	return user, token, claims, err
+}
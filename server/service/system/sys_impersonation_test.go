@@ -0,0 +1,85 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestImpersonate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.JWT.SigningKey = "test"
+	global.GVA_CONFIG.JWT.ExpiresTime = "7d"
+	global.GVA_CONFIG.Impersonation.AuthorityIds = []uint{888}
+	global.GVA_CONFIG.Impersonation.ExpiresTime = "30m"
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysAuthority{}, &system.SysUserAuthority{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&[]system.SysAuthority{{AuthorityId: 888, AuthorityName: "admin"}, {AuthorityId: 8881, AuthorityName: "user"}})
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "admin", Enable: 1, AuthorityId: 888})
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 2}, Username: "user", Enable: 1, AuthorityId: 8881})
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 3}, Username: "disabled", Enable: 2, AuthorityId: 8881})
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 4}, Username: "admin2", Enable: 1, AuthorityId: 8881})
+	db.Create(&system.SysUserAuthority{SysUserId: 4, SysAuthorityAuthorityId: 888})
+
+	admin := &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, Username: "admin", AuthorityId: 888}}
+	tests := []struct {
+		name     string
+		operator *systemReq.CustomClaims
+		userID   uint
+		err      error
+	}{
+		{"forbidden authority", &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 2, Username: "user", AuthorityId: 8881}}, 4, ErrImpersonateForbidden},
+		{"nested", &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, Username: "admin", AuthorityId: 888, ImpersonatorID: 9}}, 2, ErrImpersonateNested},
+		{"self", admin, 1, ErrImpersonateSelf},
+		{"disabled target", admin, 3, ErrImpersonateTarget},
+		// 附加角色具备模拟权限的用户同样不能被模拟
+		{"privileged target", admin, 4, ErrImpersonateTarget},
+		{"unknown target", admin, 5, gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, token, _, err := UserServiceApp.Impersonate(context.Background(), tt.operator, tt.userID)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("want %v, got %v", tt.err, err)
+			}
+			if token != "" {
+				t.Error("no token should be issued on failure")
+			}
+		})
+	}
+
+	user, token, claims, err := UserServiceApp.Impersonate(context.Background(), admin, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 2 || claims.BaseClaims.ID != 2 || claims.AuthorityId != 8881 {
+		t.Errorf("token should carry the target user, got %+v", claims.BaseClaims)
+	}
+	parsed, err := utils.NewJWT().ParseToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.ImpersonatorID != 1 || parsed.ImpersonatorName != "admin" {
+		t.Errorf("token should record the operator, got %d %q", parsed.ImpersonatorID, parsed.ImpersonatorName)
+	}
+	if d := time.Until(parsed.ExpiresAt.Time); d > 30*time.Minute {
+		t.Errorf("token should expire after the configured 30m instead of the jwt expires-time, got %s", d)
+	}
+}
@@ -0,0 +1,214 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	loginFailPre  = "GVA_LOGIN_FAIL_"
+	loginLockPre  = "GVA_LOGIN_LOCK_"
+	loginLevelPre = "GVA_LOGIN_LEVEL_"
+)
+
+type LoginLockService struct{}
+
+var LoginLockServiceApp = new(LoginLockService)
+
+//@function: LockedUntil
+//@description: 查询用户名是否处于锁定期 返回锁定截止时间
+//@param: username string
+//@return: until time.Time, locked bool, err error
+
+func (loginLockService *LoginLockService) LockedUntil(username string) (until time.Time, locked bool, err error) {
+	if global.GVA_CONFIG.LoginLock.MaxAttempts <= 0 || username == "" {
+		return until, false, nil
+	}
+	now := time.Now()
+	if useRedisLock() {
+		v, e := global.GVA_REDIS.Get(context.Background(), loginLockPre+username).Int64()
+		if errors.Is(e, redis.Nil) {
+			return until, false, nil
+		}
+		if e != nil {
+			return until, false, e
+		}
+		until = time.Unix(v, 0)
+		return until, until.After(now), nil
+	}
+	var lock system.SysLoginLock
+	err = global.GVA_DB.Where("username = ?", username).First(&lock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return until, false, nil
+	}
+	if err != nil || lock.LockedUntil == nil {
+		return until, false, err
+	}
+	return *lock.LockedUntil, lock.LockedUntil.After(now), nil
+}
+
+//@function: RecordFailure
+//@description: 记录一次登录失败 窗口内失败次数达到上限时锁定账号 锁定时长随连续锁定次数指数增长
+//@param: username string
+//@return: until time.Time, locked bool, err error
+
+func (loginLockService *LoginLockService) RecordFailure(username string) (until time.Time, locked bool, err error) {
+	conf := global.GVA_CONFIG.LoginLock
+	if conf.MaxAttempts <= 0 || username == "" {
+		return until, false, nil
+	}
+	if useRedisLock() {
+		return recordFailureRedis(conf, username)
+	}
+	return recordFailureDB(conf, username, time.Now())
+}
+
+//@function: RecordSuccess
+//@description: 登录成功后清除失败次数与锁定等级
+//@param: username string
+//@return: err error
+
+func (loginLockService *LoginLockService) RecordSuccess(username string) error {
+	if global.GVA_CONFIG.LoginLock.MaxAttempts <= 0 || username == "" {
+		return nil
+	}
+	return loginLockService.Unlock(username)
+}
+
+//@function: Unlock
+//@description: 解除用户名的锁定 同时清除失败次数与锁定等级
+//@param: username string
+//@return: err error
+
+func (loginLockService *LoginLockService) Unlock(username string) error {
+	if useRedisLock() {
+		return global.GVA_REDIS.Del(context.Background(), loginFailPre+username, loginLockPre+username, loginLevelPre+username).Err()
+	}
+	return global.GVA_DB.Unscoped().Where("username = ?", username).Delete(&system.SysLoginLock{}).Error
+}
+
+//@function: UnlockUser
+//@description: 管理员解除用户的锁定 请求已解析租户时只能解除当前租户用户的锁定
+//@param: ctx context.Context, username string
+//@return: err error
+
+func (loginLockService *LoginLockService) UnlockUser(ctx context.Context, username string) error {
+	if _, ok := tenant.FromContext(ctx); ok {
+		err := global.GVA_DB.WithContext(ctx).Select("id").Where("username = ?", username).First(&system.SysUser{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return loginLockService.Unlock(username)
+}
+
+func useRedisLock() bool {
+	return global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil
+}
+
+// lockDuration 第level次锁定的时长 从lock-duration开始每次翻倍 不超过max-duration
+func lockDuration(conf config.LoginLock, level int) time.Duration {
+	d := time.Duration(conf.LockDuration) * time.Second
+	if d <= 0 {
+		d = time.Minute
+	}
+	max := time.Duration(conf.MaxDuration) * time.Second
+	for i := 1; i < level && i <= 20; i++ {
+		d *= 2
+		if max > 0 && d >= max {
+			break
+		}
+	}
+	if max > 0 && d > max {
+		d = max
+	}
+	return d
+}
+
+func lockWindow(seconds int, def time.Duration) time.Duration {
+	if seconds <= 0 {
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// recordFailureRedis 失败计数 锁定截止时间 锁定等级分别存放 计数使用INCR保证多实例并发下准确
+func recordFailureRedis(conf config.LoginLock, username string) (until time.Time, locked bool, err error) {
+	ctx := context.Background()
+	failKey := loginFailPre + username
+	n, err := global.GVA_REDIS.Incr(ctx, failKey).Result()
+	if err != nil {
+		return until, false, err
+	}
+	if n == 1 {
+		global.GVA_REDIS.Expire(ctx, failKey, lockWindow(conf.Window, 15*time.Minute))
+	}
+	if n < int64(conf.MaxAttempts) {
+		return until, false, nil
+	}
+	levelKey := loginLevelPre + username
+	level, err := global.GVA_REDIS.Incr(ctx, levelKey).Result()
+	if err != nil {
+		return until, false, err
+	}
+	global.GVA_REDIS.Expire(ctx, levelKey, lockWindow(conf.ResetAfter, 24*time.Hour))
+	d := lockDuration(conf, int(level))
+	until = time.Now().Add(d)
+	_, err = global.GVA_REDIS.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, loginLockPre+username, strconv.FormatInt(until.Unix(), 10), d)
+		pipe.Del(ctx, failKey)
+		return nil
+	})
+	return until, err == nil, err
+}
+
+func recordFailureDB(conf config.LoginLock, username string, now time.Time) (until time.Time, locked bool, err error) {
+	// 只记录已存在用户的失败 避免随意的用户名使锁定表无限增长
+	var exists int64
+	if err = global.GVA_DB.Model(&system.SysUser{}).Where("username = ?", username).Count(&exists).Error; err != nil || exists == 0 {
+		return until, false, err
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 并发的首次失败同时插入时以唯一索引去重 再加锁读取
+		if e := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "username"}}, DoNothing: true}).
+			Create(&system.SysLoginLock{Username: username}).Error; e != nil {
+			return e
+		}
+		var lock system.SysLoginLock
+		if e := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("username = ?", username).First(&lock).Error; e != nil {
+			return e
+		}
+		if lock.FirstFailAt == nil || now.Sub(*lock.FirstFailAt) > lockWindow(conf.Window, 15*time.Minute) {
+			lock.FailCount = 0
+			lock.FirstFailAt = &now
+		}
+		lock.FailCount++
+		if lock.FailCount >= conf.MaxAttempts {
+			if lock.LockedAt == nil || now.Sub(*lock.LockedAt) > lockWindow(conf.ResetAfter, 24*time.Hour) {
+				lock.LockLevel = 0
+			}
+			lock.LockLevel++
+			until = now.Add(lockDuration(conf, lock.LockLevel))
+			lock.LockedAt = &now
+			lock.LockedUntil = &until
+			lock.FailCount = 0
+			lock.FirstFailAt = nil
+			locked = true
+		}
+		return tx.Select("fail_count", "first_fail_at", "lock_level", "locked_at", "locked_until").Save(&lock).Error
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return until, locked, nil
+}
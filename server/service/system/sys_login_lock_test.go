@@ -0,0 +1,100 @@
+package system
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestLockDuration(t *testing.T) {
+	conf := config.LoginLock{LockDuration: 60, MaxDuration: 600}
+	tests := []struct {
+		level int
+		want  time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{5, 10 * time.Minute},
+		{100, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := lockDuration(conf, tt.level); got != tt.want {
+			t.Errorf("lockDuration(level=%d) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestRecordFailureDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysLoginLock{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{Username: "alice"})
+	conf := config.LoginLock{MaxAttempts: 3, Window: 60, LockDuration: 60, MaxDuration: 600, ResetAfter: 3600}
+	now := time.Now()
+
+	// 不存在的用户名不写入锁定表
+	for i := 0; i < 5; i++ {
+		if _, locked, err := recordFailureDB(conf, "nobody", now); err != nil || locked {
+			t.Fatalf("unknown user: locked %v, err %v", locked, err)
+		}
+	}
+	var count int64
+	db.Model(&system.SysLoginLock{}).Count(&count)
+	if count != 0 {
+		t.Errorf("unknown usernames should not be recorded, got %d rows", count)
+	}
+
+	// 并发的首次失败不应因唯一索引冲突报错
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := recordFailureDB(conf, "alice", now)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent first failure: %v", err)
+		}
+	}
+	until, locked, err := recordFailureDB(conf, "alice", now)
+	if err != nil || !locked || !until.Equal(now.Add(time.Minute)) {
+		t.Fatalf("third failure should lock for 1m: until %v, locked %v, err %v", until, locked, err)
+	}
+
+	// 窗口过期后重新计数
+	later := now.Add(2 * time.Minute)
+	if _, locked, _ = recordFailureDB(conf, "alice", later); locked {
+		t.Error("failure in a new window should not lock")
+	}
+	recordFailureDB(conf, "alice", later)
+	// 重置期内再次锁定 时长翻倍
+	if until, locked, _ = recordFailureDB(conf, "alice", later); !locked || !until.Equal(later.Add(2*time.Minute)) {
+		t.Errorf("second lock should last 2m: until %v, locked %v", until, locked)
+	}
+	db.Model(&system.SysLoginLock{}).Count(&count)
+	if count != 1 {
+		t.Errorf("want 1 lock row, got %d", count)
+	}
+}
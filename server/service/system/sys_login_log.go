@@ -2,9 +2,9 @@ package system
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
-	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"gorm.io/gorm"
 )
 
 type LoginLogService struct{}
@@ -12,18 +12,19 @@ type LoginLogService struct{}
 var LoginLogServiceApp = new(LoginLogService)
 
 func (loginLogService *LoginLogService) CreateLoginLog(loginLog system.SysLoginLog) (err error) {
-	err = global.GVA_DB.Create(&loginLog).Error
-	return err
-}
-
-func (loginLogService *LoginLogService) DeleteLoginLogByIds(ids request.IdsReq) (err error) {
-	err = global.GVA_DB.Delete(&[]system.SysLoginLog{}, "id in (?)", ids.Ids).Error
-	return err
-}
-
-func (loginLogService *LoginLogService) DeleteLoginLog(loginLog system.SysLoginLog) (err error) {
-	err = global.GVA_DB.Delete(&loginLog).Error
-	return err
+	if loginLog.Event == "" {
+		loginLog.Event = system.LoginEventFailed
+		if loginLog.Status {
+			loginLog.Event = system.LoginEventSuccess
+		}
+	}
+	// 登录日志与审计哈希链在同一事务中写入 只能归档不能删除
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&loginLog).Error; err != nil {
+			return err
+		}
+		return appendAuditLog(tx, AuditSourceLoginLog, []uint{loginLog.ID})
+	})
 }
 
 func (loginLogService *LoginLogService) GetLoginLog(id uint) (loginLog system.SysLoginLog, err error) {
@@ -44,6 +45,9 @@ func (loginLogService *LoginLogService) GetLoginLogInfoList(info systemReq.SysLo
 	if info.Status != false {
 		db = db.Where("status = ?", info.Status)
 	}
+	if info.Event != "" {
+		db = db.Where("event = ?", info.Event)
+	}
 	err = db.Count(&total).Error
 	if err != nil {
 		return
@@ -134,6 +134,7 @@ func (menuService *MenuService) getBaseChildrenList(menu *system.SysBaseMenu, tr
 //@return: error
 
 func (menuService *MenuService) AddBaseMenu(menu system.SysBaseMenu) error {
+	defer AuthorityBtnServiceApp.ClearBtnApiCache()
 	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		// 检查name是否重复
 		if !errors.Is(tx.Where("name = ?", menu.Name).First(&system.SysBaseMenu{}).Error, gorm.ErrRecordNotFound) {
@@ -177,8 +178,8 @@ func (menuService *MenuService) AddBaseMenu(menu system.SysBaseMenu) error {
 			}
 		}
 
-		// 创建菜单
-		return tx.Create(&menu).Error
+		// 创建菜单 按钮关联的api只建立关联
+		return tx.Omit("MenuBtn.Apis.*").Create(&menu).Error
 	})
 }
 
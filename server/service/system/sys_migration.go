@@ -0,0 +1,95 @@
+package system
+
+import (
+	"time"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// migration 一次性的数据升级 按名称记录在sys_migrations中 执行成功后不再重复执行
+type migration struct {
+	name string
+	run  func(tx *gorm.DB) error
+}
+
+// migrations 按顺序执行 新的升级追加在末尾 已发布的升级不要修改名称
+var migrations = []migration{
+	{name: "20261018_expire_legacy_api_tokens", run: expireLegacyApiTokens},
+	{name: "20261018_casbin_effect_and_domain", run: fillCasbinEffectAndDomain},
+}
+
+//@function: RunMigrations
+//@description: 执行尚未执行过的数据升级 需在加载jwt黑名单与casbin策略之前调用
+//@return: err error
+
+func RunMigrations() error {
+	for _, m := range migrations {
+		if err := runMigration(global.GVA_DB, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runMigration 先写入升级记录再执行 其他实例写入同一记录时会等待本事务结束 提交后直接跳过
+func runMigration(db *gorm.DB, m migration) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&system.SysMigration{Name: m.name})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if err := m.run(tx); err != nil {
+			return err
+		}
+		global.GVA_LOG.Info("数据升级完成", zap.String("name", m.name))
+		return nil
+	})
+}
+
+// expireLegacyApiTokens 旧版API Token是有效期100年的jwt 拉黑后停用并清除保存的原文
+func expireLegacyApiTokens(tx *gorm.DB) error {
+	var list []system.SysApiToken
+	if err := tx.Select("id", "token", "expires_at").Where("token <> ?", "").Find(&list).Error; err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(list))
+	for _, t := range list {
+		exp := t.ExpiresAt
+		if e, ok := utils.TokenExpiresAt(t.Token); ok {
+			exp = e
+		}
+		if err := tx.Create(&system.JwtBlacklist{Jwt: t.Token, ExpiresAt: &exp}).Error; err != nil {
+			return err
+		}
+		ids = append(ids, t.ID)
+	}
+	return tx.Model(&system.SysApiToken{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"token":      "",
+		"status":     false,
+		"expires_at": time.Now(),
+	}).Error
+}
+
+// fillCasbinEffectAndDomain 旧版本的策略没有效果与域 补为allow并归入平台租户 不改动g规则 角色默认不继承父角色
+func fillCasbinEffectAndDomain(tx *gorm.DB) error {
+	err := tx.Model(&gormadapter.CasbinRule{}).
+		Where("ptype = ? AND (v3 = ? OR v3 IS NULL)", "p", "").
+		Update("v3", request.CasbinAllow).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&gormadapter.CasbinRule{}).
+		Where("ptype = ? AND (v4 = ? OR v4 IS NULL)", "p", "").
+		Update("v4", tenant.Domain(tenant.Platform)).Error
+}
@@ -0,0 +1,142 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+	"github.com/glebarez/sqlite"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestExpireLegacyApiTokens(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.JWT.SigningKey = "test"
+	if err = db.AutoMigrate(&system.SysMigration{}, &system.SysApiToken{}, &system.JwtBlacklist{}, &gormadapter.CasbinRule{}); err != nil {
+		t.Fatal(err)
+	}
+	// 旧版签发的有效期100年的jwt
+	exp := time.Now().AddDate(100, 0, 0).Truncate(time.Second)
+	j := utils.NewJWT()
+	legacy, err := j.CreateToken(request.CustomClaims{
+		BaseClaims:       request.BaseClaims{ID: 1, AuthorityId: 888},
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(exp)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysApiToken{UserID: 1, AuthorityID: 888, Token: legacy, Status: true, ExpiresAt: exp})
+	db.Create(&system.SysApiToken{UserID: 1, AuthorityID: 888, TokenHash: "hash", Status: true, ExpiresAt: time.Now().Add(time.Hour)})
+
+	for i := 0; i < 2; i++ {
+		if err = RunMigrations(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var black []system.JwtBlacklist
+	db.Find(&black)
+	if len(black) != 1 || black[0].Jwt != legacy || black[0].ExpiresAt == nil || !black[0].ExpiresAt.Equal(exp) {
+		t.Fatalf("legacy token should be blacklisted once until it expires: %+v", black)
+	}
+	var tokens []system.SysApiToken
+	db.Order("id").Find(&tokens)
+	if tokens[0].Token != "" || tokens[0].Status || tokens[0].ExpiresAt.After(time.Now()) {
+		t.Errorf("legacy row should be cleared and expired: %+v", tokens[0])
+	}
+	if !tokens[1].Status {
+		t.Error("hashed token should not be touched")
+	}
+	var count int64
+	db.Model(&system.SysMigration{}).Count(&count)
+	if count != int64(len(migrations)) {
+		t.Errorf("want %d migrations recorded, got %d", len(migrations), count)
+	}
+}
+
+func TestFillCasbinEffectAndDomain(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysMigration{}, &system.SysApiToken{}, &system.JwtBlacklist{}, &gormadapter.CasbinRule{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&[]gormadapter.CasbinRule{
+		{Ptype: "p", V0: "8881", V1: "/user/getUserInfo", V2: "GET"},
+		{Ptype: "p", V0: "8881", V1: "/api/deleteApi", V2: "POST", V3: request.CasbinDeny, V4: tenant.Domain(1)},
+		{Ptype: "g", V0: "101", V1: "100", V2: tenant.Domain(1)},
+	})
+	if err = RunMigrations(); err != nil {
+		t.Fatal(err)
+	}
+	var rules []gormadapter.CasbinRule
+	db.Order("id").Find(&rules)
+	if rules[0].V3 != request.CasbinAllow || rules[0].V4 != tenant.Domain(tenant.Platform) {
+		t.Errorf("legacy policy should become an allow in the platform domain: %+v", rules[0])
+	}
+	if rules[1].V3 != request.CasbinDeny || rules[1].V4 != tenant.Domain(1) {
+		t.Errorf("policy with effect and domain should not be touched: %+v", rules[1])
+	}
+	// 不根据父角色生成g规则 已有的g规则保留
+	if len(rules) != 3 || rules[2].V0 != "101" || rules[2].V1 != "100" {
+		t.Errorf("grouping rules should be kept as is: %+v", rules)
+	}
+
+	// 已执行的升级不再重复执行
+	db.Create(&gormadapter.CasbinRule{Ptype: "p", V0: "9528", V1: "/user/getUserInfo", V2: "GET"})
+	if err = RunMigrations(); err != nil {
+		t.Fatal(err)
+	}
+	var rule gormadapter.CasbinRule
+	db.Where("v0 = ?", "9528").First(&rule)
+	if rule.V3 != "" {
+		t.Errorf("migration should run only once, got %+v", rule)
+	}
+}
+
+func TestStopApiTokenUsage(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysApiToken{}); err != nil {
+		t.Fatal(err)
+	}
+	token := system.SysApiToken{UserID: 1, TokenHash: "hash", Status: true, ExpiresAt: time.Now().Add(time.Hour)}
+	db.Create(&token)
+	recordApiTokenUsage(token.ID, "10.0.0.1")
+	recordApiTokenUsage(token.ID, "10.0.0.2")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err = StopApiTokenUsage(ctx); err != nil {
+		t.Fatal(err)
+	}
+	db.First(&token, token.ID)
+	if token.RequestCount != 2 || token.LastUsedIp != "10.0.0.2" {
+		t.Errorf("usage should be flushed on stop: count %d, ip %s", token.RequestCount, token.LastUsedIp)
+	}
+}
@@ -2,7 +2,6 @@ package system
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
-	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 )
@@ -17,29 +16,6 @@ type OperationRecordService struct{}
 
 var OperationRecordServiceApp = new(OperationRecordService)
 
-//@author: [granty1](https://github.com/granty1)
-//@author: [piexlmax](https://github.com/piexlmax)
-//@function: DeleteSysOperationRecordByIds
-//@description: 批量删除记录
-//@param: ids request.IdsReq
-//@return: err error
-
-func (operationRecordService *OperationRecordService) DeleteSysOperationRecordByIds(ids request.IdsReq) (err error) {
-	err = global.GVA_DB.Delete(&[]system.SysOperationRecord{}, "id in (?)", ids.Ids).Error
-	return err
-}
-
-//@author: [granty1](https://github.com/granty1)
-//@function: DeleteSysOperationRecord
-//@description: 删除操作记录
-//@param: sysOperationRecord model.SysOperationRecord
-//@return: err error
-
-func (operationRecordService *OperationRecordService) DeleteSysOperationRecord(sysOperationRecord system.SysOperationRecord) (err error) {
-	err = global.GVA_DB.Delete(&sysOperationRecord).Error
-	return err
-}
-
 //@author: [granty1](https://github.com/granty1)
 //@function: GetSysOperationRecord
 //@description: 根据id获取单条操作记录
@@ -74,10 +50,13 @@ func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoL
 	if info.Status != 0 {
 		db = db.Where("status = ?", info.Status)
 	}
+	if info.RequestID != "" {
+		db = db.Where("request_id = ?", info.RequestID)
+	}
 	err = db.Count(&total).Error
 	if err != nil {
 		return
 	}
-	err = db.Order("id desc").Limit(limit).Offset(offset).Preload("User").Find(&sysOperationRecords).Error
+	err = db.Order("id desc").Limit(limit).Offset(offset).Preload("User").Preload("Impersonator").Find(&sysOperationRecords).Error
 	return sysOperationRecords, total, err
 }
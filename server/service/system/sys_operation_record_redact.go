@@ -0,0 +1,88 @@
+package system
+
+import (
+	"errors"
+	"time"
+
+	"github.com/casbin/casbin/v2/util"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/redact"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+)
+
+// redactTTL api上的脱敏规则只缓存在本地 其他实例修改后最多延迟该时间生效
+const redactTTL = 10 * time.Second
+
+var redactCache = local_cache.NewCache(local_cache.SetDefaultExpire(redactTTL))
+
+//@function: Redactor
+//@description: 获取请求对应的脱敏规则 合并内置规则 配置与匹配到的api上的规则
+//@param: method string, path string
+//@return: *redact.Redactor
+
+func (operationRecordService *OperationRecordService) Redactor(method, path string) *redact.Redactor {
+	key := method + " " + path
+	if v, ok := redactCache.Get(key); ok {
+		return v.(*redact.Redactor)
+	}
+	conf := global.GVA_CONFIG.Redaction
+	rules := []redact.Rules{{Fields: conf.Fields, Headers: conf.Headers, Patterns: conf.Patterns}}
+	apis, err := redactApis()
+	if err != nil {
+		global.GVA_LOG.Error("获取api脱敏规则失败!", zap.Error(err))
+	}
+	for _, api := range apis {
+		if api.Method == method && util.KeyMatch2(path, api.Path) {
+			rules = append(rules, redact.Rules{Fields: api.RedactFields, Headers: api.RedactHeaders, Patterns: api.RedactPatterns})
+		}
+	}
+	r, err := redact.New(conf.Mask, rules...)
+	if err != nil {
+		// 配置的规则有误时至少保证内置规则生效
+		global.GVA_LOG.Error("脱敏规则配置错误 仅使用内置规则", zap.Error(err))
+		r, _ = redact.New(conf.Mask)
+	}
+	redactCache.Set(key, r, redactTTL)
+	return r
+}
+
+//@function: ClearRedactCache
+//@description: api的脱敏规则变化后清除缓存
+//@return:
+
+func (operationRecordService *OperationRecordService) ClearRedactCache() {
+	redactCache.Flush()
+}
+
+// checkRedactRules 校验api上的脱敏正则
+func checkRedactRules(api system.SysApi) error {
+	if _, err := redact.New("", redact.Rules{Patterns: api.RedactPatterns}); err != nil {
+		return errors.New("脱敏正则有误: " + err.Error())
+	}
+	return nil
+}
+
+// redactApis 配置了脱敏规则的api
+func redactApis() ([]system.SysApi, error) {
+	if v, ok := redactCache.Get("apis"); ok {
+		return v.([]system.SysApi), nil
+	}
+	if global.GVA_DB == nil {
+		return nil, nil
+	}
+	var all []system.SysApi
+	err := global.GVA_DB.Select("path", "method", "redact_fields", "redact_headers", "redact_patterns").Find(&all).Error
+	if err != nil {
+		return nil, err
+	}
+	var apis []system.SysApi
+	for _, v := range all {
+		if len(v.RedactFields) > 0 || len(v.RedactHeaders) > 0 || len(v.RedactPatterns) > 0 {
+			apis = append(apis, v)
+		}
+	}
+	redactCache.Set("apis", apis, redactTTL)
+	return apis, nil
+}
@@ -0,0 +1,210 @@
+package system
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	OperationRecordDropNewest = "newest" // 队列已满时丢弃新记录
+	OperationRecordDropOldest = "oldest" // 队列已满时丢弃队列中最早的记录
+)
+
+// operationRecordWriter 操作记录写入内存队列 由后台协程按条数或间隔批量入库
+type operationRecordWriter struct {
+	mu         sync.RWMutex
+	queue      chan system.SysOperationRecord
+	done       chan struct{}
+	closed     bool
+	batchSize  int
+	interval   time.Duration
+	dropOldest bool
+
+	written atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+var recordWriter *operationRecordWriter
+
+// StartOperationRecordWriter 按配置启动操作记录的后台写入 未启动时操作记录同步写入
+func StartOperationRecordWriter() {
+	conf := global.GVA_CONFIG.OperationRecord
+	w := &operationRecordWriter{
+		done:       make(chan struct{}),
+		batchSize:  conf.BatchSize,
+		interval:   time.Duration(conf.FlushInterval) * time.Millisecond,
+		dropOldest: conf.DropPolicy == OperationRecordDropOldest,
+	}
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = 10000
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = 100
+	}
+	if w.interval <= 0 {
+		w.interval = time.Second
+	}
+	if conf.DropPolicy != "" && conf.DropPolicy != OperationRecordDropNewest && !w.dropOldest {
+		global.GVA_LOG.Error("操作记录丢弃策略配置错误 使用默认值newest", zap.String("drop-policy", conf.DropPolicy))
+	}
+	w.queue = make(chan system.SysOperationRecord, queueSize)
+	recordWriter = w
+	go w.run()
+}
+
+// StopOperationRecordWriter 停止接收新记录并写完队列中的记录 超时后放弃等待
+func StopOperationRecordWriter(ctx context.Context) error {
+	w := recordWriter
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//@function: RecordOperation
+//@description: 记录操作 后台写入已启动时放入队列 否则直接入库
+//@param: record system.SysOperationRecord
+//@return:
+
+func (operationRecordService *OperationRecordService) RecordOperation(record system.SysOperationRecord) {
+	w := recordWriter
+	if w == nil || !w.enqueue(record) {
+		if err := createOperationRecords([]system.SysOperationRecord{record}, 1); err != nil {
+			global.GVA_LOG.Error("create operation record error:", zap.Error(err))
+		}
+	}
+}
+
+//@function: GetOperationRecordStats
+//@description: 获取操作记录后台写入的统计
+//@return: stats response.SysOperationRecordStats
+
+func (operationRecordService *OperationRecordService) GetOperationRecordStats() (stats response.SysOperationRecordStats) {
+	w := recordWriter
+	if w == nil {
+		return stats
+	}
+	stats.Enabled = true
+	stats.Queued = len(w.queue)
+	stats.Capacity = cap(w.queue)
+	stats.Written = w.written.Load()
+	stats.Dropped = w.dropped.Load()
+	stats.Failed = w.failed.Load()
+	return stats
+}
+
+// enqueue 放入队列 队列已满时按策略丢弃 已停止时返回false由调用方直接入库
+func (w *operationRecordWriter) enqueue(record system.SysOperationRecord) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return false
+	}
+	select {
+	case w.queue <- record:
+		return true
+	default:
+	}
+	if !w.dropOldest {
+		w.dropped.Add(1)
+		return true
+	}
+	// 腾出位置后仍可能被其他请求抢先 此时丢弃新记录
+	select {
+	case <-w.queue:
+		w.dropped.Add(1)
+	default:
+	}
+	select {
+	case w.queue <- record:
+	default:
+		w.dropped.Add(1)
+	}
+	return true
+}
+
+func (w *operationRecordWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	batch := make([]system.SysOperationRecord, 0, w.batchSize)
+	for {
+		select {
+		case record, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (w *operationRecordWriter) flush(batch []system.SysOperationRecord) {
+	if len(batch) == 0 {
+		return
+	}
+	ids := make([]uint, len(batch))
+	for i := range batch {
+		ids[i] = batch[i].ID
+	}
+	err := createOperationRecords(batch, w.batchSize)
+	if err == nil {
+		w.written.Add(uint64(len(batch)))
+		return
+	}
+	// 整批失败时逐条重试 只丢弃本身无法写入的记录
+	global.GVA_LOG.Warn("批量写入操作记录失败 逐条重试", zap.Error(err), zap.Int("count", len(batch)))
+	for i := range batch {
+		// 事务回滚后恢复写入前的主键
+		batch[i].ID = ids[i]
+		if err = createOperationRecords(batch[i:i+1], 1); err != nil {
+			w.failed.Add(1)
+			global.GVA_LOG.Error("写入操作记录失败!", zap.Error(err), zap.String("path", batch[i].Path))
+			continue
+		}
+		w.written.Add(1)
+	}
+}
+
+// createOperationRecords 操作记录与审计哈希链在同一事务中写入
+func createOperationRecords(records []system.SysOperationRecord, batchSize int) error {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(records, batchSize).Error; err != nil {
+			return err
+		}
+		ids := make([]uint, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.ID)
+		}
+		return appendAuditLog(tx, AuditSourceOperationRecord, ids)
+	})
+}
@@ -0,0 +1,84 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestOperationRecordWriter(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 内存库每个连接是独立的库 写入事务需要使用同一个连接
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysOperationRecord{}, &system.SysAuditLog{}, &system.SysAuditHead{}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { recordWriter = nil }()
+
+	// 未启动消费协程时队列填满 验证丢弃策略
+	for _, tt := range []struct {
+		oldest bool
+		want   string
+	}{{false, "/1"}, {true, "/3"}} {
+		w := &operationRecordWriter{queue: make(chan system.SysOperationRecord, 2), dropOldest: tt.oldest}
+		for _, path := range []string{"/1", "/2", "/3"} {
+			w.enqueue(system.SysOperationRecord{Path: path})
+		}
+		if w.dropped.Load() != 1 || len(w.queue) != 2 {
+			t.Fatalf("oldest=%v: dropped %d queued %d, want 1 and 2", tt.oldest, w.dropped.Load(), len(w.queue))
+		}
+		first, last := <-w.queue, <-w.queue
+		if tt.oldest && last.Path != tt.want || !tt.oldest && first.Path != tt.want {
+			t.Errorf("oldest=%v: queue kept %s %s", tt.oldest, first.Path, last.Path)
+		}
+	}
+
+	// 关闭时写完队列中不足一批的记录 关闭后直接入库
+	global.GVA_CONFIG.OperationRecord.BatchSize = 100
+	global.GVA_CONFIG.OperationRecord.FlushInterval = int(time.Hour / time.Millisecond)
+	StartOperationRecordWriter()
+	for i := 0; i < 5; i++ {
+		OperationRecordServiceApp.RecordOperation(system.SysOperationRecord{Path: "/queued"})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = StopOperationRecordWriter(ctx); err != nil {
+		t.Fatal(err)
+	}
+	OperationRecordServiceApp.RecordOperation(system.SysOperationRecord{Path: "/direct"})
+	var total int64
+	db.Model(&system.SysOperationRecord{}).Count(&total)
+	if total != 6 {
+		t.Errorf("got %d records, want 6", total)
+	}
+	if stats := OperationRecordServiceApp.GetOperationRecordStats(); stats.Written != 5 || stats.Dropped != 0 {
+		t.Errorf("stats = %+v, want 5 written", stats)
+	}
+
+	// 整批失败时逐条写入 只有主键冲突的记录失败
+	w := &operationRecordWriter{batchSize: 10}
+	w.flush([]system.SysOperationRecord{{Path: "/a"}, {GVA_MODEL: global.GVA_MODEL{ID: 1}, Path: "/dup"}, {Path: "/b"}})
+	if w.written.Load() != 2 || w.failed.Load() != 1 {
+		t.Errorf("written %d failed %d, want 2 and 1", w.written.Load(), w.failed.Load())
+	}
+	db.Model(&system.SysOperationRecord{}).Where("path IN ?", []string{"/a", "/b"}).Count(&total)
+	if total != 2 {
+		t.Errorf("got %d records from the failed batch, want 2", total)
+	}
+	db.Model(&system.SysAuditLog{}).Count(&total)
+	if total != 8 {
+		t.Errorf("got %d audit logs, want 8", total)
+	}
+}
@@ -0,0 +1,85 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOldPasswordWrong = errors.New("原密码错误")
+	ErrPasswordReused   = fmt.Errorf("%w: 不能使用最近使用过的密码", utils.ErrPasswordPolicy)
+)
+
+//@function: checkNewPassword
+//@description: 校验新密码是否满足密码策略 并且不能与当前密码及最近使用过的密码相同
+//@param: db *gorm.DB, user system.SysUser, password string
+//@return: error
+
+func checkNewPassword(db *gorm.DB, user system.SysUser, password string) error {
+	policy := global.GVA_CONFIG.PasswordPolicy
+	if err := utils.CheckPasswordPolicy(policy, user.Username, password); err != nil {
+		return err
+	}
+	if policy.HistoryCount <= 0 || user.ID == 0 {
+		return nil
+	}
+	if user.Password != "" && utils.BcryptCheck(password, user.Password) {
+		return ErrPasswordReused
+	}
+	var hashes []string
+	err := db.Model(&system.SysPasswordHistory{}).Where("user_id = ?", user.ID).
+		Order("id desc").Limit(policy.HistoryCount).Pluck("password", &hashes).Error
+	if err != nil {
+		return err
+	}
+	for _, h := range hashes {
+		if utils.BcryptCheck(password, h) {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+//@function: savePasswordHistory
+//@description: 记录新密码哈希 只保留策略要求的最近N条
+//@param: db *gorm.DB, userID uint, hash string
+//@return: error
+
+func savePasswordHistory(db *gorm.DB, userID uint, hash string) error {
+	keep := global.GVA_CONFIG.PasswordPolicy.HistoryCount
+	if keep <= 0 {
+		return nil
+	}
+	if err := db.Create(&system.SysPasswordHistory{UserID: userID, Password: hash}).Error; err != nil {
+		return err
+	}
+	var ids []uint
+	err := db.Model(&system.SysPasswordHistory{}).Where("user_id = ?", userID).Order("id desc").Pluck("id", &ids).Error
+	if err != nil || len(ids) <= keep {
+		return err
+	}
+	return db.Unscoped().Delete(&system.SysPasswordHistory{}, ids[keep:]).Error
+}
+
+//@function: passwordExpired
+//@description: 按密码有效期判断密码是否已过期 从未修改过密码时以创建时间为准
+//@param: user system.SysUser, now time.Time
+//@return: bool
+
+func passwordExpired(user system.SysUser, now time.Time) bool {
+	maxAge := global.GVA_CONFIG.PasswordPolicy.MaxAge
+	if maxAge <= 0 {
+		return false
+	}
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return now.Sub(changedAt) > time.Duration(maxAge)*24*time.Hour
+}
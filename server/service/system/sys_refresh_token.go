@@ -0,0 +1,131 @@
+package system
+
+import (
+	"errors"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token无效或已过期")
+	ErrRefreshTokenReused  = errors.New("refresh token重复使用 该会话已被注销")
+)
+
+type RefreshTokenService struct{}
+
+var RefreshTokenServiceApp = new(RefreshTokenService)
+
+//@function: IssueRefreshToken
+//@description: 签发refresh token familyID为空时开启新的令牌族(新的登录会话)
+//@param: userID uint, familyID string, ip string, agent string
+//@return: token string, record system.SysRefreshToken, err error
+
+func (refreshTokenService *RefreshTokenService) IssueRefreshToken(userID uint, familyID, ip, agent string) (token string, record system.SysRefreshToken, err error) {
+	return refreshTokenService.issue(global.GVA_DB, userID, familyID, ip, agent)
+}
+
+func (refreshTokenService *RefreshTokenService) issue(db *gorm.DB, userID uint, familyID, ip, agent string) (token string, record system.SysRefreshToken, err error) {
+	ep, err := utils.ParseDuration(global.GVA_CONFIG.JWT.RefreshExpiresTime)
+	if err != nil {
+		return "", record, err
+	}
+	token, err = utils.RandomToken(32)
+	if err != nil {
+		return "", record, err
+	}
+	if familyID == "" {
+		familyID = uuid.NewString()
+	}
+	record = system.SysRefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.SHA256Hex(token),
+		ExpiresAt: time.Now().Add(ep),
+		Ip:        ip,
+		Agent:     agent,
+	}
+	err = db.Create(&record).Error
+	return token, record, err
+}
+
+//@function: RotateRefreshToken
+//@description: 使用refresh token换取同族的新令牌 旧令牌只能使用一次 重复使用视为泄露并注销整个令牌族与对应的登录会话
+//@param: token string, ip string, agent string
+//@return: newToken string, record system.SysRefreshToken, err error
+
+func (refreshTokenService *RefreshTokenService) RotateRefreshToken(token, ip, agent string) (newToken string, record system.SysRefreshToken, err error) {
+	var old system.SysRefreshToken
+	err = global.GVA_DB.Where("token_hash = ?", utils.SHA256Hex(token)).First(&old).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", record, ErrRefreshTokenInvalid
+		}
+		return "", record, err
+	}
+	if old.RevokedAt != nil || old.ExpiresAt.Before(time.Now()) {
+		return "", record, ErrRefreshTokenInvalid
+	}
+	if old.UsedAt != nil {
+		_ = SessionServiceApp.RevokeFamilySession(old.UserID, old.FamilyID)
+		return "", record, ErrRefreshTokenReused
+	}
+
+	reused := false
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// 以条件更新抢占令牌 并发刷新时只有一个请求能成功
+		res := tx.Model(&system.SysRefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", old.ID).
+			Update("used_at", &now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			reused = true
+			return nil
+		}
+		var e error
+		newToken, record, e = refreshTokenService.issue(tx, old.UserID, old.FamilyID, ip, agent)
+		return e
+	})
+	if err != nil {
+		return "", record, err
+	}
+	if reused {
+		_ = SessionServiceApp.RevokeFamilySession(old.UserID, old.FamilyID)
+		return "", record, ErrRefreshTokenReused
+	}
+	return newToken, record, nil
+}
+
+//@function: RevokeFamily
+//@description: 注销一个令牌族 该会话下所有refresh token失效
+//@param: familyID string
+//@return: err error
+
+func (refreshTokenService *RefreshTokenService) RevokeFamily(familyID string) error {
+	if familyID == "" {
+		return nil
+	}
+	now := time.Now()
+	return global.GVA_DB.Model(&system.SysRefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", &now).Error
+}
+
+//@function: RevokeUserRefreshTokens
+//@description: 注销用户全部refresh token
+//@param: userID uint
+//@return: err error
+
+func (refreshTokenService *RefreshTokenService) RevokeUserRefreshTokens(userID uint) error {
+	now := time.Now()
+	return global.GVA_DB.Model(&system.SysRefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", &now).Error
+}
@@ -0,0 +1,108 @@
+package system
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/session"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestRefreshTokenRotation(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.JWT.RefreshExpiresTime = "7d"
+	if err = db.AutoMigrate(&system.SysRefreshToken{}); err != nil {
+		t.Fatal(err)
+	}
+
+	first, record, err := RefreshTokenServiceApp.IssueRefreshToken(1, "", "127.0.0.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, rotated, err := RefreshTokenServiceApp.RotateRefreshToken(first, "127.0.0.1", "test")
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if second == first || rotated.FamilyID != record.FamilyID {
+		t.Errorf("rotation should issue a new token in family %s, got family %s", record.FamilyID, rotated.FamilyID)
+	}
+
+	// 旧令牌再次使用 整个令牌族被注销
+	if _, _, err = RefreshTokenServiceApp.RotateRefreshToken(first, "127.0.0.1", "test"); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("reusing a rotated token: want ErrRefreshTokenReused, got %v", err)
+	}
+	if _, _, err = RefreshTokenServiceApp.RotateRefreshToken(second, "127.0.0.1", "test"); !errors.Is(err, ErrRefreshTokenInvalid) {
+		t.Errorf("token of a revoked family: want ErrRefreshTokenInvalid, got %v", err)
+	}
+
+	// 其他令牌族不受影响
+	other, _, err := RefreshTokenServiceApp.IssueRefreshToken(1, "", "127.0.0.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = RefreshTokenServiceApp.RotateRefreshToken(other, "127.0.0.1", "test"); err != nil {
+		t.Errorf("other family: %v", err)
+	}
+	if _, _, err = RefreshTokenServiceApp.RotateRefreshToken("unknown", "127.0.0.1", "test"); !errors.Is(err, ErrRefreshTokenInvalid) {
+		t.Errorf("unknown token: want ErrRefreshTokenInvalid, got %v", err)
+	}
+}
+
+// TestRefreshTokenReuseEndsSession 刷新后旧的access token失效 重复使用refresh token时注销对应的登录会话
+func TestRefreshTokenReuseEndsSession(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_CONFIG.JWT.RefreshExpiresTime = "7d"
+	if err = db.AutoMigrate(&system.SysRefreshToken{}, &system.JwtBlacklist{}, &system.SysAuthority{}); err != nil {
+		t.Fatal(err)
+	}
+
+	user := system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", AuthorityId: 888}
+	first, record, err := RefreshTokenServiceApp.IssueRefreshToken(1, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = SessionServiceApp.CreateSession(user, record.FamilyID, "access-1", "", "", record.ExpiresAt); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = RefreshTokenServiceApp.RotateRefreshToken(first, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	claims := systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, Username: "u", AuthorityId: 888, SessionID: record.FamilyID}}
+	if err = SessionServiceApp.RenewSession(claims, "access-2", "", "", record.ExpiresAt); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := global.BlackCache.Get("access-1"); !ok {
+		t.Error("access token replaced by a refresh should be blacklisted")
+	}
+
+	if _, _, err = RefreshTokenServiceApp.RotateRefreshToken(first, "", ""); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("want ErrRefreshTokenReused, got %v", err)
+	}
+	if err = SessionServiceApp.CheckSession(&claims); !errors.Is(err, session.ErrSessionNotFound) {
+		t.Errorf("session of a reused family should be ended, got %v", err)
+	}
+	if _, ok := global.BlackCache.Get("access-2"); !ok {
+		t.Error("current access token of a reused family should be blacklisted")
+	}
+}
@@ -0,0 +1,265 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/session"
+	"go.uber.org/zap"
+)
+
+// sessionTouchInterval 最近活跃时间的最小写入间隔 避免每个请求都写存储
+const sessionTouchInterval = time.Minute
+
+type SessionService struct{}
+
+var SessionServiceApp = new(SessionService)
+
+var sessionTouched sync.Map // 会话ID -> 上次写入活跃时间
+
+//@function: CreateSession
+//@description: 登录成功后登记会话 超出角色允许的在线数时注销最久未活跃的会话
+//@param: user system.SysUser, sessionID string, token string, ip string, agent string, expiresAt time.Time
+//@return: err error
+
+func (sessionService *SessionService) CreateSession(user system.SysUser, sessionID, token, ip, agent string, expiresAt time.Time) error {
+	now := time.Now()
+	err := session.GetStore().Save(system.SysSession{
+		ID:          sessionID,
+		UserID:      user.ID,
+		Username:    user.Username,
+		AuthorityId: user.AuthorityId,
+		Token:       token,
+		Ip:          ip,
+		UserAgent:   agent,
+		LoginAt:     now,
+		LastSeenAt:  now,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	return sessionService.enforceLimit(user.ID, user.AuthorityId, sessionID)
+}
+
+//@function: RenewSession
+//@description: refresh token轮换后更新会话持有的access token并拉黑旧的access token 会话丢失(如进程重启)时重新登记
+//@param: claims systemReq.CustomClaims, token string, ip string, agent string, expiresAt time.Time
+//@return: err error
+
+func (sessionService *SessionService) RenewSession(claims systemReq.CustomClaims, token, ip, agent string, expiresAt time.Time) error {
+	store := session.GetStore()
+	now := time.Now()
+	sess, err := store.Get(claims.BaseClaims.ID, claims.SessionID)
+	if err != nil {
+		if !errors.Is(err, session.ErrSessionNotFound) {
+			return err
+		}
+		sess = &system.SysSession{
+			ID:       claims.SessionID,
+			UserID:   claims.BaseClaims.ID,
+			Username: claims.Username,
+			LoginAt:  now,
+		}
+	}
+	previous := sess.Token
+	sess.AuthorityId = claims.AuthorityId
+	sess.Token = token
+	sess.Ip = ip
+	sess.UserAgent = agent
+	sess.LastSeenAt = now
+	sess.ExpiresAt = expiresAt
+	if err = store.Save(*sess); err != nil {
+		return err
+	}
+	// 刷新后旧的access token立即失效 同一会话只保留一个有效的access token
+	if previous == "" || previous == token {
+		return nil
+	}
+	return JwtServiceApp.JsonInBlacklist(system.JwtBlacklist{Jwt: previous})
+}
+
+//@function: CheckSession
+//@description: 校验access token所属的登录会话仍然存在 会话被注销或强制下线后该会话签发过的全部access token失效
+//@param: claims *systemReq.CustomClaims
+//@return: err error 会话不存在时为session.ErrSessionNotFound
+
+func (sessionService *SessionService) CheckSession(claims *systemReq.CustomClaims) error {
+	if claims == nil || claims.SessionID == "" {
+		return session.ErrSessionNotFound
+	}
+	_, err := session.GetStore().Get(claims.BaseClaims.ID, claims.SessionID)
+	return err
+}
+
+//@function: TouchSession
+//@description: 记录会话最近活跃时间 同一会话一分钟内只写一次
+//@param: claims *systemReq.CustomClaims
+//@return:
+
+func (sessionService *SessionService) TouchSession(claims *systemReq.CustomClaims) {
+	if claims == nil || claims.SessionID == "" {
+		return
+	}
+	now := time.Now()
+	if last, ok := sessionTouched.Load(claims.SessionID); ok && now.Sub(last.(time.Time)) < sessionTouchInterval {
+		return
+	}
+	sessionTouched.Store(claims.SessionID, now)
+	if err := session.GetStore().Touch(claims.BaseClaims.ID, claims.SessionID, now); err != nil && !errors.Is(err, session.ErrSessionNotFound) {
+		global.GVA_LOG.Warn("更新会话活跃时间失败", zap.Error(err))
+	}
+}
+
+//@function: GetUserSessions
+//@description: 获取用户的在线会话列表
+//@param: userID uint
+//@return: list []system.SysSession, err error
+
+func (sessionService *SessionService) GetUserSessions(userID uint) (list []system.SysSession, err error) {
+	return session.GetStore().List(userID)
+}
+
+//@function: GetTenantUserSessions
+//@description: 管理员获取指定用户的在线会话 用户不属于当前租户时返回记录不存在
+//@param: ctx context.Context, userID uint
+//@return: list []system.SysSession, err error
+
+func (sessionService *SessionService) GetTenantUserSessions(ctx context.Context, userID uint) (list []system.SysSession, err error) {
+	if err = checkTenantUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return sessionService.GetUserSessions(userID)
+}
+
+//@function: ForceLogout
+//@description: 管理员强制下线 会话ID为空时注销用户的全部会话 用户不属于当前租户时返回记录不存在
+//@param: ctx context.Context, userID uint, sessionID string
+//@return: err error
+
+func (sessionService *SessionService) ForceLogout(ctx context.Context, userID uint, sessionID string) error {
+	if err := checkTenantUser(ctx, userID); err != nil {
+		return err
+	}
+	if sessionID == "" {
+		return sessionService.RevokeUserSessions(userID)
+	}
+	return sessionService.RevokeSession(userID, sessionID)
+}
+
+//@function: RevokeSession
+//@description: 注销指定会话 当前access token加入黑名单 refresh token族作废
+//@param: userID uint, sessionID string
+//@return: err error
+
+func (sessionService *SessionService) RevokeSession(userID uint, sessionID string) error {
+	sess, err := session.GetStore().Get(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	return sessionService.revoke(*sess)
+}
+
+//@function: RevokeUserSessions
+//@description: 注销用户的全部会话
+//@param: userID uint
+//@return: err error
+
+func (sessionService *SessionService) RevokeUserSessions(userID uint) error {
+	list, err := session.GetStore().List(userID)
+	if err != nil {
+		return err
+	}
+	for i := range list {
+		if err = sessionService.revoke(list[i]); err != nil {
+			return err
+		}
+	}
+	return RefreshTokenServiceApp.RevokeUserRefreshTokens(userID)
+}
+
+//@function: RevokeFamilySession
+//@description: 注销refresh token族及其登录会话 会话此前签发的access token随之失效 会话已不存在时只注销令牌族
+//@param: userID uint, familyID string
+//@return: err error
+
+func (sessionService *SessionService) RevokeFamilySession(userID uint, familyID string) error {
+	err := sessionService.RevokeSession(userID, familyID)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return RefreshTokenServiceApp.RevokeFamily(familyID)
+	}
+	return err
+}
+
+//@function: EndSession
+//@description: 退出登录 移除会话并作废refresh token族 当前token由调用方拉黑
+//@param: userID uint, sessionID string
+//@return: err error
+
+func (sessionService *SessionService) EndSession(userID uint, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	sessionTouched.Delete(sessionID)
+	if err := session.GetStore().Delete(userID, sessionID); err != nil {
+		return err
+	}
+	return RefreshTokenServiceApp.RevokeFamily(sessionID)
+}
+
+func (sessionService *SessionService) revoke(sess system.SysSession) error {
+	if err := sessionService.EndSession(sess.UserID, sess.ID); err != nil {
+		return err
+	}
+	if sess.Token == "" {
+		return nil
+	}
+	return JwtServiceApp.JsonInBlacklist(system.JwtBlacklist{Jwt: sess.Token})
+}
+
+// MaxSessions 角色配置优先 其次系统配置 兼容旧的多点登录拦截开关
+func (sessionService *SessionService) MaxSessions(authorityId uint) int {
+	var authority system.SysAuthority
+	err := global.GVA_DB.Select("authority_id", "max_sessions").Where("authority_id = ?", authorityId).First(&authority).Error
+	if err == nil && authority.MaxSessions != nil && *authority.MaxSessions > 0 {
+		return *authority.MaxSessions
+	}
+	if global.GVA_CONFIG.System.MaxSessions > 0 {
+		return global.GVA_CONFIG.System.MaxSessions
+	}
+	if global.GVA_CONFIG.System.UseMultipoint {
+		return 1
+	}
+	return 0
+}
+
+func (sessionService *SessionService) enforceLimit(userID, authorityId uint, keepID string) error {
+	limit := sessionService.MaxSessions(authorityId)
+	if limit <= 0 {
+		return nil
+	}
+	list, err := session.GetStore().List(userID)
+	if err != nil || len(list) <= limit {
+		return err
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastSeenAt.Before(list[j].LastSeenAt)
+	})
+	over := len(list) - limit
+	for i := 0; i < len(list) && over > 0; i++ {
+		if list[i].ID == keepID {
+			continue
+		}
+		if err = sessionService.revoke(list[i]); err != nil {
+			return err
+		}
+		over--
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+)
+
+// tenantTTL 租户只缓存在本地 其他实例停用租户后最多延迟该时间生效
+const tenantTTL = 10 * time.Second
+
+var tenantCache = local_cache.NewCache(local_cache.SetDefaultExpire(tenantTTL))
+
+// tenantCodeRegexp 租户编码同时用作子域名
+var tenantCodeRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,62}[a-z0-9])?$`)
+
+var ErrTenantInvalid = errors.New("租户不存在或已停用")
+
+type TenantService struct{}
+
+var TenantServiceApp = new(TenantService)
+
+//@function: CreateTenant
+//@description: 创建租户
+//@param: t system.SysTenant
+//@return: err error
+
+func (tenantService *TenantService) CreateTenant(t system.SysTenant) (err error) {
+	if !tenantCodeRegexp.MatchString(t.Code) {
+		return errors.New("租户编码只能包含小写字母 数字与中划线")
+	}
+	if !errors.Is(global.GVA_DB.Where("code = ?", t.Code).First(&system.SysTenant{}).Error, gorm.ErrRecordNotFound) {
+		return errors.New("存在相同租户编码")
+	}
+	return global.GVA_DB.Create(&t).Error
+}
+
+//@function: UpdateTenant
+//@description: 更新租户 停用后租户用户无法登录与访问
+//@param: t system.SysTenant
+//@return: err error
+
+func (tenantService *TenantService) UpdateTenant(t system.SysTenant) (err error) {
+	if !tenantCodeRegexp.MatchString(t.Code) {
+		return errors.New("租户编码只能包含小写字母 数字与中划线")
+	}
+	var old system.SysTenant
+	if err = global.GVA_DB.First(&old, t.ID).Error; err != nil {
+		return err
+	}
+	if !errors.Is(global.GVA_DB.Where("code = ? AND id <> ?", t.Code, t.ID).First(&system.SysTenant{}).Error, gorm.ErrRecordNotFound) {
+		return errors.New("存在相同租户编码")
+	}
+	err = global.GVA_DB.Model(&old).Select("name", "code", "enable", "remark").Updates(&t).Error
+	tenantCache.Delete("code:" + old.Code)
+	tenantCache.Delete("id:" + strconv.FormatUint(uint64(t.ID), 10))
+	return err
+}
+
+//@function: DeleteTenant
+//@description: 删除租户 租户下仍有用户或角色时不允许删除
+//@param: id uint
+//@return: err error
+
+func (tenantService *TenantService) DeleteTenant(id uint) (err error) {
+	var t system.SysTenant
+	if err = global.GVA_DB.First(&t, id).Error; err != nil {
+		return err
+	}
+	var total int64
+	if err = global.GVA_DB.Model(&system.SysUser{}).Where("tenant_id = ?", id).Count(&total).Error; err != nil {
+		return err
+	}
+	if total == 0 {
+		err = global.GVA_DB.Model(&system.SysAuthority{}).Where("tenant_id = ?", id).Count(&total).Error
+		if err != nil {
+			return err
+		}
+	}
+	if total > 0 {
+		return errors.New("租户下仍有用户或角色 请先删除")
+	}
+	err = global.GVA_DB.Delete(&t).Error
+	tenantCache.Delete("code:" + t.Code)
+	tenantCache.Delete("id:" + strconv.FormatUint(uint64(id), 10))
+	return err
+}
+
+//@function: GetTenantList
+//@description: 分页获取租户列表
+//@param: info request.PageInfo
+//@return: list interface{}, total int64, err error
+
+func (tenantService *TenantService) GetTenantList(info request.PageInfo) (list interface{}, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysTenant{})
+	if info.Keyword != "" {
+		db = db.Where("name LIKE ? OR code LIKE ?", "%"+info.Keyword+"%", "%"+info.Keyword+"%")
+	}
+	var tenants []system.SysTenant
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("id").Find(&tenants).Error
+	return tenants, total, err
+}
+
+//@function: ResolveTenant
+//@description: 按租户编码获取启用的租户ID 供子域名与请求头解析租户使用
+//@param: code string
+//@return: tenantID uint, err error
+
+func (tenantService *TenantService) ResolveTenant(code string) (tenantID uint, err error) {
+	t, err := tenantService.cachedTenant("code:"+code, "code = ?", code)
+	if err != nil {
+		return 0, err
+	}
+	return t.ID, nil
+}
+
+//@function: CheckTenant
+//@description: 校验租户是否启用 平台租户始终可用
+//@param: tenantID uint
+//@return: err error
+
+func (tenantService *TenantService) CheckTenant(tenantID uint) error {
+	if tenantID == tenant.Platform {
+		return nil
+	}
+	_, err := tenantService.cachedTenant("id:"+strconv.FormatUint(uint64(tenantID), 10), "id = ?", tenantID)
+	return err
+}
+
+//@function: CheckLoginTenant
+//@description: 登录时校验用户所属租户 请求已解析租户时只能登录该租户的用户 所属租户停用时不能登录
+//@param: ctx context.Context, tenantID uint
+//@return: err error
+
+func (tenantService *TenantService) CheckLoginTenant(ctx context.Context, tenantID uint) error {
+	if !global.GVA_CONFIG.Tenant.Enable {
+		return nil
+	}
+	if current, ok := tenant.FromContext(ctx); ok && current != tenantID {
+		return ErrTenantInvalid
+	}
+	return tenantService.CheckTenant(tenantID)
+}
+
+// checkTenantUser 管理操作按请求参数指定目标用户时调用 用户不属于当前租户时返回记录不存在
+func checkTenantUser(ctx context.Context, userID uint) error {
+	return global.GVA_DB.WithContext(ctx).Select("id").First(&system.SysUser{}, userID).Error
+}
+
+// tenantUserScope 凭证等没有租户列的数据按所属用户过滤 只保留当前租户用户的数据 ctx中没有租户时不过滤
+func tenantUserScope(ctx context.Context, column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID, ok := tenant.FromContext(ctx)
+		if !ok {
+			return db
+		}
+		users := global.GVA_DB.Model(&system.SysUser{}).Select("id").Where("tenant_id = ?", tenantID)
+		return db.Where("? IN (?)", clause.Column{Table: clause.CurrentTable, Name: column}, users)
+	}
+}
+
+// cachedTenant 读取启用的租户 不存在或已停用时返回ErrTenantInvalid
+func (tenantService *TenantService) cachedTenant(key string, query string, arg interface{}) (system.SysTenant, error) {
+	if v, ok := tenantCache.Get(key); ok {
+		t := v.(system.SysTenant)
+		if t.ID == 0 {
+			return t, ErrTenantInvalid
+		}
+		return t, nil
+	}
+	var t system.SysTenant
+	err := global.GVA_DB.Where(query, arg).First(&t).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return t, err
+	}
+	if t.Enable != nil && !*t.Enable {
+		t = system.SysTenant{}
+	}
+	// 不存在的租户同样缓存 避免随意构造的请求头每次查询数据库
+	tenantCache.Set(key, t, tenantTTL)
+	if t.ID == 0 {
+		return t, ErrTenantInvalid
+	}
+	return t, nil
+}
@@ -0,0 +1,130 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	commonReq "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// TestTenantUserIsolation 租户管理员按用户ID或用户名操作其他租户的用户时按记录不存在处理
+func TestTenantUserIsolation(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.Use(tenant.Plugin{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_CONFIG.Impersonation.AuthorityIds = []uint{888}
+	err = db.AutoMigrate(&system.SysUser{}, &system.SysAuthority{}, &system.SysUserAuthority{}, &system.SysLoginLock{},
+		&system.SysUserAuthorityGrant{}, &system.SysUserAuthorityAudit{}, &system.SysRefreshToken{}, &system.SysAccessKey{},
+		&system.SysApiToken{}, &system.SysApi{}, &system.JwtBlacklist{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&[]system.SysUser{
+		{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "own", Enable: 1, AuthorityId: 8881, TenantID: 1, TotpEnabled: true},
+		{GVA_MODEL: global.GVA_MODEL{ID: 2}, Username: "other", Enable: 1, AuthorityId: 8881, TenantID: 2, TotpEnabled: true},
+	})
+	ctx := tenant.WithTenant(context.Background(), 1)
+	operator := &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 9, Username: "admin", AuthorityId: 888, TenantID: 1}}
+
+	notFound := func(name string, err error) {
+		t.Helper()
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("%s: want record not found, got %v", name, err)
+		}
+	}
+	_, _, _, err = UserServiceApp.Impersonate(ctx, operator, 2)
+	notFound("Impersonate", err)
+	notFound("UnlockUser", LoginLockServiceApp.UnlockUser(ctx, "other"))
+	notFound("ResetTwoFactor", TwoFactorServiceApp.ResetTwoFactor(ctx, 2))
+	notFound("ForceLogout", SessionServiceApp.ForceLogout(ctx, 2, ""))
+	_, err = SessionServiceApp.GetTenantUserSessions(ctx, 2)
+	notFound("GetTenantUserSessions", err)
+
+	// 签发凭证与临时授权按用户不存在处理
+	if _, err = new(ApiTokenService).CreateApiToken(ctx, systemReq.CreateApiToken{UserID: 2, AuthorityID: 8881, Days: 1, ApiIds: []uint{1}}); err == nil || err.Error() != "用户不存在" {
+		t.Errorf("CreateApiToken: want 用户不存在, got %v", err)
+	}
+	if _, _, err = new(AccessKeyService).CreateAccessKey(ctx, systemReq.CreateAccessKey{UserID: 2, AuthorityID: 8881}); err == nil || err.Error() != "用户不存在" {
+		t.Errorf("CreateAccessKey: want 用户不存在, got %v", err)
+	}
+	now := time.Now()
+	grant := systemReq.CreateAuthorityGrant{UserID: 2, AuthorityId: 8882, StartAt: now, EndAt: now.Add(time.Hour)}
+	if _, err = UserServiceApp.CreateAuthorityGrant(ctx, 888, 9, grant); err == nil || err.Error() != "用户不存在" {
+		t.Errorf("CreateAuthorityGrant: want 用户不存在, got %v", err)
+	}
+
+	// 凭证列表只包含当前租户用户的凭证 其他租户的凭证不能作废
+	db.Create(&[]system.SysAccessKey{{UserID: 1, AccessKey: "AKown", Status: true}, {UserID: 2, AccessKey: "AKother", Status: true}})
+	db.Create(&[]system.SysApiToken{{UserID: 1, TokenHash: "own", Status: true}, {UserID: 2, TokenHash: "other", Status: true}})
+	keys, total, err := new(AccessKeyService).GetAccessKeyList(ctx, systemReq.SysAccessKeySearch{PageInfo: commonReq.PageInfo{Page: 1, PageSize: 10}})
+	if err != nil || total != 1 || len(keys) != 1 || keys[0].UserID != 1 {
+		t.Errorf("GetAccessKeyList: want only the own tenant's key, got %d %+v %v", total, keys, err)
+	}
+	tokens, total, err := new(ApiTokenService).GetApiTokenList(ctx, systemReq.SysApiTokenSearch{PageInfo: commonReq.PageInfo{Page: 1, PageSize: 10}})
+	if err != nil || total != 1 || len(tokens) != 1 || tokens[0].UserID != 1 {
+		t.Errorf("GetApiTokenList: want only the own tenant's token, got %d %+v %v", total, tokens, err)
+	}
+	notFound("DeleteAccessKey", new(AccessKeyService).DeleteAccessKey(ctx, 2))
+	notFound("DeleteApiToken", new(ApiTokenService).DeleteApiToken(ctx, 2))
+	var activeKeys, activeTokens int64
+	db.Model(&system.SysAccessKey{}).Where("status = ?", true).Count(&activeKeys)
+	db.Model(&system.SysApiToken{}).Where("status = ?", true).Count(&activeTokens)
+	if activeKeys != 2 || activeTokens != 2 {
+		t.Errorf("credentials of the other tenant should stay active, got %d keys %d tokens", activeKeys, activeTokens)
+	}
+
+	// 不能修改其他租户角色的资源权限 也不能把其他租户的角色设为资源
+	root := uint(0)
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 9001, AuthorityName: "own", ParentId: &root, TenantID: 1},
+		{AuthorityId: 9002, AuthorityName: "other", ParentId: &root, TenantID: 2},
+	})
+	notFound("SetDataAuthority", AuthorityServiceApp.SetDataAuthority(ctx, 888, system.SysAuthority{AuthorityId: 9002}))
+	err = AuthorityServiceApp.SetDataAuthority(ctx, 888, system.SysAuthority{AuthorityId: 9001, DataAuthorityId: []*system.SysAuthority{{AuthorityId: 9002}}})
+	if err == nil {
+		t.Error("SetDataAuthority: a role of another tenant should not be granted as data authority")
+	}
+	if err = AuthorityServiceApp.SetDataAuthority(ctx, 888, system.SysAuthority{AuthorityId: 9001, DataAuthorityId: []*system.SysAuthority{{AuthorityId: 9001}}}); err != nil {
+		t.Errorf("SetDataAuthority within the tenant: %v", err)
+	}
+	var own system.SysAuthority
+	if db.Preload("DataAuthorityId").First(&own, "authority_id = ?", 9001); len(own.DataAuthorityId) != 1 || own.DataAuthorityId[0].AuthorityId != 9001 {
+		t.Errorf("data authority of the own tenant's role should be replaced, got %+v", own.DataAuthorityId)
+	}
+
+	var other system.SysUser
+	db.First(&other, 2)
+	if !other.TotpEnabled {
+		t.Error("two factor of the other tenant's user should be kept")
+	}
+
+	// 当前租户的用户不受影响
+	if err = TwoFactorServiceApp.ResetTwoFactor(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = LoginLockServiceApp.UnlockUser(ctx, "own"); err != nil {
+		t.Fatal(err)
+	}
+	if err = SessionServiceApp.ForceLogout(ctx, 1, ""); err != nil {
+		t.Fatal(err)
+	}
+}
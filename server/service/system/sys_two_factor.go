@@ -0,0 +1,328 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+const (
+	twoFactorChallengeTTL  = 5 * time.Minute  // 登录二次验证的有效期
+	twoFactorSetupTTL      = 10 * time.Minute // 自助绑定时待确认密钥的有效期
+	twoFactorMaxAttempts   = 5                // 单次登录允许的验证码错误次数
+	twoFactorRecoveryCount = 10               // 恢复码数量
+	twoFactorChallengePre  = "GVA_2FA_CHALLENGE_"
+	twoFactorAttemptPre    = "GVA_2FA_ATTEMPT_"
+	twoFactorSetupPre      = "GVA_2FA_SETUP_"
+	twoFactorRecoverySplit = ","
+)
+
+var (
+	ErrTwoFactorChallengeInvalid = errors.New("二次验证已过期，请重新登录")
+	ErrTwoFactorCodeInvalid      = errors.New("验证码错误")
+	ErrTwoFactorNotEnrolled      = errors.New("尚未绑定两步验证")
+	ErrTwoFactorAlreadyEnabled   = errors.New("已启用两步验证")
+	ErrTwoFactorRequired         = errors.New("当前角色要求必须启用两步验证")
+)
+
+// twoFactorChallenge 密码校验通过后等待验证码的登录状态 未绑定时同时保存待确认的密钥
+type twoFactorChallenge struct {
+	UserID        uint     `json:"userId"`
+	Secret        string   `json:"secret,omitempty"`
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+type TwoFactorService struct{}
+
+var TwoFactorServiceApp = new(TwoFactorService)
+
+//@function: RequiresTwoFactor
+//@description: 用户已启用两步验证 或所属任一角色要求强制启用时 登录需要二次验证
+//@param: user *system.SysUser
+//@return: bool
+
+func (twoFactorService *TwoFactorService) RequiresTwoFactor(user *system.SysUser) bool {
+	if user.TotpEnabled {
+		return true
+	}
+	return twoFactorService.RoleRequiresTwoFactor(user)
+}
+
+//@function: RoleRequiresTwoFactor
+//@description: 用户所属角色是否强制两步验证 需预加载Authority与Authorities
+//@param: user *system.SysUser
+//@return: bool
+
+func (twoFactorService *TwoFactorService) RoleRequiresTwoFactor(user *system.SysUser) bool {
+	if user.Authority.RequireTwoFactor != nil && *user.Authority.RequireTwoFactor {
+		return true
+	}
+	for i := range user.Authorities {
+		if user.Authorities[i].RequireTwoFactor != nil && *user.Authorities[i].RequireTwoFactor {
+			return true
+		}
+	}
+	return false
+}
+
+//@function: BeginChallenge
+//@description: 密码校验通过后开启二次验证 返回一次性的challenge token
+//@param: user *system.SysUser
+//@return: res systemRes.TwoFactorChallengeResponse, err error
+
+func (twoFactorService *TwoFactorService) BeginChallenge(user *system.SysUser) (res systemRes.TwoFactorChallengeResponse, err error) {
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return res, err
+	}
+	if err = tempStateSet(twoFactorChallengePre+token, twoFactorChallenge{UserID: user.ID}, twoFactorChallengeTTL); err != nil {
+		return res, err
+	}
+	return systemRes.TwoFactorChallengeResponse{
+		NeedTwoFactor:  true,
+		NeedEnroll:     !user.TotpEnabled,
+		ChallengeToken: token,
+		ExpiresAt:      time.Now().Add(twoFactorChallengeTTL).UnixMilli(),
+	}, nil
+}
+
+//@function: EnrollChallenge
+//@description: 角色强制两步验证但用户尚未绑定时 在登录过程中生成待绑定的密钥与恢复码
+//@param: token string
+//@return: res systemRes.TwoFactorSetupResponse, err error
+
+func (twoFactorService *TwoFactorService) EnrollChallenge(token string) (res systemRes.TwoFactorSetupResponse, err error) {
+	var ch twoFactorChallenge
+	if !tempStateGet(twoFactorChallengePre+token, &ch) {
+		return res, ErrTwoFactorChallengeInvalid
+	}
+	var user system.SysUser
+	if err = global.GVA_DB.Where("id = ?", ch.UserID).First(&user).Error; err != nil {
+		return res, err
+	}
+	if user.TotpEnabled {
+		return res, ErrTwoFactorAlreadyEnabled
+	}
+	res, ch.Secret, ch.RecoveryCodes, err = newTwoFactorSetup(user.Username)
+	if err != nil {
+		return res, err
+	}
+	return res, tempStateSet(twoFactorChallengePre+token, ch, twoFactorChallengeTTL)
+}
+
+//@function: VerifyChallenge
+//@description: 校验登录二次验证 可使用验证码或恢复码 登录中绑定时校验通过即启用 验证码错误时仍返回用户用于记录登录日志
+//@param: token string, code string, recoveryCode string
+//@return: user *system.SysUser, err error
+
+func (twoFactorService *TwoFactorService) VerifyChallenge(token, code, recoveryCode string) (user *system.SysUser, err error) {
+	key := twoFactorChallengePre + token
+	var ch twoFactorChallenge
+	if !tempStateGet(key, &ch) {
+		return nil, ErrTwoFactorChallengeInvalid
+	}
+	// 计数单独存放并原子递增 并发请求不会绕过次数限制
+	attempts, err := tempStateIncr(twoFactorAttemptPre+token, twoFactorChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	var u system.SysUser
+	err = global.GVA_DB.Where("id = ?", ch.UserID).Preload("Authorities").Preload("Authority").First(&u).Error
+	if err != nil {
+		return nil, err
+	}
+	if attempts > twoFactorMaxAttempts {
+		tempStateDel(key)
+		tempStateDel(twoFactorAttemptPre + token)
+		return &u, ErrTwoFactorChallengeInvalid
+	}
+	switch {
+	case u.TotpEnabled:
+		err = twoFactorService.verifyUserCode(&u, code, recoveryCode)
+	case ch.Secret != "":
+		err = twoFactorService.activate(u.ID, ch.Secret, code, ch.RecoveryCodes)
+		if err == nil {
+			u.TotpEnabled = true
+		}
+	default:
+		err = ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return &u, err
+	}
+	tempStateDel(key)
+	tempStateDel(twoFactorAttemptPre + token)
+	return &u, nil
+}
+
+//@function: SetupTwoFactor
+//@description: 已登录用户自助绑定 生成待确认的密钥与恢复码 输入验证码确认后才生效
+//@param: userID uint
+//@return: res systemRes.TwoFactorSetupResponse, err error
+
+func (twoFactorService *TwoFactorService) SetupTwoFactor(userID uint) (res systemRes.TwoFactorSetupResponse, err error) {
+	var user system.SysUser
+	if err = global.GVA_DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		return res, err
+	}
+	if user.TotpEnabled {
+		return res, ErrTwoFactorAlreadyEnabled
+	}
+	var pending twoFactorChallenge
+	res, pending.Secret, pending.RecoveryCodes, err = newTwoFactorSetup(user.Username)
+	if err != nil {
+		return res, err
+	}
+	pending.UserID = user.ID
+	return res, tempStateSet(twoFactorSetupPre+strconv.FormatUint(uint64(userID), 10), pending, twoFactorSetupTTL)
+}
+
+//@function: EnableTwoFactor
+//@description: 使用认证器生成的验证码确认绑定
+//@param: userID uint, code string
+//@return: err error
+
+func (twoFactorService *TwoFactorService) EnableTwoFactor(userID uint, code string) error {
+	key := twoFactorSetupPre + strconv.FormatUint(uint64(userID), 10)
+	var pending twoFactorChallenge
+	if !tempStateGet(key, &pending) {
+		return errors.New("绑定已过期，请重新获取二维码")
+	}
+	if err := twoFactorService.activate(userID, pending.Secret, code, pending.RecoveryCodes); err != nil {
+		return err
+	}
+	tempStateDel(key)
+	return nil
+}
+
+//@function: DisableTwoFactor
+//@description: 用户关闭两步验证 需提供当前验证码或恢复码 角色强制时不允许关闭
+//@param: userID uint, code string, recoveryCode string
+//@return: err error
+
+func (twoFactorService *TwoFactorService) DisableTwoFactor(userID uint, code, recoveryCode string) error {
+	var user system.SysUser
+	err := global.GVA_DB.Where("id = ?", userID).Preload("Authorities").Preload("Authority").First(&user).Error
+	if err != nil {
+		return err
+	}
+	if !user.TotpEnabled {
+		return ErrTwoFactorNotEnrolled
+	}
+	if twoFactorService.RoleRequiresTwoFactor(&user) {
+		return ErrTwoFactorRequired
+	}
+	if err = twoFactorService.verifyUserCode(&user, code, recoveryCode); err != nil {
+		return err
+	}
+	return twoFactorService.ResetTwoFactor(context.Background(), userID)
+}
+
+//@function: ResetTwoFactor
+//@description: 清除用户的两步验证 管理员在用户丢失设备时使用 角色强制时用户下次登录会重新绑定
+//@param: ctx context.Context, userID uint
+//@return: err error
+
+func (twoFactorService *TwoFactorService) ResetTwoFactor(ctx context.Context, userID uint) error {
+	if err := checkTenantUser(ctx, userID); err != nil {
+		return err
+	}
+	tempStateDel(twoFactorSetupPre + strconv.FormatUint(uint64(userID), 10))
+	return global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"totp_enabled":   false,
+		"totp_secret":    "",
+		"totp_last_step": 0,
+		"recovery_codes": "",
+	}).Error
+}
+
+// verifyUserCode 校验已启用用户的验证码或恢复码 均以条件更新保证只能使用一次
+func (twoFactorService *TwoFactorService) verifyUserCode(user *system.SysUser, code, recoveryCode string) error {
+	if recoveryCode != "" {
+		hash := utils.SHA256Hex(utils.NormalizeRecoveryCode(recoveryCode))
+		codes := strings.Split(user.RecoveryCodes, twoFactorRecoverySplit)
+		for i := range codes {
+			if codes[i] != hash {
+				continue
+			}
+			rest := append(codes[:i:i], codes[i+1:]...)
+			res := global.GVA_DB.Model(&system.SysUser{}).
+				Where("id = ? AND recovery_codes = ?", user.ID, user.RecoveryCodes).
+				Update("recovery_codes", strings.Join(rest, twoFactorRecoverySplit))
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return ErrTwoFactorCodeInvalid
+			}
+			return nil
+		}
+		return ErrTwoFactorCodeInvalid
+	}
+	step, ok := utils.ValidateTotp(user.TotpSecret, code, time.Now())
+	if !ok {
+		return ErrTwoFactorCodeInvalid
+	}
+	res := global.GVA_DB.Model(&system.SysUser{}).
+		Where("id = ? AND totp_last_step < ?", user.ID, step).
+		Update("totp_last_step", step)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTwoFactorCodeInvalid
+	}
+	return nil
+}
+
+// activate 校验待绑定密钥的验证码 通过后保存密钥与恢复码哈希
+func (twoFactorService *TwoFactorService) activate(userID uint, secret, code string, recoveryCodes []string) error {
+	step, ok := utils.ValidateTotp(secret, code, time.Now())
+	if !ok {
+		return ErrTwoFactorCodeInvalid
+	}
+	hashes := make([]string, 0, len(recoveryCodes))
+	for i := range recoveryCodes {
+		hashes = append(hashes, utils.SHA256Hex(utils.NormalizeRecoveryCode(recoveryCodes[i])))
+	}
+	res := global.GVA_DB.Model(&system.SysUser{}).
+		Where("id = ? AND totp_enabled = ?", userID, false).
+		Updates(map[string]interface{}{
+			"totp_enabled":   true,
+			"totp_secret":    secret,
+			"totp_last_step": step,
+			"recovery_codes": strings.Join(hashes, twoFactorRecoverySplit),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+func newTwoFactorSetup(account string) (res systemRes.TwoFactorSetupResponse, secret string, codes []string, err error) {
+	secret, err = utils.GenerateTotpSecret()
+	if err != nil {
+		return
+	}
+	codes, err = utils.GenerateRecoveryCodes(twoFactorRecoveryCount)
+	if err != nil {
+		return
+	}
+	res = systemRes.TwoFactorSetupResponse{
+		Secret:        secret,
+		Uri:           utils.TotpURI(global.GVA_CONFIG.JWT.Issuer, account, secret),
+		RecoveryCodes: codes,
+	}
+	return
+}
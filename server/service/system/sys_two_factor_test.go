@@ -0,0 +1,118 @@
+package system
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestTempStateIncr(t *testing.T) {
+	global.BlackCache = local_cache.NewCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tempStateIncr("incr", time.Minute); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n, _ := tempStateIncr("incr", time.Minute); n != 51 {
+		t.Errorf("want 51, got %d", n)
+	}
+}
+
+func TestVerifyChallengeAttempts(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysAuthority{}, &system.SysUserAuthority{}); err != nil {
+		t.Fatal(err)
+	}
+	user := system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", TotpEnabled: true, TotpSecret: "JBSWY3DPEHPK3PXP"}
+	db.Create(&user)
+
+	res, err := TwoFactorServiceApp.BeginChallenge(&user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < twoFactorMaxAttempts; i++ {
+		u, err := TwoFactorServiceApp.VerifyChallenge(res.ChallengeToken, "000000", "")
+		if !errors.Is(err, ErrTwoFactorCodeInvalid) {
+			t.Fatalf("attempt %d: want ErrTwoFactorCodeInvalid, got %v", i+1, err)
+		}
+		// 失败时返回用户以便登录日志记录用户名
+		if u == nil || u.Username != "u" {
+			t.Fatalf("attempt %d: failed verification should return the user", i+1)
+		}
+	}
+	if _, err = TwoFactorServiceApp.VerifyChallenge(res.ChallengeToken, "000000", ""); !errors.Is(err, ErrTwoFactorChallengeInvalid) {
+		t.Errorf("over the limit: want ErrTwoFactorChallengeInvalid, got %v", err)
+	}
+	if _, err = TwoFactorServiceApp.VerifyChallenge(res.ChallengeToken, "000000", ""); !errors.Is(err, ErrTwoFactorChallengeInvalid) {
+		t.Errorf("challenge should be discarded, got %v", err)
+	}
+}
+
+// TestSetupTwoFactorRedacted 绑定两步验证的响应写入操作记录前 密钥 地址与恢复码均被脱敏
+func TestSetupTwoFactorRedacted(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_CONFIG.JWT.Issuer = "GVA"
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysApi{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u"})
+	OperationRecordServiceApp.ClearRedactCache()
+
+	setup, err := TwoFactorServiceApp.SetupTwoFactor(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 与接口返回的内容一致
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	response.OkWithDetailed(setup, "获取成功", c)
+	body := w.Body.String()
+	if !strings.Contains(body, setup.Secret) {
+		t.Fatalf("response should contain the secret: %s", body)
+	}
+
+	redacted := OperationRecordServiceApp.Redactor("GET", "/user/setupTwoFactor").Body(body)
+	for _, v := range append([]string{setup.Secret, setup.Uri}, setup.RecoveryCodes...) {
+		if strings.Contains(redacted, v) {
+			t.Errorf("%q should be redacted: %s", v, redacted)
+		}
+	}
+	// 单独出现的otpauth地址按正则脱敏
+	if text := OperationRecordServiceApp.Redactor("GET", "/user/setupTwoFactor").Text(setup.Uri); strings.Contains(text, setup.Secret) {
+		t.Errorf("secret in the otpauth uri should be redacted: %s", text)
+	}
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -18,25 +19,73 @@ import (
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: Register
 //@description: 用户注册
-//@param: u model.SysUser
+//@param: ctx context.Context, u model.SysUser
 //@return: userInter system.SysUser, err error
 
 type UserService struct{}
 
 var UserServiceApp = new(UserService)
 
-func (userService *UserService) Register(u system.SysUser) (userInter system.SysUser, err error) {
+func (userService *UserService) Register(ctx context.Context, u system.SysUser) (userInter system.SysUser, err error) {
 	var user system.SysUser
+	// 用户名在全部租户内唯一 登录时才能确定用户所属租户
 	if !errors.Is(global.GVA_DB.Where("username = ?", u.Username).First(&user).Error, gorm.ErrRecordNotFound) { // 判断用户名是否注册
 		return userInter, errors.New("用户名已注册")
 	}
+	if err = checkNewPassword(global.GVA_DB, u, u.Password); err != nil {
+		return userInter, err
+	}
+	ids := []uint{u.AuthorityId}
+	for _, v := range u.Authorities {
+		ids = append(ids, v.AuthorityId)
+	}
+	if err = checkAuthoritiesExist(global.GVA_DB.WithContext(ctx), ids); err != nil {
+		return userInter, err
+	}
 	// 否则 附加uuid 密码hash加密 注册
 	u.Password = utils.BcryptHash(u.Password)
-	u.UUID = uuid.New()
-	err = global.GVA_DB.Create(&u).Error
+	err = global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createUser(tx, &u)
+	})
 	return u, err
 }
 
+// checkAuthoritiesExist 校验角色均存在 db携带租户时只能使用当前租户的角色
+func checkAuthoritiesExist(db *gorm.DB, ids []uint) error {
+	unique := make(map[uint]bool, len(ids))
+	for _, v := range ids {
+		unique[v] = true
+	}
+	var total int64
+	err := db.Model(&system.SysAuthority{}).Where("authority_id IN ?", ids).Count(&total).Error
+	if err != nil {
+		return err
+	}
+	if int(total) != len(unique) {
+		return errors.New("角色不存在")
+	}
+	return nil
+}
+
+// createUser 写入新用户与首条密码历史 u.Password需已是哈希 用户与其角色属于同一租户
+func createUser(tx *gorm.DB, u *system.SysUser) error {
+	now := time.Now()
+	u.PasswordChangedAt = &now
+	u.UUID = uuid.New()
+	var tenantIDs []uint
+	err := tx.Model(&system.SysAuthority{}).Where("authority_id = ?", u.AuthorityId).Pluck("tenant_id", &tenantIDs).Error
+	if err != nil {
+		return err
+	}
+	if len(tenantIDs) > 0 {
+		u.TenantID = tenantIDs[0]
+	}
+	if err := tx.Create(u).Error; err != nil {
+		return err
+	}
+	return savePasswordHistory(tx, u.ID, u.Password)
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@author: [SliverHorn](https://github.com/SliverHorn)
 //@function: Login
@@ -55,6 +104,13 @@ func (userService *UserService) Login(u *system.SysUser) (userInter *system.SysU
 		if ok := utils.BcryptCheck(u.Password, user.Password); !ok {
 			return nil, errors.New("密码错误")
 		}
+		if !user.MustChangePassword && passwordExpired(user, time.Now()) {
+			user.MustChangePassword = true
+			if err = global.GVA_DB.Model(&user).Update("must_change_password", true).Error; err != nil {
+				return nil, err
+			}
+			UserServiceApp.InvalidateUserStatus(user.ID)
+		}
 		MenuServiceApp.UserAuthorityDefaultRouter(&user)
 	}
 	return &user, err
@@ -68,28 +124,29 @@ func (userService *UserService) Login(u *system.SysUser) (userInter *system.SysU
 
 func (userService *UserService) ChangePassword(u *system.SysUser, newPassword string) (err error) {
 	var user system.SysUser
-	err = global.GVA_DB.Select("id, password").Where("id = ?", u.ID).First(&user).Error
+	err = global.GVA_DB.Select("id, username, password").Where("id = ?", u.ID).First(&user).Error
 	if err != nil {
 		return err
 	}
 	if ok := utils.BcryptCheck(u.Password, user.Password); !ok {
-		return errors.New("原密码错误")
+		return ErrOldPasswordWrong
 	}
-	pwd := utils.BcryptHash(newPassword)
-	err = global.GVA_DB.Model(&user).Update("password", pwd).Error
-	return err
+	if err = checkNewPassword(global.GVA_DB, user, newPassword); err != nil {
+		return err
+	}
+	return setPassword(global.GVA_DB, user.ID, newPassword, false)
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetUserInfoList
 //@description: 分页获取数据
-//@param: info request.PageInfo
+//@param: ctx context.Context, info request.PageInfo
 //@return: err error, list interface{}, total int64
 
-func (userService *UserService) GetUserInfoList(info systemReq.GetUserList) (list interface{}, total int64, err error) {
+func (userService *UserService) GetUserInfoList(ctx context.Context, info systemReq.GetUserList) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.GVA_DB.Model(&system.SysUser{})
+	db := global.GVA_DB.WithContext(ctx).Model(&system.SysUser{})
 	var userList []system.SysUser
 
 	if info.NickName != "" {
@@ -159,41 +216,115 @@ func (userService *UserService) SetUserAuthority(id uint, authorityId uint) (err
 	}
 
 	err = global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", id).Update("authority_id", authorityId).Error
+	if err == nil {
+		userService.InvalidateUserStatus(id)
+	}
 	return err
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: SetUserAuthorities
-//@description: 设置一个用户的权限
-//@param: id uint, authorityIds []string
+//@description: 设置一个用户的权限 生效中的临时授权保持临时 从列表中移除时撤销
+//@param: ctx context.Context, adminAuthorityID uint, operatorID uint, id uint, authorityIds []uint
 //@return: err error
 
-func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, authorityIds []uint) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+func (userService *UserService) SetUserAuthorities(ctx context.Context, adminAuthorityID, operatorID, id uint, authorityIds []uint) (err error) {
+	defer func() {
+		if err == nil {
+			userService.InvalidateUserStatus(id)
+		}
+	}()
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var user system.SysUser
 		TxErr := tx.Where("id = ?", id).First(&user).Error
 		if TxErr != nil {
 			global.GVA_LOG.Debug(TxErr.Error())
 			return errors.New("查询用户数据失败")
 		}
-		TxErr = tx.Delete(&[]system.SysUserAuthority{}, "sys_user_id = ?", id).Error
+		if TxErr = checkAuthoritiesExist(tx, authorityIds); TxErr != nil {
+			return TxErr
+		}
+		var oldIds []uint
+		TxErr = tx.Model(&system.SysUserAuthority{}).Where("sys_user_id = ?", id).Pluck("sys_authority_authority_id", &oldIds).Error
 		if TxErr != nil {
 			return TxErr
 		}
+		var grants []system.SysUserAuthorityGrant
+		TxErr = tx.Where("user_id = ? AND status = ?", id, system.GrantStatusActive).Find(&grants).Error
+		if TxErr != nil {
+			return TxErr
+		}
+		temporary := make(map[uint]*system.SysUserAuthorityGrant, len(grants))
+		for i := range grants {
+			temporary[grants[i].AuthorityId] = &grants[i]
+		}
+		keep := make(map[uint]bool, len(authorityIds))
 		var useAuthority []system.SysUserAuthority
 		for _, v := range authorityIds {
 			e := AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, v)
 			if e != nil {
 				return e
 			}
+			keep[v] = true
+			if temporary[v] != nil {
+				continue
+			}
 			useAuthority = append(useAuthority, system.SysUserAuthority{
 				SysUserId: id, SysAuthorityAuthorityId: v,
 			})
 		}
+		if len(useAuthority) == 0 {
+			return errors.New("至少需要保留一个非临时授予的角色")
+		}
+		// 临时授予的角色由临时授权管理 这里只撤销被移除的
+		for authorityId, grant := range temporary {
+			if keep[authorityId] {
+				continue
+			}
+			res := tx.Model(grant).Where("status = ?", system.GrantStatusActive).
+				Updates(map[string]interface{}{"status": system.GrantStatusRevoked, "revoked_by": operatorID, "revoked_at": time.Now()})
+			if res.Error != nil {
+				return res.Error
+			}
+			if TxErr = authorityAudit(tx, grant, system.AuthorityAuditRevoke, operatorID, "设置用户角色时移除"); TxErr != nil {
+				return TxErr
+			}
+		}
+		TxErr = tx.Delete(&[]system.SysUserAuthority{}, "sys_user_id = ?", id).Error
+		if TxErr != nil {
+			return TxErr
+		}
+		for authorityId := range temporary {
+			if keep[authorityId] {
+				useAuthority = append(useAuthority, system.SysUserAuthority{
+					SysUserId: id, SysAuthorityAuthorityId: authorityId,
+				})
+			}
+		}
 		TxErr = tx.Create(&useAuthority).Error
 		if TxErr != nil {
 			return TxErr
 		}
+		// 记录直接设置产生的角色变化
+		old := make(map[uint]bool, len(oldIds))
+		var audits []system.SysUserAuthorityAudit
+		for _, v := range oldIds {
+			old[v] = true
+			if !keep[v] && temporary[v] == nil {
+				audits = append(audits, system.SysUserAuthorityAudit{UserID: id, AuthorityId: v, Action: system.AuthorityAuditRemove, OperatorID: operatorID})
+			}
+		}
+		for _, v := range authorityIds {
+			if !old[v] {
+				audits = append(audits, system.SysUserAuthorityAudit{UserID: id, AuthorityId: v, Action: system.AuthorityAuditAssign, OperatorID: operatorID})
+				old[v] = true
+			}
+		}
+		if len(audits) > 0 {
+			if TxErr = tx.Create(&audits).Error; TxErr != nil {
+				return TxErr
+			}
+		}
 		TxErr = tx.Model(&user).Update("authority_id", authorityIds[0]).Error
 		if TxErr != nil {
 			return TxErr
@@ -206,13 +337,23 @@ func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, au
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: DeleteUser
 //@description: 删除用户
-//@param: id float64
+//@param: ctx context.Context, id float64
 //@return: err error
 
-func (userService *UserService) DeleteUser(id int) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("id = ?", id).Delete(&system.SysUser{}).Error; err != nil {
-			return err
+func (userService *UserService) DeleteUser(ctx context.Context, id int) (err error) {
+	defer func() {
+		if err == nil {
+			userService.InvalidateUserStatus(uint(id))
+		}
+	}()
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ?", id).Delete(&system.SysUser{})
+		if res.Error != nil {
+			return res.Error
+		}
+		// 其他租户的用户不会被删除 此时不能清理其角色
+		if res.RowsAffected == 0 {
+			return errors.New("用户不存在")
 		}
 		if err := tx.Delete(&[]system.SysUserAuthority{}, "sys_user_id = ?", id).Error; err != nil {
 			return err
@@ -224,11 +365,12 @@ func (userService *UserService) DeleteUser(id int) (err error) {
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: SetUserInfo
 //@description: 设置用户信息
-//@param: reqUser model.SysUser
+//@param: ctx context.Context, reqUser model.SysUser
 //@return: err error, user model.SysUser
 
-func (userService *UserService) SetUserInfo(req system.SysUser) error {
-	return global.GVA_DB.Model(&system.SysUser{}).
+func (userService *UserService) SetUserInfo(ctx context.Context, req system.SysUser) error {
+	defer userService.InvalidateUserStatus(req.ID)
+	return global.GVA_DB.WithContext(ctx).Model(&system.SysUser{}).
 		Select("updated_at", "nick_name", "header_img", "phone", "email", "enable").
 		Where("id=?", req.ID).
 		Updates(map[string]interface{}{
@@ -309,10 +451,39 @@ func (userService *UserService) FindUserByUuid(uuid string) (user *system.SysUse
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: ResetPassword
 //@description: 修改用户密码
-//@param: ID uint
+//@param: ctx context.Context, ID uint
 //@return: err error
 
-func (userService *UserService) ResetPassword(ID uint, password string) (err error) {
-	err = global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", ID).Update("password", utils.BcryptHash(password)).Error
+func (userService *UserService) ResetPassword(ctx context.Context, ID uint, password string) (err error) {
+	var user system.SysUser
+	err = global.GVA_DB.WithContext(ctx).Select("id, username, password").Where("id = ?", ID).First(&user).Error
+	if err != nil {
+		return err
+	}
+	if err = checkNewPassword(global.GVA_DB, user, password); err != nil {
+		return err
+	}
+	// 管理员重置的密码只用于本次登录 用户登录后必须修改
+	return setPassword(global.GVA_DB, ID, password, true)
+}
+
+// setPassword 更新密码及修改时间 并记录历史密码
+func setPassword(db *gorm.DB, userID uint, password string, mustChange bool) error {
+	hash := utils.BcryptHash(password)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&system.SysUser{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"password":             hash,
+			"password_changed_at":  time.Now(),
+			"must_change_password": mustChange,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return savePasswordHistory(tx, userID, hash)
+	})
+	if err == nil {
+		// 修改密码的要求由JWTAuth从用户状态读取 需立即生效
+		UserServiceApp.InvalidateUserStatus(userID)
+	}
 	return err
 }
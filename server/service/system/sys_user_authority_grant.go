@@ -0,0 +1,362 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+// ErrGrantChanged 临时授权不存在或已被其他操作或其他实例处理
+var ErrGrantChanged = errors.New("临时授权不存在或状态已变更")
+
+// ErrGrantNoFallback 回收临时角色后用户没有其他角色 且未配置默认角色
+var ErrGrantNoFallback = errors.New("用户没有其他角色且未配置回收后的默认角色 无法回收临时授权")
+
+// openGrantStatuses 尚未结束的临时授权状态
+var openGrantStatuses = []string{system.GrantStatusPending, system.GrantStatusScheduled, system.GrantStatusActive}
+
+//@function: CreateAuthorityGrant
+//@description: 发起临时角色授权 需要审批时进入待审批 否则到达开始时间后生效
+//@param: ctx context.Context, adminAuthorityID uint, operatorID uint, req systemReq.CreateAuthorityGrant
+//@return: grant system.SysUserAuthorityGrant, err error
+
+func (userService *UserService) CreateAuthorityGrant(ctx context.Context, adminAuthorityID, operatorID uint, req systemReq.CreateAuthorityGrant) (grant system.SysUserAuthorityGrant, err error) {
+	if !req.EndAt.After(req.StartAt) {
+		return grant, errors.New("结束时间必须晚于开始时间")
+	}
+	if !req.EndAt.After(time.Now()) {
+		return grant, errors.New("结束时间必须晚于当前时间")
+	}
+	if max := grantMaxDuration(); max > 0 && req.EndAt.Sub(req.StartAt) > max {
+		return grant, fmt.Errorf("临时授权时长不能超过%s", max)
+	}
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, req.AuthorityId); err != nil {
+		return grant, err
+	}
+	if errors.Is(checkTenantUser(ctx, req.UserID), gorm.ErrRecordNotFound) {
+		return grant, errors.New("用户不存在")
+	}
+	err = global.GVA_DB.Where("sys_user_id = ? AND sys_authority_authority_id = ?", req.UserID, req.AuthorityId).
+		First(&system.SysUserAuthority{}).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		if err == nil {
+			err = errors.New("用户已拥有该角色")
+		}
+		return grant, err
+	}
+	var total int64
+	err = global.GVA_DB.Model(&system.SysUserAuthorityGrant{}).
+		Where("user_id = ? AND authority_id = ? AND status IN ?", req.UserID, req.AuthorityId, openGrantStatuses).
+		Count(&total).Error
+	if err != nil {
+		return grant, err
+	}
+	if total > 0 {
+		return grant, errors.New("该用户已有未结束的同角色临时授权")
+	}
+
+	grant = system.SysUserAuthorityGrant{
+		UserID:      req.UserID,
+		AuthorityId: req.AuthorityId,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		Reason:      req.Reason,
+		Status:      system.GrantStatusScheduled,
+		RequestedBy: operatorID,
+	}
+	if global.GVA_CONFIG.AuthorityGrant.RequireApproval {
+		grant.Status = system.GrantStatusPending
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&grant).Error; err != nil {
+			return err
+		}
+		return authorityAudit(tx, &grant, system.AuthorityAuditRequest, operatorID, req.Reason)
+	})
+	if err != nil || grant.Status != system.GrantStatusScheduled || grant.StartAt.After(time.Now()) {
+		return grant, err
+	}
+	return grant, userService.activateAuthorityGrant(&grant, operatorID)
+}
+
+//@function: ApproveAuthorityGrant
+//@description: 审批通过临时授权 审批人不能是发起人或被授权人
+//@param: adminAuthorityID uint, approverID uint, req systemReq.AuthorityGrantAction
+//@return: err error
+
+func (userService *UserService) ApproveAuthorityGrant(adminAuthorityID, approverID uint, req systemReq.AuthorityGrantAction) (err error) {
+	var grant system.SysUserAuthorityGrant
+	if err = global.GVA_DB.Where("id = ? AND status = ?", req.ID, system.GrantStatusPending).First(&grant).Error; err != nil {
+		return ErrGrantChanged
+	}
+	if grant.RequestedBy == approverID {
+		return errors.New("不能审批自己发起的临时授权")
+	}
+	if grant.UserID == approverID {
+		return errors.New("不能审批授予自己的临时授权")
+	}
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, grant.AuthorityId); err != nil {
+		return err
+	}
+	now := time.Now()
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&system.SysUserAuthorityGrant{}).Where("id = ? AND status = ?", grant.ID, system.GrantStatusPending).
+			Updates(map[string]interface{}{"status": system.GrantStatusScheduled, "approved_by": approverID, "approved_at": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrGrantChanged
+		}
+		return authorityAudit(tx, &grant, system.AuthorityAuditApprove, approverID, req.Detail)
+	})
+	if err != nil || grant.StartAt.After(now) || !grant.EndAt.After(now) {
+		// 已过结束时间的由定时任务标记为到期
+		return err
+	}
+	grant.Status = system.GrantStatusScheduled
+	return userService.activateAuthorityGrant(&grant, approverID)
+}
+
+//@function: RejectAuthorityGrant
+//@description: 拒绝待审批的临时授权
+//@param: adminAuthorityID uint, operatorID uint, req systemReq.AuthorityGrantAction
+//@return: err error
+
+func (userService *UserService) RejectAuthorityGrant(adminAuthorityID, operatorID uint, req systemReq.AuthorityGrantAction) (err error) {
+	var grant system.SysUserAuthorityGrant
+	if err = global.GVA_DB.Where("id = ? AND status = ?", req.ID, system.GrantStatusPending).First(&grant).Error; err != nil {
+		return ErrGrantChanged
+	}
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, grant.AuthorityId); err != nil {
+		return err
+	}
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&system.SysUserAuthorityGrant{}).Where("id = ? AND status = ?", grant.ID, system.GrantStatusPending).
+			Updates(map[string]interface{}{"status": system.GrantStatusRejected, "approved_by": operatorID, "approved_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrGrantChanged
+		}
+		return authorityAudit(tx, &grant, system.AuthorityAuditReject, operatorID, req.Detail)
+	})
+}
+
+//@function: RevokeAuthorityGrant
+//@description: 提前撤销未结束的临时授权 已生效的立即回收角色
+//@param: adminAuthorityID uint, operatorID uint, req systemReq.AuthorityGrantAction
+//@return: err error
+
+func (userService *UserService) RevokeAuthorityGrant(adminAuthorityID, operatorID uint, req systemReq.AuthorityGrantAction) (err error) {
+	var grant system.SysUserAuthorityGrant
+	if err = global.GVA_DB.Where("id = ? AND status IN ?", req.ID, openGrantStatuses).First(&grant).Error; err != nil {
+		return ErrGrantChanged
+	}
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, grant.AuthorityId); err != nil {
+		return err
+	}
+	return userService.endAuthorityGrant(&grant, system.GrantStatusRevoked, operatorID, req.Detail)
+}
+
+//@function: ProcessAuthorityGrants
+//@description: 定时任务调用 回收到期的临时授权并使到达开始时间的授权生效 多实例同时执行时按状态条件更新 只有一个实例生效
+
+func (userService *UserService) ProcessAuthorityGrants() {
+	now := time.Now()
+	var expired []system.SysUserAuthorityGrant
+	if err := global.GVA_DB.Where("status IN ? AND end_at <= ?", openGrantStatuses, now).Find(&expired).Error; err != nil {
+		global.GVA_LOG.Error("获取到期临时授权失败!", zap.Error(err))
+		return
+	}
+	for i := range expired {
+		err := userService.endAuthorityGrant(&expired[i], system.GrantStatusExpired, 0, "到期自动回收")
+		if err != nil && !errors.Is(err, ErrGrantChanged) {
+			global.GVA_LOG.Error("回收临时授权失败!", zap.Uint("grant", expired[i].ID), zap.Error(err))
+		}
+	}
+	var due []system.SysUserAuthorityGrant
+	err := global.GVA_DB.Where("status = ? AND start_at <= ? AND end_at > ?", system.GrantStatusScheduled, now, now).Find(&due).Error
+	if err != nil {
+		global.GVA_LOG.Error("获取待生效临时授权失败!", zap.Error(err))
+		return
+	}
+	for i := range due {
+		err := userService.activateAuthorityGrant(&due[i], 0)
+		if err != nil && !errors.Is(err, ErrGrantChanged) {
+			global.GVA_LOG.Error("临时授权生效失败!", zap.Uint("grant", due[i].ID), zap.Error(err))
+		}
+	}
+}
+
+//@function: GetAuthorityGrantList
+//@description: 分页获取临时授权列表
+//@param: info systemReq.AuthorityGrantSearch
+//@return: list interface{}, total int64, err error
+
+func (userService *UserService) GetAuthorityGrantList(info systemReq.AuthorityGrantSearch) (list interface{}, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysUserAuthorityGrant{})
+	if info.UserID != 0 {
+		db = db.Where("user_id = ?", info.UserID)
+	}
+	if info.Status != "" {
+		db = db.Where("status = ?", info.Status)
+	}
+	var grants []system.SysUserAuthorityGrant
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(limit).Offset(offset).Order("id desc").Preload("User").Preload("Authority").Find(&grants).Error
+	return grants, total, err
+}
+
+//@function: GetAuthorityAuditList
+//@description: 分页获取用户角色授权审计记录
+//@param: info systemReq.AuthorityAuditSearch
+//@return: list interface{}, total int64, err error
+
+func (userService *UserService) GetAuthorityAuditList(info systemReq.AuthorityAuditSearch) (list interface{}, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysUserAuthorityAudit{})
+	if info.UserID != 0 {
+		db = db.Where("user_id = ?", info.UserID)
+	}
+	if info.AuthorityId != 0 {
+		db = db.Where("authority_id = ?", info.AuthorityId)
+	}
+	if info.Action != "" {
+		db = db.Where("action = ?", info.Action)
+	}
+	var audits []system.SysUserAuthorityAudit
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Limit(limit).Offset(offset).Order("id desc").Find(&audits).Error
+	return audits, total, err
+}
+
+// activateAuthorityGrant 临时授权生效 写入用户角色
+func (userService *UserService) activateAuthorityGrant(grant *system.SysUserAuthorityGrant, operatorID uint) error {
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&system.SysUserAuthorityGrant{}).Where("id = ? AND status = ?", grant.ID, system.GrantStatusScheduled).
+			Update("status", system.GrantStatusActive)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrGrantChanged
+		}
+		userAuthority := system.SysUserAuthority{SysUserId: grant.UserID, SysAuthorityAuthorityId: grant.AuthorityId}
+		if err := tx.Where(&userAuthority).FirstOrCreate(&system.SysUserAuthority{}).Error; err != nil {
+			return err
+		}
+		return authorityAudit(tx, grant, system.AuthorityAuditActivate, operatorID, "")
+	})
+	if err == nil {
+		grant.Status = system.GrantStatusActive
+		userService.InvalidateUserStatus(grant.UserID)
+	}
+	return err
+}
+
+// endAuthorityGrant 结束临时授权 已生效的同时回收角色 持有该角色的令牌由鉴权中间件降级或拒绝
+func (userService *UserService) endAuthorityGrant(grant *system.SysUserAuthorityGrant, status string, operatorID uint, detail string) error {
+	action := system.AuthorityAuditRevoke
+	if status == system.GrantStatusExpired {
+		action = system.AuthorityAuditExpire
+	}
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&system.SysUserAuthorityGrant{}).Where("id = ? AND status = ?", grant.ID, grant.Status).
+			Updates(map[string]interface{}{"status": status, "revoked_by": operatorID, "revoked_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrGrantChanged
+		}
+		if grant.Status == system.GrantStatusActive {
+			if err := removeUserAuthority(tx, grant.UserID, grant.AuthorityId); err != nil {
+				return err
+			}
+		}
+		return authorityAudit(tx, grant, action, operatorID, detail)
+	})
+	if err == nil {
+		wasActive := grant.Status == system.GrantStatusActive
+		grant.Status = status
+		if wasActive {
+			userService.InvalidateUserStatus(grant.UserID)
+		}
+	}
+	return err
+}
+
+// removeUserAuthority 移除用户角色 当前使用的角色被移除时切换到剩余的第一个角色 没有剩余角色时切换到配置的默认角色
+func removeUserAuthority(tx *gorm.DB, userID, authorityID uint) error {
+	err := tx.Delete(&[]system.SysUserAuthority{}, "sys_user_id = ? AND sys_authority_authority_id = ?", userID, authorityID).Error
+	if err != nil {
+		return err
+	}
+	var user system.SysUser
+	if err = tx.Select("id", "authority_id").First(&user, userID).Error; err != nil {
+		// 用户已删除
+		return nil
+	}
+	if user.AuthorityId != authorityID {
+		return nil
+	}
+	var next system.SysUserAuthority
+	err = tx.Where("sys_user_id = ?", userID).First(&next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 未配置默认角色时回收失败 不能让用户继续持有临时角色
+		fallback := global.GVA_CONFIG.AuthorityGrant.DefaultAuthorityId
+		if fallback == 0 || fallback == authorityID {
+			return ErrGrantNoFallback
+		}
+		next = system.SysUserAuthority{SysUserId: userID, SysAuthorityAuthorityId: fallback}
+		err = tx.Create(&next).Error
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Model(&user).Update("authority_id", next.SysAuthorityAuthorityId).Error
+}
+
+// authorityAudit 写入临时授权相关的审计记录
+func authorityAudit(tx *gorm.DB, grant *system.SysUserAuthorityGrant, action string, operatorID uint, detail string) error {
+	return tx.Create(&system.SysUserAuthorityAudit{
+		UserID:      grant.UserID,
+		AuthorityId: grant.AuthorityId,
+		GrantID:     grant.ID,
+		Action:      action,
+		OperatorID:  operatorID,
+		Detail:      detail,
+	}).Error
+}
+
+// grantMaxDuration 单次临时授权的最长时长 未配置或配置无效时不限制
+func grantMaxDuration() time.Duration {
+	conf := global.GVA_CONFIG.AuthorityGrant.MaxDuration
+	if conf == "" {
+		return 0
+	}
+	d, err := utils.ParseDuration(conf)
+	if err != nil {
+		global.GVA_LOG.Error("临时授权最长时长配置无效!", zap.String("max-duration", conf), zap.Error(err))
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,136 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestAuthorityGrant(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.AuthorityGrant.RequireApproval = true
+	global.GVA_CONFIG.AuthorityGrant.MaxDuration = "1d"
+	defer func() { global.GVA_CONFIG.AuthorityGrant.RequireApproval = false }()
+	err = db.AutoMigrate(&system.SysUser{}, &system.SysUserAuthority{}, &system.SysUserAuthorityGrant{}, &system.SysUserAuthorityAudit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 1}, Username: "u", AuthorityId: 100})
+	db.Create(&system.SysUserAuthority{SysUserId: 1, SysAuthorityAuthorityId: 100})
+	roles := func() (ids []uint) {
+		db.Model(&system.SysUserAuthority{}).Where("sys_user_id = 1").Order("sys_authority_authority_id").Pluck("sys_authority_authority_id", &ids)
+		return
+	}
+
+	now := time.Now()
+	req := systemReq.CreateAuthorityGrant{UserID: 1, AuthorityId: 200, StartAt: now.Add(-time.Minute), EndAt: now.Add(time.Hour), Reason: "oncall"}
+	if _, err = UserServiceApp.CreateAuthorityGrant(context.Background(), 888, 9, systemReq.CreateAuthorityGrant{UserID: 1, AuthorityId: 200, StartAt: now, EndAt: now.Add(48 * time.Hour), Reason: "x"}); err == nil {
+		t.Error("grant longer than max-duration should be rejected")
+	}
+	grant, err := UserServiceApp.CreateAuthorityGrant(context.Background(), 888, 9, req)
+	if err != nil || grant.Status != system.GrantStatusPending {
+		t.Fatalf("create: status %s, err %v", grant.Status, err)
+	}
+	if _, err = UserServiceApp.CreateAuthorityGrant(context.Background(), 888, 9, req); err == nil {
+		t.Error("overlapping grant should be rejected")
+	}
+	action := systemReq.AuthorityGrantAction{ID: grant.ID}
+	if err = UserServiceApp.ApproveAuthorityGrant(888, 9, action); err == nil {
+		t.Error("requester should not approve own grant")
+	}
+	if err = UserServiceApp.ApproveAuthorityGrant(888, 1, action); err == nil {
+		t.Error("grantee should not approve own grant")
+	}
+	if err = UserServiceApp.ApproveAuthorityGrant(888, 10, action); err != nil {
+		t.Fatal(err)
+	}
+	if got := roles(); len(got) != 2 {
+		t.Fatalf("approved grant past start should be active, roles %v", got)
+	}
+
+	// 切换到临时角色后到期 回收并切回剩余角色
+	db.Model(&system.SysUser{}).Where("id = 1").Update("authority_id", 200)
+	db.Model(&system.SysUserAuthorityGrant{}).Where("id = ?", grant.ID).Update("end_at", now.Add(-time.Second))
+	UserServiceApp.ProcessAuthorityGrants()
+	if got := roles(); len(got) != 1 || got[0] != 100 {
+		t.Errorf("expired grant should be removed, roles %v", got)
+	}
+	var user system.SysUser
+	db.First(&user, 1)
+	if user.AuthorityId != 100 {
+		t.Errorf("authority_id = %d, want 100", user.AuthorityId)
+	}
+	db.First(&grant, grant.ID)
+	if grant.Status != system.GrantStatusExpired {
+		t.Errorf("status = %s, want expired", grant.Status)
+	}
+	// 再次执行不会重复处理
+	UserServiceApp.ProcessAuthorityGrants()
+
+	var actions []string
+	db.Model(&system.SysUserAuthorityAudit{}).Where("user_id = 1").Order("id").Pluck("action", &actions)
+	want := []string{system.AuthorityAuditRequest, system.AuthorityAuditApprove, system.AuthorityAuditActivate, system.AuthorityAuditExpire}
+	if len(actions) != len(want) {
+		t.Fatalf("audit actions = %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("audit actions = %v, want %v", actions, want)
+			break
+		}
+	}
+}
+
+func TestRevokeLastAuthorityGrant(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	err = db.AutoMigrate(&system.SysUser{}, &system.SysUserAuthority{}, &system.SysUserAuthorityGrant{}, &system.SysUserAuthorityAudit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 用户的其他角色已被移除 只剩临时角色
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 2}, Username: "v", AuthorityId: 200})
+	db.Create(&system.SysUserAuthority{SysUserId: 2, SysAuthorityAuthorityId: 200})
+	grant := system.SysUserAuthorityGrant{UserID: 2, AuthorityId: 200, StartAt: time.Now().Add(-time.Hour), EndAt: time.Now().Add(time.Hour), Status: system.GrantStatusActive}
+	db.Create(&grant)
+	action := systemReq.AuthorityGrantAction{ID: grant.ID}
+
+	if err = UserServiceApp.RevokeAuthorityGrant(888, 9, action); err != ErrGrantNoFallback {
+		t.Fatalf("want ErrGrantNoFallback, got %v", err)
+	}
+	var n int64
+	db.Model(&system.SysUserAuthority{}).Where("sys_user_id = 2 AND sys_authority_authority_id = 200").Count(&n)
+	db.First(&grant, grant.ID)
+	if n != 1 || grant.Status != system.GrantStatusActive {
+		t.Errorf("failed revocation should be rolled back, roles %d status %s", n, grant.Status)
+	}
+
+	global.GVA_CONFIG.AuthorityGrant.DefaultAuthorityId = 300
+	defer func() { global.GVA_CONFIG.AuthorityGrant.DefaultAuthorityId = 0 }()
+	if err = UserServiceApp.RevokeAuthorityGrant(888, 9, action); err != nil {
+		t.Fatal(err)
+	}
+	var user system.SysUser
+	db.First(&user, 2)
+	var ids []uint
+	db.Model(&system.SysUserAuthority{}).Where("sys_user_id = 2").Pluck("sys_authority_authority_id", &ids)
+	if user.AuthorityId != 300 || len(ids) != 1 || ids[0] != 300 {
+		t.Errorf("user should fall back to the default authority, authority_id %d roles %v", user.AuthorityId, ids)
+	}
+}
@@ -0,0 +1,323 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/identity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdentityNotLinked     = errors.New("该账号尚未关联本地用户，请联系管理员")
+	ErrIdentityUsernameTaken = errors.New("本地已存在同名用户，请使用本地账号登录后绑定")
+	ErrIdentityLinkedToOther = errors.New("该账号已关联其他用户")
+)
+
+const (
+	ssoStateTTL = 10 * time.Minute
+	ssoStatePre = "GVA_SSO_STATE_"
+)
+
+// ssoState 跳转到身份提供方前保存的状态 回调时校验并取出PKCE verifier与nonce
+type ssoState struct {
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	UserID   uint   `json:"userId"` // 非0时为已登录用户绑定 而不是登录
+}
+
+type UserIdentityService struct{}
+
+var UserIdentityServiceApp = new(UserIdentityService)
+
+//@function: LoginByIdentity
+//@description: 外部身份登录 已关联时直接返回本地用户 未关联时按配置自动关联同名用户或自动创建用户
+//@param: conf config.IdentityProvider, id *identity.Identity
+//@return: user *system.SysUser, err error
+
+func (userIdentityService *UserIdentityService) LoginByIdentity(conf config.IdentityProvider, id *identity.Identity) (user *system.SysUser, err error) {
+	var userID uint
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		var link system.SysUserIdentity
+		e := tx.Where("provider = ? AND subject = ?", id.Provider, id.Subject).First(&link).Error
+		if e == nil {
+			userID = link.UserID
+			now := time.Now()
+			e = tx.Model(&link).Updates(map[string]interface{}{"username": id.Username, "email": id.Email, "last_login_at": &now}).Error
+			if e != nil {
+				return e
+			}
+			if conf.SyncAuthorities {
+				return syncIdentityAuthorities(tx, userID, identity.MapAuthorities(conf, id.Groups))
+			}
+			return nil
+		}
+		if !errors.Is(e, gorm.ErrRecordNotFound) {
+			return e
+		}
+
+		var local system.SysUser
+		e = gorm.ErrRecordNotFound
+		if id.Username != "" {
+			e = tx.Where("username = ?", id.Username).First(&local).Error
+		}
+		switch {
+		case e == nil && conf.AutoLink:
+			userID = local.ID
+			if conf.SyncAuthorities {
+				if e = syncIdentityAuthorities(tx, userID, identity.MapAuthorities(conf, id.Groups)); e != nil {
+					return e
+				}
+			}
+		case e == nil:
+			return ErrIdentityUsernameTaken
+		case !errors.Is(e, gorm.ErrRecordNotFound):
+			return e
+		case conf.AutoCreate:
+			created, e := createIdentityUser(tx, conf, id)
+			if e != nil {
+				return e
+			}
+			userID = created.ID
+		default:
+			return ErrIdentityNotLinked
+		}
+		now := time.Now()
+		return tx.Create(&system.SysUserIdentity{
+			UserID:      userID,
+			Provider:    id.Provider,
+			Subject:     id.Subject,
+			Username:    id.Username,
+			Email:       id.Email,
+			LastLoginAt: &now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if conf.SyncAuthorities {
+		UserServiceApp.InvalidateUserStatus(userID)
+	}
+	var u system.SysUser
+	err = global.GVA_DB.Where("id = ?", userID).Preload("Authorities").Preload("Authority").First(&u).Error
+	return &u, err
+}
+
+//@function: PasswordLogin
+//@description: 使用LDAP等用户名密码类提供方登录
+//@param: ctx context.Context, provider string, username string, password string
+//@return: user *system.SysUser, err error
+
+func (userIdentityService *UserIdentityService) PasswordLogin(ctx context.Context, provider, username, password string) (*system.SysUser, error) {
+	conf, id, err := passwordAuthenticate(ctx, provider, username, password)
+	if err != nil {
+		return nil, err
+	}
+	return userIdentityService.LoginByIdentity(conf, id)
+}
+
+//@function: LinkPasswordIdentity
+//@description: 已登录用户使用提供方的用户名密码绑定外部身份
+//@param: ctx context.Context, userID uint, provider string, username string, password string
+//@return: err error
+
+func (userIdentityService *UserIdentityService) LinkPasswordIdentity(ctx context.Context, userID uint, provider, username, password string) error {
+	_, id, err := passwordAuthenticate(ctx, provider, username, password)
+	if err != nil {
+		return err
+	}
+	return userIdentityService.LinkIdentity(userID, id)
+}
+
+//@function: BeginSSO
+//@description: 生成跳转到提供方的授权地址 state nonce 与 PKCE verifier 保存在服务端 userID非0时回调后执行绑定
+//@param: ctx context.Context, provider string, userID uint
+//@return: authURL string, err error
+
+func (userIdentityService *UserIdentityService) BeginSSO(ctx context.Context, provider string, userID uint) (string, error) {
+	p, err := identity.GetProvider(provider)
+	if err != nil {
+		return "", err
+	}
+	rp, ok := p.(identity.RedirectProvider)
+	if !ok {
+		return "", identity.ErrProviderNotFound
+	}
+	state, err := utils.RandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := utils.RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	verifier, challenge, err := identity.NewPKCE()
+	if err != nil {
+		return "", err
+	}
+	authURL, err := rp.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		return "", err
+	}
+	st := ssoState{Provider: provider, Nonce: nonce, Verifier: verifier, UserID: userID}
+	return authURL, tempStateSet(ssoStatePre+state, st, ssoStateTTL)
+}
+
+//@function: FinishSSO
+//@description: 处理提供方回调 state只能使用一次 绑定流程返回linked为true 登录流程返回本地用户
+//@param: ctx context.Context, code string, state string
+//@return: user *system.SysUser, linked bool, err error
+
+func (userIdentityService *UserIdentityService) FinishSSO(ctx context.Context, code, state string) (user *system.SysUser, linked bool, err error) {
+	var st ssoState
+	if state == "" || !tempStateTake(ssoStatePre+state, &st) {
+		return nil, false, errors.New("登录已过期，请重新发起")
+	}
+	p, err := identity.GetProvider(st.Provider)
+	if err != nil {
+		return nil, false, err
+	}
+	rp, ok := p.(identity.RedirectProvider)
+	if !ok {
+		return nil, false, identity.ErrProviderNotFound
+	}
+	id, err := rp.Exchange(ctx, code, st.Verifier, st.Nonce)
+	if err != nil {
+		return nil, false, err
+	}
+	if st.UserID != 0 {
+		return nil, true, userIdentityService.LinkIdentity(st.UserID, id)
+	}
+	user, err = userIdentityService.LoginByIdentity(p.Config(), id)
+	return user, false, err
+}
+
+//@function: LinkIdentity
+//@description: 已登录用户绑定外部身份
+//@param: userID uint, id *identity.Identity
+//@return: err error
+
+func (userIdentityService *UserIdentityService) LinkIdentity(userID uint, id *identity.Identity) error {
+	var link system.SysUserIdentity
+	err := global.GVA_DB.Where("provider = ? AND subject = ?", id.Provider, id.Subject).First(&link).Error
+	if err == nil {
+		if link.UserID != userID {
+			return ErrIdentityLinkedToOther
+		}
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return global.GVA_DB.Create(&system.SysUserIdentity{
+		UserID:   userID,
+		Provider: id.Provider,
+		Subject:  id.Subject,
+		Username: id.Username,
+		Email:    id.Email,
+	}).Error
+}
+
+//@function: UnlinkIdentity
+//@description: 解除外部身份绑定
+//@param: userID uint, id uint
+//@return: err error
+
+func (userIdentityService *UserIdentityService) UnlinkIdentity(userID, id uint) error {
+	return global.GVA_DB.Where("id = ? AND user_id = ?", id, userID).Delete(&system.SysUserIdentity{}).Error
+}
+
+//@function: GetUserIdentities
+//@description: 获取用户绑定的外部身份
+//@param: userID uint
+//@return: list []system.SysUserIdentity, err error
+
+func (userIdentityService *UserIdentityService) GetUserIdentities(userID uint) (list []system.SysUserIdentity, err error) {
+	err = global.GVA_DB.Where("user_id = ?", userID).Order("id").Find(&list).Error
+	return list, err
+}
+
+// createIdentityUser 自动创建本地用户 使用随机密码 只能通过外部身份登录
+func createIdentityUser(tx *gorm.DB, conf config.IdentityProvider, id *identity.Identity) (*system.SysUser, error) {
+	if id.Username == "" {
+		return nil, errors.New("身份提供方未返回用户名")
+	}
+	authorityIds := identity.MapAuthorities(conf, id.Groups)
+	if len(authorityIds) == 0 {
+		if conf.DefaultAuthorityId == 0 {
+			return nil, ErrIdentityNotLinked
+		}
+		authorityIds = []uint{conf.DefaultAuthorityId}
+	}
+	password, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	user := system.SysUser{
+		UUID:        uuid.New(),
+		Username:    id.Username,
+		NickName:    id.NickName,
+		Password:    utils.BcryptHash(password),
+		AuthorityId: authorityIds[0],
+		Phone:       id.Phone,
+		Email:       id.Email,
+		Enable:      1,
+	}
+	if user.NickName == "" {
+		user.NickName = id.Username
+	}
+	for _, v := range authorityIds {
+		user.Authorities = append(user.Authorities, system.SysAuthority{AuthorityId: v})
+	}
+	if err = tx.Create(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// syncIdentityAuthorities 按组映射重置用户角色 没有任何映射命中时保持原有角色
+func syncIdentityAuthorities(tx *gorm.DB, userID uint, authorityIds []uint) error {
+	if len(authorityIds) == 0 {
+		return nil
+	}
+	if err := tx.Delete(&[]system.SysUserAuthority{}, "sys_user_id = ?", userID).Error; err != nil {
+		return err
+	}
+	useAuthority := make([]system.SysUserAuthority, 0, len(authorityIds))
+	for _, v := range authorityIds {
+		useAuthority = append(useAuthority, system.SysUserAuthority{SysUserId: userID, SysAuthorityAuthorityId: v})
+	}
+	if err := tx.Create(&useAuthority).Error; err != nil {
+		return err
+	}
+	var user system.SysUser
+	if err := tx.Select("id", "authority_id").Where("id = ?", userID).First(&user).Error; err != nil {
+		return err
+	}
+	for _, v := range authorityIds {
+		if v == user.AuthorityId {
+			return nil
+		}
+	}
+	return tx.Model(&system.SysUser{}).Where("id = ?", userID).Update("authority_id", authorityIds[0]).Error
+}
+
+func passwordAuthenticate(ctx context.Context, provider, username, password string) (config.IdentityProvider, *identity.Identity, error) {
+	p, err := identity.GetProvider(provider)
+	if err != nil {
+		return config.IdentityProvider{}, nil, err
+	}
+	pp, ok := p.(identity.PasswordProvider)
+	if !ok {
+		return config.IdentityProvider{}, nil, identity.ErrProviderNotFound
+	}
+	id, err := pp.Authenticate(ctx, username, password)
+	return p.Config(), id, err
+}
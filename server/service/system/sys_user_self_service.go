@@ -0,0 +1,397 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	emailUtils "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 自助注册由系统参数控制 未配置时视为关闭
+const (
+	ParamSelfRegistration            = "selfRegistration"            // 值为true时开启自助注册
+	ParamSelfRegistrationAuthorityId = "selfRegistrationAuthorityId" // 自助注册用户的角色ID
+)
+
+const (
+	pwdResetPre       = "GVA_PWD_RESET_"
+	pwdResetCooldown  = "GVA_PWD_RESET_CD_"
+	registerVerifyPre = "GVA_REGISTER_VERIFY_"
+)
+
+var (
+	ErrResetTokenInvalid        = errors.New("链接无效或已过期")
+	ErrSelfRegistrationDisabled = errors.New("未开放注册")
+	ErrInvitationInvalid        = errors.New("邀请链接无效或已过期")
+	ErrInvitationEmailDomain    = errors.New("邮箱域名不符合邀请要求")
+	ErrEmailInvalid             = errors.New("邮箱格式错误")
+	ErrEmailTaken               = errors.New("该邮箱已被使用")
+	ErrUsernameTaken            = errors.New("用户名已注册")
+	ErrSiteURLMissing           = errors.New("未配置站点地址 self-service.site-url")
+)
+
+// pendingRegistration 等待邮箱验证的注册信息 只保存密码哈希
+type pendingRegistration struct {
+	Username     string `json:"username"`
+	NickName     string `json:"nickName"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	AuthorityId  uint   `json:"authorityId"`
+	InvitationID uint   `json:"invitationId"`
+}
+
+//@function: ForgotPassword
+//@description: 向邮箱发送重置密码链接 邮箱不存在时同样返回成功 避免被用于探测账号
+//@param: email string
+//@return: err error
+
+func (userService *UserService) ForgotPassword(email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil
+	}
+	// 同一邮箱短时间内只发送一次
+	var sent bool
+	if tempStateGet(pwdResetCooldown+strings.ToLower(email), &sent) {
+		return nil
+	}
+	_ = tempStateSet(pwdResetCooldown+strings.ToLower(email), true, time.Minute)
+
+	var user system.SysUser
+	err := global.GVA_DB.Where("email = ? AND enable = ?", email, 1).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return err
+	}
+	expires := selfServiceDuration(global.GVA_CONFIG.SelfService.ResetExpires, 30*time.Minute)
+	link, err := selfServiceLink("resetPassword", token)
+	if err != nil {
+		return err
+	}
+	if err = tempStateSet(pwdResetPre+utils.SHA256Hex(token), user.ID, expires); err != nil {
+		return err
+	}
+	body := fmt.Sprintf(`<p>%s 您好:</p><p>请在 %s 内点击下方链接重置密码 如非本人操作请忽略本邮件</p><p><a href="%s">%s</a></p>`,
+		html.EscapeString(user.NickName), expiresText(expires), link, link)
+	return emailUtils.Email(user.Email, "重置密码", body)
+}
+
+//@function: ResetPasswordByToken
+//@description: 校验找回密码链接后设置新密码 链接只能使用一次 成功后注销该用户的全部会话
+//@param: token string, password string
+//@return: err error
+
+func (userService *UserService) ResetPasswordByToken(token, password string) error {
+	key := pwdResetPre + utils.SHA256Hex(token)
+	var userID uint
+	if !tempStateGet(key, &userID) {
+		return ErrResetTokenInvalid
+	}
+	var user system.SysUser
+	if err := global.GVA_DB.Where("id = ?", userID).First(&user).Error; err != nil {
+		return ErrResetTokenInvalid
+	}
+	// 先校验密码策略 不满足时链接仍可继续使用
+	if err := checkNewPassword(global.GVA_DB, user, password); err != nil {
+		return err
+	}
+	if !tempStateTake(key, &userID) {
+		return ErrResetTokenInvalid
+	}
+	err := setPassword(global.GVA_DB, user.ID, password, false)
+	if err != nil {
+		return err
+	}
+	if err = LoginLockServiceApp.Unlock(user.Username); err != nil {
+		global.GVA_LOG.Error("清除登录锁定失败!", zap.Error(err))
+	}
+	return SessionServiceApp.RevokeUserSessions(user.ID)
+}
+
+//@function: CreateInvitation
+//@description: 生成注册邀请链接 链接只返回一次 只能邀请注册为当前角色可管理的角色
+//@param: adminAuthorityID uint, creator uint, req systemReq.CreateInvitation
+//@return: link string, err error
+
+func (userService *UserService) CreateInvitation(adminAuthorityID, creator uint, req systemReq.CreateInvitation) (string, error) {
+	if err := AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, req.AuthorityId); err != nil {
+		return "", err
+	}
+	var authority system.SysAuthority
+	if err := global.GVA_DB.Where("authority_id = ?", req.AuthorityId).First(&authority).Error; err != nil {
+		return "", errors.New("角色不存在")
+	}
+	days := req.Days
+	if days <= 0 {
+		days = 7
+	}
+	token, err := utils.RandomToken(24)
+	if err != nil {
+		return "", err
+	}
+	link, err := selfServiceLink("register", "", "invitation", token)
+	if err != nil {
+		return "", err
+	}
+	invitation := system.SysUserInvitation{
+		TokenHash:   utils.SHA256Hex(token),
+		AuthorityId: req.AuthorityId,
+		EmailDomain: strings.ToLower(strings.TrimPrefix(strings.TrimSpace(req.EmailDomain), "@")),
+		ExpiresAt:   time.Now().Add(time.Duration(days) * 24 * time.Hour),
+		CreatedBy:   creator,
+		Remark:      req.Remark,
+	}
+	if err = global.GVA_DB.Create(&invitation).Error; err != nil {
+		return "", err
+	}
+	return link, nil
+}
+
+//@function: GetInvitationList
+//@description: 分页获取注册邀请
+//@param: info systemReq.SysUserInvitationSearch
+//@return: list []system.SysUserInvitation, total int64, err error
+
+func (userService *UserService) GetInvitationList(info systemReq.SysUserInvitationSearch) (list []system.SysUserInvitation, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysUserInvitation{})
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Preload("Authority").Order("id desc").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+//@function: RevokeInvitation
+//@description: 作废未使用的注册邀请
+//@param: id uint
+//@return: err error
+
+func (userService *UserService) RevokeInvitation(id uint) error {
+	return global.GVA_DB.Model(&system.SysUserInvitation{}).Where("id = ?", id).Update("revoked", true).Error
+}
+
+//@function: GetRegistrationOptions
+//@description: 注册页信息 是否开放自助注册以及邀请链接对应的角色和邮箱域名
+//@param: invitation string
+//@return: options systemRes.RegistrationOptions, err error
+
+func (userService *UserService) GetRegistrationOptions(invitation string) (options systemRes.RegistrationOptions, err error) {
+	options.SelfRegistration, _ = selfRegistrationAuthority()
+	if invitation == "" {
+		return options, nil
+	}
+	inv, err := findInvitation(global.GVA_DB, invitation)
+	if err != nil {
+		return options, err
+	}
+	options.Invitation = &systemRes.InvitationInfo{AuthorityName: inv.Authority.AuthorityName, EmailDomain: inv.EmailDomain}
+	return options, nil
+}
+
+//@function: SelfRegister
+//@description: 校验注册信息后发送邮箱验证邮件 验证通过后才创建用户
+//@param: req systemReq.SelfRegister
+//@return: err error
+
+func (userService *UserService) SelfRegister(req systemReq.SelfRegister) error {
+	pending := pendingRegistration{
+		Username: strings.TrimSpace(req.Username),
+		NickName: req.NickName,
+		Email:    strings.TrimSpace(req.Email),
+	}
+	if pending.NickName == "" {
+		pending.NickName = pending.Username
+	}
+	at := strings.LastIndex(pending.Email, "@")
+	if at <= 0 || at == len(pending.Email)-1 {
+		return ErrEmailInvalid
+	}
+	if req.Invitation != "" {
+		inv, err := findInvitation(global.GVA_DB, req.Invitation)
+		if err != nil {
+			return err
+		}
+		if inv.EmailDomain != "" && strings.ToLower(pending.Email[at+1:]) != inv.EmailDomain {
+			return ErrInvitationEmailDomain
+		}
+		pending.AuthorityId = inv.AuthorityId
+		pending.InvitationID = inv.ID
+	} else {
+		enabled, authorityId := selfRegistrationAuthority()
+		if !enabled {
+			return ErrSelfRegistrationDisabled
+		}
+		pending.AuthorityId = authorityId
+	}
+	if err := checkRegistrationConflict(global.GVA_DB, pending.Username, pending.Email); err != nil {
+		return err
+	}
+	if err := checkNewPassword(global.GVA_DB, system.SysUser{Username: pending.Username}, req.Password); err != nil {
+		return err
+	}
+	pending.Password = utils.BcryptHash(req.Password)
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return err
+	}
+	link, err := selfServiceLink("verifyRegistration", token)
+	if err != nil {
+		return err
+	}
+	expires := selfServiceDuration(global.GVA_CONFIG.SelfService.VerifyExpires, 24*time.Hour)
+	if err = tempStateSet(registerVerifyPre+utils.SHA256Hex(token), pending, expires); err != nil {
+		return err
+	}
+	body := fmt.Sprintf(`<p>%s 您好:</p><p>请在 %s 内点击下方链接完成邮箱验证 验证后即可使用用户名 %s 登录</p><p><a href="%s">%s</a></p>`,
+		html.EscapeString(pending.NickName), expiresText(expires), html.EscapeString(pending.Username), link, link)
+	return emailUtils.Email(pending.Email, "验证邮箱", body)
+}
+
+//@function: VerifyRegistration
+//@description: 邮箱验证通过后创建用户 邀请注册时同时占用邀请
+//@param: token string
+//@return: user system.SysUser, err error
+
+func (userService *UserService) VerifyRegistration(token string) (user system.SysUser, err error) {
+	var pending pendingRegistration
+	if !tempStateTake(registerVerifyPre+utils.SHA256Hex(token), &pending) {
+		return user, ErrResetTokenInvalid
+	}
+	user = system.SysUser{
+		Username:    pending.Username,
+		NickName:    pending.NickName,
+		Email:       pending.Email,
+		Password:    pending.Password,
+		AuthorityId: pending.AuthorityId,
+		Authorities: []system.SysAuthority{{AuthorityId: pending.AuthorityId}},
+		Enable:      1,
+	}
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		// 提交注册后到验证期间 用户名或邮箱可能已被占用
+		if err := checkRegistrationConflict(tx, user.Username, user.Email); err != nil {
+			return err
+		}
+		if err := createUser(tx, &user); err != nil {
+			return err
+		}
+		if pending.InvitationID == 0 {
+			return nil
+		}
+		res := tx.Model(&system.SysUserInvitation{}).
+			Where("id = ? AND used_at IS NULL AND revoked = ? AND expires_at > ?", pending.InvitationID, false, time.Now()).
+			Updates(map[string]interface{}{"used_at": time.Now(), "used_by": user.ID})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInvitationInvalid
+		}
+		return nil
+	})
+	return user, err
+}
+
+func findInvitation(db *gorm.DB, token string) (inv system.SysUserInvitation, err error) {
+	err = db.Preload("Authority").Where("token_hash = ?", utils.SHA256Hex(token)).First(&inv).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return inv, ErrInvitationInvalid
+	}
+	if err != nil {
+		return inv, err
+	}
+	if inv.Revoked || inv.UsedAt != nil || time.Now().After(inv.ExpiresAt) {
+		return inv, ErrInvitationInvalid
+	}
+	return inv, nil
+}
+
+func checkRegistrationConflict(db *gorm.DB, username, email string) error {
+	var count int64
+	if err := db.Model(&system.SysUser{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrUsernameTaken
+	}
+	if err := db.Model(&system.SysUser{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+// selfRegistrationAuthority 读取自助注册的系统参数 未开启或未配置角色时返回false 使用平台租户的参数
+func selfRegistrationAuthority() (bool, uint) {
+	paramsService := SysParamsService{}
+	enabled, err := paramsService.GetSysParam(context.Background(), ParamSelfRegistration)
+	if err != nil || enabled.Value != "true" {
+		return false, 0
+	}
+	param, err := paramsService.GetSysParam(context.Background(), ParamSelfRegistrationAuthorityId)
+	if err != nil {
+		return false, 0
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(param.Value), 10, 64)
+	if err != nil || id == 0 {
+		return false, 0
+	}
+	return true, uint(id)
+}
+
+// selfServiceLink 拼接邮件中指向前端页面的链接 前端使用hash路由
+func selfServiceLink(page, token string, extra ...string) (string, error) {
+	site := strings.TrimRight(global.GVA_CONFIG.SelfService.SiteURL, "/")
+	if site == "" {
+		return "", ErrSiteURLMissing
+	}
+	q := url.Values{}
+	if token != "" {
+		q.Set("token", token)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		q.Set(extra[i], extra[i+1])
+	}
+	return site + "/#/" + page + "?" + q.Encode(), nil
+}
+
+func selfServiceDuration(s string, def time.Duration) time.Duration {
+	if s == "" {
+		return def
+	}
+	d, err := utils.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+func expiresText(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d小时", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%d分钟", int(d/time.Minute))
+}
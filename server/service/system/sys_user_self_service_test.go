@@ -0,0 +1,219 @@
+package system
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupSelfServiceDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_CONFIG.SelfService.SiteURL = "https://gva.example.com/"
+	err = db.AutoMigrate(&system.SysUser{}, &system.SysAuthority{}, &system.SysUserAuthority{}, &system.SysUserInvitation{},
+		&system.SysParams{}, &system.SysPasswordHistory{}, &system.SysLoginLock{}, &system.SysRefreshToken{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, admin := uint(0), uint(888)
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 888, AuthorityName: "admin", ParentId: &root},
+		{AuthorityId: 8881, AuthorityName: "user", ParentId: &admin},
+		{AuthorityId: 9528, AuthorityName: "test", ParentId: &root},
+	})
+	return db
+}
+
+// invitationToken 从邀请链接中取出令牌
+func invitationToken(t *testing.T, link string) string {
+	u, err := url.Parse(strings.Replace(link, "/#/", "/", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query().Get("invitation")
+}
+
+func TestCreateInvitation(t *testing.T) {
+	db := setupSelfServiceDB(t)
+	global.GVA_CONFIG.System.UseStrictAuth = true
+	defer func() { global.GVA_CONFIG.System.UseStrictAuth = false }()
+
+	// 只能邀请注册为当前角色可管理的角色
+	if _, err := UserServiceApp.CreateInvitation(8881, 2, systemReq.CreateInvitation{AuthorityId: 888}); err == nil {
+		t.Error("inviting to a parent authority should be rejected")
+	}
+	if _, err := UserServiceApp.CreateInvitation(888, 1, systemReq.CreateInvitation{AuthorityId: 9528}); err == nil {
+		t.Error("inviting to an unrelated authority should be rejected")
+	}
+	if _, err := UserServiceApp.CreateInvitation(888, 1, systemReq.CreateInvitation{AuthorityId: 1}); err == nil {
+		t.Error("inviting to an unknown authority should be rejected")
+	}
+
+	link, err := UserServiceApp.CreateInvitation(888, 1, systemReq.CreateInvitation{AuthorityId: 8881, EmailDomain: " @Example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(link, "https://gva.example.com/#/register?") {
+		t.Errorf("unexpected link %s", link)
+	}
+	token := invitationToken(t, link)
+	var inv system.SysUserInvitation
+	db.First(&inv)
+	if inv.TokenHash != utils.SHA256Hex(token) || inv.EmailDomain != "example.com" || inv.CreatedBy != 1 {
+		t.Errorf("unexpected invitation %+v", inv)
+	}
+	if d := time.Until(inv.ExpiresAt); d < 6*24*time.Hour || d > 7*24*time.Hour {
+		t.Errorf("invitation should expire after 7 days by default, got %s", d)
+	}
+
+	options, err := UserServiceApp.GetRegistrationOptions(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.SelfRegistration || options.Invitation == nil || options.Invitation.AuthorityName != "user" {
+		t.Errorf("unexpected options %+v", options)
+	}
+	if err = UserServiceApp.RevokeInvitation(inv.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = UserServiceApp.GetRegistrationOptions(token); !errors.Is(err, ErrInvitationInvalid) {
+		t.Errorf("revoked invitation: want ErrInvitationInvalid, got %v", err)
+	}
+
+	global.GVA_CONFIG.SelfService.SiteURL = ""
+	if _, err = UserServiceApp.CreateInvitation(888, 1, systemReq.CreateInvitation{AuthorityId: 8881}); !errors.Is(err, ErrSiteURLMissing) {
+		t.Errorf("want ErrSiteURLMissing, got %v", err)
+	}
+}
+
+func TestSelfRegister(t *testing.T) {
+	db := setupSelfServiceDB(t)
+	db.Create(&system.SysUser{Username: "taken", Email: "taken@example.com", AuthorityId: 8881})
+	link, err := UserServiceApp.CreateInvitation(888, 1, systemReq.CreateInvitation{AuthorityId: 8881, EmailDomain: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invitation := invitationToken(t, link)
+	db.Create(&system.SysUserInvitation{TokenHash: utils.SHA256Hex("expired"), AuthorityId: 8881, ExpiresAt: time.Now().Add(-time.Minute)})
+
+	// 以下情况均在发送验证邮件之前返回
+	tests := []struct {
+		name string
+		req  systemReq.SelfRegister
+		err  error
+	}{
+		{"registration disabled", systemReq.SelfRegister{Username: "a", Email: "a@example.com", Password: "Passw0rd!"}, ErrSelfRegistrationDisabled},
+		{"invalid email", systemReq.SelfRegister{Username: "a", Email: "a@", Password: "Passw0rd!", Invitation: invitation}, ErrEmailInvalid},
+		{"unknown invitation", systemReq.SelfRegister{Username: "a", Email: "a@example.com", Password: "Passw0rd!", Invitation: "unknown"}, ErrInvitationInvalid},
+		{"expired invitation", systemReq.SelfRegister{Username: "a", Email: "a@example.com", Password: "Passw0rd!", Invitation: "expired"}, ErrInvitationInvalid},
+		{"email domain", systemReq.SelfRegister{Username: "a", Email: "a@other.com", Password: "Passw0rd!", Invitation: invitation}, ErrInvitationEmailDomain},
+		{"username taken", systemReq.SelfRegister{Username: "taken", Email: "a@example.com", Password: "Passw0rd!", Invitation: invitation}, ErrUsernameTaken},
+		{"email taken", systemReq.SelfRegister{Username: "a", Email: "taken@example.com", Password: "Passw0rd!", Invitation: invitation}, ErrEmailTaken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UserServiceApp.SelfRegister(tt.req); !errors.Is(err, tt.err) {
+				t.Errorf("want %v, got %v", tt.err, err)
+			}
+		})
+	}
+
+	// 自助注册的开关与角色来自系统参数
+	db.Create(&[]system.SysParams{{Name: "a", Key: ParamSelfRegistration, Value: "true"}, {Name: "b", Key: ParamSelfRegistrationAuthorityId, Value: "8881"}})
+	if enabled, authorityId := selfRegistrationAuthority(); !enabled || authorityId != 8881 {
+		t.Errorf("self registration: %v %d", enabled, authorityId)
+	}
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	db := setupSelfServiceDB(t)
+	inv := system.SysUserInvitation{TokenHash: utils.SHA256Hex("inv"), AuthorityId: 8881, ExpiresAt: time.Now().Add(time.Hour)}
+	db.Create(&inv)
+	pending := func(token, username string) {
+		err := tempStateSet(registerVerifyPre+utils.SHA256Hex(token), pendingRegistration{
+			Username: username, NickName: username, Email: username + "@example.com",
+			Password: utils.BcryptHash("Passw0rd!"), AuthorityId: 8881, InvitationID: inv.ID,
+		}, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pending("first", "first")
+	user, err := UserServiceApp.VerifyRegistration("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID == 0 || user.AuthorityId != 8881 || user.Enable != 1 {
+		t.Errorf("unexpected user %+v", user)
+	}
+	var n int64
+	db.Model(&system.SysUserAuthority{}).Where("sys_user_id = ? AND sys_authority_authority_id = ?", user.ID, 8881).Count(&n)
+	if n != 1 {
+		t.Error("registered user should be given the invitation's authority")
+	}
+	db.First(&inv, inv.ID)
+	if inv.UsedAt == nil || inv.UsedBy != user.ID {
+		t.Errorf("invitation should be marked used, got %+v", inv)
+	}
+	if _, err = UserServiceApp.VerifyRegistration("first"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Errorf("verification link reused: want ErrResetTokenInvalid, got %v", err)
+	}
+
+	// 同一邀请在验证前被其他人用掉 注册整体回滚
+	pending("second", "second")
+	if _, err = UserServiceApp.VerifyRegistration("second"); !errors.Is(err, ErrInvitationInvalid) {
+		t.Errorf("used invitation: want ErrInvitationInvalid, got %v", err)
+	}
+	db.Model(&system.SysUser{}).Where("username = ?", "second").Count(&n)
+	if n != 0 {
+		t.Error("user should not be created when the invitation is already used")
+	}
+}
+
+func TestResetPasswordByToken(t *testing.T) {
+	db := setupSelfServiceDB(t)
+	user := system.SysUser{Username: "u", Password: utils.BcryptHash("OldPassw0rd!"), AuthorityId: 8881, Enable: 1}
+	db.Create(&user)
+	db.Create(&system.SysLoginLock{Username: "u"})
+	if err := tempStateSet(pwdResetPre+utils.SHA256Hex("reset"), user.ID, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UserServiceApp.ResetPasswordByToken("unknown", "NewPassw0rd!"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Errorf("unknown token: want ErrResetTokenInvalid, got %v", err)
+	}
+	if err := UserServiceApp.ResetPasswordByToken("reset", "NewPassw0rd!"); err != nil {
+		t.Fatal(err)
+	}
+	db.First(&user, user.ID)
+	if !utils.BcryptCheck("NewPassw0rd!", user.Password) {
+		t.Error("password should be changed")
+	}
+	var n int64
+	db.Model(&system.SysLoginLock{}).Where("username = ?", "u").Count(&n)
+	if n != 0 {
+		t.Error("login lock should be cleared")
+	}
+	if err := UserServiceApp.ResetPasswordByToken("reset", "OtherPassw0rd!"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Errorf("reused token: want ErrResetTokenInvalid, got %v", err)
+	}
+}
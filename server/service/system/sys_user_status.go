@@ -0,0 +1,140 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+// userStatusChannel 开启redis时用户状态变更通过该频道通知所有实例清除缓存
+const userStatusChannel = "gva:user:status"
+
+// 开启redis时变更会即时广播 缓存可以保留较久 否则多实例之间只能依赖较短的缓存时间
+const (
+	userStatusTTL      = 10 * time.Minute
+	userStatusLocalTTL = 10 * time.Second
+)
+
+// UserStatus 鉴权时需要的用户状态 由JWTAuth在每次请求时读取
+type UserStatus struct {
+	Enable             int
+	Deleted            bool
+	AuthorityId        uint
+	Authorities        []uint
+	MustChangePassword bool
+}
+
+// Active 用户存在且未被冻结
+func (s UserStatus) Active() bool {
+	return !s.Deleted && s.Enable == 1
+}
+
+// HasAuthority 用户当前是否仍拥有该角色
+func (s UserStatus) HasAuthority(authorityId uint) bool {
+	if authorityId == s.AuthorityId {
+		return true
+	}
+	for _, v := range s.Authorities {
+		if v == authorityId {
+			return true
+		}
+	}
+	return false
+}
+
+var userStatusCache = local_cache.NewCache(local_cache.SetDefaultExpire(userStatusTTL))
+
+//@function: GetUserStatus
+//@description: 读取用户状态 优先使用本地缓存 未命中时查询数据库
+//@param: userID uint
+//@return: status UserStatus, err error
+
+func (userService *UserService) GetUserStatus(userID uint) (status UserStatus, err error) {
+	key := strconv.FormatUint(uint64(userID), 10)
+	if v, ok := userStatusCache.Get(key); ok {
+		return v.(UserStatus), nil
+	}
+	var user system.SysUser
+	err = global.GVA_DB.Unscoped().Select("id", "enable", "authority_id", "must_change_password", "deleted_at").Where("id = ?", userID).First(&user).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status.Deleted = true
+	case err != nil:
+		return status, err
+	default:
+		status.Enable = user.Enable
+		status.Deleted = user.DeletedAt.Valid
+		status.AuthorityId = user.AuthorityId
+		status.MustChangePassword = user.MustChangePassword
+		err = global.GVA_DB.Model(&system.SysUserAuthority{}).Where("sys_user_id = ?", userID).
+			Pluck("sys_authority_authority_id", &status.Authorities).Error
+		if err != nil {
+			return status, err
+		}
+	}
+	ttl := userStatusTTL
+	if !global.GVA_CONFIG.System.UseRedis || global.GVA_REDIS == nil {
+		ttl = userStatusLocalTTL
+	}
+	userStatusCache.Set(key, status, ttl)
+	return status, nil
+}
+
+//@function: InvalidateUserStatus
+//@description: 用户被冻结 删除 角色变更或密码状态变更后清除状态缓存 开启redis时通知其他实例
+//@param: userIDs ...uint
+
+func (userService *UserService) InvalidateUserStatus(userIDs ...uint) {
+	for _, id := range userIDs {
+		key := strconv.FormatUint(uint64(id), 10)
+		userStatusCache.Delete(key)
+		if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+			if err := global.GVA_REDIS.Publish(context.Background(), userStatusChannel, key).Err(); err != nil {
+				global.GVA_LOG.Error("广播用户状态变更失败!", zap.Error(err))
+			}
+		}
+	}
+}
+
+//@function: WatchUserStatus
+//@description: 开启redis时订阅其他实例的用户状态变更 断线重连后清空本地缓存
+//@param: ctx context.Context
+
+func WatchUserStatus(ctx context.Context) {
+	if !global.GVA_CONFIG.System.UseRedis || global.GVA_REDIS == nil {
+		return
+	}
+	go func() {
+		pubsub := global.GVA_REDIS.Subscribe(ctx, userStatusChannel)
+		defer pubsub.Close()
+		for {
+			msg, err := pubsub.Receive(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				global.GVA_LOG.Error("订阅用户状态变更失败!", zap.Error(err))
+				time.Sleep(time.Second)
+				continue
+			}
+			switch m := msg.(type) {
+			case *redis.Subscription:
+				// 断线期间可能遗漏了变更通知
+				if m.Kind == "subscribe" {
+					userStatusCache.Flush()
+				}
+			case *redis.Message:
+				userStatusCache.Delete(m.Payload)
+			}
+		}
+	}()
+}
@@ -0,0 +1,58 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestUserStatus(t *testing.T) {
+	s := UserStatus{Enable: 1, AuthorityId: 888, Authorities: []uint{888, 9528}}
+	if !s.Active() {
+		t.Errorf("Active() = false, want true")
+	}
+	if !s.HasAuthority(9528) || s.HasAuthority(8881) {
+		t.Errorf("HasAuthority() mismatch for %+v", s)
+	}
+	if (UserStatus{Enable: 2}).Active() || (UserStatus{Enable: 1, Deleted: true}).Active() {
+		t.Errorf("Active() should be false for frozen or deleted users")
+	}
+}
+
+func TestUserStatusMustChangePassword(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysUserAuthority{}, &system.SysPasswordHistory{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 7}, Username: "u", Enable: 1, AuthorityId: 888})
+	userStatusCache.Flush()
+
+	if status, _ := UserServiceApp.GetUserStatus(7); status.MustChangePassword {
+		t.Fatal("new user should not need to change password")
+	}
+	// 管理员重置密码后 已签发的token也需要先修改密码 不能等待缓存过期
+	if err = UserServiceApp.ResetPassword(context.Background(), 7, "Reset#2024pw"); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := UserServiceApp.GetUserStatus(7); !status.MustChangePassword {
+		t.Error("status should require a password change after reset")
+	}
+	if err = UserServiceApp.ChangePassword(&system.SysUser{GVA_MODEL: global.GVA_MODEL{ID: 7}, Password: "Reset#2024pw"}, "Changed#2024pw"); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := UserServiceApp.GetUserStatus(7); status.MustChangePassword {
+		t.Error("status should be cleared after the user changes the password")
+	}
+}
@@ -0,0 +1,92 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/redis/go-redis/v9"
+)
+
+// tempStateSet 保存短期有效的登录中间状态(二次验证 单点登录等) 开启redis时多实例共享 否则使用本地缓存
+func tempStateSet(key string, v any, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		return global.GVA_REDIS.Set(context.Background(), key, data, ttl).Err()
+	}
+	global.BlackCache.Set(key, data, ttl)
+	return nil
+}
+
+func tempStateGet(key string, v any) bool {
+	var data []byte
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		b, err := global.GVA_REDIS.Get(context.Background(), key).Bytes()
+		if err != nil {
+			return false
+		}
+		data = b
+	} else {
+		cached, ok := global.BlackCache.Get(key)
+		if !ok {
+			return false
+		}
+		if data, ok = cached.([]byte); !ok {
+			return false
+		}
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+func tempStateDel(key string) {
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		global.GVA_REDIS.Del(context.Background(), key)
+		return
+	}
+	global.BlackCache.Delete(key)
+}
+
+// tempStateTake 读取后立即删除 保证状态只能使用一次
+func tempStateTake(key string, v any) bool {
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		b, err := global.GVA_REDIS.GetDel(context.Background(), key).Bytes()
+		if err != nil {
+			return false
+		}
+		return json.Unmarshal(b, v) == nil
+	}
+	ok := tempStateGet(key, v)
+	global.BlackCache.Delete(key)
+	return ok
+}
+
+var tempStateIncrMu sync.Mutex
+
+var incrScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// tempStateIncr 原子递增计数 首次递增时设置有效期 用于限制尝试次数
+func tempStateIncr(key string, ttl time.Duration) (int64, error) {
+	if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+		return incrScript.Run(context.Background(), global.GVA_REDIS, []string{key}, ttl.Milliseconds()).Int64()
+	}
+	tempStateIncrMu.Lock()
+	defer tempStateIncrMu.Unlock()
+	n, err := global.BlackCache.IncrementInt64(key, 1)
+	if err != nil {
+		// 不存在或已过期时重新计数
+		global.BlackCache.Set(key, int64(1), ttl)
+		return 1, nil
+	}
+	return n, nil
+}
@@ -46,14 +46,28 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 	entities := []sysModel.SysApi{
 		{ApiGroup: "jwt", Method: "POST", Path: "/jwt/jsonInBlacklist", Description: "jwt加入黑名单(退出，必选)"},
 
-		{ApiGroup: "登录日志", Method: "DELETE", Path: "/sysLoginLog/deleteLoginLog", Description: "删除登录日志"},
-		{ApiGroup: "登录日志", Method: "DELETE", Path: "/sysLoginLog/deleteLoginLogByIds", Description: "批量删除登录日志"},
 		{ApiGroup: "登录日志", Method: "GET", Path: "/sysLoginLog/findLoginLog", Description: "根据ID获取登录日志"},
 		{ApiGroup: "登录日志", Method: "GET", Path: "/sysLoginLog/getLoginLogList", Description: "获取登录日志列表"},
 
 		{ApiGroup: "API Token", Method: "POST", Path: "/sysApiToken/createApiToken", Description: "签发API Token"},
 		{ApiGroup: "API Token", Method: "POST", Path: "/sysApiToken/getApiTokenList", Description: "获取API Token列表"},
 		{ApiGroup: "API Token", Method: "POST", Path: "/sysApiToken/deleteApiToken", Description: "作废API Token"},
+		{ApiGroup: "AccessKey", Method: "POST", Path: "/accessKey/createAccessKey", Description: "签发AK/SK"},
+		{ApiGroup: "AccessKey", Method: "POST", Path: "/accessKey/getAccessKeyList", Description: "获取AK/SK列表"},
+		{ApiGroup: "AccessKey", Method: "POST", Path: "/accessKey/deleteAccessKey", Description: "作废AK/SK"},
+
+		{ApiGroup: "在线会话", Method: "GET", Path: "/session/getSelfSessions", Description: "获取自己的在线会话"},
+		{ApiGroup: "在线会话", Method: "POST", Path: "/session/revokeSelfSession", Description: "注销自己的会话"},
+		{ApiGroup: "在线会话", Method: "POST", Path: "/session/getUserSessions", Description: "获取指定用户的在线会话"},
+		{ApiGroup: "在线会话", Method: "POST", Path: "/session/forceLogout", Description: "强制用户下线"},
+
+		{ApiGroup: "租户管理", Method: "POST", Path: "/tenant/createTenant", Description: "创建租户"},
+		{ApiGroup: "租户管理", Method: "PUT", Path: "/tenant/updateTenant", Description: "更新租户"},
+		{ApiGroup: "租户管理", Method: "DELETE", Path: "/tenant/deleteTenant", Description: "删除租户"},
+		{ApiGroup: "租户管理", Method: "POST", Path: "/tenant/getTenantList", Description: "分页获取租户列表"},
+
+		{ApiGroup: "变更历史", Method: "GET", Path: "/changeHistory/getChangeHistoryList", Description: "分页获取变更历史"},
+		{ApiGroup: "审计日志", Method: "GET", Path: "/auditLog/verifyAuditLog", Description: "校验审计日志"},
 
 		{ApiGroup: "系统用户", Method: "DELETE", Path: "/user/deleteUser", Description: "删除用户"},
 		{ApiGroup: "系统用户", Method: "POST", Path: "/user/admin_register", Description: "用户注册"},
@@ -65,7 +79,26 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "系统用户", Method: "POST", Path: "/user/changePassword", Description: "修改密码（建议选择)"},
 		{ApiGroup: "系统用户", Method: "POST", Path: "/user/setUserAuthority", Description: "修改用户角色(必选)"},
 		{ApiGroup: "系统用户", Method: "POST", Path: "/user/resetPassword", Description: "重置用户密码"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/unlockUser", Description: "解除用户登录锁定"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/impersonate", Description: "模拟登录"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/stopImpersonate", Description: "退出模拟登录"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/createInvitation", Description: "生成注册邀请"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/getInvitationList", Description: "分页获取注册邀请"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/revokeInvitation", Description: "作废注册邀请"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/createAuthorityGrant", Description: "发起临时角色授权"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/approveAuthorityGrant", Description: "审批通过临时角色授权"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/rejectAuthorityGrant", Description: "拒绝临时角色授权"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/revokeAuthorityGrant", Description: "撤销临时角色授权"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/getAuthorityGrantList", Description: "分页获取临时角色授权"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/getAuthorityAuditList", Description: "分页获取角色授权审计记录"},
 		{ApiGroup: "系统用户", Method: "PUT", Path: "/user/setSelfSetting", Description: "用户界面配置"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/setupTwoFactor", Description: "获取两步验证绑定信息(建议选择)"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/enableTwoFactor", Description: "启用两步验证(建议选择)"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/disableTwoFactor", Description: "关闭两步验证(建议选择)"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/resetTwoFactor", Description: "重置用户两步验证"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/linkIdentity", Description: "绑定外部身份(建议选择)"},
+		{ApiGroup: "系统用户", Method: "POST", Path: "/user/unlinkIdentity", Description: "解除外部身份绑定(建议选择)"},
+		{ApiGroup: "系统用户", Method: "GET", Path: "/user/getIdentities", Description: "获取绑定的外部身份(建议选择)"},
 
 		{ApiGroup: "api", Method: "POST", Path: "/api/createApi", Description: "创建api"},
 		{ApiGroup: "api", Method: "POST", Path: "/api/deleteApi", Description: "删除Api"},
@@ -88,6 +121,9 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 
 		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/updateCasbin", Description: "更改角色api权限"},
 		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/getPolicyPathByAuthorityId", Description: "获取权限列表"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/explainPermission", Description: "权限诊断"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/getPermissionMatrix", Description: "获取权限矩阵"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/exportPermissionMatrix", Description: "导出权限矩阵"},
 
 		{ApiGroup: "菜单", Method: "POST", Path: "/menu/addBaseMenu", Description: "新增菜单"},
 		{ApiGroup: "菜单", Method: "POST", Path: "/menu/getMenu", Description: "获取菜单树(必选)"},
@@ -185,8 +221,7 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "操作记录", Method: "POST", Path: "/sysOperationRecord/createSysOperationRecord", Description: "新增操作记录"},
 		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/findSysOperationRecord", Description: "根据ID获取操作记录"},
 		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getSysOperationRecordList", Description: "获取操作记录列表"},
-		{ApiGroup: "操作记录", Method: "DELETE", Path: "/sysOperationRecord/deleteSysOperationRecord", Description: "删除操作记录"},
-		{ApiGroup: "操作记录", Method: "DELETE", Path: "/sysOperationRecord/deleteSysOperationRecordByIds", Description: "批量删除操作历史"},
+		{ApiGroup: "操作记录", Method: "GET", Path: "/sysOperationRecord/getOperationRecordStats", Description: "获取操作记录后台写入的统计"},
 
 		{ApiGroup: "断点续传(插件版)", Method: "POST", Path: "/simpleUploader/upload", Description: "插件版分片上传"},
 		{ApiGroup: "断点续传(插件版)", Method: "GET", Path: "/simpleUploader/checkFileMd5", Description: "文件完整度验证"},
@@ -198,6 +233,9 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "按钮权限", Method: "POST", Path: "/authorityBtn/setAuthorityBtn", Description: "设置按钮权限"},
 		{ApiGroup: "按钮权限", Method: "POST", Path: "/authorityBtn/getAuthorityBtn", Description: "获取已有按钮权限"},
 		{ApiGroup: "按钮权限", Method: "POST", Path: "/authorityBtn/canRemoveAuthorityBtn", Description: "删除按钮"},
+		{ApiGroup: "字段权限", Method: "GET", Path: "/authorityField/getFieldModels", Description: "获取可配置字段权限的模型"},
+		{ApiGroup: "字段权限", Method: "POST", Path: "/authorityField/getAuthorityField", Description: "获取角色字段权限"},
+		{ApiGroup: "字段权限", Method: "POST", Path: "/authorityField/setAuthorityField", Description: "设置角色字段权限"},
 
 		{ApiGroup: "导出模板", Method: "POST", Path: "/sysExportTemplate/createSysExportTemplate", Description: "新增导出模板"},
 		{ApiGroup: "导出模板", Method: "DELETE", Path: "/sysExportTemplate/deleteSysExportTemplate", Description: "删除导出模板"},
@@ -47,11 +47,23 @@ func (i *initApiIgnore) InitializeData(ctx context.Context) (context.Context, er
 		{Method: "GET", Path: "/api/freshCasbin"},
 		{Method: "GET", Path: "/uploads/file/*filepath"},
 		{Method: "GET", Path: "/health"},
+		{Method: "GET", Path: "/.well-known/jwks.json"},
 		{Method: "HEAD", Path: "/uploads/file/*filepath"},
 		{Method: "POST", Path: "/autoCode/llmAuto"},
 		{Method: "POST", Path: "/system/reloadSystem"},
 		{Method: "POST", Path: "/base/login"},
 		{Method: "POST", Path: "/base/captcha"},
+		{Method: "POST", Path: "/base/refresh"},
+		{Method: "POST", Path: "/base/enrollTwoFactor"},
+		{Method: "POST", Path: "/base/verifyTwoFactor"},
+		{Method: "GET", Path: "/base/identityProviders"},
+		{Method: "POST", Path: "/base/ssoAuthorize"},
+		{Method: "POST", Path: "/base/ssoCallback"},
+		{Method: "POST", Path: "/base/forgotPassword"},
+		{Method: "POST", Path: "/base/resetPasswordByToken"},
+		{Method: "GET", Path: "/base/registrationOptions"},
+		{Method: "POST", Path: "/base/selfRegister"},
+		{Method: "POST", Path: "/base/verifyRegistration"},
 		{Method: "POST", Path: "/init/initdb"},
 		{Method: "POST", Path: "/init/checkdb"},
 		{Method: "GET", Path: "/info/getInfoDataSource"},
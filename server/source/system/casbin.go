@@ -4,7 +4,9 @@ import (
 	"context"
 
 	adapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/tenant"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 )
@@ -47,14 +49,28 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 	entities := []adapter.CasbinRule{
 		{Ptype: "p", V0: "888", V1: "/user/admin_register", V2: "POST"},
 
-		{Ptype: "p", V0: "888", V1: "/sysLoginLog/deleteLoginLog", V2: "DELETE"},
-		{Ptype: "p", V0: "888", V1: "/sysLoginLog/deleteLoginLogByIds", V2: "DELETE"},
 		{Ptype: "p", V0: "888", V1: "/sysLoginLog/findLoginLog", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/sysLoginLog/getLoginLogList", V2: "GET"},
 
 		{Ptype: "p", V0: "888", V1: "/sysApiToken/createApiToken", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/sysApiToken/getApiTokenList", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/sysApiToken/deleteApiToken", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/accessKey/createAccessKey", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/accessKey/getAccessKeyList", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/accessKey/deleteAccessKey", V2: "POST"},
+
+		{Ptype: "p", V0: "888", V1: "/session/getSelfSessions", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/session/revokeSelfSession", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/session/getUserSessions", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/session/forceLogout", V2: "POST"},
+
+		{Ptype: "p", V0: "888", V1: "/tenant/createTenant", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/tenant/updateTenant", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/tenant/deleteTenant", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/tenant/getTenantList", V2: "POST"},
+
+		{Ptype: "p", V0: "888", V1: "/changeHistory/getChangeHistoryList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/auditLog/verifyAuditLog", V2: "GET"},
 
 		{Ptype: "p", V0: "888", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/api/getApiList", V2: "POST"},
@@ -94,7 +110,26 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/user/setUserAuthority", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/user/setUserAuthorities", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/user/resetPassword", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/unlockUser", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/impersonate", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/stopImpersonate", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/createInvitation", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/getInvitationList", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/revokeInvitation", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/createAuthorityGrant", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/approveAuthorityGrant", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/rejectAuthorityGrant", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/revokeAuthorityGrant", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/getAuthorityGrantList", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/getAuthorityAuditList", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/user/setSelfSetting", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/user/setupTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/enableTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/disableTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/resetTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/linkIdentity", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/unlinkIdentity", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/user/getIdentities", V2: "GET"},
 
 		{Ptype: "p", V0: "888", V1: "/fileUploadAndDownload/findFile", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/fileUploadAndDownload/breakpointContinueFinish", V2: "POST"},
@@ -109,6 +144,9 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 
 		{Ptype: "p", V0: "888", V1: "/casbin/updateCasbin", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/casbin/getPolicyPathByAuthorityId", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/explainPermission", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/getPermissionMatrix", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/exportPermissionMatrix", V2: "POST"},
 
 		{Ptype: "p", V0: "888", V1: "/jwt/jsonInBlacklist", V2: "POST"},
 
@@ -186,8 +224,7 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/updateSysOperationRecord", V2: "PUT"},
 		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/createSysOperationRecord", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getSysOperationRecordList", V2: "GET"},
-		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/deleteSysOperationRecord", V2: "DELETE"},
-		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/deleteSysOperationRecordByIds", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/sysOperationRecord/getOperationRecordStats", V2: "GET"},
 
 		{Ptype: "p", V0: "888", V1: "/email/emailTest", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/email/sendEmail", V2: "POST"},
@@ -199,6 +236,9 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/authorityBtn/setAuthorityBtn", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/authorityBtn/getAuthorityBtn", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/authorityBtn/canRemoveAuthorityBtn", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/authorityField/getFieldModels", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/authorityField/getAuthorityField", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/authorityField/setAuthorityField", V2: "POST"},
 
 		{Ptype: "p", V0: "888", V1: "/sysExportTemplate/createSysExportTemplate", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/sysExportTemplate/deleteSysExportTemplate", V2: "DELETE"},
@@ -284,6 +324,14 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "8881", V1: "/customer/customer", V2: "GET"},
 		{Ptype: "p", V0: "8881", V1: "/customer/customerList", V2: "GET"},
 		{Ptype: "p", V0: "8881", V1: "/user/getUserInfo", V2: "GET"},
+		{Ptype: "p", V0: "8881", V1: "/session/getSelfSessions", V2: "GET"},
+		{Ptype: "p", V0: "8881", V1: "/session/revokeSelfSession", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/setupTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/enableTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/disableTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/linkIdentity", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/unlinkIdentity", V2: "POST"},
+		{Ptype: "p", V0: "8881", V1: "/user/getIdentities", V2: "GET"},
 
 		{Ptype: "p", V0: "9528", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/api/createApi", V2: "POST"},
@@ -327,6 +375,19 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "9528", V1: "/customer/customerList", V2: "GET"},
 		{Ptype: "p", V0: "9528", V1: "/autoCode/createTemp", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/user/getUserInfo", V2: "GET"},
+		{Ptype: "p", V0: "9528", V1: "/session/getSelfSessions", V2: "GET"},
+		{Ptype: "p", V0: "9528", V1: "/session/revokeSelfSession", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/setupTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/enableTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/disableTwoFactor", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/linkIdentity", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/unlinkIdentity", V2: "POST"},
+		{Ptype: "p", V0: "9528", V1: "/user/getIdentities", V2: "GET"},
+	}
+	// 初始策略均为allow 属于平台租户
+	for k := range entities {
+		entities[k].V3 = request.CasbinAllow
+		entities[k].V4 = tenant.Domain(tenant.Platform)
 	}
 	if err := db.Create(&entities).Error; err != nil {
 		return ctx, errors.Wrap(err, "Casbin 表 ("+i.InitializerName()+") 数据初始化失败!")
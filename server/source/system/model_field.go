@@ -0,0 +1,69 @@
+package system
+
+import (
+	"context"
+
+	sysModel "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type initModelField struct{}
+
+const initOrderModelField = initOrderExcelTemplate + 1
+
+// auto run
+func init() {
+	system.RegisterInit(initOrderModelField, &initModelField{})
+}
+
+func (i *initModelField) InitializerName() string {
+	return sysModel.SysModelField{}.TableName()
+}
+
+func (i *initModelField) MigrateTable(ctx context.Context) (context.Context, error) {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return ctx, system.ErrMissingDBContext
+	}
+	return ctx, db.AutoMigrate(&sysModel.SysModelField{}, &sysModel.SysAuthorityField{})
+}
+
+func (i *initModelField) TableCreated(ctx context.Context) bool {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return false
+	}
+	return db.Migrator().HasTable(&sysModel.SysModelField{}) && db.Migrator().HasTable(&sysModel.SysAuthorityField{})
+}
+
+func (i *initModelField) InitializeData(ctx context.Context) (context.Context, error) {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return ctx, system.ErrMissingDBContext
+	}
+	// 客户示例的字段 代码生成的模型会在生成时自动登记
+	entities := []sysModel.SysModelField{
+		{Model: "model/example.ExaCustomer", Description: "客户", Field: "customerName", Label: "客户名", Table: "exa_customers", Column: "customer_name"},
+		{Model: "model/example.ExaCustomer", Description: "客户", Field: "customerPhoneData", Label: "客户手机号", Table: "exa_customers", Column: "customer_phone_data"},
+		{Model: "model/example.ExaCustomer", Description: "客户", Field: "sysUserId", Label: "管理ID", Table: "exa_customers", Column: "sys_user_id"},
+		{Model: "model/example.ExaCustomer", Description: "客户", Field: "sysUserAuthorityID", Label: "管理角色ID", Table: "exa_customers", Column: "sys_user_authority_id"},
+	}
+	if err := db.Create(&entities).Error; err != nil {
+		return ctx, errors.Wrap(err, sysModel.SysModelField{}.TableName()+"表数据初始化失败!")
+	}
+	next := context.WithValue(ctx, i.InitializerName(), entities)
+	return next, nil
+}
+
+func (i *initModelField) DataInserted(ctx context.Context) bool {
+	db, ok := ctx.Value("db").(*gorm.DB)
+	if !ok {
+		return false
+	}
+	if errors.Is(db.First(&sysModel.SysModelField{}).Error, gorm.ErrRecordNotFound) {
+		return false
+	}
+	return true
+}
@@ -18,16 +18,18 @@ import (
 func ClearTable(db *gorm.DB) error {
 	var ClearTableDetail []common.ClearDB
 
+	// 操作记录与登录日志写入了审计哈希链 由审计日志归档任务按保留天数归档 不在此清理
+	// 黑名单需保留到token过期 旧版长期有效的API Token拉黑后不能被清理
 	ClearTableDetail = append(ClearTableDetail, common.ClearDB{
-		TableName:    "sys_operation_records",
-		CompareField: "created_at",
-		Interval:     "2160h",
+		TableName:    "jwt_blacklists",
+		CompareField: "COALESCE(expires_at, created_at)",
+		Interval:     "168h",
 	})
 
 	ClearTableDetail = append(ClearTableDetail, common.ClearDB{
-		TableName:    "jwt_blacklists",
-		CompareField: "created_at",
-		Interval:     "168h",
+		TableName:    "sys_refresh_tokens",
+		CompareField: "expires_at",
+		Interval:     "0h",
 	})
 
 	if db == nil {
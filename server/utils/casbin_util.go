@@ -10,6 +10,25 @@ import (
 	"go.uber.org/zap"
 )
 
+// casbinModel 策略带有效果字段 命中任一deny即拒绝 子角色通过g继承父角色的全部策略
+// 域为角色所属租户 策略与继承关系只在同一租户内生效 域放在策略末尾以保持v0-v3的含义不变
+const casbinModel = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, obj, act, eft, dom
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && keyMatch2(r.obj,p.obj) && r.act == p.act
+`
+
 var (
 	syncedCachedEnforcer *casbin.SyncedCachedEnforcer
 	once                 sync.Once
@@ -23,23 +42,7 @@ func GetCasbin() *casbin.SyncedCachedEnforcer {
 			zap.L().Error("适配数据库失败请检查casbin表是否为InnoDB引擎!", zap.Error(err))
 			return
 		}
-		text := `
-		[request_definition]
-		r = sub, obj, act
-		
-		[policy_definition]
-		p = sub, obj, act
-		
-		[role_definition]
-		g = _, _
-		
-		[policy_effect]
-		e = some(where (p.eft == allow))
-		
-		[matchers]
-		m = r.sub == p.sub && keyMatch2(r.obj,p.obj) && r.act == p.act
-		`
-		m, err := model.NewModelFromString(text)
+		m, err := model.NewModelFromString(casbinModel)
 		if err != nil {
 			zap.L().Error("字符串加载模型失败!", zap.Error(err))
 			return
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+)
+
+func TestCasbinModelDenyAndInheritance(t *testing.T) {
+	m, err := model.NewModelFromString(casbinModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = e.AddPolicies([][]string{
+		{"888", "/user/:id", "GET", "allow", "0"},
+		{"888", "/user/delete", "POST", "allow", "0"},
+		{"888", "/api/list", "POST", "allow", "0"},
+		{"8881", "/user/delete", "POST", "deny", "0"},
+		{"8881", "/own", "GET", "allow", "0"},
+		{"888", "/legacy", "GET", "", "0"},
+		{"100", "/tenant", "GET", "allow", "1"},
+	})
+	_, _ = e.AddGroupingPolicy("8881", "888", "0")
+	_, _ = e.AddGroupingPolicy("101", "888", "1")
+
+	tests := []struct {
+		sub, dom, obj, act string
+		want               bool
+	}{
+		{"888", "0", "/user/1", "GET", true},
+		{"888", "0", "/user/delete", "POST", true},
+		{"8881", "0", "/user/1", "GET", true},        // 继承父角色
+		{"8881", "0", "/user/delete", "POST", false}, // 子角色deny优先
+		{"8881", "0", "/own", "GET", true},
+		{"888", "0", "/own", "GET", false}, // 父角色不继承子角色
+		{"888", "0", "/legacy", "GET", false},
+		{"9528", "0", "/user/1", "GET", false},
+		{"100", "1", "/tenant", "GET", true},
+		{"100", "0", "/tenant", "GET", false}, // 其他租户的用户不能使用该租户的策略
+		{"888", "1", "/user/1", "GET", false},
+		{"101", "1", "/user/1", "GET", false}, // 跨租户的继承不生效
+	}
+	for _, tt := range tests {
+		got, err := e.Enforce(tt.sub, tt.dom, tt.obj, tt.act)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Enforce(%s, %s, %s, %s) = %v, want %v", tt.sub, tt.dom, tt.obj, tt.act, got, tt.want)
+		}
+	}
+
+	// 父角色的deny同样作用于子角色
+	_, _ = e.AddPolicy("888", "/api/list", "POST", "deny", "0")
+	if ok, _ := e.Enforce("8881", "0", "/api/list", "POST"); ok {
+		t.Error("inherited deny should reject child authority")
+	}
+}
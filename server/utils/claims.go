@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"net"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -64,6 +66,23 @@ func GetClaims(c *gin.Context) (*systemReq.CustomClaims, error) {
 	return claims, err
 }
 
+type claimsContextKey struct{}
+
+// SetClaims 将jwt解析出来的信息写入Gin的Context 同时写入请求的context 供service层通过ctx获取当前用户
+func SetClaims(c *gin.Context, claims *systemReq.CustomClaims) {
+	c.Set("claims", claims)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), claimsContextKey{}, claims))
+}
+
+// GetClaimsFromContext 从请求的context中获取jwt解析出来的信息 需要经过JWTAuth或SignatureAuth中间件
+func GetClaimsFromContext(ctx context.Context) (*systemReq.CustomClaims, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	claims, ok := ctx.Value(claimsContextKey{}).(*systemReq.CustomClaims)
+	return claims, ok && claims != nil
+}
+
 // GetUserID 从Gin的Context中获取从jwt解析出来的用户ID
 func GetUserID(c *gin.Context) uint {
 	if claims, exists := c.Get("claims"); !exists {
@@ -134,15 +153,43 @@ func GetUserName(c *gin.Context) string {
 	}
 }
 
-func LoginToken(user system.Login) (token string, claims systemReq.CustomClaims, err error) {
+// LoginToken 签发access token sessionID 为本次登录的refresh token族ID
+func LoginToken(user system.Login, sessionID string) (token string, claims systemReq.CustomClaims, err error) {
 	j := NewJWT()
-	claims = j.CreateClaims(systemReq.BaseClaims{
+	base := systemReq.BaseClaims{
 		UUID:        user.GetUUID(),
 		ID:          user.GetUserId(),
 		NickName:    user.GetNickname(),
 		Username:    user.GetUsername(),
 		AuthorityId: user.GetAuthorityId(),
-	})
+		SessionID:   sessionID,
+	}
+	if u, ok := user.(*system.SysUser); ok {
+		base.MustChangePassword = u.MustChangePassword
+		base.TenantID = u.TenantID
+	}
+	claims = j.CreateClaims(base)
+	token, err = j.CreateToken(claims)
+	return
+}
+
+// ImpersonateToken 签发模拟登录token claims为被模拟的用户 同时记录实际操作人 不关联登录会话与refresh token
+func ImpersonateToken(user system.Login, impersonator *systemReq.CustomClaims, expires time.Duration) (token string, claims systemReq.CustomClaims, err error) {
+	j := NewJWT()
+	base := systemReq.BaseClaims{
+		UUID:             user.GetUUID(),
+		ID:               user.GetUserId(),
+		NickName:         user.GetNickname(),
+		Username:         user.GetUsername(),
+		AuthorityId:      user.GetAuthorityId(),
+		ImpersonatorID:   impersonator.BaseClaims.ID,
+		ImpersonatorName: impersonator.Username,
+	}
+	if u, ok := user.(*system.SysUser); ok {
+		base.TenantID = u.TenantID
+	}
+	claims = j.CreateClaims(base)
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(expires))
 	token, err = j.CreateToken(claims)
 	return
 }
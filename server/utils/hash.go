@@ -2,6 +2,9 @@ package utils
 
 import (
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -29,3 +32,18 @@ func MD5V(str []byte, b ...byte) string {
 	h.Write(str)
 	return hex.EncodeToString(h.Sum(b))
 }
+
+// SHA256Hex 计算sha256并返回十六进制字符串 用于令牌落库前脱敏
+func SHA256Hex(str string) string {
+	sum := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+// RandomToken 生成n字节随机数的base64url编码 用作不透明令牌
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,317 @@
+package history
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/redact"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// MaxRows 单条语句最多记录的行数 超出的批量修改只记录前MaxRows行
+const MaxRows = 1000
+
+const snapshotKey = "gva:history_before"
+
+// Tracked 需要记录变更历史的模型 由Plugin在增删改后写入字段变化
+type Tracked interface {
+	TrackHistory() bool
+}
+
+// Plugin GORM变更历史插件 记录开启了变更历史的模型每次增删改的字段变化
+// 操作人与请求ID取自语句的context 未通过WithContext传入请求context时只记录变化
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "gva:history"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Register("gva:history_create", afterCreate); err != nil {
+		return err
+	}
+	// 在租户插件追加条件之后读取修改前的数据
+	if err := cb.Update().Before("gorm:update").After("gva:tenant_update").Register("gva:history_before_update", snapshot); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("gva:history_update", afterUpdate); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").After("gva:tenant_delete").Register("gva:history_before_delete", snapshot); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Register("gva:history_delete", afterDelete)
+}
+
+// tracked 模型开启了变更历史且有主键
+func tracked(db *gorm.DB) bool {
+	s := db.Statement.Schema
+	if db.Error != nil || s == nil || s.PrioritizedPrimaryField == nil {
+		return false
+	}
+	t, ok := reflect.New(s.ModelType).Interface().(Tracked)
+	return ok && t.TrackHistory()
+}
+
+func afterCreate(db *gorm.DB) {
+	if !tracked(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	ctx, s := db.Statement.Context, db.Statement.Schema
+	var records []system.SysChangeHistory
+	add := func(rv reflect.Value) {
+		var changes []system.FieldChange
+		for _, f := range s.Fields {
+			if skipField(f) {
+				continue
+			}
+			if v, zero := f.ValueOf(ctx, rv); !zero {
+				changes = append(changes, system.FieldChange{Field: f.DBName, After: normalize(v)})
+			}
+		}
+		id, _ := s.PrioritizedPrimaryField.ValueOf(ctx, rv)
+		records = append(records, newRecord(db, system.ChangeActionCreate, id, changes))
+	}
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len() && i < MaxRows; i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				add(elem)
+			}
+		}
+	case reflect.Struct:
+		add(rv)
+	}
+	save(db, records)
+}
+
+// snapshot 按修改或删除语句的条件读取修改前的数据
+func snapshot(db *gorm.DB) {
+	if !tracked(db) {
+		return
+	}
+	stmt := db.Statement
+	conds := primaryConds(stmt)
+	if where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where); ok {
+		conds = append(conds, where.Exprs...)
+	}
+	if len(conds) == 0 {
+		return
+	}
+	// 条件中可能使用主键占位 需要通过模型解析 与原语句一样排除软删除的记录
+	tx := newSession(db).Model(reflect.New(stmt.Schema.ModelType).Interface()).Table(stmt.Table)
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+	var rows []map[string]interface{}
+	err := tx.Clauses(clause.Where{Exprs: conds}).Limit(MaxRows).Find(&rows).Error
+	if err != nil {
+		db.Logger.Error(stmt.Context, "读取修改前的数据失败: %v", err)
+		return
+	}
+	db.InstanceSet(snapshotKey, rows)
+}
+
+func afterUpdate(db *gorm.DB) {
+	before := snapshotRows(db)
+	if len(before) == 0 || db.Statement.RowsAffected == 0 {
+		return
+	}
+	stmt := db.Statement
+	pk := stmt.Schema.PrioritizedPrimaryField.DBName
+	ids := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row[pk])
+	}
+	var rows []map[string]interface{}
+	err := newSession(db).Model(reflect.New(stmt.Schema.ModelType).Interface()).Table(stmt.Table).Unscoped().Where(clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: pk}, Values: ids}).Find(&rows).Error
+	if err != nil {
+		db.Logger.Error(stmt.Context, "读取修改后的数据失败: %v", err)
+		return
+	}
+	after := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		after[fmt.Sprint(normalize(row[pk]))] = row
+	}
+	var records []system.SysChangeHistory
+	for _, old := range before {
+		row, ok := after[fmt.Sprint(normalize(old[pk]))]
+		if !ok {
+			continue
+		}
+		var changes []system.FieldChange
+		for _, f := range stmt.Schema.Fields {
+			if skipField(f) {
+				continue
+			}
+			b, a := normalize(old[f.DBName]), normalize(row[f.DBName])
+			if fmt.Sprint(b) != fmt.Sprint(a) {
+				changes = append(changes, system.FieldChange{Field: f.DBName, Before: b, After: a})
+			}
+		}
+		if len(changes) > 0 {
+			records = append(records, newRecord(db, system.ChangeActionUpdate, old[pk], changes))
+		}
+	}
+	save(db, records)
+}
+
+func afterDelete(db *gorm.DB) {
+	before := snapshotRows(db)
+	if len(before) == 0 || db.Statement.RowsAffected == 0 {
+		return
+	}
+	s := db.Statement.Schema
+	records := make([]system.SysChangeHistory, 0, len(before))
+	for _, row := range before {
+		var changes []system.FieldChange
+		for _, f := range s.Fields {
+			if skipField(f) {
+				continue
+			}
+			if v := normalize(row[f.DBName]); v != nil {
+				changes = append(changes, system.FieldChange{Field: f.DBName, Before: v})
+			}
+		}
+		records = append(records, newRecord(db, system.ChangeActionDelete, row[s.PrioritizedPrimaryField.DBName], changes))
+	}
+	save(db, records)
+}
+
+func snapshotRows(db *gorm.DB) []map[string]interface{} {
+	if db.Error != nil {
+		return nil
+	}
+	v, ok := db.InstanceGet(snapshotKey)
+	if !ok {
+		return nil
+	}
+	return v.([]map[string]interface{})
+}
+
+// primaryConds 按模型上的主键定位记录 如Save与Delete(&record)
+func primaryConds(stmt *gorm.Statement) []clause.Expression {
+	f := stmt.Schema.PrioritizedPrimaryField
+	column := clause.Column{Table: clause.CurrentTable, Name: f.DBName}
+	switch rv := stmt.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		var ids []interface{}
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				if id, zero := f.ValueOf(stmt.Context, elem); !zero {
+					ids = append(ids, id)
+				}
+			}
+		}
+		if len(ids) > 0 {
+			return []clause.Expression{clause.IN{Column: column, Values: ids}}
+		}
+	case reflect.Struct:
+		if id, zero := f.ValueOf(stmt.Context, rv); !zero {
+			return []clause.Expression{clause.Eq{Column: column, Value: id}}
+		}
+	}
+	return nil
+}
+
+// skipField 不记录关联与时间戳字段
+func skipField(f *schema.Field) bool {
+	return f.DBName == "" || f.AutoCreateTime > 0 || f.AutoUpdateTime > 0 || f.FieldType == reflect.TypeOf(gorm.DeletedAt{})
+}
+
+// newRecord 操作人与请求ID取自语句的context
+func newRecord(db *gorm.DB, action string, id interface{}, changes []system.FieldChange) system.SysChangeHistory {
+	record := system.SysChangeHistory{
+		Table:     db.Statement.Table,
+		RecordID:  fmt.Sprint(normalize(id)),
+		Action:    action,
+		Changes:   changes,
+		RequestID: utils.GetRequestIDFromContext(db.Statement.Context),
+	}
+	if claims, ok := utils.GetClaimsFromContext(db.Statement.Context); ok {
+		record.UserID = claims.BaseClaims.ID
+		record.ImpersonatorID = claims.ImpersonatorID
+	}
+	return record
+}
+
+// save 主库上的修改与变更历史在同一事务中写入 其他业务库的变更历史写入主库
+func save(db *gorm.DB, records []system.SysChangeHistory) {
+	if len(records) == 0 {
+		return
+	}
+	redactChanges(db.Statement.Schema, records)
+	tx := newSession(db)
+	if global.GVA_DB != nil && db.Config != global.GVA_DB.Config {
+		tx = global.GVA_DB.WithContext(db.Statement.Context)
+	}
+	if err := tx.Omit(clause.Associations).Create(&records).Error; err != nil {
+		db.Logger.Error(db.Statement.Context, "记录变更历史失败: %v", err)
+	}
+}
+
+// redactChanges json中不输出的字段与匹配脱敏规则的列只记录掩码 其余字符串按脱敏正则处理
+// 规则与操作记录的全局脱敏配置一致 配置有误时仅使用内置规则
+func redactChanges(s *schema.Schema, records []system.SysChangeHistory) {
+	conf := global.GVA_CONFIG.Redaction
+	r, err := redact.New(conf.Mask, redact.Rules{Fields: conf.Fields, Patterns: conf.Patterns})
+	if err != nil {
+		r, _ = redact.New(conf.Mask)
+	}
+	value := func(v interface{}, mask bool) interface{} {
+		if v == nil {
+			return nil
+		}
+		if mask {
+			return r.Mask()
+		}
+		if str, ok := v.(string); ok {
+			return r.Body(str)
+		}
+		return v
+	}
+	for i := range records {
+		for j := range records[i].Changes {
+			c := &records[i].Changes[j]
+			mask := r.Key(c.Field)
+			if f := s.LookUpField(c.Field); f != nil {
+				name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+				mask = mask || name == "-" || r.Key(name)
+			}
+			c.Before, c.After = value(c.Before, mask), value(c.After, mask)
+		}
+	}
+}
+
+func newSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+}
+
+// normalize 统一不同驱动返回的类型 便于比较与序列化
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case *time.Time:
+		if val == nil {
+			return nil
+		}
+		return val.Format(time.RFC3339Nano)
+	case gorm.DeletedAt:
+		if !val.Valid {
+			return nil
+		}
+		return val.Time.Format(time.RFC3339Nano)
+	}
+	return v
+}
@@ -0,0 +1,132 @@
+package history
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type trackedRecord struct {
+	ID        uint
+	Name      string
+	Age       int
+	DeletedAt gorm.DeletedAt
+}
+
+func (trackedRecord) TrackHistory() bool {
+	return true
+}
+
+type secretRecord struct {
+	ID       uint
+	Name     string
+	Password string `json:"-"`
+	ApiToken string `json:"token"`
+}
+
+func (secretRecord) TrackHistory() bool {
+	return true
+}
+
+type untrackedRecord struct {
+	ID   uint
+	Name string
+}
+
+func TestPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Plugins: map[string]gorm.Plugin{Plugin{}.Name(): Plugin{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysChangeHistory{}, &trackedRecord{}, &untrackedRecord{}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("PUT", "/", nil)
+	utils.SetRequestID(c, "req-1")
+	utils.SetClaims(c, &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 7}})
+	tx := db.WithContext(c.Request.Context())
+
+	records := []trackedRecord{{Name: "a", Age: 1}, {Name: "b", Age: 2}}
+	tx.Create(&records)
+	tx.Create(&untrackedRecord{Name: "x"})
+	tx.Model(&records[0]).Update("name", "a2")
+	tx.Model(&trackedRecord{}).Where("age > ?", 1).Updates(map[string]interface{}{"age": 3, "name": "b"})
+	tx.Save(&trackedRecord{ID: records[1].ID, Name: "b", Age: 3})
+	tx.Delete(&trackedRecord{}, records[1].ID)
+	// 已删除的记录不再产生变更历史
+	tx.Where("id = ?", records[1].ID).Delete(&trackedRecord{})
+
+	var list []system.SysChangeHistory
+	db.Order("id").Find(&list)
+	want := []struct {
+		action, record string
+		changes        int
+	}{
+		{system.ChangeActionCreate, "1", 3},
+		{system.ChangeActionCreate, "2", 3},
+		{system.ChangeActionUpdate, "1", 1},
+		{system.ChangeActionUpdate, "2", 1},
+		{system.ChangeActionDelete, "2", 3},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("got %d change records, want %d: %+v", len(list), len(want), list)
+	}
+	for i, w := range want {
+		got := list[i]
+		if got.Table != "tracked_records" || got.Action != w.action || got.RecordID != w.record || len(got.Changes) != w.changes {
+			t.Errorf("record %d = %s %s %s %+v, want %s %s with %d changes", i, got.Table, got.Action, got.RecordID, got.Changes, w.action, w.record, w.changes)
+		}
+		if got.UserID != 7 || got.RequestID != "req-1" {
+			t.Errorf("record %d acted by %d in %q, want 7 in req-1", i, got.UserID, got.RequestID)
+		}
+	}
+	if c := list[2].Changes[0]; c.Field != "name" || c.Before != "a" || c.After != "a2" {
+		t.Errorf("update change = %+v, want name a -> a2", c)
+	}
+	if c := list[3].Changes[0]; c.Field != "age" || c.Before != float64(2) || c.After != float64(3) {
+		t.Errorf("update change = %+v, want age 2 -> 3", c)
+	}
+}
+
+// TestPluginRedact 不输出到json的字段与匹配脱敏规则的字段只记录掩码
+func TestPluginRedact(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Plugins: map[string]gorm.Plugin{Plugin{}.Name(): Plugin{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&system.SysChangeHistory{}, &secretRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	record := secretRecord{Name: "a?password=p1", Password: "hash", ApiToken: "t1"}
+	db.Create(&record)
+	db.Model(&record).Updates(map[string]interface{}{"password": "hash2", "api_token": "t2"})
+
+	var list []system.SysChangeHistory
+	db.Order("id").Find(&list)
+	if len(list) != 2 {
+		t.Fatalf("got %d change records, want 2", len(list))
+	}
+	for _, c := range append(list[0].Changes, list[1].Changes...) {
+		switch c.Field {
+		case "name":
+			if c.After != "a?password=******" {
+				t.Errorf("name = %v, want the password parameter masked", c.After)
+			}
+		case "id":
+		case "password", "api_token":
+			if c.Before != nil && c.Before != "******" || c.After != "******" {
+				t.Errorf("%s = %v -> %v, want masked", c.Field, c.Before, c.After)
+			}
+		default:
+			t.Errorf("unexpected change %+v", c)
+		}
+	}
+}
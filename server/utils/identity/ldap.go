@@ -0,0 +1,167 @@
+package identity
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/go-ldap/ldap/v3"
+)
+
+var _ PasswordProvider = (*LDAP)(nil)
+
+// LDAP 先用服务账号查询用户DN 再以用户DN和密码绑定校验
+type LDAP struct {
+	conf    config.IdentityProvider
+	Timeout time.Duration
+}
+
+func NewLDAP(conf config.IdentityProvider) *LDAP {
+	return &LDAP{conf: conf, Timeout: 10 * time.Second}
+}
+
+func (l *LDAP) Config() config.IdentityProvider {
+	return l.conf
+}
+
+// Authenticate 校验用户名密码 返回用户属性与所属组
+func (l *LDAP) Authenticate(ctx context.Context, username, password string) (*Identity, error) {
+	// 空密码在多数目录上会被当作匿名绑定而成功 必须拒绝
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	conf := l.conf.LDAP
+	conn, err := l.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetTimeout(time.Until(deadline))
+	}
+
+	if err = l.serviceBind(conn); err != nil {
+		return nil, err
+	}
+	usernameAttr := withDefault(conf.UsernameAttr, "uid")
+	emailAttr := withDefault(conf.EmailAttr, "mail")
+	nameAttr := withDefault(conf.NameAttr, "cn")
+	phoneAttr := withDefault(conf.PhoneAttr, "telephoneNumber")
+	filter := fmt.Sprintf(withDefault(conf.UserFilter, "(uid=%s)"), ldap.EscapeFilter(username))
+	res, err := conn.Search(ldap.NewSearchRequest(
+		conf.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		filter, []string{usernameAttr, emailAttr, nameAttr, phoneAttr, "memberOf"}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("ldap查询用户失败: %w", err)
+	}
+	if len(res.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	entry := res.Entries[0]
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap绑定失败: %w", err)
+	}
+
+	id := &Identity{
+		Provider: l.conf.Name,
+		Username: entry.GetAttributeValue(usernameAttr),
+		NickName: entry.GetAttributeValue(nameAttr),
+		Email:    entry.GetAttributeValue(emailAttr),
+		Phone:    entry.GetAttributeValue(phoneAttr),
+	}
+	if id.Username == "" {
+		id.Username = username
+	}
+	id.Subject = id.Username
+	for _, dn := range entry.GetAttributeValues("memberOf") {
+		if cn := firstRDNValue(dn); cn != "" {
+			id.Groups = append(id.Groups, cn)
+		}
+	}
+	if conf.GroupBaseDN != "" {
+		// 组查询使用服务账号 用户本身通常没有读取组的权限
+		if err = l.serviceBind(conn); err != nil {
+			return nil, err
+		}
+		groupAttr := withDefault(conf.GroupAttr, "cn")
+		groupFilter := fmt.Sprintf(withDefault(conf.GroupFilter, "(member=%s)"), ldap.EscapeFilter(entry.DN))
+		groups, err := conn.Search(ldap.NewSearchRequest(
+			conf.GroupBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			groupFilter, []string{groupAttr}, nil,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("ldap查询用户组失败: %w", err)
+		}
+		for _, g := range groups.Entries {
+			if v := g.GetAttributeValue(groupAttr); v != "" && !containsString(id.Groups, v) {
+				id.Groups = append(id.Groups, v)
+			}
+		}
+	}
+	return id, nil
+}
+
+func (l *LDAP) dial() (*ldap.Conn, error) {
+	conf := l.conf.LDAP
+	u, err := url.Parse(conf.URL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), InsecureSkipVerify: conf.InsecureSkipVerify}
+	conn, err := ldap.DialURL(conf.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("连接ldap失败: %w", err)
+	}
+	conn.SetTimeout(l.Timeout)
+	if conf.StartTLS && u.Scheme == "ldap" {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap StartTLS失败: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+func (l *LDAP) serviceBind(conn *ldap.Conn) error {
+	conf := l.conf.LDAP
+	if conf.BindDN == "" {
+		return conn.UnauthenticatedBind("")
+	}
+	if err := conn.Bind(conf.BindDN, conf.BindPassword); err != nil {
+		return fmt.Errorf("ldap服务账号绑定失败: %w", err)
+	}
+	return nil
+}
+
+// firstRDNValue 从 cn=admins,ou=groups,dc=example,dc=com 中取出 admins
+func firstRDNValue(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return ""
+	}
+	return parsed.RDNs[0].Attributes[0].Value
+}
+
+func withDefault(v, def string) string {
+	if strings.TrimSpace(v) == "" {
+		return def
+	}
+	return v
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
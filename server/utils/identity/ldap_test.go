@@ -0,0 +1,181 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+type ldapEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// stubLDAP 进程内LDAP服务 只实现简单绑定与等值过滤查询
+type stubLDAP struct {
+	ln      net.Listener
+	entries []ldapEntry
+}
+
+func newStubLDAP(t *testing.T, entries []ldapEntry) *stubLDAP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &stubLDAP{ln: ln, entries: entries}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *stubLDAP) URL() string {
+	return "ldap://" + s.ln.Addr().String()
+}
+
+func (s *stubLDAP) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			code := uint16(ldap.LDAPResultInvalidCredentials)
+			if e := s.find(dn); e != nil && password != "" && e.password == password {
+				code = ldap.LDAPResultSuccess
+			}
+			s.reply(conn, id, ldap.ApplicationBindResponse, code)
+		case ldap.ApplicationSearchRequest:
+			base := strings.ToLower(op.Children[0].Value.(string))
+			filter, _ := ldap.DecompileFilter(op.Children[6])
+			attr, value := parseEqualityFilter(filter)
+			for _, e := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(e.dn), base) || !hasValue(e.attrs[attr], value) {
+					continue
+				}
+				s.writeEntry(conn, id, e)
+			}
+			s.reply(conn, id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		default:
+			return
+		}
+	}
+}
+
+func (s *stubLDAP) find(dn string) *ldapEntry {
+	for i := range s.entries {
+		if strings.EqualFold(s.entries[i].dn, dn) {
+			return &s.entries[i]
+		}
+	}
+	return nil
+}
+
+func (s *stubLDAP) reply(conn net.Conn, id int64, tag ber.Tag, code uint16) {
+	msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	msg.AppendChild(res)
+	_, _ = conn.Write(msg.Bytes())
+}
+
+func (s *stubLDAP) writeEntry(conn net.Conn, id int64, e ldapEntry) {
+	msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, ""))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, values := range e.attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	res.AppendChild(attrs)
+	msg.AppendChild(res)
+	_, _ = conn.Write(msg.Bytes())
+}
+
+// parseEqualityFilter 解析 (attr=value) 形式的过滤条件
+func parseEqualityFilter(filter string) (string, string) {
+	filter = strings.TrimSuffix(strings.TrimPrefix(filter, "("), ")")
+	attr, value, _ := strings.Cut(filter, "=")
+	return attr, value
+}
+
+func hasValue(values []string, v string) bool {
+	for _, s := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	const aliceDN = "uid=alice,ou=people,dc=example,dc=com"
+	srv := newStubLDAP(t, []ldapEntry{
+		{dn: "cn=svc,dc=example,dc=com", password: "svc-pass"},
+		{dn: aliceDN, password: "alice-pass", attrs: map[string][]string{
+			"uid": {"alice"}, "mail": {"alice@example.com"}, "cn": {"Alice"},
+		}},
+		{dn: "cn=admins,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+			"cn": {"admins"}, "member": {aliceDN},
+		}},
+		{dn: "cn=others,ou=groups,dc=example,dc=com", attrs: map[string][]string{
+			"cn": {"others"}, "member": {"uid=bob,ou=people,dc=example,dc=com"},
+		}},
+	})
+	p := NewLDAP(config.IdentityProvider{Name: "corp-ldap", Type: TypeLDAP, LDAP: config.LDAPProvider{
+		URL:          srv.URL(),
+		BindDN:       "cn=svc,dc=example,dc=com",
+		BindPassword: "svc-pass",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		GroupBaseDN:  "ou=groups,dc=example,dc=com",
+	}})
+	ctx := context.Background()
+
+	id, err := p.Authenticate(ctx, "alice", "alice-pass")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if id.Username != "alice" || id.Email != "alice@example.com" || len(id.Groups) != 1 || id.Groups[0] != "admins" {
+		t.Errorf("Authenticate() = %+v", id)
+	}
+	if _, err = p.Authenticate(ctx, "alice", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Authenticate() wrong password error = %v", err)
+	}
+	if _, err = p.Authenticate(ctx, "alice", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Authenticate() empty password error = %v", err)
+	}
+	if _, err = p.Authenticate(ctx, "nobody", "x"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Authenticate() unknown user error = %v", err)
+	}
+}
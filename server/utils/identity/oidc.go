@@ -0,0 +1,338 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+var _ RedirectProvider = (*OIDC)(nil)
+
+// OIDC 授权码模式 + PKCE 使用id_token中的声明作为用户信息
+type OIDC struct {
+	conf   config.IdentityProvider
+	Client *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type oidcJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func NewOIDC(conf config.IdentityProvider) *OIDC {
+	return &OIDC{conf: conf, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (o *OIDC) Config() config.IdentityProvider {
+	return o.conf
+}
+
+// AuthCodeURL 生成跳转到提供方的授权地址
+func (o *OIDC) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := o.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := o.conf.OIDC.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", o.conf.OIDC.ClientID)
+	v.Set("redirect_uri", o.conf.OIDC.RedirectURL)
+	v.Set("scope", strings.Join(scopes, " "))
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", codeChallenge)
+	v.Set("code_challenge_method", "S256")
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + v.Encode(), nil
+}
+
+// Exchange 使用授权码换取令牌 并校验id_token的签名 签发者 受众与nonce
+func (o *OIDC) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	d, err := o.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", o.conf.OIDC.RedirectURL)
+	form.Set("client_id", o.conf.OIDC.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.conf.OIDC.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(o.conf.OIDC.ClientID), url.QueryEscape(o.conf.OIDC.ClientSecret))
+	}
+	var token oidcTokenResponse
+	if err = o.doJSON(req, &token); err != nil {
+		return nil, err
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("oidc换取令牌失败: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("oidc响应缺少id_token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token.IDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return o.publicKey(ctx, d, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "PS256", "EdDSA"}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(o.conf.OIDC.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("id_token校验失败: %w", err)
+	}
+	if n, _ := claims["nonce"].(string); n == "" || n != nonce {
+		return nil, errors.New("id_token nonce不匹配")
+	}
+
+	groupsClaim := o.groupsClaim()
+	if _, ok := claims[groupsClaim]; !ok && d.UserinfoEndpoint != "" && token.AccessToken != "" {
+		// 部分提供方只在userinfo中返回组信息
+		if info, e := o.userinfo(ctx, d, token.AccessToken); e == nil && info["sub"] == claims["sub"] {
+			for k, v := range info {
+				if _, exists := claims[k]; !exists {
+					claims[k] = v
+				}
+			}
+		}
+	}
+	return o.identity(claims)
+}
+
+func (o *OIDC) identity(claims jwt.MapClaims) (*Identity, error) {
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, errors.New("id_token缺少sub")
+	}
+	usernameClaim := o.conf.OIDC.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "preferred_username"
+	}
+	id := &Identity{Provider: o.conf.Name, Subject: sub}
+	id.Username, _ = claims[usernameClaim].(string)
+	id.NickName, _ = claims["name"].(string)
+	id.Email, _ = claims["email"].(string)
+	id.Phone, _ = claims["phone_number"].(string)
+	if id.Username == "" {
+		id.Username = id.Email
+	}
+	switch groups := claims[o.groupsClaim()].(type) {
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				id.Groups = append(id.Groups, s)
+			}
+		}
+	case string:
+		id.Groups = append(id.Groups, groups)
+	}
+	return id, nil
+}
+
+func (o *OIDC) groupsClaim() string {
+	if o.conf.OIDC.GroupsClaim != "" {
+		return o.conf.OIDC.GroupsClaim
+	}
+	return "groups"
+}
+
+func (o *OIDC) discover(ctx context.Context) (*oidcDiscovery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+	issuer := strings.TrimSuffix(o.conf.OIDC.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var d oidcDiscovery
+	if err = o.doJSON(req, &d); err != nil {
+		return nil, fmt.Errorf("获取oidc配置失败: %w", err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc issuer不匹配: %s", d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JwksURI == "" {
+		return nil, errors.New("oidc配置缺少必要的端点")
+	}
+	o.discovery = &d
+	return o.discovery, nil
+}
+
+// publicKey 按kid查找公钥 未知kid时重新拉取一次JWKS 兼容提供方轮换密钥
+func (o *OIDC) publicKey(ctx context.Context, d *oidcDiscovery, kid string) (crypto.PublicKey, error) {
+	o.mu.Lock()
+	key, ok := o.lookupKey(kid)
+	o.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.JwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err = o.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("获取oidc公钥失败: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		pub, e := k.publicKey()
+		if e != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	o.mu.Lock()
+	o.keys = keys
+	key, ok = o.lookupKey(kid)
+	o.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("未找到kid为%q的公钥", kid)
+	}
+	return key, nil
+}
+
+func (o *OIDC) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(o.keys) == 1 {
+		for _, k := range o.keys {
+			return k, true
+		}
+	}
+	k, ok := o.keys[kid]
+	return k, ok
+}
+
+func (o *OIDC) userinfo(ctx context.Context, d *oidcDiscovery, accessToken string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	info := map[string]interface{}{}
+	return info, o.doJSON(req, &info)
+}
+
+func (o *OIDC) doJSON(req *http.Request, v any) error {
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	// 令牌端点的错误响应同样是json 交给调用方读取error字段
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("%s 返回 %d", req.URL.Path, resp.StatusCode)
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (k oidcJWK) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("不支持的曲线: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if k.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("不支持的曲线: %s", k.Crv)
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("不支持的密钥类型: %s", k.Kty)
+}
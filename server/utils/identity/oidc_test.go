@@ -0,0 +1,122 @@
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// stubIdP 最小化的OIDC提供方 校验PKCE后签发ES256的id_token
+type stubIdP struct {
+	*httptest.Server
+	key       *ecdsa.PrivateKey
+	challenge string
+	nonce     string
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &stubIdP{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "EC", "crv": "P-256", "kid": "k1", "use": "sig", "alg": "ES256",
+			"x": base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			"y": base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("code") != "good-code" || PKCEChallenge(r.Form.Get("code_verifier")) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+			"iss":                idp.URL,
+			"aud":                "gva",
+			"sub":                "user-1",
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"nonce":              idp.nonce,
+			"preferred_username": "alice",
+			"email":              "alice@example.com",
+			"groups":             []string{"admins", "staff"},
+		})
+		token.Header["kid"] = "k1"
+		signed, _ := token.SignedString(key)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "access_token": "at"})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+func TestOIDCAuthorizationCodeFlow(t *testing.T) {
+	idp := newStubIdP(t)
+	p := NewOIDC(config.IdentityProvider{Name: "corp", Type: TypeOIDC, OIDC: config.OIDCProvider{
+		Issuer: idp.URL, ClientID: "gva", RedirectURL: "http://127.0.0.1:8080/",
+	}})
+	ctx := context.Background()
+
+	verifier, challenge, err := NewPKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp.challenge, idp.nonce = challenge, "n-1"
+	authURL, err := p.AuthCodeURL(ctx, "s-1", "n-1", challenge)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	u, _ := url.Parse(authURL)
+	if q := u.Query(); q.Get("code_challenge") != challenge || q.Get("code_challenge_method") != "S256" || q.Get("state") != "s-1" {
+		t.Errorf("AuthCodeURL() = %s, missing pkce/state parameters", authURL)
+	}
+
+	id, err := p.Exchange(ctx, "good-code", verifier, "n-1")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if id.Subject != "user-1" || id.Username != "alice" || len(id.Groups) != 2 {
+		t.Errorf("Exchange() = %+v", id)
+	}
+	if _, err = p.Exchange(ctx, "good-code", "wrong-verifier", "n-1"); err == nil {
+		t.Errorf("Exchange() should fail with a wrong code_verifier")
+	}
+	if _, err = p.Exchange(ctx, "good-code", verifier, "other-nonce"); err == nil {
+		t.Errorf("Exchange() should fail when nonce does not match")
+	}
+}
+
+func TestMapAuthorities(t *testing.T) {
+	conf := config.IdentityProvider{GroupMappings: []config.IdentityGroupMapping{
+		{Group: "staff", AuthorityId: 8881},
+		{Group: "admins", AuthorityId: 888},
+		{Group: "ops", AuthorityId: 888},
+	}}
+	got := MapAuthorities(conf, []string{"admins", "ops", "staff"})
+	if len(got) != 2 || got[0] != 8881 || got[1] != 888 {
+		t.Errorf("MapAuthorities() = %v, want [8881 888]", got)
+	}
+}
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"context"
 	"errors"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 
 type JWT struct {
 	SigningKey []byte
+	KeySet     *JWTKeySet // 非对称签名密钥 为nil时使用SigningKey做HS256签名
 }
 
 var (
@@ -25,20 +25,19 @@ var (
 
 func NewJWT() *JWT {
 	return &JWT{
-		[]byte(global.GVA_CONFIG.JWT.SigningKey),
+		SigningKey: []byte(global.GVA_CONFIG.JWT.SigningKey),
+		KeySet:     GetJWTKeySet(),
 	}
 }
 
 func (j *JWT) CreateClaims(baseClaims request.BaseClaims) request.CustomClaims {
-	bf, _ := ParseDuration(global.GVA_CONFIG.JWT.BufferTime)
 	ep, _ := ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
 	claims := request.CustomClaims{
-		BaseClaims: baseClaims,
-		BufferTime: int64(bf / time.Second), // 缓冲时间1天 缓冲时间内会获得新的token刷新令牌 此时一个用户会存在两个有效令牌 但是前端只留一个 另一个会丢失
+		BaseClaims: baseClaims, // access token 有效期较短 过期后使用 refresh token 换取新的令牌对
 		RegisteredClaims: jwt.RegisteredClaims{
 			Audience:  jwt.ClaimStrings{"GVA"},                   // 受众
 			NotBefore: jwt.NewNumericDate(time.Now().Add(-1000)), // 签名生效时间
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ep)),    // 过期时间 7天  配置文件
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ep)),    // 过期时间 配置文件
 			Issuer:    global.GVA_CONFIG.JWT.Issuer,              // 签名的发行者
 		},
 	}
@@ -47,23 +46,22 @@ func (j *JWT) CreateClaims(baseClaims request.BaseClaims) request.CustomClaims {
 
 // CreateToken 创建一个token
 func (j *JWT) CreateToken(claims request.CustomClaims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.SigningKey)
-}
-
-// CreateTokenByOldToken 旧token 换新token 使用归并回源避免并发问题
-func (j *JWT) CreateTokenByOldToken(oldToken string, claims request.CustomClaims) (string, error) {
-	v, err, _ := global.GVA_Concurrency_Control.Do("JWT:"+oldToken, func() (interface{}, error) {
-		return j.CreateToken(claims)
-	})
-	return v.(string), err
+	if j.KeySet == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(j.SigningKey)
+	}
+	key, err := j.KeySet.SigningKey(time.Now())
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.Private)
 }
 
 // ParseToken 解析 token
 func (j *JWT) ParseToken(tokenString string) (*request.CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &request.CustomClaims{}, func(token *jwt.Token) (i interface{}, e error) {
-		return j.SigningKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &request.CustomClaims{}, j.keyFunc, jwt.WithValidMethods([]string{j.method()}))
 
 	if err != nil {
 		switch {
@@ -71,7 +69,7 @@ func (j *JWT) ParseToken(tokenString string) (*request.CustomClaims, error) {
 			return nil, TokenExpired
 		case errors.Is(err, jwt.ErrTokenMalformed):
 			return nil, TokenMalformed
-		case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, TokenSignatureInvalid):
 			return nil, TokenSignatureInvalid
 		case errors.Is(err, jwt.ErrTokenNotValidYet):
 			return nil, TokenNotValidYet
@@ -87,19 +85,31 @@ func (j *JWT) ParseToken(tokenString string) (*request.CustomClaims, error) {
 	return nil, TokenValid
 }
 
-//@author: [piexlmax](https://github.com/piexlmax)
-//@function: SetRedisJWT
-//@description: jwt存入redis并设置过期时间
-//@param: jwt string, userName string
-//@return: err error
+// TokenExpiresAt 不校验签名读取token的过期时间 用于设置黑名单的保留时长
+func TokenExpiresAt(tokenString string) (time.Time, bool) {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, &claims); err != nil || claims.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+	return claims.ExpiresAt.Time, true
+}
+
+func (j *JWT) method() string {
+	if j.KeySet == nil {
+		return jwt.SigningMethodHS256.Alg()
+	}
+	return j.KeySet.Method.Alg()
+}
 
-func SetRedisJWT(jwt string, userName string) (err error) {
-	// 此处过期时间等于jwt过期时间
-	dr, err := ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
+// keyFunc 根据token头部的kid选择验签公钥 已轮换出宽限期的密钥签发的token视为无效
+func (j *JWT) keyFunc(token *jwt.Token) (interface{}, error) {
+	if j.KeySet == nil {
+		return j.SigningKey, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, err := j.KeySet.VerifyKey(kid, time.Now())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	timer := dr
-	err = global.GVA_REDIS.Set(context.Background(), userName, jwt, timer).Err()
-	return err
+	return key.Private.Public(), nil
 }
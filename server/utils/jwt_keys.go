@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	jwtKeySet     *JWTKeySet
+	jwtKeySetLock sync.RWMutex
+)
+
+// JWTKey 一把非对称签名密钥
+type JWTKey struct {
+	Kid        string
+	Method     jwt.SigningMethod
+	Private    crypto.Signer
+	ActiveFrom time.Time
+}
+
+// JWTKeySet 按生效时间排序的密钥集合 最新生效的密钥用于签名 被替换的密钥在宽限期内仍可验签
+type JWTKeySet struct {
+	Method jwt.SigningMethod
+	Keys   []JWTKey
+	Grace  time.Duration
+}
+
+// JWK json web key 只包含公钥部分
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS json web key set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// InitJWTKeySet 根据配置加载签名密钥 HS256 时不加载任何密钥 沿用 signing-key
+func InitJWTKeySet(conf config.JWT) error {
+	set, err := NewJWTKeySet(conf)
+	if err != nil {
+		return err
+	}
+	jwtKeySetLock.Lock()
+	jwtKeySet = set
+	jwtKeySetLock.Unlock()
+	return nil
+}
+
+// GetJWTKeySet 获取当前密钥集合 对称签名时返回nil
+func GetJWTKeySet() *JWTKeySet {
+	jwtKeySetLock.RLock()
+	defer jwtKeySetLock.RUnlock()
+	return jwtKeySet
+}
+
+func NewJWTKeySet(conf config.JWT) (*JWTKeySet, error) {
+	method := strings.ToUpper(strings.TrimSpace(conf.SigningMethod))
+	if method == "" || method == jwt.SigningMethodHS256.Alg() {
+		return nil, nil
+	}
+	set := &JWTKeySet{}
+	switch method {
+	case jwt.SigningMethodRS256.Alg():
+		set.Method = jwt.SigningMethodRS256
+	case jwt.SigningMethodES256.Alg():
+		set.Method = jwt.SigningMethodES256
+	case strings.ToUpper(jwt.SigningMethodEdDSA.Alg()):
+		set.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("不支持的jwt签名算法: %s", conf.SigningMethod)
+	}
+	if len(conf.Keys) == 0 {
+		return nil, fmt.Errorf("jwt签名算法 %s 需要配置至少一把密钥", set.Method.Alg())
+	}
+	if conf.RotationGrace != "" {
+		grace, err := ParseDuration(conf.RotationGrace)
+		if err != nil {
+			return nil, fmt.Errorf("jwt rotation-grace 配置错误: %w", err)
+		}
+		set.Grace = grace
+	} else {
+		grace, err := ParseDuration(conf.ExpiresTime)
+		if err != nil {
+			return nil, err
+		}
+		set.Grace = grace
+	}
+
+	kids := make(map[string]struct{}, len(conf.Keys))
+	for _, k := range conf.Keys {
+		if k.Kid == "" {
+			return nil, errors.New("jwt密钥缺少kid")
+		}
+		if _, ok := kids[k.Kid]; ok {
+			return nil, fmt.Errorf("jwt密钥kid重复: %s", k.Kid)
+		}
+		kids[k.Kid] = struct{}{}
+		signer, err := loadPrivateKey(k.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("加载jwt密钥 %s 失败: %w", k.Kid, err)
+		}
+		if err = checkKeyMethod(set.Method, signer); err != nil {
+			return nil, fmt.Errorf("jwt密钥 %s: %w", k.Kid, err)
+		}
+		key := JWTKey{Kid: k.Kid, Method: set.Method, Private: signer}
+		if k.ActiveFrom != "" {
+			key.ActiveFrom, err = time.Parse(time.RFC3339, k.ActiveFrom)
+			if err != nil {
+				return nil, fmt.Errorf("jwt密钥 %s active-from 格式错误: %w", k.Kid, err)
+			}
+		}
+		set.Keys = append(set.Keys, key)
+	}
+	sort.SliceStable(set.Keys, func(i, j int) bool {
+		return set.Keys[i].ActiveFrom.Before(set.Keys[j].ActiveFrom)
+	})
+	return set, nil
+}
+
+// SigningKey 返回当前时间应使用的签名密钥 即已生效密钥中生效时间最晚的一把
+func (s *JWTKeySet) SigningKey(now time.Time) (*JWTKey, error) {
+	for i := len(s.Keys) - 1; i >= 0; i-- {
+		if !s.Keys[i].ActiveFrom.After(now) {
+			return &s.Keys[i], nil
+		}
+	}
+	return nil, errors.New("没有已生效的jwt签名密钥")
+}
+
+// VerifyKey 按kid查找可用于验签的密钥 密钥被下一把替换后 仅在宽限期内有效
+func (s *JWTKeySet) VerifyKey(kid string, now time.Time) (*JWTKey, error) {
+	for i := range s.Keys {
+		if s.Keys[i].Kid != kid {
+			continue
+		}
+		if s.Keys[i].ActiveFrom.After(now) {
+			return nil, TokenSignatureInvalid
+		}
+		if i+1 < len(s.Keys) && s.Keys[i+1].ActiveFrom.Add(s.Grace).Before(now) {
+			return nil, TokenSignatureInvalid
+		}
+		return &s.Keys[i], nil
+	}
+	return nil, TokenSignatureInvalid
+}
+
+// JWKS 导出仍在使用或即将生效的公钥 已超过宽限期的密钥不再公开
+func (s *JWTKeySet) JWKS(now time.Time) JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for i := range s.Keys {
+		if i+1 < len(s.Keys) && s.Keys[i+1].ActiveFrom.Add(s.Grace).Before(now) {
+			continue
+		}
+		set.Keys = append(set.Keys, s.Keys[i].JWK())
+	}
+	return set
+}
+
+// JWK 导出公钥
+func (k *JWTKey) JWK() JWK {
+	jwk := JWK{Use: "sig", Alg: k.Method.Alg(), Kid: k.Kid}
+	switch pub := k.Private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+	return jwk
+}
+
+// loadPrivateKey 支持文件路径或直接填写PEM内容
+func loadPrivateKey(src string) (crypto.Signer, error) {
+	data := []byte(src)
+	if !strings.Contains(src, "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(src)
+		if err != nil {
+			return nil, err
+		}
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("无法解析PEM")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("不支持的私钥类型")
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("不支持的私钥格式")
+}
+
+func checkKeyMethod(method jwt.SigningMethod, signer crypto.Signer) error {
+	switch key := signer.(type) {
+	case *rsa.PrivateKey:
+		if method == jwt.SigningMethodRS256 {
+			return nil
+		}
+	case *ecdsa.PrivateKey:
+		if method == jwt.SigningMethodES256 && key.Curve == elliptic.P256() {
+			return nil
+		}
+	case ed25519.PrivateKey:
+		if method == jwt.SigningMethodEdDSA {
+			return nil
+		}
+	}
+	return fmt.Errorf("密钥类型与签名算法 %s 不匹配", method.Alg())
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+func genES256PEM(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestJWTKeySetRotation(t *testing.T) {
+	now := time.Now()
+	conf := config.JWT{
+		ExpiresTime:   "7d",
+		SigningMethod: "ES256",
+		RotationGrace: "1h",
+		Keys: []config.JWTKey{
+			{Kid: "new", PrivateKey: genES256PEM(t), ActiveFrom: now.Add(-30 * time.Minute).Format(time.RFC3339)},
+			{Kid: "old", PrivateKey: genES256PEM(t), ActiveFrom: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+			{Kid: "next", PrivateKey: genES256PEM(t), ActiveFrom: now.Add(24 * time.Hour).Format(time.RFC3339)},
+		},
+	}
+	set, err := NewJWTKeySet(conf)
+	if err != nil {
+		t.Fatalf("NewJWTKeySet() error = %v", err)
+	}
+
+	key, err := set.SigningKey(now)
+	if err != nil || key.Kid != "new" {
+		t.Fatalf("SigningKey() = %v, %v, want kid new", key, err)
+	}
+	if _, err = set.VerifyKey("old", now); err != nil {
+		t.Errorf("VerifyKey(old) within grace error = %v", err)
+	}
+	if _, err = set.VerifyKey("old", now.Add(2*time.Hour)); err == nil {
+		t.Errorf("VerifyKey(old) after grace should fail")
+	}
+	if _, err = set.VerifyKey("next", now); err == nil {
+		t.Errorf("VerifyKey(next) before active should fail")
+	}
+	if got := len(set.JWKS(now).Keys); got != 3 {
+		t.Errorf("JWKS() len = %d, want 3", got)
+	}
+	if got := len(set.JWKS(now.Add(2 * time.Hour)).Keys); got != 2 {
+		t.Errorf("JWKS() after grace len = %d, want 2", got)
+	}
+
+	j := &JWT{KeySet: set}
+	claims := request.CustomClaims{
+		BaseClaims:       request.BaseClaims{Username: "admin"},
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour))},
+	}
+	token, err := j.CreateToken(claims)
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	parsed, err := j.ParseToken(token)
+	if err != nil || parsed.Username != "admin" {
+		t.Fatalf("ParseToken() = %v, %v", parsed, err)
+	}
+
+	hs := &JWT{SigningKey: []byte("secret")}
+	hsToken, _ := hs.CreateToken(claims)
+	if _, err = j.ParseToken(hsToken); err == nil {
+		t.Errorf("ParseToken() should reject HS256 token when asymmetric keys are configured")
+	}
+}
+
+func TestNewJWTKeySetMismatch(t *testing.T) {
+	_, err := NewJWTKeySet(config.JWT{
+		ExpiresTime:   "7d",
+		SigningMethod: "RS256",
+		Keys:          []config.JWTKey{{Kid: "a", PrivateKey: genES256PEM(t)}},
+	})
+	if err == nil {
+		t.Errorf("NewJWTKeySet() should reject an EC key for RS256")
+	}
+}
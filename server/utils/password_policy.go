@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+)
+
+// ErrPasswordPolicy 密码不满足策略 具体原因见错误信息
+var ErrPasswordPolicy = errors.New("密码不符合安全策略")
+
+// CheckPasswordPolicy 校验密码复杂度 长度 字符种类 弱密码列表 以及不能与用户名相同
+func CheckPasswordPolicy(policy config.PasswordPolicy, username, password string) error {
+	if password == "" {
+		return policyError("密码不能为空")
+	}
+	if policy.MinLength > 0 && utf8.RuneCountInString(password) < policy.MinLength {
+		return policyError(fmt.Sprintf("密码长度不能少于%d位", policy.MinLength))
+	}
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	var missing []string
+	if policy.RequireUpper && !upper {
+		missing = append(missing, "大写字母")
+	}
+	if policy.RequireLower && !lower {
+		missing = append(missing, "小写字母")
+	}
+	if policy.RequireDigit && !digit {
+		missing = append(missing, "数字")
+	}
+	if policy.RequireSymbol && !symbol {
+		missing = append(missing, "特殊字符")
+	}
+	if len(missing) > 0 {
+		return policyError("密码必须包含" + strings.Join(missing, "、"))
+	}
+	if username != "" && strings.EqualFold(password, username) {
+		return policyError("密码不能与用户名相同")
+	}
+	for _, banned := range policy.BannedPasswords {
+		if strings.EqualFold(password, banned) {
+			return policyError("密码过于简单，请更换")
+		}
+	}
+	return nil
+}
+
+func policyError(msg string) error {
+	return fmt.Errorf("%w: %s", ErrPasswordPolicy, msg)
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+)
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	policy := config.PasswordPolicy{
+		MinLength:       8,
+		RequireLower:    true,
+		RequireDigit:    true,
+		RequireSymbol:   true,
+		BannedPasswords: []string{"Passw0rd!"},
+	}
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{"empty", "", true},
+		{"too short", "ab1!", true},
+		{"missing digit", "abcdefg!", true},
+		{"missing symbol", "abcdefg1", true},
+		{"mixed case", "Alice-2024", false},
+		{"banned ignores case", "passw0rd!", true},
+		{"valid", "correct-horse-1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPasswordPolicy(policy, "bob", tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPasswordPolicy(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrPasswordPolicy) {
+				t.Errorf("CheckPasswordPolicy(%q) error = %v, want ErrPasswordPolicy", tt.password, err)
+			}
+		})
+	}
+	if err := CheckPasswordPolicy(policy, "abc-1234", "ABC-1234"); err == nil {
+		t.Errorf("CheckPasswordPolicy() should reject a password equal to the username")
+	}
+	if err := CheckPasswordPolicy(config.PasswordPolicy{}, "bob", "1"); err != nil {
+		t.Errorf("CheckPasswordPolicy() with empty policy error = %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// DefaultMask 脱敏后的替换内容
+const DefaultMask = "******"
+
+// 内置规则 配置与api上的规则在此基础上追加
+var (
+	DefaultFields = []string{"password", "newPassword", "oldPassword", "token", "accessToken", "refreshToken", "secret", "secretKey", "clientSecret",
+		"accessKey", "challengeToken", "recoveryCode", "recoveryCodes", "uri"}
+	DefaultHeaders  = []string{"Authorization", "Cookie", "Set-Cookie", "X-Token", "X-Gva-Signature"}
+	DefaultPatterns = []string{`eyJ[\w-]+\.[\w-]+\.[\w-]+`, `(?:[?&]|\\u0026)(?:token|invitation)=([^&"\s\\]+)`,
+		`(?i)password=([^&"\s\\]+)`, `(?i)secret=([^&"\s\\]+)`}
+)
+
+// Rules 脱敏规则
+// Fields 为json字段 不含"."时匹配任意层级的同名字段 含"."时为从根开始的路径 "*"匹配任意字段 数组按元素逐个匹配
+// Headers 为请求头名称 Patterns 为正则 有分组时只替换第一个分组 否则替换整个匹配
+type Rules struct {
+	Fields   []string
+	Headers  []string
+	Patterns []string
+}
+
+type Redactor struct {
+	mask     string
+	keys     map[string]bool
+	paths    [][]string
+	headers  map[string]bool
+	patterns []*regexp.Regexp
+}
+
+// New 合并内置规则与传入的规则 正则无法编译时返回错误
+func New(mask string, rules ...Rules) (*Redactor, error) {
+	if mask == "" {
+		mask = DefaultMask
+	}
+	r := &Redactor{mask: mask, keys: make(map[string]bool), headers: make(map[string]bool)}
+	all := append([]Rules{{Fields: DefaultFields, Headers: DefaultHeaders, Patterns: DefaultPatterns}}, rules...)
+	for _, rule := range all {
+		for _, field := range rule.Fields {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if strings.Contains(field, ".") {
+				r.paths = append(r.paths, strings.Split(strings.ToLower(field), "."))
+			} else {
+				r.keys[strings.ToLower(field)] = true
+			}
+		}
+		for _, header := range rule.Headers {
+			if header = strings.TrimSpace(header); header != "" {
+				r.headers[http.CanonicalHeaderKey(header)] = true
+			}
+		}
+		for _, pattern := range rule.Patterns {
+			if pattern == "" {
+				continue
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, err
+			}
+			r.patterns = append(r.patterns, re)
+		}
+	}
+	return r, nil
+}
+
+// Body 处理请求或响应内容 json按字段脱敏后重新序列化 之后对全文应用正则
+func (r *Redactor) Body(s string) string {
+	if s == "" {
+		return s
+	}
+	var v interface{}
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	if d.Decode(&v) == nil && !d.More() {
+		if redacted, changed := r.value(v, nil); changed {
+			if b, err := marshal(redacted); err == nil {
+				s = string(b)
+			}
+		}
+	}
+	return r.Text(s)
+}
+
+// Text 对文本应用正则
+func (r *Redactor) Text(s string) string {
+	for _, re := range r.patterns {
+		if re.NumSubexp() == 0 {
+			s = re.ReplaceAllLiteralString(s, r.mask)
+			continue
+		}
+		s = re.ReplaceAllStringFunc(s, func(match string) string {
+			loc := re.FindStringSubmatchIndex(match)
+			if len(loc) < 4 || loc[2] < 0 {
+				return match
+			}
+			return match[:loc[2]] + r.mask + match[loc[3]:]
+		})
+	}
+	return s
+}
+
+// Key 字段名是否匹配任意层级的字段规则 忽略大小写与下划线 用于按列名脱敏
+func (r *Redactor) Key(name string) bool {
+	name = strings.ToLower(name)
+	return r.keys[name] || r.keys[strings.ReplaceAll(name, "_", "")]
+}
+
+// Mask 脱敏后的替换内容
+func (r *Redactor) Mask() string {
+	return r.mask
+}
+
+// Header 复制请求头并替换需要脱敏的值
+func (r *Redactor) Header(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, v := range h {
+		if r.headers[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{r.mask}
+			continue
+		}
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = r.Text(v[i])
+		}
+		out[k] = values
+	}
+	return out
+}
+
+func (r *Redactor) value(v interface{}, path []string) (interface{}, bool) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		changed := false
+		for k, item := range val {
+			p := append(path[:len(path):len(path)], strings.ToLower(k))
+			if r.keys[p[len(p)-1]] || r.matchPath(p) {
+				if item != nil {
+					val[k] = r.mask
+					changed = true
+				}
+				continue
+			}
+			if redacted, ok := r.value(item, p); ok {
+				val[k] = redacted
+				changed = true
+			}
+		}
+		return val, changed
+	case []interface{}:
+		changed := false
+		for i, item := range val {
+			if redacted, ok := r.value(item, path); ok {
+				val[i] = redacted
+				changed = true
+			}
+		}
+		return val, changed
+	}
+	return v, false
+}
+
+func (r *Redactor) matchPath(path []string) bool {
+	for _, p := range r.paths {
+		if len(p) != len(path) {
+			continue
+		}
+		matched := true
+		for i := range p {
+			if p[i] != "*" && p[i] != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// marshal 不转义html字符 保持与原始内容一致
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
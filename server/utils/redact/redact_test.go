@@ -0,0 +1,53 @@
+package redact
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	r, err := New("", Rules{
+		Fields:   []string{"data.*.phone", "list.idCard"},
+		Headers:  []string{"x-api-key"},
+		Patterns: []string{`card=(\d+)`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{`{"username":"admin","password":"123456"}`, `{"password":"******","username":"admin"}`},
+		{`{"code":0,"data":{"token":"abc","user":{"phone":"138","userName":"a"}}}`, `{"code":0,"data":{"token":"******","user":{"phone":"******","userName":"a"}}}`},
+		{`{"list":[{"idCard":"1","name":"a"},{"idCard":"2"}],"phone":"138"}`, `{"list":[{"idCard":"******","name":"a"},{"idCard":"******"}],"phone":"138"}`},
+		{`{"id":12345678901234567890,"html":"<b>","secret":"s"}`, `{"html":"<b>","id":12345678901234567890,"secret":"******"}`},
+		{`{"Password":null}`, `{"Password":null}`},
+		{`page=1&card=6222021234`, `page=1&card=******`},
+		{`bearer eyJhbGciOiJIUzI1NiJ9.eyJpZCI6MX0.sig-_1`, `bearer ******`},
+		{`{"data":{"url":"https://a.com/#/register?invitation=abc\u0026token=def"}}`, `{"data":{"url":"https://a.com/#/register?invitation=******\u0026token=******"}}`},
+		{`username=a&Password=p%40ss&remember=1`, `username=a&Password=******&remember=1`},
+		{`otpauth://totp/GVA:admin?secret=JBSWY3DP\u0026issuer=GVA`, `otpauth://totp/GVA:admin?secret=******\u0026issuer=GVA`},
+		{`[超出记录长度]`, `[超出记录长度]`},
+	}
+	for _, tt := range tests {
+		if got := r.Body(tt.in); got != tt.want {
+			t.Errorf("Body(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	h := r.Header(http.Header{"X-Token": {"abc"}, "X-Api-Key": {"k"}, "Accept": {"*/*"}})
+	if h.Get("X-Token") != DefaultMask || h.Get("X-Api-Key") != DefaultMask || h.Get("Accept") != "*/*" {
+		t.Errorf("Header = %v", h)
+	}
+
+	for name, want := range map[string]bool{"recovery_codes": true, "Secret_Key": true, "password": true, "phone": false, "id_card": false} {
+		if r.Key(name) != want {
+			t.Errorf("Key(%s) = %v, want %v", name, !want, want)
+		}
+	}
+
+	if _, err = New("", Rules{Patterns: []string{"("}}); err == nil {
+		t.Error("invalid pattern should return an error")
+	}
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader 请求ID的请求头与响应头
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDContextKey struct{}
+
+// SetRequestID 同时写入gin.Context与请求的context 使用请求context的数据库操作可以关联到该请求
+func SetRequestID(c *gin.Context, id string) {
+	c.Set("requestId", id)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), requestIDContextKey{}, id))
+}
+
+// GetRequestIDFromContext 从请求的context中获取请求ID 需要经过RequestID中间件
+func GetRequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
@@ -0,0 +1,76 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[uint]map[string]system.SysSession)}
+}
+
+// MemoryStore 进程内会话存储 重启后会话列表丢失 仅适用于单实例部署
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[uint]map[string]system.SysSession
+}
+
+func (ms *MemoryStore) Save(s system.SysSession) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.sessions[s.UserID] == nil {
+		ms.sessions[s.UserID] = make(map[string]system.SysSession)
+	}
+	ms.sessions[s.UserID][s.ID] = s
+	return nil
+}
+
+func (ms *MemoryStore) Get(userID uint, id string) (*system.SysSession, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	s, ok := ms.sessions[userID][id]
+	if !ok || s.ExpiresAt.Before(time.Now()) {
+		return nil, ErrSessionNotFound
+	}
+	return &s, nil
+}
+
+func (ms *MemoryStore) List(userID uint) ([]system.SysSession, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	now := time.Now()
+	list := make([]system.SysSession, 0, len(ms.sessions[userID]))
+	for id, s := range ms.sessions[userID] {
+		if s.ExpiresAt.Before(now) {
+			delete(ms.sessions[userID], id)
+			continue
+		}
+		list = append(list, s)
+	}
+	sortSessions(list)
+	return list, nil
+}
+
+func (ms *MemoryStore) Delete(userID uint, id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.sessions[userID], id)
+	if len(ms.sessions[userID]) == 0 {
+		delete(ms.sessions, userID)
+	}
+	return nil
+}
+
+func (ms *MemoryStore) Touch(userID uint, id string, at time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	s, ok := ms.sessions[userID][id]
+	if !ok {
+		return ErrSessionNotFound
+	}
+	s.LastSeenAt = at
+	ms.sessions[userID][id] = s
+	return nil
+}
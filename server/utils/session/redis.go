@@ -0,0 +1,125 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/redis/go-redis/v9"
+)
+
+// touchScript 仅在会话仍存在时更新 避免与注销并发时把已删除的会话写回
+var touchScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 1 then
+	return redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+end
+return -1
+`)
+
+// saveScript 写入会话 hash的过期时间只延长不缩短 保证其中有效期最长的会话不会被提前清除
+var saveScript = redis.NewScript(`
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+local ttl = tonumber(ARGV[3])
+if redis.call('PTTL', KEYS[1]) < ttl then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
+
+func NewRedisStore() *RedisStore {
+	return &RedisStore{
+		PreKey:  "GVA_SESSION_",
+		Context: context.Background(),
+	}
+}
+
+// RedisStore 每个用户一个hash field为会话ID value为会话json
+type RedisStore struct {
+	PreKey  string
+	Context context.Context
+}
+
+func (rs *RedisStore) key(userID uint) string {
+	return rs.PreKey + strconv.FormatUint(uint64(userID), 10)
+}
+
+func (rs *RedisStore) Save(s system.SysSession) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(s.ExpiresAt).Milliseconds()
+	if ttl <= 0 {
+		return rs.Delete(s.UserID, s.ID)
+	}
+	return saveScript.Run(rs.Context, global.GVA_REDIS, []string{rs.key(s.UserID)}, s.ID, data, ttl).Err()
+}
+
+func (rs *RedisStore) Get(userID uint, id string) (*system.SysSession, error) {
+	data, err := global.GVA_REDIS.HGet(rs.Context, rs.key(userID), id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s system.SysSession
+	if err = json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.ExpiresAt.Before(time.Now()) {
+		return nil, ErrSessionNotFound
+	}
+	return &s, nil
+}
+
+func (rs *RedisStore) List(userID uint) ([]system.SysSession, error) {
+	all, err := global.GVA_REDIS.HGetAll(rs.Context, rs.key(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	list := make([]system.SysSession, 0, len(all))
+	var expired []string
+	for id, data := range all {
+		var s system.SysSession
+		if err = json.Unmarshal([]byte(data), &s); err != nil || s.ExpiresAt.Before(now) {
+			expired = append(expired, id)
+			continue
+		}
+		list = append(list, s)
+	}
+	if len(expired) > 0 {
+		global.GVA_REDIS.HDel(rs.Context, rs.key(userID), expired...)
+	}
+	sortSessions(list)
+	return list, nil
+}
+
+func (rs *RedisStore) Delete(userID uint, id string) error {
+	return global.GVA_REDIS.HDel(rs.Context, rs.key(userID), id).Err()
+}
+
+func (rs *RedisStore) Touch(userID uint, id string, at time.Time) error {
+	s, err := rs.Get(userID, id)
+	if err != nil {
+		return err
+	}
+	s.LastSeenAt = at
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	res, err := touchScript.Run(rs.Context, global.GVA_REDIS, []string{rs.key(userID)}, id, data).Int()
+	if err != nil {
+		return err
+	}
+	if res < 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+var ErrSessionNotFound = errors.New("会话不存在或已失效")
+
+// Store 会话存储 开启redis时多实例共享 否则退化为进程内存
+type Store interface {
+	Save(s system.SysSession) error
+	Get(userID uint, id string) (*system.SysSession, error)
+	List(userID uint) ([]system.SysSession, error)
+	Delete(userID uint, id string) error
+	Touch(userID uint, id string, at time.Time) error
+}
+
+var (
+	store Store
+	once  sync.Once
+)
+
+// GetStore 获取会话存储 首次调用时根据是否启用redis决定实现
+func GetStore() Store {
+	once.Do(func() {
+		if global.GVA_CONFIG.System.UseRedis && global.GVA_REDIS != nil {
+			store = NewRedisStore()
+		} else {
+			store = NewMemoryStore()
+		}
+	})
+	return store
+}
+
+// sortSessions 按登录时间倒序
+func sortSessions(list []system.SysSession) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LoginAt.After(list[j].LoginAt)
+	})
+}
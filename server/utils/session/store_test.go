@@ -0,0 +1,80 @@
+package session
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/redis/go-redis/v9"
+)
+
+func testStore(t *testing.T, st Store) {
+	now := time.Now()
+	long := system.SysSession{ID: "long", UserID: 1, LoginAt: now, LastSeenAt: now, ExpiresAt: now.Add(time.Hour)}
+	short := system.SysSession{ID: "short", UserID: 1, LoginAt: now.Add(time.Second), LastSeenAt: now, ExpiresAt: now.Add(time.Minute)}
+	for _, s := range []system.SysSession{long, short} {
+		if err := st.Save(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := st.Get(1, "long"); err != nil {
+		t.Errorf("get long: %v", err)
+	}
+	list, err := st.List(1)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("list: %d sessions, err %v", len(list), err)
+	}
+
+	if err = st.Touch(1, "short", now.Add(time.Second)); err != nil {
+		t.Errorf("touch: %v", err)
+	}
+	if err = st.Delete(1, "short"); err != nil {
+		t.Fatal(err)
+	}
+	if err = st.Touch(1, "short", now); err != ErrSessionNotFound {
+		t.Errorf("touch deleted session: want ErrSessionNotFound, got %v", err)
+	}
+
+	expired := system.SysSession{ID: "expired", UserID: 1, ExpiresAt: now.Add(-time.Second)}
+	if err = st.Save(expired); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = st.Get(1, "expired"); err != ErrSessionNotFound {
+		t.Errorf("get expired session: want ErrSessionNotFound, got %v", err)
+	}
+	if list, _ = st.List(1); len(list) != 1 || list[0].ID != "long" {
+		t.Errorf("list after delete: %+v", list)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+// TestRedisStore 需要可用的redis 通过GVA_TEST_REDIS指定地址 未设置时跳过
+func TestRedisStore(t *testing.T) {
+	addr := os.Getenv("GVA_TEST_REDIS")
+	if addr == "" {
+		t.Skip("GVA_TEST_REDIS not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Skip(err)
+	}
+	global.GVA_REDIS = client
+	rs := NewRedisStore()
+	rs.PreKey = "GVA_SESSION_TEST_"
+	defer client.Del(rs.Context, rs.key(1))
+	client.Del(rs.Context, rs.key(1))
+
+	testStore(t, rs)
+
+	// 后保存的短会话不应缩短hash的过期时间
+	ttl := client.TTL(rs.Context, rs.key(1)).Val()
+	if ttl < 50*time.Minute {
+		t.Errorf("hash ttl %s shorter than the longest session", ttl)
+	}
+}
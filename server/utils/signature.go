@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// AK/SK 签名鉴权使用的请求头
+const (
+	SignAccessKeyHeader = "X-Gva-Access-Key"
+	SignTimestampHeader = "X-Gva-Timestamp" // unix秒
+	SignNonceHeader     = "X-Gva-Nonce"
+	SignatureHeader     = "X-Gva-Signature"
+)
+
+// SignString 待签名字符串 按行拼接 请求方法 请求路径(含查询参数) 时间戳 nonce 请求体sha256
+func SignString(method, uri, timestamp, nonce string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strings.Join([]string{strings.ToUpper(method), uri, timestamp, nonce, hex.EncodeToString(sum[:])}, "\n")
+}
+
+// Sign 使用SecretKey对请求做HMAC-SHA256签名 返回十六进制字符串 客户端与服务端使用相同算法
+func Sign(secret, method, uri, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(SignString(method, uri, timestamp, nonce, body)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySign 常量时间比较签名
+func VerifySign(secret, method, uri, timestamp, nonce string, body []byte, signature string) bool {
+	expected := Sign(secret, method, uri, timestamp, nonce, body)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
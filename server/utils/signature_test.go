@@ -0,0 +1,21 @@
+package utils
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"page":1}`)
+	want := "7f6318fca611f614e82a753330e694d6b4ae319e2eb05a22c7a2462ae006b578"
+	got := Sign("secret", "post", "/api/user/getUserList?x=1", "1700000000", "n0nce", body)
+	if got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+	if !VerifySign("secret", "POST", "/api/user/getUserList?x=1", "1700000000", "n0nce", body, want) {
+		t.Errorf("VerifySign() = false, want true")
+	}
+	if VerifySign("secret", "POST", "/api/user/getUserList?x=2", "1700000000", "n0nce", body, want) {
+		t.Errorf("VerifySign() should reject a tampered query")
+	}
+	if VerifySign("secret", "POST", "/api/user/getUserList?x=1", "1700000000", "n0nce", []byte(`{"page":2}`), want) {
+		t.Errorf("VerifySign() should reject a tampered body")
+	}
+}
@@ -0,0 +1,123 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrUpsert upsert冲突时会更新其他租户的同主键数据 Save更新不存在的记录时同样会走到这里
+var ErrUpsert = errors.New("按租户隔离的数据不支持upsert 记录不存在或不属于当前租户")
+
+// Shared 租户可以读取平台租户数据的模型 如字典与参数 租户只能修改自己的数据
+type Shared interface {
+	SharedWithPlatform() bool
+}
+
+// Plugin GORM租户插件 语句的context中带有租户时 查询 更新与删除追加租户条件 创建时写入租户
+// 只处理包含tenant_id列的模型 未通过WithContext传入请求context的语句不受影响
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "gva:tenant"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("gva:tenant_create", assignTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("gva:tenant_query", queryTenant); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("gva:tenant_row", queryTenant); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("gva:tenant_update", updateTenant); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("gva:tenant_delete", filterTenant)
+}
+
+// assignTenant 创建时写入当前租户 忽略提交数据中的租户
+func assignTenant(db *gorm.DB) {
+	tenantID, field := tenantField(db)
+	if field == nil {
+		return
+	}
+	if _, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		db.AddError(ErrUpsert)
+		return
+	}
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				db.AddError(field.Set(ctx, elem, tenantID))
+			}
+		}
+	case reflect.Struct:
+		db.AddError(field.Set(ctx, rv, tenantID))
+	}
+}
+
+// queryTenant 查询时追加租户条件 共享平台数据的模型同时可以读取平台租户的数据
+func queryTenant(db *gorm.DB) {
+	tenantID, field := tenantField(db)
+	if field == nil {
+		return
+	}
+	if shared, ok := reflect.New(db.Statement.Schema.ModelType).Interface().(Shared); !ok || !shared.SharedWithPlatform() || tenantID == Platform {
+		filterTenant(db)
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Values: []interface{}{Platform, tenantID}},
+	}})
+}
+
+// filterTenant 删除时追加租户条件
+func filterTenant(db *gorm.DB) {
+	tenantID, field := tenantField(db)
+	if field == nil {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+// updateTenant 更新时追加租户条件 且不允许修改租户
+func updateTenant(db *gorm.DB) {
+	filterTenant(db)
+	if _, field := tenantField(db); field != nil {
+		db.Statement.Omits = append(db.Statement.Omits, field.DBName)
+	}
+}
+
+func tenantField(db *gorm.DB) (uint, *schema.Field) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return 0, nil
+	}
+	tenantID, ok := FromContext(db.Statement.Context)
+	if !ok {
+		return 0, nil
+	}
+	return tenantID, db.Statement.Schema.LookUpField(Column)
+}
+
+// Own 只查询当前租户自己的数据 共享平台数据的模型在租户内校验唯一性或修改前查询时使用
+func Own(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenantID, ok := FromContext(ctx)
+		if !ok {
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: tenantID})
+	}
+}
@@ -0,0 +1,91 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type isolatedRecord struct {
+	ID       uint
+	Name     string
+	TenantID uint
+}
+
+type sharedRecord struct {
+	ID       uint
+	Key      string
+	TenantID uint
+}
+
+func (sharedRecord) SharedWithPlatform() bool {
+	return true
+}
+
+func TestPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Plugins: map[string]gorm.Plugin{Plugin{}.Name(): Plugin{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&isolatedRecord{}, &sharedRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	platform, t1, t2 := WithTenant(context.Background(), Platform), WithTenant(context.Background(), 1), WithTenant(context.Background(), 2)
+
+	// 创建时忽略提交的租户
+	db.WithContext(t1).Create(&[]isolatedRecord{{Name: "a", TenantID: 2}, {Name: "b"}})
+	db.WithContext(t2).Create(&isolatedRecord{Name: "c"})
+	db.WithContext(platform).Create(&sharedRecord{Key: "k"})
+	db.WithContext(t1).Create(&sharedRecord{Key: "k"})
+
+	count := func(ctx context.Context, model interface{}) (total int64) {
+		db.WithContext(ctx).Model(model).Count(&total)
+		return total
+	}
+	if got := count(t1, &isolatedRecord{}); got != 2 {
+		t.Errorf("tenant 1 sees %d records, want 2", got)
+	}
+	if got := count(context.Background(), &isolatedRecord{}); got != 3 {
+		t.Errorf("without tenant sees %d records, want 3", got)
+	}
+	if got := count(t2, &sharedRecord{}); got != 1 {
+		t.Errorf("tenant 2 sees %d shared records, want the platform one", got)
+	}
+	if got := count(platform, &sharedRecord{}); got != 1 {
+		t.Errorf("platform sees %d shared records, want 1", got)
+	}
+
+	var own sharedRecord
+	if err = db.WithContext(t1).Order("tenant_id desc").First(&own, "key = ?", "k").Error; err != nil || own.TenantID != 1 {
+		t.Errorf("tenant 1 should prefer its own shared record, got %+v %v", own, err)
+	}
+	var n int64
+	db.WithContext(t2).Scopes(Own(t2)).Model(&sharedRecord{}).Count(&n)
+	if n != 0 {
+		t.Errorf("tenant 2 owns %d shared records, want 0", n)
+	}
+
+	// 不能修改或删除其他租户的数据 也不能修改租户
+	var c isolatedRecord
+	db.First(&c, "name = ?", "c")
+	if res := db.WithContext(t1).Model(&c).Updates(map[string]interface{}{"name": "x", "tenant_id": 1}); res.RowsAffected != 0 {
+		t.Error("tenant 1 updated a record of tenant 2")
+	}
+	db.WithContext(t2).Model(&c).Updates(map[string]interface{}{"name": "x", "tenant_id": 1})
+	db.First(&c, c.ID)
+	if c.Name != "x" || c.TenantID != 2 {
+		t.Errorf("update within tenant = %+v, want name changed and tenant kept", c)
+	}
+	if res := db.WithContext(t1).Delete(&isolatedRecord{}, c.ID); res.RowsAffected != 0 {
+		t.Error("tenant 1 deleted a record of tenant 2")
+	}
+
+	err = db.WithContext(t1).Clauses(clause.OnConflict{UpdateAll: true}).Create(&isolatedRecord{ID: c.ID, Name: "y"}).Error
+	if !errors.Is(err, ErrUpsert) {
+		t.Errorf("upsert error = %v, want ErrUpsert", err)
+	}
+}
@@ -0,0 +1,33 @@
+package tenant
+
+import (
+	"context"
+	"strconv"
+)
+
+// Platform 平台租户 开启多租户之前的数据与未分配租户的数据都属于平台租户
+const Platform uint = 0
+
+// Column 按租户隔离的模型使用的列 模型包含该列时由Plugin自动过滤与填充
+const Column = "tenant_id"
+
+type contextKey struct{}
+
+// WithTenant 在context中写入当前租户 携带该context的查询只能访问该租户的数据
+func WithTenant(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext 获取context中的当前租户 未解析租户时返回false 此时不做租户过滤
+func FromContext(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	tenantID, ok := ctx.Value(contextKey{}).(uint)
+	return tenantID, ok
+}
+
+// Domain 租户在casbin中的域
+func Domain(tenantID uint) string {
+	return strconv.FormatUint(uint64(tenantID), 10)
+}
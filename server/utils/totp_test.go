@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录B的SHA1测试向量 取低6位
+func TestTotpCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := TotpCode(secret, TotpStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TotpCode() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("TotpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTotp(t *testing.T) {
+	secret, err := GenerateTotpSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	prev, _ := TotpCode(secret, TotpStep(now)-1)
+	if step, ok := ValidateTotp(secret, prev, now); !ok || step != TotpStep(now)-1 {
+		t.Errorf("ValidateTotp() should accept previous step, got %d %v", step, ok)
+	}
+	old, _ := TotpCode(secret, TotpStep(now)-3)
+	if _, ok := ValidateTotp(secret, old, now); ok {
+		t.Errorf("ValidateTotp() should reject code outside skew window")
+	}
+	if _, ok := ValidateTotp(secret, "12345", now); ok {
+		t.Errorf("ValidateTotp() should reject short code")
+	}
+}
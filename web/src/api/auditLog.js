@@ -0,0 +1,16 @@
+import service from '@/utils/request'
+
+// @Tags AuditLog
+// @Summary 分批校验操作记录与登录日志的审计哈希链 完整校验使用命令行 audit verify
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query {fromSeq:number,limit:number} true "起始位置, 本批记录数"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"校验完成"}"
+// @Router /auditLog/verifyAuditLog [get]
+export const verifyAuditLog = (params) => {
+  return service({
+    url: '/auditLog/verifyAuditLog',
+    method: 'get',
+    params
+  })
+}
@@ -0,0 +1,24 @@
+import service from '@/utils/request'
+
+export const getFieldModels = () => {
+  return service({
+    url: '/authorityField/getFieldModels',
+    method: 'get'
+  })
+}
+
+export const getAuthorityField = (data) => {
+  return service({
+    url: '/authorityField/getAuthorityField',
+    method: 'post',
+    data
+  })
+}
+
+export const setAuthorityField = (data) => {
+  return service({
+    url: '/authorityField/setAuthorityField',
+    method: 'post',
+    data
+  })
+}
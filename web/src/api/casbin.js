@@ -30,3 +30,48 @@ export const getPolicyPathByAuthorityId = (data) => {
     data
   })
 }
+
+// @Tags casbin
+// @Summary 权限诊断
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {userId:"number",authorityId:"number",method:"string",path:"string"}
+// @Router /casbin/explainPermission [post]
+export const explainPermission = (data) => {
+  return service({
+    url: '/casbin/explainPermission',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 获取权限矩阵
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {userId:"number",authorityId:"number"}
+// @Router /casbin/getPermissionMatrix [post]
+export const getPermissionMatrix = (data) => {
+  return service({
+    url: '/casbin/getPermissionMatrix',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 导出权限矩阵Excel
+// @Security ApiKeyAuth
+// @accept application/json
+// @Param data body {userId:"number",authorityId:"number"}
+// @Router /casbin/exportPermissionMatrix [post]
+export const exportPermissionMatrix = (data) => {
+  return service({
+    url: '/casbin/exportPermissionMatrix',
+    method: 'post',
+    data,
+    responseType: 'blob'
+  })
+}
@@ -0,0 +1,17 @@
+import service from '@/utils/request'
+
+// @Tags ChangeHistory
+// @Summary 分页获取变更历史 传入表名与记录主键时为该记录的时间线
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.SysChangeHistorySearch true "表名, 记录主键, 请求ID, 操作人, 页码, 每页大小"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /changeHistory/getChangeHistoryList [get]
+export const getChangeHistoryList = (params) => {
+  return service({
+    url: '/changeHistory/getChangeHistoryList',
+    method: 'get',
+    params
+  })
+}
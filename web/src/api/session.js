@@ -0,0 +1,57 @@
+import service from '@/utils/request'
+// @Tags Session
+// @Summary 获取当前用户的在线会话
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Router /session/getSelfSessions [get]
+export const getSelfSessions = () => {
+  return service({
+    url: '/session/getSelfSessions',
+    method: 'get'
+  })
+}
+
+// @Tags Session
+// @Summary 注销当前用户的指定会话
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {id:string} true "会话ID"
+// @Router /session/revokeSelfSession [post]
+export const revokeSelfSession = (data) => {
+  return service({
+    url: '/session/revokeSelfSession',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Session
+// @Summary 获取指定用户的在线会话
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {userId:number} true "用户ID"
+// @Router /session/getUserSessions [post]
+export const getUserSessions = (data) => {
+  return service({
+    url: '/session/getUserSessions',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Session
+// @Summary 强制下线 不传会话ID时下线该用户全部会话
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {userId:number,id:string} true "用户ID 会话ID"
+// @Router /session/forceLogout [post]
+export const forceLogout = (data) => {
+  return service({
+    url: '/session/forceLogout',
+    method: 'post',
+    data
+  })
+}
@@ -0,0 +1,25 @@
+import service from '@/utils/request'
+
+export const createAccessKey = (data) => {
+  return service({
+    url: '/accessKey/createAccessKey',
+    method: 'post',
+    data
+  })
+}
+
+export const getAccessKeyList = (data) => {
+  return service({
+    url: '/accessKey/getAccessKeyList',
+    method: 'post',
+    data
+  })
+}
+
+export const deleteAccessKey = (data) => {
+  return service({
+    url: '/accessKey/deleteAccessKey',
+    method: 'post',
+    data
+  })
+}
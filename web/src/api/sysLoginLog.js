@@ -1,21 +1,5 @@
 import service from '@/utils/request'
 
-export const deleteLoginLog = (data) => {
-  return service({
-    url: '/sysLoginLog/deleteLoginLog',
-    method: 'delete',
-    data
-  })
-}
-
-export const deleteLoginLogByIds = (data) => {
-  return service({
-    url: '/sysLoginLog/deleteLoginLogByIds',
-    method: 'delete',
-    data
-  })
-}
-
 export const getLoginLogList = (params) => {
   return service({
     url: '/sysLoginLog/getLoginLogList',
@@ -0,0 +1,61 @@
+import service from '@/utils/request'
+
+// @Tags SysTenant
+// @Summary 创建租户
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {name:"string",code:"string",enable:"boolean",remark:"string"}
+// @Router /tenant/createTenant [post]
+export const createTenant = (data) => {
+  return service({
+    url: '/tenant/createTenant',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags SysTenant
+// @Summary 更新租户
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {ID:"number",name:"string",code:"string",enable:"boolean",remark:"string"}
+// @Router /tenant/updateTenant [put]
+export const updateTenant = (data) => {
+  return service({
+    url: '/tenant/updateTenant',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags SysTenant
+// @Summary 删除租户
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {ID:"number"}
+// @Router /tenant/deleteTenant [delete]
+export const deleteTenant = (data) => {
+  return service({
+    url: '/tenant/deleteTenant',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags SysTenant
+// @Summary 分页获取租户列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {page:"number",pageSize:"number",keyword:"string"}
+// @Router /tenant/getTenantList [post]
+export const getTenantList = (data) => {
+  return service({
+    url: '/tenant/getTenantList',
+    method: 'post',
+    data
+  })
+}
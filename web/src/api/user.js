@@ -11,6 +11,30 @@ export const login = (data) => {
   })
 }
 
+// @Summary 登录二次验证
+// @Produce  application/json
+// @Param data body {challengeToken:"string",code:"string",recoveryCode:"string"}
+// @Router /base/verifyTwoFactor [post]
+export const verifyTwoFactor = (data) => {
+  return service({
+    url: '/base/verifyTwoFactor',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Summary 登录时绑定两步验证
+// @Produce  application/json
+// @Param data body {challengeToken:"string"}
+// @Router /base/enrollTwoFactor [post]
+export const enrollTwoFactor = (data) => {
+  return service({
+    url: '/base/enrollTwoFactor',
+    method: 'post',
+    data: data
+  })
+}
+
 // @Summary 获取验证码
 // @Produce  application/json
 // @Param data body {username:"string",password:"string"}
@@ -179,3 +203,365 @@ export const resetPassword = (data) => {
     data: data
   })
 }
+
+// @Tags SysUser
+// @Summary 获取两步验证绑定信息
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Router /user/setupTwoFactor [post]
+export const setupTwoFactor = () => {
+  return service({
+    url: '/user/setupTwoFactor',
+    method: 'post'
+  })
+}
+
+// @Tags SysUser
+// @Summary 确认绑定两步验证
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {code:"string"}
+// @Router /user/enableTwoFactor [post]
+export const enableTwoFactor = (data) => {
+  return service({
+    url: '/user/enableTwoFactor',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 关闭两步验证
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {code:"string",recoveryCode:"string"}
+// @Router /user/disableTwoFactor [post]
+export const disableTwoFactor = (data) => {
+  return service({
+    url: '/user/disableTwoFactor',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 重置用户两步验证
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:number}
+// @Router /user/resetTwoFactor [post]
+export const resetTwoFactor = (data) => {
+  return service({
+    url: '/user/resetTwoFactor',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 解除用户登录锁定
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {username:"string"}
+// @Router /user/unlockUser [post]
+export const unlockUser = (data) => {
+  return service({
+    url: '/user/unlockUser',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags Base
+// @Summary 获取已启用的外部身份提供方
+// @Produce  application/json
+// @Router /base/identityProviders [get]
+export const getIdentityProviders = () => {
+  return service({
+    url: '/base/identityProviders',
+    method: 'get'
+  })
+}
+
+// @Tags Base
+// @Summary 发起单点登录
+// @Produce  application/json
+// @Param data body {provider:"string"}
+// @Router /base/ssoAuthorize [post]
+export const ssoAuthorize = (data) => {
+  return service({
+    url: '/base/ssoAuthorize',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags Base
+// @Summary 单点登录回调
+// @Produce  application/json
+// @Param data body {code:"string",state:"string"}
+// @Router /base/ssoCallback [post]
+export const ssoCallback = (data) => {
+  return service({
+    url: '/base/ssoCallback',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 绑定外部身份
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {provider:"string",username:"string",password:"string"}
+// @Router /user/linkIdentity [post]
+export const linkIdentity = (data) => {
+  return service({
+    url: '/user/linkIdentity',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 获取绑定的外部身份
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Router /user/getIdentities [get]
+export const getIdentities = () => {
+  return service({
+    url: '/user/getIdentities',
+    method: 'get'
+  })
+}
+
+// @Tags SysUser
+// @Summary 解除外部身份绑定
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:number}
+// @Router /user/unlinkIdentity [post]
+export const unlinkIdentity = (data) => {
+  return service({
+    url: '/user/unlinkIdentity',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 模拟登录
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:number}
+// @Router /user/impersonate [post]
+export const impersonate = (data) => {
+  return service({
+    url: '/user/impersonate',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 退出模拟登录
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Router /user/stopImpersonate [post]
+export const stopImpersonate = () => {
+  return service({
+    url: '/user/stopImpersonate',
+    method: 'post'
+  })
+}
+
+// @Tags Base
+// @Summary 找回密码 向邮箱发送重置密码链接
+// @Produce  application/json
+// @Param data body {email:"string",captcha:"string",captchaId:"string"}
+// @Router /base/forgotPassword [post]
+export const forgotPassword = (data) => {
+  return service({
+    url: '/base/forgotPassword',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags Base
+// @Summary 通过邮件中的链接重置密码
+// @Produce  application/json
+// @Param data body {token:"string",password:"string"}
+// @Router /base/resetPasswordByToken [post]
+export const resetPasswordByToken = (data) => {
+  return service({
+    url: '/base/resetPasswordByToken',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags Base
+// @Summary 获取注册页信息
+// @Produce  application/json
+// @Param invitation query string false "邀请令牌"
+// @Router /base/registrationOptions [get]
+export const getRegistrationOptions = (params) => {
+  return service({
+    url: '/base/registrationOptions',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Base
+// @Summary 自助注册或邀请注册
+// @Produce  application/json
+// @Param data body {userName:"string",nickName:"string",passWord:"string",email:"string",invitation:"string",captcha:"string",captchaId:"string"}
+// @Router /base/selfRegister [post]
+export const selfRegister = (data) => {
+  return service({
+    url: '/base/selfRegister',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags Base
+// @Summary 验证注册邮箱
+// @Produce  application/json
+// @Param data body {token:"string"}
+// @Router /base/verifyRegistration [post]
+export const verifyRegistration = (data) => {
+  return service({
+    url: '/base/verifyRegistration',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 生成注册邀请链接
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {authorityId:"number",emailDomain:"string",days:"number",remark:"string"}
+// @Router /user/createInvitation [post]
+export const createInvitation = (data) => {
+  return service({
+    url: '/user/createInvitation',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 分页获取注册邀请
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {page:"number",pageSize:"number"}
+// @Router /user/getInvitationList [post]
+export const getInvitationList = (data) => {
+  return service({
+    url: '/user/getInvitationList',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 作废注册邀请
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:"number"}
+// @Router /user/revokeInvitation [post]
+export const revokeInvitation = (data) => {
+  return service({
+    url: '/user/revokeInvitation',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 发起临时角色授权
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {userId:"number",authorityId:"number",startAt:"string",endAt:"string",reason:"string"}
+// @Router /user/createAuthorityGrant [post]
+export const createAuthorityGrant = (data) => {
+  return service({
+    url: '/user/createAuthorityGrant',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 审批通过临时角色授权
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:"number",detail:"string"}
+// @Router /user/approveAuthorityGrant [post]
+export const approveAuthorityGrant = (data) => {
+  return service({
+    url: '/user/approveAuthorityGrant',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 拒绝临时角色授权
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:"number",detail:"string"}
+// @Router /user/rejectAuthorityGrant [post]
+export const rejectAuthorityGrant = (data) => {
+  return service({
+    url: '/user/rejectAuthorityGrant',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 撤销临时角色授权
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {id:"number",detail:"string"}
+// @Router /user/revokeAuthorityGrant [post]
+export const revokeAuthorityGrant = (data) => {
+  return service({
+    url: '/user/revokeAuthorityGrant',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 分页获取临时角色授权
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {page:"number",pageSize:"number",userId:"number",status:"string"}
+// @Router /user/getAuthorityGrantList [post]
+export const getAuthorityGrantList = (data) => {
+  return service({
+    url: '/user/getAuthorityGrantList',
+    method: 'post',
+    data: data
+  })
+}
+
+// @Tags SysUser
+// @Summary 分页获取角色授权审计记录
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body {page:"number",pageSize:"number",userId:"number",authorityId:"number",action:"string"}
+// @Router /user/getAuthorityAuditList [post]
+export const getAuthorityAuditList = (data) => {
+  return service({
+    url: '/user/getAuthorityAuditList',
+    method: 'post',
+    data: data
+  })
+}
@@ -13,7 +13,14 @@ Nprogress.configure({
 })
 
 // 白名单路由
-const WHITE_LIST = ['Login', 'Init']
+const WHITE_LIST = [
+  'Login',
+  'Init',
+  'ForgotPassword',
+  'ResetPassword',
+  'Register',
+  'VerifyRegistration'
+]
 
 function isExternalUrl(val) {
   return typeof val === 'string' && /^(https?:)?\/\//.test(val)
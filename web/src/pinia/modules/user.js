@@ -1,4 +1,4 @@
-import { login, getUserInfo } from '@/api/user'
+import { login, getUserInfo, verifyTwoFactor, ssoCallback, impersonate, stopImpersonate } from '@/api/user'
 import { jsonInBlacklist } from '@/api/jwt'
 import router from '@/router/index'
 import { ElLoading, ElMessage } from 'element-plus'
@@ -23,6 +23,13 @@ export const useUserStore = defineStore('user', () => {
   const token = useStorage('token', '')
   const xToken = useCookies('x-token')
   const currentToken = computed(() => token.value || xToken.value || '')
+  const refreshToken = useStorage('refreshToken', '')
+  // 模拟登录时的实际操作人 由getUserInfo返回
+  const impersonator = ref(null)
+  // 模拟登录前自己的令牌 退出模拟登录时恢复
+  const impersonatorTokens = useStorage('impersonatorTokens', null, undefined, {
+    serializer: { read: (v) => (v ? JSON.parse(v) : null), write: (v) => JSON.stringify(v) }
+  })
 
   const setUserInfo = (val) => {
     userInfo.value = val
@@ -40,6 +47,10 @@ export const useUserStore = defineStore('user', () => {
     xToken.value = val
   }
 
+  const setRefreshToken = (val) => {
+    refreshToken.value = val
+  }
+
   const NeedInit = async () => {
     await ClearStorage()
     await router.push({ name: 'Init', replace: true })
@@ -56,10 +67,45 @@ export const useUserStore = defineStore('user', () => {
     const res = await getUserInfo()
     if (res.code === 0) {
       setUserInfo(res.data.userInfo)
+      impersonator.value = res.data.impersonator || null
     }
     return res
   }
-  /* 登录*/
+  /* 模拟登录 保存自己的令牌后切换为目标用户 刷新页面按目标用户重新加载菜单*/
+  const Impersonate = async (userId) => {
+    const res = await impersonate({ id: userId })
+    if (res.code !== 0) {
+      return false
+    }
+    impersonatorTokens.value = { token: currentToken.value, refreshToken: refreshToken.value }
+    setToken(res.data.token)
+    // 模拟登录token过期后不使用自己的refresh token续期
+    setRefreshToken('')
+    sessionStorage.clear()
+    // 回到根路径 由路由守卫跳转到目标用户的默认首页
+    window.location.hash = '#/'
+    window.location.reload()
+    return true
+  }
+  /* 退出模拟登录 恢复自己的令牌*/
+  const StopImpersonate = async () => {
+    // 模拟登录token可能已过期 作废失败时同样恢复
+    await stopImpersonate()
+    const saved = impersonatorTokens.value
+    impersonatorTokens.value = null
+    if (!saved) {
+      await ClearStorage()
+      router.push({ name: 'Login', replace: true })
+      window.location.reload()
+      return
+    }
+    setToken(saved.token)
+    setRefreshToken(saved.refreshToken)
+    sessionStorage.clear()
+    window.location.hash = '#/'
+    window.location.reload()
+  }
+  /* 登录 需要两步验证时返回challenge信息 由登录页继续验证*/
   const LoginIn = async (loginInfo) => {
     try {
       loadingInstance.value = ElLoading.service({
@@ -72,43 +118,91 @@ export const useUserStore = defineStore('user', () => {
       if (res.code !== 0) {
         return false
       }
-      // 登陆成功，设置用户信息和权限相关信息
-      setUserInfo(res.data.user)
-      setToken(res.data.token)
-
-      // 初始化路由信息
-      const routerStore = useRouterStore()
-      await routerStore.SetAsyncRouter()
-      const asyncRouters = routerStore.asyncRouters
-
-      // 注册到路由表里
-      asyncRouters.forEach((asyncRouter) => {
-        router.addRoute(asyncRouter)
+      if (res.data.needTwoFactor) {
+        return res.data
+      }
+      return await afterLogin(res.data)
+    } catch (error) {
+      console.error('LoginIn error:', error)
+      return false
+    } finally {
+      loadingInstance.value?.close()
+    }
+  }
+  /* 两步验证*/
+  const VerifyTwoFactor = async (data) => {
+    try {
+      loadingInstance.value = ElLoading.service({
+        fullscreen: true,
+        text: '登录中，请稍候...'
       })
-
-      if(router.currentRoute.value.query.redirect) {
-        await router.replace(router.currentRoute.value.query.redirect)
-        return true
+      const res = await verifyTwoFactor(data)
+      if (res.code !== 0) {
+        return false
       }
-
-      if (!router.hasRoute(userInfo.value.authority.defaultRouter)) {
-        ElMessage.error('不存在可以登陆的首页，请联系管理员进行配置')
-      } else {
-        await router.replace({ name: userInfo.value.authority.defaultRouter })
+      return await afterLogin(res.data)
+    } catch (error) {
+      console.error('VerifyTwoFactor error:', error)
+      return false
+    } finally {
+      loadingInstance.value?.close()
+    }
+  }
+  /* 单点登录回调 绑定流程返回linked 需要两步验证时返回challenge信息*/
+  const SSOCallback = async (data) => {
+    try {
+      loadingInstance.value = ElLoading.service({
+        fullscreen: true,
+        text: '登录中，请稍候...'
+      })
+      const res = await ssoCallback(data)
+      if (res.code !== 0) {
+        return false
       }
-
-      const isWindows = /windows/i.test(navigator.userAgent)
-      window.localStorage.setItem('osType', isWindows ? 'WIN' : 'MAC')
-
-      // 全部操作均结束，关闭loading并返回
-      return true
+      if (res.data.linked || res.data.needTwoFactor) {
+        return res.data
+      }
+      return await afterLogin(res.data)
     } catch (error) {
-      console.error('LoginIn error:', error)
+      console.error('SSOCallback error:', error)
       return false
     } finally {
       loadingInstance.value?.close()
     }
   }
+  const afterLogin = async (data) => {
+    // 登陆成功，设置用户信息和权限相关信息
+    setUserInfo(data.user)
+    setToken(data.token)
+    setRefreshToken(data.refreshToken)
+
+    // 初始化路由信息
+    const routerStore = useRouterStore()
+    await routerStore.SetAsyncRouter()
+    const asyncRouters = routerStore.asyncRouters
+
+    // 注册到路由表里
+    asyncRouters.forEach((asyncRouter) => {
+      router.addRoute(asyncRouter)
+    })
+
+    if(router.currentRoute.value.query.redirect) {
+      await router.replace(router.currentRoute.value.query.redirect)
+      return true
+    }
+
+    if (!router.hasRoute(userInfo.value.authority.defaultRouter)) {
+      ElMessage.error('不存在可以登陆的首页，请联系管理员进行配置')
+    } else {
+      await router.replace({ name: userInfo.value.authority.defaultRouter })
+    }
+
+    const isWindows = /windows/i.test(navigator.userAgent)
+    window.localStorage.setItem('osType', isWindows ? 'WIN' : 'MAC')
+
+    // 全部操作均结束，返回
+    return true
+  }
   /* 登出*/
   const LoginOut = async () => {
     const res = await jsonInBlacklist()
@@ -127,23 +221,33 @@ export const useUserStore = defineStore('user', () => {
   /* 清理数据 */
   const ClearStorage = async () => {
     token.value = ''
+    refreshToken.value = ''
     // 使用remove方法正确删除cookie
     xToken.remove()
     sessionStorage.clear()
     // 清理所有相关的localStorage项
     localStorage.removeItem('originSetting')
     localStorage.removeItem('token')
+    localStorage.removeItem('refreshToken')
+    localStorage.removeItem('impersonatorTokens')
   }
 
   return {
     userInfo,
     token: currentToken,
+    refreshToken,
+    impersonator,
     NeedInit,
     ResetUserInfo,
     GetUserInfo,
+    Impersonate,
+    StopImpersonate,
     LoginIn,
+    VerifyTwoFactor,
+    SSOCallback,
     LoginOut,
     setToken,
+    setRefreshToken,
     loadingInstance,
     ClearStorage
   }
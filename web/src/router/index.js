@@ -15,6 +15,26 @@ const routes = [
     name: 'Login',
     component: () => import('@/view/login/index.vue')
   },
+  {
+    path: '/forgotPassword',
+    name: 'ForgotPassword',
+    component: () => import('@/view/login/forgotPassword.vue')
+  },
+  {
+    path: '/resetPassword',
+    name: 'ResetPassword',
+    component: () => import('@/view/login/resetPassword.vue')
+  },
+  {
+    path: '/register',
+    name: 'Register',
+    component: () => import('@/view/login/register.vue')
+  },
+  {
+    path: '/verifyRegistration',
+    name: 'VerifyRegistration',
+    component: () => import('@/view/login/verifyRegistration.vue')
+  },
   {
     path: '/scanUpload',
     name: 'ScanUpload',
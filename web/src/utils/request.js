@@ -109,6 +109,11 @@ service.interceptors.request.use(
       'x-user-id': userStore.userInfo.ID,
       ...config.headers
     }
+    // 平台用户在租户管理中切换的租户
+    const tenant = window.localStorage.getItem('x-tenant')
+    if (tenant && !config.headers['x-tenant']) {
+      config.headers['x-tenant'] = tenant
+    }
     return config
   },
   (error) => {
@@ -123,6 +128,33 @@ service.interceptors.request.use(
   }
 )
 
+// 多个请求同时遇到401时只发起一次刷新
+let refreshing = null
+
+const refreshAccessToken = () => {
+  const userStore = useUserStore()
+  if (!refreshing) {
+    refreshing = axios
+      .post(
+        (import.meta.env.VITE_BASE_API || '') + '/base/refresh',
+        { refreshToken: userStore.refreshToken },
+        { headers: { 'Content-Type': 'application/json' } }
+      )
+      .then((res) => {
+        if (res.data.code !== 0) {
+          return Promise.reject(res)
+        }
+        userStore.setToken(res.data.data.token)
+        userStore.setRefreshToken(res.data.data.refreshToken)
+        return res.data.data.token
+      })
+      .finally(() => {
+        refreshing = null
+      })
+  }
+  return refreshing
+}
+
 function getErrorMessage(error) {
   // 优先级： 响应体中的 msg > statusText > 默认消息
   return error.response?.data?.msg || error.response?.statusText || '请求失败'
@@ -155,7 +187,7 @@ service.interceptors.response.use(
       return response.data.msg ? response.data : response
     }
   },
-  (error) => {
+  async (error) => {
     if (!error.config.donNotShowLoading) {
       closeLoading()
     }
@@ -170,6 +202,23 @@ service.interceptors.response.use(
       return Promise.reject(error)
     }
 
+    // access token 过期时使用 refresh token 换取新令牌后重试原请求
+    if (
+      error.response.status === 401 &&
+      !error.config.skipRefresh &&
+      !error.config._retried &&
+      useUserStore().refreshToken
+    ) {
+      try {
+        const newToken = await refreshAccessToken()
+        error.config._retried = true
+        error.config.headers['x-token'] = newToken
+        return service(error.config)
+      } catch (e) {
+        // 刷新失败 按401处理
+      }
+    }
+
     // HTTP 状态码错误
     if (error.response.status === 401) {
       emitter.emit('show-error', {
@@ -199,5 +248,5 @@ if (typeof window !== 'undefined') {
 }
 
 // 导出service和resetLoading函数
-export { resetLoading }
+export { resetLoading, refreshAccessToken }
 export default service